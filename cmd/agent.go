@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var agentCmd = &cobra.Command{
+	Use:           "agent",
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Short:         "Serve this machine's Copilot CLI sessions over stdio for a remote viewer",
+	Long: `agent answers session, log and token usage requests as JSON lines on
+stdin and stdout. It is started over ssh by a gh-agent-viz that lists this
+machine under remotes in its config, and is not meant to be run by hand.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load("")
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		tui.ApplySessionRoots(cfg)
+		return data.ServeAgent(os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	archiveOlderThan       string
+	archiveExpireDismissed string
+	archiveMoveTo          string
+	archiveDir             string
+	archiveDryRun          bool
+)
+
+var archiveCmd = &cobra.Command{
+	Use:     "archive",
+	Aliases: []string{"prune"},
+	// Execute reports errors itself; a usage dump would bury them.
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Short:         "Archive finished local sessions and expire old dismissals",
+	Long: `archive packs finished local Copilot CLI sessions that have been idle for
+longer than --older-than into a tar.gz in the archive directory, then deletes
+their session-state directories (or moves them to --move-to). Dismissals
+older than --expire-dismissed are forgotten.
+
+Archived sessions stay browsable, read-only, from the TUI command palette.
+Defaults come from the archive: section of the config.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load("")
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		tui.ApplySessionRoots(cfg)
+		tui.ApplyArchiveDir(cfg)
+		if archiveDir != "" {
+			data.SetArchiveDir(archiveDir)
+		}
+
+		olderThan, err := data.ParseDuration(flagOr(archiveOlderThan, cfg.ArchiveOlderThan()))
+		if err != nil {
+			return fmt.Errorf("--older-than: %w", err)
+		}
+		expireAfter, err := data.ParseDuration(flagOr(archiveExpireDismissed, cfg.ArchiveExpireDismissed()))
+		if err != nil {
+			return fmt.Errorf("--expire-dismissed: %w", err)
+		}
+
+		result, err := data.ArchiveSessions(data.ArchiveOptions{
+			OlderThan: olderThan,
+			MoveTo:    flagOr(archiveMoveTo, cfg.ArchiveMoveToPath()),
+			DryRun:    archiveDryRun,
+		})
+		for _, s := range result.Sessions {
+			fmt.Fprintf(os.Stdout, "%s  %-9s  %s  %s\n", s.ID, s.Status, s.UpdatedAt.Format("2006-01-02"), s.Title)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case archiveDryRun:
+			fmt.Fprintf(os.Stderr, "%d session(s) would be archived to %s\n", len(result.Sessions), data.ArchiveDir())
+		case result.Path != "":
+			fmt.Fprintf(os.Stderr, "%d session(s) archived to %s\n", len(result.Sessions), result.Path)
+		default:
+			fmt.Fprintln(os.Stderr, "No sessions old enough to archive")
+		}
+		if !archiveDryRun {
+			if n := data.NewAnnotationStore().Expire(expireAfter); n > 0 {
+				fmt.Fprintf(os.Stderr, "%d dismissal(s) expired\n", n)
+			}
+		}
+		return nil
+	},
+}
+
+// flagOr returns the flag value when it was given, otherwise the fallback.
+func flagOr(flag, fallback string) string {
+	if flag != "" {
+		return flag
+	}
+	return fallback
+}
+
+func init() {
+	archiveCmd.Flags().StringVar(&archiveOlderThan, "older-than", "", "Archive finished sessions idle for longer than this, e.g. 30d, 2w, 36h (default: archive.olderThan or 30d)")
+	archiveCmd.Flags().StringVar(&archiveExpireDismissed, "expire-dismissed", "", "Forget dismissals older than this (default: archive.expireDismissed or 30d)")
+	archiveCmd.Flags().StringVar(&archiveMoveTo, "move-to", "", "Move archived session directories here instead of deleting them")
+	archiveCmd.Flags().StringVar(&archiveDir, "dir", "", "Archive directory (default: archive.dir or ~/.gh-agent-viz/archive)")
+	archiveCmd.Flags().BoolVar(&archiveDryRun, "dry-run", false, "List the sessions that would be archived without changing anything")
+	rootCmd.AddCommand(archiveCmd)
+}
@@ -6,8 +6,10 @@ import (
 	"runtime/pprof"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
 	"github.com/spf13/cobra"
 )
 
@@ -16,7 +18,12 @@ var (
 	debugFlag    bool
 	demoFlag     bool
 	snapshotFlag string
+	replayFlag   string
+	recordFlag   string
+	fixtureFlag  string
 	profileFlag  string
+	plainFlag    bool
+	a11yFlag     bool
 )
 
 // Version is set by goreleaser at build time.
@@ -50,16 +57,63 @@ terminal UI for visualizing and managing GitHub Copilot coding agent sessions.
 			}()
 		}
 
+		// Record or replay data-layer fixtures
+		stopRecording := func() {}
+		if recordFlag != "" {
+			rec, err := data.StartRecording(recordFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not start recording: %v\n", err)
+				os.Exit(1)
+			}
+			stopRecording = func() {
+				if err := rec.Stop(); err != nil {
+					fmt.Fprintf(os.Stderr, "Could not write fixture: %v\n", err)
+					return
+				}
+				fmt.Fprintf(os.Stderr, "Fixture written to %s\n"+
+					"Only GitHub tokens, bearer credentials and your home directory were scrubbed. It still holds\n"+
+					"session transcripts, prompts, command output and file contents, which may include other secrets.\n"+
+					"Review it before sharing, then reproduce with: gh agent-viz --fixture %s\n", recordFlag, recordFlag)
+			}
+			defer stopRecording()
+		}
+		if fixtureFlag != "" {
+			restore, err := data.ReplayFixture(fixtureFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not replay fixture: %v\n", err)
+				os.Exit(1)
+			}
+			defer restore()
+		}
+
 		// Create the Bubble Tea program
-		model := tui.NewModel(repoFlag, debugFlag, demoFlag, snapshotFlag, Version)
-		p := tea.NewProgram(model)
+		tui.SetRenderFlags(plainFlag, a11yFlag)
+		var model tea.Model
+		if replayFlag != "" {
+			snap, err := data.ReadSnapshot(replayFlag)
+			if err == nil {
+				model, err = tui.NewReplayModel(snap, snapshotFlag, Version)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not replay snapshot: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			model = tui.NewModel(repoFlag, debugFlag, demoFlag, snapshotFlag, Version)
+		}
+		var opts []tea.ProgramOption
+		if a11y.Current().Plain {
+			opts = append(opts, tea.WithColorProfile(colorprofile.Ascii))
+		}
+		p := tea.NewProgram(model, opts...)
 
 		// Run the program
 		if _, err := p.Run(); err != nil {
-			// Stop profiling before exit so the profile is flushed
+			// Stop profiling and recording before exit so both are flushed
 			if profileFlag != "" {
 				pprof.StopCPUProfile()
 			}
+			stopRecording()
 			fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 			os.Exit(1)
 		}
@@ -83,5 +137,13 @@ func init() {
 	rootCmd.Flags().BoolVar(&debugFlag, "debug", false, "Enable debug diagnostics and write command logs to ~/.gh-agent-viz-debug.log")
 	rootCmd.Flags().BoolVar(&demoFlag, "demo", false, "Run with fake demo data for screenshots and recordings")
 	rootCmd.Flags().StringVar(&snapshotFlag, "snapshot", "", "Write a JSON snapshot of TUI state after initial load and exit")
+	rootCmd.Flags().StringVar(&replayFlag, "replay", "", "Drive the TUI from a snapshot written by --snapshot instead of live data")
+	rootCmd.MarkFlagsMutuallyExclusive("replay", "demo")
+	rootCmd.MarkFlagsMutuallyExclusive("replay", "repo")
+	rootCmd.Flags().StringVar(&recordFlag, "record", "", "Record gh output, Copilot API responses and ~/.copilot session files into a sanitized fixture directory")
+	rootCmd.Flags().StringVar(&fixtureFlag, "fixture", "", "Answer all data requests from a fixture directory written by --record")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "fixture", "demo", "replay")
+	rootCmd.Flags().BoolVar(&plainFlag, "plain", false, "Render without color or non-ASCII glyphs (also enabled by NO_COLOR)")
+	rootCmd.Flags().BoolVar(&a11yFlag, "accessible", false, "Screen-reader friendly output: statuses in words, no animation, single-column dashboard")
 	rootCmd.Flags().StringVar(&profileFlag, "profile", "", "Write a CPU profile to the given file (analyze with: go tool pprof)")
 }
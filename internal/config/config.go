@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -16,6 +17,201 @@ type Config struct {
 	Animations      *bool    `yaml:"animations,omitempty"`
 	AsciiHeader     *bool    `yaml:"asciiHeader,omitempty"`
 	Theme           string   `yaml:"theme,omitempty"`
+	// Plain drops color and non-ASCII glyphs; also enabled by NO_COLOR.
+	Plain bool `yaml:"plain,omitempty"`
+	// Accessible renders for screen readers: statuses in words, no
+	// animation and a single-column dashboard.
+	Accessible bool `yaml:"accessible,omitempty"`
+	// ThemesDir holds custom theme files (default: ~/.gh-agent-viz/themes).
+	ThemesDir string `yaml:"themesDir,omitempty"`
+	// ExportDir receives saved log selections and conversation exports
+	// (default: ~/.gh-agent-viz/exports).
+	ExportDir string `yaml:"exportDir,omitempty"`
+	// Filters maps a name to a saved search expression, usable in the
+	// search bar as "@name".
+	Filters map[string]string `yaml:"filters,omitempty"`
+	// Views are named workspace layouts, switchable at runtime.
+	Views []View `yaml:"views,omitempty"`
+	// Keys overrides key bindings, mapping an action name (e.g. "dismiss")
+	// to the keys that trigger it. An empty list unbinds the action.
+	Keys map[string]KeyList `yaml:"keys,omitempty"`
+	// CopilotRoots lists the directories local sessions and token usage are
+	// read from (default: ~/.copilot). The GH_AGENT_VIZ_COPILOT_ROOTS
+	// environment variable overrides it.
+	CopilotRoots []CopilotRoot `yaml:"copilotRoots,omitempty"`
+	// Remotes are machines whose local sessions are read over ssh through
+	// the `gh agent-viz agent` helper and merged into the fleet.
+	Remotes []Remote `yaml:"remotes,omitempty"`
+	// Archive sets the defaults of `gh agent-viz archive` and the archive
+	// command in the palette.
+	Archive Archive `yaml:"archive,omitempty"`
+	// Worktrees configures checking session branches out into local git
+	// worktrees.
+	Worktrees Worktrees `yaml:"worktrees,omitempty"`
+	// Clipboard picks how text is copied: "auto" (default) sends an OSC 52
+	// escape sequence to the terminal and runs the first clipboard tool
+	// available, "osc52" only sends the escape sequence, and "wl-copy",
+	// "xclip", "xsel", "pbcopy" or "clip.exe" only run that tool. Anything
+	// else is a shell command that reads the text on stdin.
+	Clipboard string `yaml:"clipboard,omitempty"`
+}
+
+// Worktrees configures where session branches are checked out and what
+// happens once they are.
+type Worktrees struct {
+	// Dir holds one worktree per repository and branch
+	// (default: ~/.gh-agent-viz/worktrees).
+	Dir string `yaml:"dir,omitempty"`
+	// Clones maps a repository ("org/name") to an existing local clone to
+	// add worktrees to. Without one, a local session's checkout of the
+	// repository is used, or the repository is cloned under Dir.
+	Clones map[string]string `yaml:"clones,omitempty"`
+	// PostCheckout is a shell command run in a new worktree before it is
+	// opened, e.g. "npm ci".
+	PostCheckout string `yaml:"postCheckout,omitempty"`
+	// Open is what the worktree is opened in: "shell" (default) or "editor".
+	Open string `yaml:"open,omitempty"`
+}
+
+// WorktreeDirPath returns worktrees.dir with "~/" expanded, or "" for the
+// default.
+func (c *Config) WorktreeDirPath() string {
+	return expandHome(c.Worktrees.Dir)
+}
+
+// WorktreeClonePath returns the configured local clone of repo, or "".
+func (c *Config) WorktreeClonePath(repo string) string {
+	return expandHome(c.Worktrees.Clones[repo])
+}
+
+// WorktreeOpensEditor reports whether worktrees open in $EDITOR rather
+// than a shell.
+func (c *Config) WorktreeOpensEditor() bool {
+	return strings.EqualFold(c.Worktrees.Open, "editor")
+}
+
+// Archive configures session archival. Durations accept "d" and "w" units
+// as well as Go durations ("36h").
+type Archive struct {
+	// Dir holds the tarballs and their index (default: ~/.gh-agent-viz/archive).
+	Dir string `yaml:"dir,omitempty"`
+	// OlderThan is how long a finished session must be idle before it is
+	// archived (default: 30d).
+	OlderThan string `yaml:"olderThan,omitempty"`
+	// ExpireDismissed is how long a dismissal is kept (default: 30d).
+	ExpireDismissed string `yaml:"expireDismissed,omitempty"`
+	// MoveTo receives archived session directories instead of deleting them.
+	MoveTo string `yaml:"moveTo,omitempty"`
+}
+
+// DefaultArchiveAge is the default for archive.olderThan and
+// archive.expireDismissed.
+const DefaultArchiveAge = "30d"
+
+// ArchiveDirPath returns the archive directory, or "" for the default.
+func (c *Config) ArchiveDirPath() string {
+	return expandHome(c.Archive.Dir)
+}
+
+// ArchiveMoveToPath returns archive.moveTo with "~/" expanded.
+func (c *Config) ArchiveMoveToPath() string {
+	return expandHome(c.Archive.MoveTo)
+}
+
+// ArchiveOlderThan returns archive.olderThan or its default.
+func (c *Config) ArchiveOlderThan() string {
+	if c.Archive.OlderThan != "" {
+		return c.Archive.OlderThan
+	}
+	return DefaultArchiveAge
+}
+
+// ArchiveExpireDismissed returns archive.expireDismissed or its default.
+func (c *Config) ArchiveExpireDismissed() string {
+	if c.Archive.ExpireDismissed != "" {
+		return c.Archive.ExpireDismissed
+	}
+	return DefaultArchiveAge
+}
+
+// Remote is a machine running Copilot CLI sessions. Host is an ssh
+// destination ("devbox" or "me@10.0.0.5"); Command replaces the ssh
+// invocation entirely, e.g. to go through a container or a jump host. Name
+// labels the machine's sessions and defaults to Host.
+type Remote struct {
+	Name    string   `yaml:"name,omitempty"`
+	Host    string   `yaml:"host,omitempty"`
+	Command []string `yaml:"command,omitempty"`
+}
+
+// DisplayName returns the label shown on the remote's sessions.
+func (r Remote) DisplayName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Host
+}
+
+// CopilotRoot is a directory of Copilot CLI state. Path points at a
+// directory laid out like ~/.copilot; SessionState and Logs override its
+// session-state and logs subdirectories, or stand alone without Path.
+// Label is shown on the root's sessions and defaults to the directory name.
+type CopilotRoot struct {
+	Path         string `yaml:"path,omitempty"`
+	SessionState string `yaml:"sessionState,omitempty"`
+	Logs         string `yaml:"logs,omitempty"`
+	Label        string `yaml:"label,omitempty"`
+}
+
+// CopilotRootsEnv overrides copilotRoots with a list of ~/.copilot-style
+// directories separated by the OS path list separator (":" or ";"), each
+// optionally prefixed with "label=".
+const CopilotRootsEnv = "GH_AGENT_VIZ_COPILOT_ROOTS"
+
+// KeyList is a list of key names. In YAML it may be written as a single
+// string ("z") or a sequence ([z, ctrl+z]).
+type KeyList []string
+
+// UnmarshalYAML accepts either a scalar or a sequence of scalars.
+func (k *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!!null" || node.Value == "" {
+			*k = KeyList{}
+			return nil
+		}
+		*k = KeyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// View is a named workspace layout: the screen to show plus the filter,
+// grouping, sort order and panels to apply when it is selected. Empty
+// fields leave the current setting unchanged.
+type View struct {
+	Name    string   `yaml:"name"`
+	Mode    string   `yaml:"mode,omitempty"`    // "dashboard", "table", "active"
+	Status  string   `yaml:"status,omitempty"`  // "all", "attention", "active", "completed", "failed"
+	Filter  string   `yaml:"filter,omitempty"`  // search query, e.g. "repo:org/* status:failed"
+	GroupBy string   `yaml:"groupBy,omitempty"` // "none", "repository", "status", "source"
+	Sort    string   `yaml:"sort,omitempty"`    // "updated", "created", "cost", "title", "status"
+	Panels  []string `yaml:"panels,omitempty"`  // dashboard panels to show (default: all)
+	Preview *bool    `yaml:"preview,omitempty"` // split-pane preview in the table view
+}
+
+// FindView returns the view with the given name (case-insensitive).
+func (c *Config) FindView(name string) (View, bool) {
+	for _, v := range c.Views {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return View{}, false
 }
 
 // AnimationsEnabled returns whether animations are enabled (default: true).
@@ -34,6 +230,117 @@ func (c *Config) AsciiHeaderEnabled() bool {
 	return *c.AsciiHeader
 }
 
+// PlainEnabled returns whether plain rendering is on, either in the config
+// or through a non-empty NO_COLOR environment variable (https://no-color.org).
+func (c *Config) PlainEnabled() bool {
+	return c.Plain || os.Getenv("NO_COLOR") != ""
+}
+
+// ThemesDirPath returns the directory custom themes are loaded from.
+func (c *Config) ThemesDirPath() string {
+	if c.ThemesDir != "" {
+		return expandHome(c.ThemesDir)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".gh-agent-viz", "themes")
+}
+
+// ExportDirPath returns the directory selections and conversations are
+// saved to.
+func (c *Config) ExportDirPath() string {
+	if c.ExportDir != "" {
+		return expandHome(c.ExportDir)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".gh-agent-viz", "exports")
+}
+
+// ResolvedCopilotRoots returns the session roots from GH_AGENT_VIZ_COPILOT_ROOTS
+// or, when that is unset, the config, with "~/" expanded, session-state and
+// logs directories filled in and labels derived from directory names. The
+// default root, ~/.copilot, keeps an empty label. Entries naming no
+// directory are skipped. It returns nil when no roots are configured.
+func (c *Config) ResolvedCopilotRoots() []CopilotRoot {
+	roots := c.CopilotRoots
+	if env := os.Getenv(CopilotRootsEnv); env != "" {
+		roots = parseCopilotRootsEnv(env)
+	}
+	var resolved []CopilotRoot
+	for _, r := range roots {
+		r.Path = expandHome(strings.TrimSpace(r.Path))
+		r.SessionState = expandHome(strings.TrimSpace(r.SessionState))
+		r.Logs = expandHome(strings.TrimSpace(r.Logs))
+		if r.Path != "" {
+			if r.SessionState == "" {
+				r.SessionState = filepath.Join(r.Path, "session-state")
+			}
+			if r.Logs == "" {
+				r.Logs = filepath.Join(r.Path, "logs")
+			}
+		}
+		if r.SessionState == "" && r.Logs == "" {
+			continue
+		}
+		if r.Label == "" {
+			r.Label = copilotRootLabel(r)
+		}
+		resolved = append(resolved, r)
+	}
+	return resolved
+}
+
+func parseCopilotRootsEnv(env string) []CopilotRoot {
+	var roots []CopilotRoot
+	for _, entry := range filepath.SplitList(env) {
+		root := CopilotRoot{Path: entry}
+		if label, path, ok := strings.Cut(entry, "="); ok && !strings.ContainsAny(label, `/\`) {
+			root = CopilotRoot{Path: path, Label: label}
+		}
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+// copilotRootLabel derives a label from the root's directory: the directory
+// name, or its parent's name for a ".copilot" directory. The default root is
+// left unlabeled.
+func copilotRootLabel(r CopilotRoot) string {
+	dir := r.Path
+	if dir == "" {
+		dir = filepath.Dir(r.SessionState)
+		if r.SessionState == "" {
+			dir = filepath.Dir(r.Logs)
+		}
+	}
+	dir = filepath.Clean(dir)
+	if home, err := os.UserHomeDir(); err == nil && dir == filepath.Join(home, ".copilot") {
+		return ""
+	}
+	name := filepath.Base(dir)
+	if name == ".copilot" {
+		name = filepath.Base(filepath.Dir(dir))
+	}
+	return strings.TrimPrefix(name, ".")
+}
+
+// expandHome replaces a leading "~/" with the user's home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[2:])
+}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
@@ -67,11 +374,15 @@ func Load(path string) (*Config, error) {
 		return nil, err
 	}
 
-	// Parse YAML
+	return Parse(data)
+}
+
+// Parse parses YAML config on top of the defaults.
+func Parse(data []byte) (*Config, error) {
+	cfg := DefaultConfig()
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, err
 	}
-
 	return cfg, nil
 }
 
@@ -188,6 +188,39 @@ func TestAnimationsEnabled_ExplicitFalse(t *testing.T) {
 	}
 }
 
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte("theme: dracula\nkeys:\n  dismiss: z\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cfg.Theme != "dracula" || len(cfg.Keys["dismiss"]) != 1 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if cfg.RefreshInterval != 30 {
+		t.Errorf("expected defaults for unset fields, got refreshInterval %d", cfg.RefreshInterval)
+	}
+	if _, err := Parse([]byte("repos: [")); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestPlainEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	cfg := DefaultConfig()
+	if cfg.PlainEnabled() {
+		t.Error("expected plain mode off by default")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if !cfg.PlainEnabled() {
+		t.Error("expected NO_COLOR to enable plain mode")
+	}
+	t.Setenv("NO_COLOR", "")
+	cfg.Plain = true
+	if !cfg.PlainEnabled() {
+		t.Error("expected plain: true to enable plain mode")
+	}
+}
+
 func TestLoad_AnimationsFalse(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "anim-config.yml")
@@ -269,6 +302,42 @@ func TestLoad_ThemeField(t *testing.T) {
 	}
 }
 
+func TestThemesDirPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg := DefaultConfig()
+	if got, want := cfg.ThemesDirPath(), filepath.Join(home, ".gh-agent-viz", "themes"); got != want {
+		t.Errorf("default ThemesDirPath() = %q, want %q", got, want)
+	}
+
+	cfg.ThemesDir = "~/my-themes"
+	if got, want := cfg.ThemesDirPath(), filepath.Join(home, "my-themes"); got != want {
+		t.Errorf("ThemesDirPath() with ~ = %q, want %q", got, want)
+	}
+
+	cfg.ThemesDir = "/etc/themes"
+	if got := cfg.ThemesDirPath(); got != "/etc/themes" {
+		t.Errorf("ThemesDirPath() = %q, want /etc/themes", got)
+	}
+}
+
+func TestExportDirPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg, err := Parse([]byte("exportDir: ~/bug-reports\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cfg.ExportDirPath(), filepath.Join(home, "bug-reports"); got != want {
+		t.Errorf("ExportDirPath() = %q, want %q", got, want)
+	}
+	if got, want := DefaultConfig().ExportDirPath(), filepath.Join(home, ".gh-agent-viz", "exports"); got != want {
+		t.Errorf("default ExportDirPath() = %q, want %q", got, want)
+	}
+}
+
 func TestDefaultConfig_FieldValues(t *testing.T) {
 	cfg := DefaultConfig()
 
@@ -282,3 +351,216 @@ func TestDefaultConfig_FieldValues(t *testing.T) {
 		t.Errorf("expected empty Theme by default, got %q", cfg.Theme)
 	}
 }
+
+func TestLoad_Views(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	content := `views:
+  - name: failures today
+    mode: table
+    status: failed
+    filter: age:<1d
+    groupBy: repository
+    sort: cost
+    preview: true
+  - name: overview
+    mode: dashboard
+    panels: [attention, fleet]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+	if len(cfg.Views) != 2 {
+		t.Fatalf("expected 2 views, got %d", len(cfg.Views))
+	}
+
+	v, ok := cfg.FindView("Failures Today")
+	if !ok {
+		t.Fatal("expected FindView to match case-insensitively")
+	}
+	if v.Mode != "table" || v.Status != "failed" || v.Filter != "age:<1d" || v.GroupBy != "repository" || v.Sort != "cost" {
+		t.Errorf("unexpected view fields: %+v", v)
+	}
+	if v.Preview == nil || !*v.Preview {
+		t.Error("expected preview to be true")
+	}
+
+	overview, _ := cfg.FindView("overview")
+	if len(overview.Panels) != 2 || overview.Panels[1] != "fleet" {
+		t.Errorf("unexpected panels: %v", overview.Panels)
+	}
+
+	if _, ok := cfg.FindView("missing"); ok {
+		t.Error("expected FindView to fail for unknown name")
+	}
+}
+
+func TestLoad_Keys(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	content := `keys:
+  dismiss: z
+  dismissDone: [Z, ctrl+z]
+  fileIssue: []
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+	if got := cfg.Keys["dismiss"]; len(got) != 1 || got[0] != "z" {
+		t.Errorf("expected scalar key to load as a one-element list, got %v", got)
+	}
+	if got := cfg.Keys["dismissDone"]; len(got) != 2 || got[1] != "ctrl+z" {
+		t.Errorf("expected sequence of keys, got %v", got)
+	}
+	if got, ok := cfg.Keys["fileIssue"]; !ok || len(got) != 0 {
+		t.Errorf("expected empty list to unbind, got %v (present=%v)", got, ok)
+	}
+}
+
+func TestResolvedCopilotRoots(t *testing.T) {
+	t.Setenv(CopilotRootsEnv, "")
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	cfg, err := Parse([]byte(`copilotRoots:
+  - path: ~/.copilot
+  - path: /mnt/devbox/.copilot
+  - path: /srv/ci-artifacts
+    label: ci
+  - sessionState: /workspaces/state
+    logs: /workspaces/logs
+  - label: empty
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	roots := cfg.ResolvedCopilotRoots()
+	want := []CopilotRoot{
+		{Path: filepath.Join(home, ".copilot"), SessionState: filepath.Join(home, ".copilot", "session-state"), Logs: filepath.Join(home, ".copilot", "logs")},
+		{Path: "/mnt/devbox/.copilot", SessionState: "/mnt/devbox/.copilot/session-state", Logs: "/mnt/devbox/.copilot/logs", Label: "devbox"},
+		{Path: "/srv/ci-artifacts", SessionState: "/srv/ci-artifacts/session-state", Logs: "/srv/ci-artifacts/logs", Label: "ci"},
+		{SessionState: "/workspaces/state", Logs: "/workspaces/logs", Label: "workspaces"},
+	}
+	if len(roots) != len(want) {
+		t.Fatalf("expected %d roots, got %d: %+v", len(want), len(roots), roots)
+	}
+	for i := range want {
+		if roots[i] != want[i] {
+			t.Errorf("root %d = %+v, want %+v", i, roots[i], want[i])
+		}
+	}
+
+	if roots := DefaultConfig().ResolvedCopilotRoots(); roots != nil {
+		t.Errorf("expected no roots by default, got %+v", roots)
+	}
+}
+
+func TestResolvedCopilotRoots_Env(t *testing.T) {
+	t.Setenv(CopilotRootsEnv, "/mnt/a/.copilot"+string(os.PathListSeparator)+"box=/mnt/b")
+	cfg := DefaultConfig()
+	cfg.CopilotRoots = []CopilotRoot{{Path: "/ignored"}}
+
+	roots := cfg.ResolvedCopilotRoots()
+	if len(roots) != 2 {
+		t.Fatalf("expected the env var to replace the config roots, got %+v", roots)
+	}
+	if roots[0].Label != "a" || roots[0].SessionState != "/mnt/a/.copilot/session-state" {
+		t.Errorf("unexpected first root %+v", roots[0])
+	}
+	if roots[1].Label != "box" || roots[1].Logs != "/mnt/b/logs" {
+		t.Errorf("unexpected labeled root %+v", roots[1])
+	}
+}
+
+func TestParse_Remotes(t *testing.T) {
+	cfg, err := Parse([]byte(`remotes:
+  - host: devbox
+  - name: gpu
+    host: me@10.0.0.5
+  - name: container
+    command: [docker, exec, -i, agents, gh, agent-viz, agent]
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(cfg.Remotes) != 3 {
+		t.Fatalf("expected 3 remotes, got %+v", cfg.Remotes)
+	}
+	if got := cfg.Remotes[0].DisplayName(); got != "devbox" {
+		t.Errorf("expected the host as the default name, got %q", got)
+	}
+	if got := cfg.Remotes[1].DisplayName(); got != "gpu" {
+		t.Errorf("expected the configured name, got %q", got)
+	}
+	if got := cfg.Remotes[2].Command; len(got) != 7 || got[0] != "docker" {
+		t.Errorf("unexpected command %v", got)
+	}
+}
+
+func TestArchiveDefaults(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.ArchiveOlderThan() != DefaultArchiveAge || cfg.ArchiveExpireDismissed() != DefaultArchiveAge {
+		t.Errorf("expected %s defaults, got %q and %q", DefaultArchiveAge, cfg.ArchiveOlderThan(), cfg.ArchiveExpireDismissed())
+	}
+	if cfg.ArchiveDirPath() != "" {
+		t.Errorf("expected no archive dir by default, got %q", cfg.ArchiveDirPath())
+	}
+
+	cfg, err := Parse([]byte(`archive:
+  dir: /srv/agent-archive
+  olderThan: 2w
+  expireDismissed: 72h
+  moveTo: /tmp/old-sessions
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if cfg.ArchiveDirPath() != "/srv/agent-archive" || cfg.ArchiveOlderThan() != "2w" ||
+		cfg.ArchiveExpireDismissed() != "72h" || cfg.ArchiveMoveToPath() != "/tmp/old-sessions" {
+		t.Errorf("unexpected archive config %+v", cfg.Archive)
+	}
+}
+
+func TestClipboardConfig(t *testing.T) {
+	cfg, err := Parse([]byte("clipboard: tmux load-buffer -w -\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Clipboard != "tmux load-buffer -w -" {
+		t.Errorf("expected the clipboard command, got %q", cfg.Clipboard)
+	}
+}
+
+func TestWorktreeConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.WorktreeDirPath() != "" || cfg.WorktreeOpensEditor() || cfg.WorktreeClonePath("org/repo") != "" {
+		t.Errorf("unexpected worktree defaults %+v", cfg.Worktrees)
+	}
+
+	cfg, err := Parse([]byte(`worktrees:
+  dir: /srv/worktrees
+  clones:
+    org/repo: /src/repo
+  postCheckout: npm ci
+  open: editor
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.WorktreeDirPath() != "/srv/worktrees" || cfg.WorktreeClonePath("org/repo") != "/src/repo" ||
+		cfg.Worktrees.PostCheckout != "npm ci" || !cfg.WorktreeOpensEditor() {
+		t.Errorf("unexpected worktree config %+v", cfg.Worktrees)
+	}
+}
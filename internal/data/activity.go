@@ -1,7 +1,5 @@
 package data
 
-import "time"
-
 // HourlyActivity buckets sessions by hour-of-day (0-23) based on CreatedAt.
 // Returns a [24]int array where index 0 = midnight, 23 = 11pm.
 func HourlyActivity(sessions []Session) [24]int {
@@ -18,7 +16,7 @@ func HourlyActivity(sessions []Session) [24]int {
 // Index 0 = N days ago, index N-1 = today. Uses CreatedAt.
 func DailySessionCounts(sessions []Session, days int) []int {
 	counts := make([]int, days)
-	now := time.Now()
+	now := Now()
 	for _, s := range sessions {
 		if s.CreatedAt.IsZero() {
 			continue
@@ -21,6 +21,16 @@ var debugEnabled bool
 // newCAPIClient is a constructor variable to allow disabling CAPI in tests.
 var newCAPIClient = func() (*capi.Client, error) { return capi.NewClient() }
 
+// ghOutput runs gh and returns its combined output. It is a variable so
+// fixtures can record and replay gh invocations.
+var ghOutput = func(args ...string) ([]byte, error) {
+	return execCommand("gh", args...).CombinedOutput()
+}
+
+// userHomeDir locates the default ~/.copilot session root. It is a variable
+// so tests can point it at a temporary directory.
+var userHomeDir = os.UserHomeDir
+
 const debugLogFileName = ".gh-agent-viz-debug.log"
 
 // SetDebug enables or disables debug logging for data-layer command execution.
@@ -321,7 +331,7 @@ func runGH(args ...string) ([]byte, error) {
 	var output []byte
 	var err error
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		output, err = execCommand("gh", args...).CombinedOutput()
+		output, err = ghOutput(args...)
 		if debugEnabled {
 			logDebugEntry(args, output, err)
 		}
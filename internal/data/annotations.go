@@ -0,0 +1,435 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	annotationsFileName = ".gh-agent-viz-annotations.json"
+	// dismissedFileName is the store's former file, which held dismissals
+	// only. It is read once when the annotations file does not exist yet.
+	dismissedFileName = ".gh-agent-viz-dismissed.json"
+)
+
+// AnnotationsVersion is the annotations file format written by this build.
+const AnnotationsVersion = 1
+
+// Annotation is what the user has recorded about one session: whether it is
+// dismissed or snoozed, pinned to the top of the list, and any note or tags.
+type Annotation struct {
+	DismissedAt time.Time `json:"dismissedAt,omitzero"`
+	// SnoozedUntil hides the session until that time.
+	SnoozedUntil time.Time `json:"snoozedUntil,omitzero"`
+	// SnoozedStatus hides the session until its status is no longer this
+	// one. It is set for snoozes that last until the next status change.
+	SnoozedStatus string   `json:"snoozedStatus,omitempty"`
+	Pinned        bool     `json:"pinned,omitempty"`
+	Note          string   `json:"note,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	// Events records actions taken on the session from here, such as
+	// stopping its process, oldest first.
+	Events []AnnotationEvent `json:"events,omitempty"`
+}
+
+// AnnotationEvent is an action taken on a session, shown in its timeline.
+type AnnotationEvent struct {
+	At   time.Time `json:"at"`
+	Text string    `json:"text"`
+}
+
+// maxAnnotationEvents caps how many events are kept per session.
+const maxAnnotationEvents = 20
+
+// Dismissed reports whether the session is dismissed.
+func (a *Annotation) Dismissed() bool {
+	return a != nil && !a.DismissedAt.IsZero()
+}
+
+// Snoozed reports whether a snooze is recorded, lapsed or not.
+func (a *Annotation) Snoozed() bool {
+	return a != nil && (!a.SnoozedUntil.IsZero() || a.SnoozedStatus != "")
+}
+
+// IsPinned reports whether the session is pinned.
+func (a *Annotation) IsPinned() bool {
+	return a != nil && a.Pinned
+}
+
+// HasTag reports whether the session carries tag, ignoring case.
+func (a *Annotation) HasTag(tag string) bool {
+	if a == nil {
+		return false
+	}
+	for _, t := range a.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// snoozeLapsed reports whether a recorded snooze no longer applies to s.
+func (a *Annotation) snoozeLapsed(s Session, now time.Time) bool {
+	if a.SnoozedStatus != "" {
+		return !strings.EqualFold(a.SnoozedStatus, s.Status)
+	}
+	return !now.Before(a.SnoozedUntil)
+}
+
+func (a *Annotation) empty() bool {
+	return !a.Dismissed() && !a.Snoozed() && !a.Pinned && a.Note == "" && len(a.Tags) == 0 && len(a.Events) == 0
+}
+
+func (a *Annotation) clone() *Annotation {
+	dup := *a
+	dup.Tags = slices.Clone(a.Tags)
+	dup.Events = slices.Clone(a.Events)
+	return &dup
+}
+
+// AnnotationStore keeps the annotation of each session and persists them to
+// a JSON file. A store without a path lives in memory only.
+type AnnotationStore struct {
+	mu   sync.Mutex
+	byID map[string]*Annotation
+	path string
+}
+
+type annotationsFile struct {
+	Version     int                    `json:"version"`
+	Annotations map[string]*Annotation `json:"annotations"`
+}
+
+// NewAnnotationStore loads annotations from the default file, importing the
+// dismissals of the older dismissed-sessions file on first use. A missing or
+// corrupt file starts an empty store.
+func NewAnnotationStore() *AnnotationStore {
+	path := homeFilePath(annotationsFileName)
+	if _, err := os.Stat(path); err != nil {
+		s := NewAnnotationStoreFromPath(homeFilePath(dismissedFileName))
+		s.path = path
+		return s
+	}
+	return NewAnnotationStoreFromPath(path)
+}
+
+// NewAnnotationStoreFromPath loads annotations from the given file path.
+func NewAnnotationStoreFromPath(path string) *AnnotationStore {
+	s := &AnnotationStore{byID: map[string]*Annotation{}, path: path}
+	s.load()
+	return s
+}
+
+// NewMemoryAnnotationStore returns a store seeded with annotations that is
+// never written to disk, e.g. for replaying a snapshot.
+func NewMemoryAnnotationStore(annotations map[string]*Annotation) *AnnotationStore {
+	s := &AnnotationStore{byID: make(map[string]*Annotation, len(annotations))}
+	for id, a := range annotations {
+		if a != nil {
+			s.byID[id] = a.clone()
+		}
+	}
+	return s
+}
+
+// Get returns a copy of a session's annotation, or nil when it has none.
+func (s *AnnotationStore) Get(id string) *Annotation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.byID[id]; ok {
+		return a.clone()
+	}
+	return nil
+}
+
+// All returns a copy of every annotation, keyed by session ID.
+func (s *AnnotationStore) All() map[string]*Annotation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]*Annotation, len(s.byID))
+	for id, a := range s.byID {
+		out[id] = a.clone()
+	}
+	return out
+}
+
+// DismissedIDs returns the set of dismissed session IDs.
+func (s *AnnotationStore) DismissedIDs() map[string]struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := map[string]struct{}{}
+	for id, a := range s.byID {
+		if a.Dismissed() {
+			out[id] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Dismiss marks a session as dismissed and persists to disk.
+func (s *AnnotationStore) Dismiss(id string) {
+	s.update(id, func(a *Annotation) bool {
+		if a.Dismissed() {
+			return false
+		}
+		a.DismissedAt = Now().UTC()
+		return true
+	})
+}
+
+// Undismiss brings a dismissed session back and persists to disk.
+func (s *AnnotationStore) Undismiss(id string) {
+	s.update(id, func(a *Annotation) bool {
+		if !a.Dismissed() {
+			return false
+		}
+		a.DismissedAt = time.Time{}
+		return true
+	})
+}
+
+// Expire forgets dismissals older than maxAge and returns how many were
+// removed. Sessions that are still around reappear in the list; their other
+// annotations are kept.
+func (s *AnnotationStore) Expire(maxAge time.Duration) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := Now().Add(-maxAge)
+	removed := 0
+	for id, a := range s.byID {
+		if a.Dismissed() && a.DismissedAt.Before(cutoff) {
+			a.DismissedAt = time.Time{}
+			s.prune(id)
+			removed++
+		}
+	}
+	if removed > 0 {
+		s.save()
+	}
+	return removed
+}
+
+// Snooze hides a session until the given time.
+func (s *AnnotationStore) Snooze(id string, until time.Time) {
+	s.update(id, func(a *Annotation) bool {
+		a.SnoozedUntil = until.UTC()
+		a.SnoozedStatus = ""
+		return true
+	})
+}
+
+// SnoozeUntilStatusChange hides a session until its status is no longer
+// status.
+func (s *AnnotationStore) SnoozeUntilStatusChange(id, status string) {
+	s.update(id, func(a *Annotation) bool {
+		a.SnoozedUntil = time.Time{}
+		a.SnoozedStatus = strings.ToLower(strings.TrimSpace(status))
+		if a.SnoozedStatus == "" {
+			a.SnoozedStatus = "unknown"
+		}
+		return true
+	})
+}
+
+// Unsnooze ends a session's snooze early.
+func (s *AnnotationStore) Unsnooze(id string) {
+	s.update(id, func(a *Annotation) bool {
+		if !a.Snoozed() {
+			return false
+		}
+		a.SnoozedUntil, a.SnoozedStatus = time.Time{}, ""
+		return true
+	})
+}
+
+// UnsnoozeAll ends every snooze and returns how many there were.
+func (s *AnnotationStore) UnsnoozeAll() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for id, a := range s.byID {
+		if a.Snoozed() {
+			a.SnoozedUntil, a.SnoozedStatus = time.Time{}, ""
+			s.prune(id)
+			n++
+		}
+	}
+	if n > 0 {
+		s.save()
+	}
+	return n
+}
+
+// Snoozed reports whether s is hidden by a snooze right now. A snooze that
+// has run out, or whose session changed status, is cleared.
+func (s *AnnotationStore) Snoozed(session Session) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.byID[session.ID]
+	if !ok || !a.Snoozed() {
+		return false
+	}
+	if !a.snoozeLapsed(session, Now()) {
+		return true
+	}
+	a.SnoozedUntil, a.SnoozedStatus = time.Time{}, ""
+	s.prune(session.ID)
+	s.save()
+	return false
+}
+
+// TogglePin pins or unpins a session and returns whether it is now pinned.
+func (s *AnnotationStore) TogglePin(id string) bool {
+	pinned := false
+	s.update(id, func(a *Annotation) bool {
+		a.Pinned = !a.Pinned
+		pinned = a.Pinned
+		return true
+	})
+	return pinned
+}
+
+// SetNote replaces a session's note; an empty note removes it.
+func (s *AnnotationStore) SetNote(id, note string) {
+	note = strings.TrimSpace(note)
+	s.update(id, func(a *Annotation) bool {
+		if a.Note == note {
+			return false
+		}
+		a.Note = note
+		return true
+	})
+}
+
+// SetTags replaces a session's tags. Tags are trimmed, stripped of a leading
+// '#', de-duplicated ignoring case and sorted.
+func (s *AnnotationStore) SetTags(id string, tags []string) {
+	clean := NormalizeTags(tags)
+	s.update(id, func(a *Annotation) bool {
+		if slices.Equal(a.Tags, clean) {
+			return false
+		}
+		a.Tags = clean
+		return true
+	})
+}
+
+// RecordEvent adds an action to a session's timeline, dropping the oldest
+// events beyond the most recent 20.
+func (s *AnnotationStore) RecordEvent(id, text string) {
+	s.update(id, func(a *Annotation) bool {
+		a.Events = append(a.Events, AnnotationEvent{At: Now().UTC(), Text: text})
+		if n := len(a.Events) - maxAnnotationEvents; n > 0 {
+			a.Events = slices.Delete(a.Events, 0, n)
+		}
+		return true
+	})
+}
+
+// NormalizeTags trims tags and their leading '#', drops empty and duplicate
+// ones (ignoring case) and sorts the rest.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "#")
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i]) < strings.ToLower(out[j]) })
+	return out
+}
+
+// update applies change to a session's annotation, creating it if needed,
+// and persists when change reports a modification.
+func (s *AnnotationStore) update(id string, change func(*Annotation) bool) {
+	if id == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.byID[id]
+	if !ok {
+		a = &Annotation{}
+	}
+	if !change(a) {
+		return
+	}
+	s.byID[id] = a
+	s.prune(id)
+	s.save()
+}
+
+// prune drops an annotation with nothing left in it. s.mu must be held.
+func (s *AnnotationStore) prune(id string) {
+	if a, ok := s.byID[id]; ok && a.empty() {
+		delete(s.byID, id)
+	}
+}
+
+// load reads the file. Besides the current format it accepts the two formats
+// of the older dismissed-sessions file: a JSON object of dismissal times keyed
+// by session ID, and a plain list of IDs dated by the file's modification
+// time.
+func (s *AnnotationStore) load() {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	var file annotationsFile
+	if err := json.Unmarshal(data, &file); err == nil && file.Version > 0 {
+		for id, a := range file.Annotations {
+			if a != nil && !a.empty() {
+				s.byID[id] = a
+			}
+		}
+		return
+	}
+	var dated map[string]time.Time
+	if err := json.Unmarshal(data, &dated); err == nil {
+		for id, at := range dated {
+			s.byID[id] = &Annotation{DismissedAt: at}
+		}
+		return
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return
+	}
+	modTime := Now()
+	if info, err := os.Stat(s.path); err == nil {
+		modTime = info.ModTime()
+	}
+	for _, id := range ids {
+		s.byID[id] = &Annotation{DismissedAt: modTime}
+	}
+}
+
+func (s *AnnotationStore) save() {
+	if s.path == "" {
+		return
+	}
+	data, err := json.Marshal(annotationsFile{Version: AnnotationsVersion, Annotations: s.byID})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(s.path, data, 0600)
+}
+
+func homeFilePath(name string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return name
+	}
+	return filepath.Join(home, name)
+}
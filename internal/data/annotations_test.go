@@ -0,0 +1,357 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAnnotationStore_AddAndIDs(t *testing.T) {
+	dir := t.TempDir()
+	s := NewAnnotationStoreFromPath(filepath.Join(dir, "dismissed.json"))
+
+	s.Dismiss("abc")
+	s.Dismiss("def")
+
+	ids := s.DismissedIDs()
+	if _, ok := ids["abc"]; !ok {
+		t.Error("expected abc in dismissed IDs")
+	}
+	if _, ok := ids["def"]; !ok {
+		t.Error("expected def in dismissed IDs")
+	}
+	if len(ids) != 2 {
+		t.Errorf("expected 2 IDs, got %d", len(ids))
+	}
+}
+
+func TestAnnotationStore_AddEmpty(t *testing.T) {
+	dir := t.TempDir()
+	s := NewAnnotationStoreFromPath(filepath.Join(dir, "dismissed.json"))
+
+	s.Dismiss("")
+	if len(s.DismissedIDs()) != 0 {
+		t.Error("empty ID should not be added")
+	}
+}
+
+func TestAnnotationStore_PersistsAcrossReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dismissed.json")
+
+	s1 := NewAnnotationStoreFromPath(path)
+	s1.Dismiss("session-1")
+	s1.Dismiss("session-2")
+
+	// Load fresh from same file
+	s2 := NewAnnotationStoreFromPath(path)
+	ids := s2.DismissedIDs()
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 IDs after reload, got %d", len(ids))
+	}
+	if _, ok := ids["session-1"]; !ok {
+		t.Error("expected session-1 after reload")
+	}
+	if _, ok := ids["session-2"]; !ok {
+		t.Error("expected session-2 after reload")
+	}
+}
+
+func TestAnnotationStore_MissingFile(t *testing.T) {
+	s := NewAnnotationStoreFromPath("/nonexistent/path/dismissed.json")
+	if len(s.DismissedIDs()) != 0 {
+		t.Error("expected empty set when file is missing")
+	}
+}
+
+func TestAnnotationStore_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dismissed.json")
+	_ = os.WriteFile(path, []byte("not json!!!"), 0600)
+
+	s := NewAnnotationStoreFromPath(path)
+	if len(s.DismissedIDs()) != 0 {
+		t.Error("expected empty set when file is corrupt")
+	}
+}
+
+func TestAnnotationStore_Deduplication(t *testing.T) {
+	dir := t.TempDir()
+	s := NewAnnotationStoreFromPath(filepath.Join(dir, "dismissed.json"))
+
+	s.Dismiss("same-id")
+	s.Dismiss("same-id")
+	s.Dismiss("same-id")
+
+	if len(s.DismissedIDs()) != 1 {
+		t.Errorf("expected 1 ID after dedup, got %d", len(s.DismissedIDs()))
+	}
+}
+
+func TestAnnotationStore_FilePermissions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dismissed.json")
+
+	s := NewAnnotationStoreFromPath(path)
+	s.Dismiss("test")
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected file to exist: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected 0600 permissions, got %o", info.Mode().Perm())
+	}
+}
+
+func TestAnnotationStore_FileFormatIsJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dismissed.json")
+
+	s := NewAnnotationStoreFromPath(path)
+	s.Dismiss("id-1")
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	var file struct {
+		Version     int                    `json:"version"`
+		Annotations map[string]*Annotation `json:"annotations"`
+	}
+	if err := json.Unmarshal(raw, &file); err != nil {
+		t.Fatalf("file is not valid JSON: %v", err)
+	}
+	if a, ok := file.Annotations["id-1"]; file.Version != AnnotationsVersion || len(file.Annotations) != 1 || !ok || a.DismissedAt.IsZero() {
+		t.Errorf("unexpected file content: %+v", file)
+	}
+}
+
+func TestAnnotationStore_LoadsLegacyList(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dismissed.json")
+	if err := os.WriteFile(path, []byte(`["old-1","old-2"]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-60 * 24 * time.Hour)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewAnnotationStoreFromPath(path)
+	if len(s.DismissedIDs()) != 2 {
+		t.Fatalf("expected 2 IDs from a legacy file, got %d", len(s.DismissedIDs()))
+	}
+	// Legacy entries are dated by the file's modification time.
+	if n := s.Expire(30 * 24 * time.Hour); n != 2 {
+		t.Errorf("expected both legacy entries to expire, got %d", n)
+	}
+}
+
+func TestAnnotationStore_Expire(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dismissed.json")
+	t.Cleanup(func() { Now = time.Now })
+
+	s := NewAnnotationStoreFromPath(path)
+	Now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	s.Dismiss("stale")
+	Now = func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) }
+	s.Dismiss("fresh")
+
+	if n := s.Expire(30 * 24 * time.Hour); n != 1 {
+		t.Fatalf("expected 1 expired entry, got %d", n)
+	}
+	ids := NewAnnotationStoreFromPath(path).DismissedIDs()
+	if _, ok := ids["stale"]; ok || len(ids) != 1 {
+		t.Errorf("expected only the fresh entry to be persisted, got %v", ids)
+	}
+}
+
+func TestAnnotationStore_Remove(t *testing.T) {
+	dir := t.TempDir()
+	s := NewAnnotationStoreFromPath(filepath.Join(dir, "dismissed.json"))
+
+	s.Dismiss("a")
+	s.Dismiss("b")
+	s.Dismiss("c")
+	s.Undismiss("b")
+
+	ids := s.DismissedIDs()
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 IDs after remove, got %d", len(ids))
+	}
+	if _, ok := ids["b"]; ok {
+		t.Error("expected b to be removed")
+	}
+	if _, ok := ids["a"]; !ok {
+		t.Error("expected a to remain")
+	}
+}
+
+func TestAnnotationStore_RemoveNonexistent(t *testing.T) {
+	dir := t.TempDir()
+	s := NewAnnotationStoreFromPath(filepath.Join(dir, "dismissed.json"))
+
+	s.Dismiss("a")
+	s.Undismiss("nonexistent") // should not panic or corrupt
+
+	ids := s.DismissedIDs()
+	if len(ids) != 1 {
+		t.Fatalf("expected 1 ID, got %d", len(ids))
+	}
+}
+
+func TestAnnotationStore_RemovePersists(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dismissed.json")
+
+	s1 := NewAnnotationStoreFromPath(path)
+	s1.Dismiss("keep")
+	s1.Dismiss("remove-me")
+	s1.Undismiss("remove-me")
+
+	// Reload from disk
+	s2 := NewAnnotationStoreFromPath(path)
+	ids := s2.DismissedIDs()
+	if len(ids) != 1 {
+		t.Fatalf("expected 1 ID after reload, got %d", len(ids))
+	}
+	if _, ok := ids["keep"]; !ok {
+		t.Error("expected 'keep' to survive reload")
+	}
+	if _, ok := ids["remove-me"]; ok {
+		t.Error("expected 'remove-me' to be gone after reload")
+	}
+}
+
+func TestNewMemoryAnnotationStore(t *testing.T) {
+	s := NewMemoryAnnotationStore(map[string]*Annotation{
+		"a": {DismissedAt: time.Now()},
+		"b": {DismissedAt: time.Now()},
+	})
+	s.Dismiss("c")
+	s.Undismiss("a")
+
+	ids := s.DismissedIDs()
+	if len(ids) != 2 {
+		t.Fatalf("expected 2 IDs, got %d", len(ids))
+	}
+	if _, ok := ids["c"]; !ok {
+		t.Error("expected c to be dismissed")
+	}
+	if _, err := os.Stat(dismissedFileName); err == nil {
+		t.Error("memory store should not write to disk")
+	}
+}
+
+func TestAnnotationStore_LoadsLegacyDatedMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dismissed.json")
+	if err := os.WriteFile(path, []byte(`{"old-1":"2026-01-01T00:00:00Z"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	a := NewAnnotationStoreFromPath(path).Get("old-1")
+	if !a.Dismissed() || a.DismissedAt.Year() != 2026 {
+		t.Errorf("expected the legacy dismissal with its date, got %+v", a)
+	}
+}
+
+func TestAnnotationStore_SnoozeUntilTime(t *testing.T) {
+	t.Cleanup(func() { Now = time.Now })
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	Now = func() time.Time { return start }
+	path := filepath.Join(t.TempDir(), "annotations.json")
+	s := NewAnnotationStoreFromPath(path)
+	session := Session{ID: "a", Status: "running"}
+
+	s.Snooze("a", start.Add(time.Hour))
+	if !s.Snoozed(session) {
+		t.Fatal("expected the session to be snoozed")
+	}
+	if !NewAnnotationStoreFromPath(path).Snoozed(session) {
+		t.Error("expected the snooze to persist")
+	}
+
+	Now = func() time.Time { return start.Add(2 * time.Hour) }
+	if s.Snoozed(session) {
+		t.Error("expected the snooze to lapse after its time")
+	}
+	if s.Get("a") != nil {
+		t.Errorf("expected the lapsed snooze to be cleared, got %+v", s.Get("a"))
+	}
+}
+
+func TestAnnotationStore_SnoozeUntilStatusChange(t *testing.T) {
+	s := NewMemoryAnnotationStore(nil)
+	s.SnoozeUntilStatusChange("a", "Running")
+
+	if !s.Snoozed(Session{ID: "a", Status: "running"}) {
+		t.Fatal("expected the session to stay snoozed while its status is unchanged")
+	}
+	if s.Snoozed(Session{ID: "a", Status: "needs-input"}) {
+		t.Error("expected the snooze to end when the status changes")
+	}
+	// The snooze is gone for good, even if the status goes back.
+	if s.Snoozed(Session{ID: "a", Status: "running"}) {
+		t.Error("expected the snooze to stay cleared")
+	}
+}
+
+func TestAnnotationStore_Unsnooze(t *testing.T) {
+	s := NewMemoryAnnotationStore(nil)
+	s.Snooze("a", time.Now().Add(time.Hour))
+	s.SnoozeUntilStatusChange("b", "running")
+	s.Unsnooze("a")
+	if s.Snoozed(Session{ID: "a"}) {
+		t.Error("expected a to be unsnoozed")
+	}
+	if n := s.UnsnoozeAll(); n != 1 {
+		t.Errorf("expected one remaining snooze, got %d", n)
+	}
+}
+
+func TestAnnotationStore_PinNoteAndTagsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "annotations.json")
+	s := NewAnnotationStoreFromPath(path)
+	if !s.TogglePin("a") {
+		t.Fatal("expected the first toggle to pin")
+	}
+	s.SetNote("a", "  waiting on review  ")
+	s.SetTags("a", []string{"#infra", "urgent", "Infra", " "})
+
+	a := NewAnnotationStoreFromPath(path).Get("a")
+	if !a.IsPinned() || a.Note != "waiting on review" {
+		t.Errorf("unexpected annotation after reload: %+v", a)
+	}
+	if len(a.Tags) != 2 || a.Tags[0] != "infra" || a.Tags[1] != "urgent" {
+		t.Errorf("expected normalized tags, got %v", a.Tags)
+	}
+	if !a.HasTag("URGENT") {
+		t.Error("expected HasTag to ignore case")
+	}
+
+	s.TogglePin("a")
+	s.SetNote("a", "")
+	s.SetTags("a", nil)
+	if got := s.Get("a"); got != nil {
+		t.Errorf("expected an emptied annotation to be dropped, got %+v", got)
+	}
+}
+
+func TestAnnotationStore_ExpireKeepsOtherAnnotations(t *testing.T) {
+	t.Cleanup(func() { Now = time.Now })
+	s := NewMemoryAnnotationStore(nil)
+	Now = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	s.Dismiss("a")
+	s.TogglePin("a")
+	Now = func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) }
+
+	if n := s.Expire(30 * 24 * time.Hour); n != 1 {
+		t.Fatalf("expected 1 expired dismissal, got %d", n)
+	}
+	if a := s.Get("a"); a.Dismissed() || !a.IsPinned() {
+		t.Errorf("expected the pin to survive expiry, got %+v", a)
+	}
+}
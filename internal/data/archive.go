@@ -0,0 +1,532 @@
+package data
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ArchiveIndexVersion is the archive index format written by this build.
+const ArchiveIndexVersion = 1
+
+const archiveIndexFile = "index.json"
+
+// ArchiveOrigin is the Origin of sessions read back from the archive.
+const ArchiveOrigin = "archive"
+
+// ArchiveOptions controls which sessions ArchiveSessions archives and what
+// happens to their directories afterwards.
+type ArchiveOptions struct {
+	// OlderThan is how long a session must have been idle to be archived.
+	OlderThan time.Duration
+	// MoveTo, when set, receives the session directories instead of them
+	// being deleted.
+	MoveTo string
+	// DryRun reports what would be archived without touching anything.
+	DryRun bool
+}
+
+// ArchiveResult describes one archive run.
+type ArchiveResult struct {
+	Path     string    // tarball written; empty for a dry run or when nothing qualified
+	Sessions []Session // sessions archived, or that would be for a dry run
+}
+
+// ArchivedSession is an archive index entry: where a session's files are
+// stored and the session as it was when archived.
+type ArchivedSession struct {
+	Archive    string        `json:"archive"` // tarball name within the archive directory
+	ArchivedAt time.Time     `json:"archivedAt"`
+	Session    SessionRecord `json:"session"`
+}
+
+type archiveIndex struct {
+	Version  int               `json:"version"`
+	Sessions []ArchivedSession `json:"sessions"`
+}
+
+var (
+	archiveMu  sync.Mutex
+	archiveDir string
+	// archiveCache holds the index last read, until the file changes.
+	archiveCache        *archiveIndex
+	archiveCacheModTime time.Time
+)
+
+// SetArchiveDir sets where archives are written and read from; empty
+// restores the default, ~/.gh-agent-viz/archive.
+func SetArchiveDir(dir string) {
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+	archiveDir = dir
+	archiveCache = nil
+}
+
+// ArchiveDir returns the directory archives are written and read from.
+func ArchiveDir() string {
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+	return archiveDirLocked()
+}
+
+func archiveDirLocked() string {
+	if archiveDir != "" {
+		return archiveDir
+	}
+	home, err := userHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gh-agent-viz", "archive")
+}
+
+// ArchiveSessions packs the finished local sessions idle for longer than
+// opts.OlderThan into a new tar.gz in the archive directory, records them in
+// the archive index and then deletes or moves their directories. Only the
+// default ~/.copilot root is archived: other roots are synced from other
+// machines, devcontainers or CI and are not this tool's to delete from.
+// Sessions with a live process are never archived.
+func ArchiveSessions(opts ArchiveOptions) (ArchiveResult, error) {
+	if fixtureActive() {
+		return ArchiveResult{}, fmt.Errorf("cannot archive while replaying a fixture")
+	}
+	type candidate struct {
+		session Session
+		dir     string
+	}
+	var candidates []candidate
+	seen := map[string]bool{}
+	for _, root := range SessionRoots() {
+		if root.Label != "" || root.SessionStateDir == "" {
+			continue
+		}
+		sessions, err := fetchLocalSessionsFromRoot(root)
+		if err != nil {
+			return ArchiveResult{}, err
+		}
+		for _, s := range sessions {
+			dir := filepath.Join(root.SessionStateDir, s.ID)
+			if seen[s.ID] || !archivable(s, opts.OlderThan) || hasLiveLock(dir) {
+				continue
+			}
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				continue
+			}
+			seen[s.ID] = true
+			candidates = append(candidates, candidate{s, dir})
+		}
+	}
+
+	var result ArchiveResult
+	for _, c := range candidates {
+		result.Sessions = append(result.Sessions, c.session)
+	}
+	if opts.DryRun || len(candidates) == 0 {
+		return result, nil
+	}
+	// Check where the sessions go before writing anything, so a failed
+	// move never leaves sessions both archived and live.
+	if opts.MoveTo != "" {
+		if err := os.MkdirAll(opts.MoveTo, 0o700); err != nil {
+			return ArchiveResult{}, err
+		}
+		for _, c := range candidates {
+			target := filepath.Join(opts.MoveTo, c.session.ID)
+			if _, err := os.Lstat(target); err == nil {
+				return ArchiveResult{}, fmt.Errorf("cannot move %s aside: %s already exists", c.session.ID, target)
+			}
+		}
+	}
+
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+	dir := archiveDirLocked()
+	if dir == "" {
+		return ArchiveResult{}, fmt.Errorf("no archive directory")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return ArchiveResult{}, err
+	}
+	now := Now().UTC()
+	name, err := reserveArchiveName(dir, now)
+	if err != nil {
+		return ArchiveResult{}, err
+	}
+	dirs := make(map[string]string, len(candidates))
+	for _, c := range candidates {
+		dirs[c.session.ID] = c.dir
+	}
+	if err := writeSessionTarball(filepath.Join(dir, name), dirs); err != nil {
+		os.Remove(filepath.Join(dir, name))
+		return ArchiveResult{}, err
+	}
+	result.Path = filepath.Join(dir, name)
+
+	index, err := readArchiveIndexLocked(dir)
+	if err != nil {
+		return result, err
+	}
+	updated := &archiveIndex{Sessions: append([]ArchivedSession(nil), index.Sessions...)}
+	for _, c := range candidates {
+		updated.Sessions = append(updated.Sessions, ArchivedSession{
+			Archive:    name,
+			ArchivedAt: now,
+			Session:    NewSessionRecord(c.session),
+		})
+	}
+	if err := writeArchiveIndexLocked(dir, updated); err != nil {
+		return result, err
+	}
+
+	// The sessions are safely archived; only now remove the originals.
+	var errs []error
+	for _, c := range candidates {
+		if opts.MoveTo != "" {
+			errs = append(errs, moveDir(c.dir, filepath.Join(opts.MoveTo, c.session.ID)))
+		} else {
+			errs = append(errs, os.RemoveAll(c.dir))
+		}
+	}
+	ResetLocalSessionCache()
+	return result, errors.Join(errs...)
+}
+
+// renameDir is os.Rename, replaceable in tests.
+var renameDir = os.Rename
+
+// moveDir moves the directory src to dst. Across filesystems, where rename
+// fails, it copies src and then removes it; a failed copy is cleaned up and
+// leaves src as it was.
+func moveDir(src, dst string) error {
+	err := renameDir(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err := copyDir(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+// copyDir copies the tree at src to dst, keeping permissions, symlinks and
+// modification times, which session status depends on.
+func copyDir(src, dst string) error {
+	var dirs []string
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			dirs = append(dirs, p)
+			return os.Mkdir(target, info.Mode().Perm()|0o700)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case !d.Type().IsRegular():
+			return nil
+		}
+		if err := copyFile(p, target, info.Mode().Perm()); err != nil {
+			return err
+		}
+		return os.Chtimes(target, info.ModTime(), info.ModTime())
+	})
+	if err != nil {
+		return err
+	}
+	// Directory times change as entries are added, so set them last.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		info, err := os.Stat(dirs[i])
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, dirs[i])
+		if err := os.Chtimes(filepath.Join(dst, rel), info.ModTime(), info.ModTime()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// reserveArchiveName creates an empty sessions-<time>.tar.gz in dir for the
+// tarball to replace, and returns its name. When another run, such as the
+// CLI and the TUI's palette, took the name within the same second, a
+// counter is added, so no archive is ever overwritten.
+func reserveArchiveName(dir string, now time.Time) (string, error) {
+	base := "sessions-" + now.Format("20060102-150405")
+	for n := 1; ; n++ {
+		name := base + ".tar.gz"
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d.tar.gz", base, n)
+		}
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return name, f.Close()
+	}
+}
+
+// archivable reports whether a session is finished and idle long enough.
+func archivable(s Session, olderThan time.Duration) bool {
+	switch s.Status {
+	case "completed", "failed":
+		return Since(s.UpdatedAt) > olderThan
+	}
+	return false
+}
+
+// hasLiveLock reports whether a session directory holds the lock file of a
+// running process.
+func hasLiveLock(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if pid, ok := lockPID(e.Name()); ok && isProcessAlive(pid) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSessionTarball writes each session directory into a gzipped tarball
+// at path, under the session ID. Lock files are left out. The file is
+// written under a temporary name and renamed once complete, replacing the
+// placeholder reserveArchiveName created.
+func writeSessionTarball(path string, dirs map[string]string) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".archive-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+	gz := gzip.NewWriter(tmp)
+	tw := tar.NewWriter(gz)
+	for id, dir := range dirs {
+		err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if _, isLock := lockPID(d.Name()); isLock || !(d.IsDir() || d.Type().IsRegular()) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			hdr, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			hdr.Name = filepath.ToSlash(filepath.Join(id, rel))
+			if d.IsDir() {
+				hdr.Name += "/"
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("archive %s: %w", id, err)
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// readArchiveIndexLocked returns the archive index in dir, empty if there is
+// none yet. archiveMu must be held.
+func readArchiveIndexLocked(dir string) (*archiveIndex, error) {
+	path := filepath.Join(dir, archiveIndexFile)
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &archiveIndex{Version: ArchiveIndexVersion}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if archiveCache != nil && info.ModTime().Equal(archiveCacheModTime) {
+		return archiveCache, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var index archiveIndex
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return nil, fmt.Errorf("parse archive index: %w", err)
+	}
+	if index.Version > ArchiveIndexVersion {
+		return nil, fmt.Errorf("archive index version %d is newer than this build supports (%d); upgrade gh-agent-viz", index.Version, ArchiveIndexVersion)
+	}
+	archiveCache, archiveCacheModTime = &index, info.ModTime()
+	return &index, nil
+}
+
+func writeArchiveIndexLocked(dir string, index *archiveIndex) error {
+	index.Version = ArchiveIndexVersion
+	raw, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, archiveIndexFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	archiveCache = nil
+	return os.Rename(tmp, path)
+}
+
+// FetchArchivedSessions returns the sessions in the archive, marked Archived
+// with the "archive" origin. They are read-only: their logs and events are
+// read from the tarballs.
+func FetchArchivedSessions() ([]Session, error) {
+	archiveMu.Lock()
+	defer archiveMu.Unlock()
+	dir := archiveDirLocked()
+	if dir == "" {
+		return nil, nil
+	}
+	index, err := readArchiveIndexLocked(dir)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]Session, 0, len(index.Sessions))
+	for _, entry := range index.Sessions {
+		s := entry.Session.Restore()
+		s.Archived = true
+		s.Origin = ArchiveOrigin
+		s.Host = ""
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+// openArchivedEvents opens the events.jsonl of an archived session inside
+// its tarball.
+func openArchivedEvents(sessionID string) (io.ReadCloser, error) {
+	archiveMu.Lock()
+	dir := archiveDirLocked()
+	var entry *ArchivedSession
+	if dir != "" {
+		if index, err := readArchiveIndexLocked(dir); err == nil {
+			for i := range index.Sessions {
+				if index.Sessions[i].Session.ID == sessionID {
+					entry = &index.Sessions[i]
+				}
+			}
+		}
+	}
+	archiveMu.Unlock()
+	if entry == nil {
+		return nil, fs.ErrNotExist
+	}
+
+	f, err := os.Open(filepath.Join(dir, entry.Archive))
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	want := sessionID + "/events.jsonl"
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			f.Close()
+			if err == io.EOF {
+				return nil, fs.ErrNotExist
+			}
+			return nil, err
+		}
+		if strings.TrimPrefix(hdr.Name, "./") == want {
+			return struct {
+				io.Reader
+				io.Closer
+			}{tr, f}, nil
+		}
+	}
+}
+
+// openSessionEvents opens a local session's events.jsonl from the first
+// session root that has the session, falling back to the archive.
+func openSessionEvents(sessionID string) (io.ReadCloser, error) {
+	if path := sessionEventsPath(sessionID); path != "" {
+		return os.Open(path)
+	}
+	return openArchivedEvents(sessionID)
+}
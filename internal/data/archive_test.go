@@ -0,0 +1,214 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// ageSession backdates a session's event log so it counts as idle.
+func ageSession(t *testing.T, root SessionRoot, id string, age time.Duration) {
+	t.Helper()
+	at := time.Now().Add(-age)
+	if err := os.Chtimes(filepath.Join(root.SessionStateDir, id, "events.jsonl"), at, at); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func setupArchive(t *testing.T) (SessionRoot, string) {
+	t.Helper()
+	base := t.TempDir()
+	root := CopilotRoot("", filepath.Join(base, ".copilot"))
+	SetSessionRoots([]SessionRoot{root})
+	SetArchiveDir(filepath.Join(base, "archive"))
+	t.Cleanup(func() { SetSessionRoots(nil); SetArchiveDir("") })
+
+	writeRootSession(t, root, "old-done", "Old work")
+	ageSession(t, root, "old-done", 40*24*time.Hour)
+	writeRootSession(t, root, "recent-done", "Recent work")
+	return root, base
+}
+
+func TestArchiveSessions(t *testing.T) {
+	root, _ := setupArchive(t)
+
+	dry, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(dry.Sessions) != 1 || dry.Sessions[0].ID != "old-done" || dry.Path != "" {
+		t.Fatalf("expected a dry run listing only the old session, got %+v", dry)
+	}
+	if _, err := os.Stat(filepath.Join(root.SessionStateDir, "old-done")); err != nil {
+		t.Fatalf("dry run must not touch the session: %v", err)
+	}
+
+	result, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("ArchiveSessions: %v", err)
+	}
+	if _, err := os.Stat(result.Path); err != nil || !strings.HasSuffix(result.Path, ".tar.gz") {
+		t.Fatalf("expected a tarball, got %q: %v", result.Path, err)
+	}
+	if _, err := os.Stat(filepath.Join(root.SessionStateDir, "old-done")); !os.IsNotExist(err) {
+		t.Errorf("expected the archived directory to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root.SessionStateDir, "recent-done")); err != nil {
+		t.Errorf("expected the recent session to stay: %v", err)
+	}
+
+	local, _ := FetchLocalSessions()
+	if len(local) != 1 || local[0].ID != "recent-done" {
+		t.Errorf("expected only the recent session to remain local, got %+v", local)
+	}
+	archived, err := FetchArchivedSessions()
+	if err != nil {
+		t.Fatalf("FetchArchivedSessions: %v", err)
+	}
+	if len(archived) != 1 || !archived[0].Archived || archived[0].Origin != ArchiveOrigin || archived[0].Title != "Old work" {
+		t.Fatalf("unexpected archived sessions: %+v", archived)
+	}
+
+	// The archived conversation stays readable from the tarball.
+	log, err := FetchLocalSessionLog("old-done")
+	if err != nil || !strings.Contains(log, "hello from Old work") {
+		t.Errorf("expected the log from the archive, got %q, %v", log, err)
+	}
+	if events, err := FetchSessionEvents("old-done"); err != nil || len(events) != 1 {
+		t.Errorf("expected the archived events, got %+v, %v", events, err)
+	}
+
+	// A second run with nothing to do leaves the index alone.
+	again, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour})
+	if err != nil || len(again.Sessions) != 0 || again.Path != "" {
+		t.Errorf("expected nothing to archive, got %+v, %v", again, err)
+	}
+}
+
+func TestArchiveSessions_MoveTo(t *testing.T) {
+	root, base := setupArchive(t)
+	moveTo := filepath.Join(base, "moved")
+
+	if _, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour, MoveTo: moveTo}); err != nil {
+		t.Fatalf("ArchiveSessions: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(moveTo, "old-done", "workspace.yaml")); err != nil {
+		t.Errorf("expected the session moved aside: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root.SessionStateDir, "old-done")); !os.IsNotExist(err) {
+		t.Errorf("expected the original directory gone, got %v", err)
+	}
+}
+
+func TestArchiveSessions_MoveToAcrossFilesystems(t *testing.T) {
+	root, base := setupArchive(t)
+	moveTo := filepath.Join(base, "moved")
+	renameDir = func(src, dst string) error {
+		return &os.LinkError{Op: "rename", Old: src, New: dst, Err: syscall.EXDEV}
+	}
+	t.Cleanup(func() { renameDir = os.Rename })
+	src := filepath.Join(root.SessionStateDir, "old-done", "events.jsonl")
+	before, err := os.Stat(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour, MoveTo: moveTo}); err != nil {
+		t.Fatalf("ArchiveSessions: %v", err)
+	}
+	moved, err := os.Stat(filepath.Join(moveTo, "old-done", "events.jsonl"))
+	if err != nil || !moved.ModTime().Equal(before.ModTime()) {
+		t.Errorf("expected the session copied with its times, got %v, %v", moved, err)
+	}
+	if _, err := os.Stat(filepath.Join(root.SessionStateDir, "old-done")); !os.IsNotExist(err) {
+		t.Errorf("expected the original directory gone, got %v", err)
+	}
+}
+
+func TestArchiveSessions_UnusableMoveToWritesNothing(t *testing.T) {
+	root, base := setupArchive(t)
+	moveTo := filepath.Join(base, "moved")
+	if err := os.WriteFile(moveTo, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour, MoveTo: moveTo}); err == nil {
+		t.Fatal("expected a MoveTo that is a file to fail")
+	}
+	if _, err := os.Stat(filepath.Join(root.SessionStateDir, "old-done")); err != nil {
+		t.Errorf("expected the session left live: %v", err)
+	}
+	if archived, _ := FetchArchivedSessions(); len(archived) != 0 {
+		t.Errorf("expected nothing indexed, got %+v", archived)
+	}
+	if tarballs, _ := filepath.Glob(filepath.Join(base, "archive", "*.tar.gz")); len(tarballs) != 0 {
+		t.Errorf("expected no tarball, got %v", tarballs)
+	}
+}
+
+func TestArchiveSessions_SkipsLiveProcess(t *testing.T) {
+	root, _ := setupArchive(t)
+	lock := filepath.Join(root.SessionStateDir, "old-done", "inuse.4242.lock")
+	if err := os.WriteFile(lock, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orig := isProcessAlive
+	isProcessAlive = func(pid int) bool { return pid == 4242 }
+	t.Cleanup(func() { isProcessAlive = orig })
+
+	result, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Sessions) != 0 {
+		t.Errorf("expected a session with a live process to be skipped, got %+v", result.Sessions)
+	}
+}
+
+func TestArchiveSessions_SameSecondKeepsBothTarballs(t *testing.T) {
+	root, _ := setupArchive(t)
+	at := time.Now()
+	origNow := Now
+	Now = func() time.Time { return at }
+	t.Cleanup(func() { Now = origNow })
+
+	first, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeRootSession(t, root, "another-old", "More old work")
+	ageSession(t, root, "another-old", 40*24*time.Hour)
+	second, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Path == second.Path {
+		t.Fatalf("expected a new tarball for the second run, both wrote %s", first.Path)
+	}
+	for _, id := range []string{"old-done", "another-old"} {
+		if log, err := FetchLocalSessionLog(id); err != nil || log == "" {
+			t.Errorf("expected %s still readable from its tarball, got %v", id, err)
+		}
+	}
+}
+
+func TestArchiveSessions_OnlyDefaultRoot(t *testing.T) {
+	base := t.TempDir()
+	synced := CopilotRoot("devbox", filepath.Join(base, "devbox"))
+	SetSessionRoots([]SessionRoot{synced})
+	SetArchiveDir(filepath.Join(base, "archive"))
+	t.Cleanup(func() { SetSessionRoots(nil); SetArchiveDir("") })
+	writeRootSession(t, synced, "synced-done", "Synced work")
+	ageSession(t, synced, "synced-done", 40*24*time.Hour)
+
+	result, err := ArchiveSessions(ArchiveOptions{OlderThan: 30 * 24 * time.Hour})
+	if err != nil || len(result.Sessions) != 0 {
+		t.Fatalf("expected nothing archived from another root, got %+v, %v", result, err)
+	}
+	if _, err := os.Stat(filepath.Join(synced.SessionStateDir, "synced-done")); err != nil {
+		t.Errorf("expected the synced session left in place: %v", err)
+	}
+}
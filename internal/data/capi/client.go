@@ -51,6 +51,18 @@ func NewClient() (*Client, error) {
 	}, nil
 }
 
+// NewClientWithTransport creates a CAPI client that sends requests through rt
+// as-is, without resolving a token or adding auth headers. Fixtures use it to
+// record and replay API exchanges.
+func NewClientWithTransport(rt http.RoundTripper) *Client {
+	return &Client{httpClient: &http.Client{Transport: rt}}
+}
+
+// Transport returns the round tripper the client sends requests through.
+func (c *Client) Transport() http.RoundTripper {
+	return c.httpClient.Transport
+}
+
 // resolveToken retrieves the user's gh OAuth token for github.com.
 func resolveToken() (string, error) {
 	host, _ := auth.DefaultHost()
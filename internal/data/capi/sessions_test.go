@@ -3,8 +3,10 @@ package capi
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -218,3 +220,38 @@ func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	newReq.Header = req.Header
 	return t.base.RoundTrip(newReq)
 }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestNewClientWithTransport(t *testing.T) {
+	var gotAuth, gotURL string
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotAuth = req.Header.Get("Authorization")
+		gotURL = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("log line")),
+			Header:     http.Header{},
+		}, nil
+	})
+
+	client := NewClientWithTransport(rt)
+	logs, err := client.GetSessionLogs(context.Background(), "sess-1")
+	if err != nil {
+		t.Fatalf("GetSessionLogs() error = %v", err)
+	}
+	if logs != "log line" {
+		t.Errorf("logs = %q, want %q", logs, "log line")
+	}
+	if gotAuth != "" {
+		t.Errorf("expected no auth header, got %q", gotAuth)
+	}
+	if gotURL != baseCAPIURL+"/agents/sessions/sess-1/logs" {
+		t.Errorf("URL = %q", gotURL)
+	}
+	if client.Transport() == nil {
+		t.Error("expected Transport() to return the round tripper")
+	}
+}
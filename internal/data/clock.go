@@ -0,0 +1,13 @@
+package data
+
+import "time"
+
+// Now returns the current time. Everything that renders relative ages
+// ("5m ago", the 7-day trend, the attention cutoff) reads the clock through
+// Now, so replaying a snapshot can pin it to the moment of capture.
+var Now = time.Now
+
+// Since returns the time elapsed since t according to Now.
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
@@ -51,6 +51,7 @@ func DemoSessions() []Session {
 			Branch:     "test/auth-module-tests",
 			PRNumber:   247,
 			PRURL:      "https://github.com/acme/web-app/pull/247",
+			PR:         &PRStatus{Number: 247, URL: "https://github.com/acme/web-app/pull/247", State: "MERGED", Mergeable: "UNKNOWN", ReviewDecision: "APPROVED", Checks: "SUCCESS"},
 			CreatedAt:  now.Add(-5 * time.Hour),
 			UpdatedAt:  now.Add(-3 * time.Hour),
 			Source:     SourceAgentTask,
@@ -124,6 +125,7 @@ func DemoSessions() []Session {
 			Branch:     "docs/api-update",
 			PRNumber:   89,
 			PRURL:      "https://github.com/acme/api-server/pull/89",
+			PR:         &PRStatus{Number: 89, URL: "https://github.com/acme/api-server/pull/89", State: "OPEN", Mergeable: "MERGEABLE", ReviewDecision: "REVIEW_REQUIRED", Checks: "FAILURE"},
 			CreatedAt:  now.Add(-6 * time.Hour),
 			UpdatedAt:  now.Add(-4 * time.Hour),
 			Source:     SourceAgentTask,
@@ -160,6 +162,7 @@ func DemoSessions() []Session {
 			Branch:     "chore/update-ci-pipeline",
 			PRNumber:   156,
 			PRURL:      "https://github.com/acme/mobile-app/pull/156",
+			PR:         &PRStatus{Number: 156, URL: "https://github.com/acme/mobile-app/pull/156", State: "OPEN", Mergeable: "MERGEABLE", ReviewDecision: "REVIEW_REQUIRED", Checks: "SUCCESS"},
 			CreatedAt:  now.Add(-8 * time.Hour),
 			UpdatedAt:  now.Add(-6 * time.Hour),
 			Source:     SourceAgentTask,
@@ -0,0 +1,408 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/data/capi"
+)
+
+// FixtureVersion is the bundle format written by StartRecording.
+const FixtureVersion = 1
+
+// fixtureIndex is the file inside a bundle that lists recorded exchanges.
+const fixtureIndex = "fixture.json"
+
+// fixtureHome replaces the recording user's home directory in everything
+// written to a bundle.
+const fixtureHome = "/home/user"
+
+// Fixture is the index of a recorded bundle: every gh invocation and Copilot
+// API exchange made while recording. Copies of each session root's
+// session-state and log files live beside it under roots/<n>.
+type Fixture struct {
+	Version    int             `json:"version"`
+	RecordedAt time.Time       `json:"recordedAt"`
+	Roots      []FixtureRoot   `json:"roots"`
+	Commands   []CommandRecord `json:"commands"`
+	HTTP       []HTTPRecord    `json:"http"`
+	LivePIDs   []int           `json:"livePids,omitempty"`
+}
+
+// FixtureRoot is a session root copied into a bundle.
+type FixtureRoot struct {
+	Label string `json:"label,omitempty"`
+	Dir   string `json:"dir"` // relative to the bundle, laid out like ~/.copilot
+}
+
+// CommandRecord is one recorded gh invocation.
+type CommandRecord struct {
+	Args     []string `json:"args"`
+	Output   string   `json:"output"`
+	ExitCode int      `json:"exitCode"`
+	Error    string   `json:"error,omitempty"` // set when gh could not be started
+}
+
+// HTTPRecord is one recorded Copilot API exchange. Request headers, which
+// carry the auth token, are not recorded.
+type HTTPRecord struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+}
+
+// Recorder captures live data access into a fixture bundle.
+type Recorder struct {
+	dir     string
+	home    string
+	restore func()
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// StartRecording starts capturing gh output and Copilot API exchanges for a
+// fixture bundle in dir. Data access keeps working normally; call Stop to
+// write the bundle.
+func StartRecording(dir string) (*Recorder, error) {
+	home, err := userHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create fixture directory: %w", err)
+	}
+
+	r := &Recorder{dir: dir, home: home, fixture: Fixture{Version: FixtureVersion}}
+	origGH, origCAPI := ghOutput, newCAPIClient
+	ghOutput = func(args ...string) ([]byte, error) {
+		out, err := origGH(args...)
+		r.recordCommand(args, out, err)
+		return out, err
+	}
+	newCAPIClient = func() (*capi.Client, error) {
+		c, err := origCAPI()
+		if err != nil {
+			return nil, err
+		}
+		return capi.NewClientWithTransport(&recordingTransport{base: c.Transport(), rec: r}), nil
+	}
+	r.restore = func() { ghOutput, newCAPIClient = origGH, origCAPI }
+	return r, nil
+}
+
+// Stop restores live data access, copies the session-state and recent log
+// files of every session root into the bundle and writes its index. GitHub
+// tokens, bearer credentials and the home directory are scrubbed from
+// everything written; nothing else is, so session files keep their prompts,
+// command output and file contents.
+func (r *Recorder) Stop() error {
+	r.restore()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, root := range SessionRoots() {
+		rel := filepath.Join("roots", strconv.Itoa(i))
+		dst := filepath.Join(r.dir, rel)
+		if err := r.copySessionState(root.SessionStateDir, filepath.Join(dst, "session-state")); err != nil {
+			return err
+		}
+		if err := r.copyLogs(root.LogDir, filepath.Join(dst, "logs")); err != nil {
+			return err
+		}
+		r.fixture.Roots = append(r.fixture.Roots, FixtureRoot{Label: root.Label, Dir: filepath.ToSlash(rel)})
+	}
+
+	r.fixture.RecordedAt = Now().UTC()
+	out, err := json.MarshalIndent(r.fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, fixtureIndex), out, 0o600)
+}
+
+func (r *Recorder) recordCommand(args []string, out []byte, err error) {
+	rec := CommandRecord{Output: r.scrub(string(out))}
+	for _, a := range args {
+		rec.Args = append(rec.Args, r.scrub(a))
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		rec.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		rec.ExitCode = -1
+		rec.Error = r.scrub(err.Error())
+	}
+	r.mu.Lock()
+	r.fixture.Commands = append(r.fixture.Commands, rec)
+	r.mu.Unlock()
+}
+
+func (r *Recorder) recordHTTP(rec HTTPRecord) {
+	rec.Body = r.scrub(rec.Body)
+	r.mu.Lock()
+	r.fixture.HTTP = append(r.fixture.HTTP, rec)
+	r.mu.Unlock()
+}
+
+// copySessionState copies every session directory, noting which lock-file
+// PIDs were alive so replay reports the same running sessions.
+func (r *Recorder) copySessionState(src, dst string) error {
+	return r.copyTree(src, dst, func(path string, _ fs.FileInfo) bool {
+		if pid, ok := lockPID(filepath.Base(path)); ok && isProcessAlive(pid) {
+			r.fixture.LivePIDs = append(r.fixture.LivePIDs, pid)
+		}
+		return true
+	})
+}
+
+// copyLogs copies the process logs recent enough to count toward token usage.
+func (r *Recorder) copyLogs(src, dst string) error {
+	cutoff := Now().Add(-tokenUsageWindow)
+	return r.copyTree(src, dst, func(path string, info fs.FileInfo) bool {
+		name := filepath.Base(path)
+		return strings.HasPrefix(name, "process-") && strings.HasSuffix(name, ".log") &&
+			!info.ModTime().Before(cutoff)
+	})
+}
+
+// copyTree copies the regular files under src that keep accepts, scrubbing
+// their contents and preserving modification times, which session status
+// and token usage depend on. A missing src is not an error.
+func (r *Recorder) copyTree(src, dst string, keep func(string, fs.FileInfo) bool) error {
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil || !keep(path, info) {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, []byte(r.scrub(string(content))), 0o600); err != nil {
+			return err
+		}
+		return os.Chtimes(target, info.ModTime(), info.ModTime())
+	})
+}
+
+var (
+	// githubTokenRe matches GitHub OAuth, personal, user, server and refresh
+	// tokens, including fine-grained personal access tokens.
+	githubTokenRe = regexp.MustCompile(`\b(?:gh[opusr]_[A-Za-z0-9]{16,}|github_pat_[A-Za-z0-9_]{16,})\b`)
+	// bearerRe matches bearer credentials in headers echoed to output.
+	bearerRe = regexp.MustCompile(`(?i)(bearer|token)(\s+)[A-Za-z0-9._~+/=-]{16,}`)
+)
+
+// scrub removes credentials from s and replaces the home directory with a
+// neutral path.
+func (r *Recorder) scrub(s string) string {
+	s = githubTokenRe.ReplaceAllString(s, "[REDACTED]")
+	s = bearerRe.ReplaceAllString(s, "$1$2[REDACTED]")
+	if r.home != "" && r.home != "/" {
+		s = strings.ReplaceAll(s, r.home, fixtureHome)
+		if slashed := filepath.ToSlash(r.home); slashed != r.home {
+			s = strings.ReplaceAll(s, slashed, fixtureHome)
+		}
+	}
+	return s
+}
+
+// recordingTransport passes requests through to the live API and records
+// each exchange.
+type recordingTransport struct {
+	base http.RoundTripper
+	rec  *Recorder
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.rec.recordHTTP(HTTPRecord{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Body:   string(body),
+	})
+	return resp, nil
+}
+
+// lockPID extracts the PID from an inuse.{PID}.lock file name.
+func lockPID(name string) (int, bool) {
+	parts := strings.Split(name, ".")
+	if len(parts) != 3 || parts[0] != "inuse" || parts[2] != "lock" {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(parts[1])
+	return pid, err == nil
+}
+
+// ReadFixture reads the index of the fixture bundle in dir.
+func ReadFixture(dir string) (*Fixture, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, fixtureIndex))
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+	var fx Fixture
+	if err := json.Unmarshal(raw, &fx); err != nil {
+		return nil, fmt.Errorf("parse fixture: %w", err)
+	}
+	if fx.Version > FixtureVersion {
+		return nil, fmt.Errorf("fixture version %d is newer than supported version %d", fx.Version, FixtureVersion)
+	}
+	return &fx, nil
+}
+
+// ReplayFixture answers data requests from the fixture bundle in dir:
+// FetchAllSessions, FetchTokenUsage and the log fetchers read the bundle's
+// copies of the session roots, gh invocations and Copilot API requests get
+// the recorded responses, and the clock is pinned to the recording time. The
+// returned function restores live data access.
+func ReplayFixture(dir string) (restore func(), err error) {
+	fx, err := ReadFixture(dir)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	// A bundle recorded without roots still gets one, empty, so local
+	// sessions come back empty rather than from the live home directory.
+	roots := []SessionRoot{CopilotRoot("", filepath.Join(abs, "roots", "0"))}
+	if len(fx.Roots) > 0 {
+		roots = roots[:0]
+		for _, r := range fx.Roots {
+			roots = append(roots, CopilotRoot(r.Label, filepath.Join(abs, filepath.FromSlash(r.Dir))))
+		}
+	}
+
+	p := newFixturePlayer(fx)
+	live := make(map[int]bool, len(fx.LivePIDs))
+	for _, pid := range fx.LivePIDs {
+		live[pid] = true
+	}
+
+	origGH, origCAPI, origAlive, origNow := ghOutput, newCAPIClient, isProcessAlive, Now
+	ghOutput = p.gh
+	newCAPIClient = func() (*capi.Client, error) { return capi.NewClientWithTransport(p), nil }
+	isProcessAlive = func(pid int) bool { return live[pid] }
+	if at := fx.RecordedAt; !at.IsZero() {
+		Now = func() time.Time { return at }
+	}
+	setFixtureRoots(roots)
+
+	return func() {
+		ghOutput, newCAPIClient, isProcessAlive, Now = origGH, origCAPI, origAlive, origNow
+		setFixtureRoots(nil)
+	}, nil
+}
+
+// fixturePlayer serves recorded responses in the order they were recorded.
+// Once a request's recordings run out, the last one is repeated.
+type fixturePlayer struct {
+	mu       sync.Mutex
+	commands map[string][]CommandRecord
+	http     map[string][]HTTPRecord
+}
+
+func newFixturePlayer(fx *Fixture) *fixturePlayer {
+	p := &fixturePlayer{
+		commands: make(map[string][]CommandRecord),
+		http:     make(map[string][]HTTPRecord),
+	}
+	for _, c := range fx.Commands {
+		key := strings.Join(c.Args, " ")
+		p.commands[key] = append(p.commands[key], c)
+	}
+	for _, h := range fx.HTTP {
+		key := h.Method + " " + h.URL
+		p.http[key] = append(p.http[key], h)
+	}
+	return p
+}
+
+func (p *fixturePlayer) gh(args ...string) ([]byte, error) {
+	key := strings.Join(args, " ")
+	p.mu.Lock()
+	c, ok := nextRecord(p.commands, key)
+	p.mu.Unlock()
+	if !ok {
+		// "not found" stops runGH from retrying.
+		return []byte("gh " + key + ": not found in fixture"), fmt.Errorf("exit status 1")
+	}
+	switch {
+	case c.Error != "":
+		return []byte(c.Output), errors.New(c.Error)
+	case c.ExitCode != 0:
+		return []byte(c.Output), fmt.Errorf("exit status %d", c.ExitCode)
+	}
+	return []byte(c.Output), nil
+}
+
+func (p *fixturePlayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.mu.Lock()
+	h, ok := nextRecord(p.http, req.Method+" "+req.URL.String())
+	p.mu.Unlock()
+	if !ok {
+		h = HTTPRecord{Status: http.StatusNotFound, Body: "not found in fixture"}
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", h.Status, http.StatusText(h.Status)),
+		StatusCode: h.Status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(h.Body)),
+		Request:    req,
+	}, nil
+}
+
+// nextRecord pops the next recording for key, keeping the last one.
+func nextRecord[T any](records map[string][]T, key string) (T, bool) {
+	queue := records[key]
+	if len(queue) == 0 {
+		var zero T
+		return zero, false
+	}
+	next := queue[0]
+	if len(queue) > 1 {
+		records[key] = queue[1:]
+	}
+	return next, true
+}
@@ -0,0 +1,274 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBranchCommits caps how many commits of a session branch are listed.
+const maxBranchCommits = 200
+
+// maxUntrackedFiles caps how many untracked files are rendered as new-file
+// diffs; agents sometimes leave build output outside .gitignore.
+const maxUntrackedFiles = 50
+
+// maxUntrackedBytes caps the size of an untracked file rendered as a
+// new-file diff; larger ones are listed with their size instead.
+const maxUntrackedBytes = 256 << 10
+
+// GitCommit is one commit on a session branch.
+type GitCommit struct {
+	Hash    string
+	Short   string
+	Author  string
+	When    time.Time
+	Subject string
+}
+
+// GitBranchResult describes the work committed on a session branch since
+// it forked from the repository's default branch.
+type GitBranchResult struct {
+	Branch    string      // current branch, or "HEAD" when detached
+	Base      string      // default branch compared against, e.g. origin/main
+	MergeBase string      // commit the branch forked from
+	Commits   []GitCommit // newest first, since MergeBase
+	Diff      string      // cumulative unified diff from MergeBase to HEAD
+	FileCount int
+	Additions int
+	Deletions int
+}
+
+// cachedBranch is a branch comparison together with the commits it was
+// made between, so it is only redone once either moves.
+type cachedBranch struct {
+	branch, head, base string
+	result             GitBranchResult
+}
+
+// cachedUntracked is an untracked file's new-file diff, kept until the
+// file changes.
+type cachedUntracked struct {
+	modTime time.Time
+	size    int64
+	patch   string
+	adds    int
+}
+
+var (
+	gitCacheMu     sync.Mutex
+	branchCache    = map[string]cachedBranch{}               // by working directory
+	untrackedCache = map[string]map[string]cachedUntracked{} // by working directory, then file
+)
+
+// FetchSessionBranch compares HEAD in workDir against the merge-base with
+// the default branch. It returns an error when no default branch can be
+// found, e.g. in a repository without main, master or origin/HEAD. The
+// comparison is reused until HEAD, the branch or the default branch moves.
+func FetchSessionBranch(workDir string) (*GitBranchResult, error) {
+	if workDir == "" {
+		return nil, fmt.Errorf("no working directory specified")
+	}
+	base, err := defaultBranch(workDir)
+	if err != nil {
+		return nil, err
+	}
+	branch, _ := runGit(workDir, "rev-parse", "--abbrev-ref", "HEAD")
+	revs, _ := runGit(workDir, "rev-parse", "HEAD", base)
+	head, baseRev, _ := strings.Cut(revs, "\n")
+	gitCacheMu.Lock()
+	cached, ok := branchCache[workDir]
+	gitCacheMu.Unlock()
+	if ok && revs != "" && cached.branch == branch && cached.head == head && cached.base == baseRev {
+		result := cached.result
+		return &result, nil
+	}
+
+	result, err := compareBranch(workDir, branch, base)
+	if err != nil {
+		return nil, err
+	}
+	if revs != "" {
+		gitCacheMu.Lock()
+		branchCache[workDir] = cachedBranch{branch: branch, head: head, base: baseRev, result: *result}
+		gitCacheMu.Unlock()
+	}
+	return result, nil
+}
+
+// compareBranch lists the commits and the cumulative diff of HEAD in
+// workDir since its merge-base with base.
+func compareBranch(workDir, branch, base string) (*GitBranchResult, error) {
+	mergeBase, err := runGit(workDir, "merge-base", base, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("git merge-base %s failed: %w", base, err)
+	}
+
+	result := &GitBranchResult{Branch: branch, Base: base, MergeBase: mergeBase}
+	log, err := runGit(workDir, "log", "--no-color", "-n", strconv.Itoa(maxBranchCommits),
+		"--format=%H%x1f%h%x1f%an%x1f%at%x1f%s", mergeBase+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+	result.Commits = parseCommitLog(log)
+	if len(result.Commits) == 0 {
+		return result, nil
+	}
+
+	if result.Diff, err = runGit(workDir, "diff", "--no-color", mergeBase, "HEAD"); err != nil {
+		return nil, fmt.Errorf("git diff %s failed: %w", base, err)
+	}
+	numstat, _ := runGit(workDir, "diff", "--numstat", "--no-color", mergeBase, "HEAD")
+	result.FileCount, result.Additions, result.Deletions = sumNumstat(numstat)
+	return result, nil
+}
+
+// FetchCommitDiff returns the unified diff a single commit introduced.
+func FetchCommitDiff(workDir, hash string) (string, error) {
+	if workDir == "" {
+		return "", fmt.Errorf("no working directory specified")
+	}
+	if hash == "" || strings.HasPrefix(hash, "-") {
+		return "", fmt.Errorf("invalid commit %q", hash)
+	}
+	diff, err := runGit(workDir, "show", "--no-color", "--format=", hash)
+	if err != nil {
+		return "", fmt.Errorf("git show %s failed: %w", hash, err)
+	}
+	return diff, nil
+}
+
+// defaultBranch finds the branch a session branch should be compared
+// against: origin's HEAD when it is known, otherwise the first of
+// origin/main, origin/master, main and master that exists.
+func defaultBranch(workDir string) (string, error) {
+	if ref, err := runGit(workDir, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil && ref != "" {
+		return ref, nil
+	}
+	for _, ref := range []string{"origin/main", "origin/master", "main", "master"} {
+		if _, err := runGit(workDir, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err == nil {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("no default branch found")
+}
+
+// parseCommitLog parses git log output formatted as unit-separated hash,
+// short hash, author, author timestamp and subject.
+func parseCommitLog(out string) []GitCommit {
+	var commits []GitCommit
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\x1f", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		c := GitCommit{Hash: fields[0], Short: fields[1], Author: fields[2], Subject: fields[4]}
+		if ts, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			c.When = time.Unix(ts, 0)
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// untrackedFiles lists files git does not track and does not ignore. The
+// list is NUL-separated, so names git would quote come through as they are.
+func untrackedFiles(workDir string) []string {
+	out, err := execCommand("git", "-C", workDir, "ls-files", "-z", "--others", "--exclude-standard").Output()
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, f := range strings.Split(string(out), "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// untrackedDiff renders untracked files as new-file diffs, so they show
+// alongside staged and unstaged changes. A file's diff is reused until its
+// size or modification time changes, so polling doesn't rerun git for
+// every file.
+func untrackedDiff(workDir string, files []string) (diff string, adds int) {
+	gitCacheMu.Lock()
+	previous := untrackedCache[workDir]
+	gitCacheMu.Unlock()
+	current := map[string]cachedUntracked{}
+
+	var parts []string
+	for i, f := range files {
+		if i == maxUntrackedFiles {
+			break
+		}
+		info, err := os.Stat(filepath.Join(workDir, f))
+		if err != nil {
+			continue
+		}
+		entry, ok := previous[f]
+		if !ok || !entry.modTime.Equal(info.ModTime()) || entry.size != info.Size() {
+			entry = cachedUntracked{modTime: info.ModTime(), size: info.Size()}
+			if info.Size() > maxUntrackedBytes {
+				entry.patch = largeFileDiff(f, info.Size())
+			} else if entry.patch, entry.adds, ok = newFileDiff(workDir, f); !ok {
+				continue
+			}
+		}
+		current[f] = entry
+		if entry.patch == "" {
+			continue
+		}
+		adds += entry.adds
+		parts = append(parts, entry.patch)
+	}
+
+	gitCacheMu.Lock()
+	untrackedCache[workDir] = current
+	gitCacheMu.Unlock()
+	return strings.Join(parts, "\n"), adds
+}
+
+// newFileDiff renders one untracked file as a new-file diff and counts its
+// added lines. It reports false when git failed.
+func newFileDiff(workDir, file string) (patch string, adds int, ok bool) {
+	// --no-index exits 1 when the files differ, which they always do
+	// against /dev/null.
+	out, err := execCommand("git", "-C", workDir, "diff", "--no-color", "--no-index", "--", "/dev/null", file).Output()
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return "", 0, false
+	}
+	patch = strings.TrimSpace(string(out))
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+			adds++
+		}
+	}
+	return patch, adds, true
+}
+
+// largeFileDiff stands in for the new-file diff of an untracked file too
+// large to render, naming it and its size.
+func largeFileDiff(file string, size int64) string {
+	return fmt.Sprintf("diff --git a/%[1]s b/%[1]s\nnew file mode 100644\n--- /dev/null\n+++ b/%[1]s\n(%[2]d bytes, not shown)", file, size)
+}
+
+// runGit runs a git subcommand in workDir and returns its trimmed output.
+func runGit(workDir string, args ...string) (string, error) {
+	out, err := execCommand("git", append([]string{"-C", workDir}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
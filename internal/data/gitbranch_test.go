@@ -0,0 +1,210 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initBranchRepo creates a repository with one commit on main and two on a
+// feature branch, which is left checked out.
+func initBranchRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Agent", "GIT_AUTHOR_EMAIL=agent@example.com",
+			"GIT_COMMITTER_NAME=Agent", "GIT_COMMITTER_EMAIL=agent@example.com",
+			"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	write("README.md", "hello\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	git("checkout", "-q", "-b", "copilot/fix")
+	write("fix.go", "package fix\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "add fix")
+	write("README.md", "hello\nworld\n")
+	git("commit", "-q", "-am", "update readme")
+	return dir
+}
+
+func TestFetchSessionBranch_CommitsSinceMergeBase(t *testing.T) {
+	dir := initBranchRepo(t)
+
+	result, err := FetchSessionBranch(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Branch != "copilot/fix" || result.Base != "main" || result.MergeBase == "" {
+		t.Fatalf("unexpected branch info %+v", result)
+	}
+	if len(result.Commits) != 2 || result.Commits[0].Subject != "update readme" || result.Commits[1].Subject != "add fix" {
+		t.Fatalf("expected the two branch commits newest first, got %+v", result.Commits)
+	}
+	if c := result.Commits[0]; c.Author != "Agent" || c.Short == "" || c.When.IsZero() {
+		t.Errorf("unexpected commit %+v", c)
+	}
+	if result.FileCount != 2 || result.Additions != 2 || result.Deletions != 0 {
+		t.Errorf("unexpected cumulative stats files=%d adds=%d dels=%d", result.FileCount, result.Additions, result.Deletions)
+	}
+	if !strings.Contains(result.Diff, "+package fix") || !strings.Contains(result.Diff, "+world") {
+		t.Errorf("expected the cumulative diff, got %q", result.Diff)
+	}
+
+	diff, err := FetchCommitDiff(dir, result.Commits[1].Hash)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(diff, "+package fix") || strings.Contains(diff, "+world") {
+		t.Errorf("expected only the first commit's changes, got %q", diff)
+	}
+}
+
+func TestFetchSessionGitDiff_CommittedAndUntracked(t *testing.T) {
+	dir := initBranchRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("one\ntwo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := FetchSessionGitDiff(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Untracked) != 1 || result.Untracked[0] != "notes.txt" {
+		t.Fatalf("expected the untracked file, got %v", result.Untracked)
+	}
+	if result.FileCount != 1 || result.Additions != 2 || !strings.Contains(result.Diff, "+++ b/notes.txt") {
+		t.Errorf("expected the untracked file as a new-file diff, got files=%d adds=%d diff=%q",
+			result.FileCount, result.Additions, result.Diff)
+	}
+	if result.Branch == nil || len(result.Branch.Commits) != 2 {
+		t.Errorf("expected branch commits alongside uncommitted changes, got %+v", result.Branch)
+	}
+}
+
+func TestFetchSessionGitDiff_ReusesUnchangedWork(t *testing.T) {
+	dir := initBranchRepo(t)
+	quoted := "naïve \"notes\".txt"
+	if err := os.WriteFile(filepath.Join(dir, quoted), []byte("one\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var calls []string
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		calls = append(calls, strings.Join(args, " "))
+		return exec.Command(name, args...)
+	}
+	t.Cleanup(func() { execCommand = exec.Command })
+	ran := func(sub string) int {
+		n := 0
+		for _, c := range calls {
+			if strings.Contains(c, sub) {
+				n++
+			}
+		}
+		return n
+	}
+
+	result, err := FetchSessionGitDiff(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Untracked) != 1 || result.Untracked[0] != quoted || result.Additions != 1 {
+		t.Fatalf("expected the file git quotes listed as it is, got %q adds=%d", result.Untracked, result.Additions)
+	}
+	if ran("--no-index") != 1 || ran("merge-base") != 1 {
+		t.Fatalf("expected one new-file diff and one branch comparison, got %v", calls)
+	}
+
+	calls = nil
+	again, err := FetchSessionGitDiff(dir)
+	if err != nil || again.Diff != result.Diff || again.Branch == nil || len(again.Branch.Commits) != 2 {
+		t.Fatalf("expected the same result from the cache, got %+v, %v", again, err)
+	}
+	if ran("--no-index") != 0 || ran("merge-base") != 0 {
+		t.Errorf("expected nothing unchanged to be diffed again, got %v", calls)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, quoted), []byte("one\ntwo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	calls = nil
+	if again, _ = FetchSessionGitDiff(dir); again.Additions != 2 || ran("--no-index") != 1 {
+		t.Errorf("expected the changed file diffed again, got adds=%d after %v", again.Additions, calls)
+	}
+}
+
+func TestFetchSessionGitDiff_LargeUntrackedFileNotShown(t *testing.T) {
+	dir := initBranchRepo(t)
+	big := strings.Repeat("line\n", maxUntrackedBytes/5+1)
+	if err := os.WriteFile(filepath.Join(dir, "build.log"), []byte(big), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var calls []string
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		calls = append(calls, strings.Join(args, " "))
+		return exec.Command(name, args...)
+	}
+	t.Cleanup(func() { execCommand = exec.Command })
+
+	result, err := FetchSessionGitDiff(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, c := range calls {
+		if strings.Contains(c, "--no-index") {
+			t.Fatalf("expected the large file not to be diffed, got %v", calls)
+		}
+	}
+	want := fmt.Sprintf("(%d bytes, not shown)", len(big))
+	if !strings.Contains(result.Diff, "+++ b/build.log") || !strings.Contains(result.Diff, want) || result.Additions != 0 {
+		t.Errorf("expected a placeholder for the large file, got adds=%d diff=%q", result.Additions, result.Diff)
+	}
+}
+
+func TestFetchSessionBranch_NoDefaultBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "init", "-q", "-b", "trunk").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if _, err := FetchSessionBranch(dir); err == nil {
+		t.Fatal("expected an error without a default branch")
+	}
+}
+
+func TestFetchCommitDiff_RejectsOptions(t *testing.T) {
+	if _, err := FetchCommitDiff(".", "--output=/tmp/x"); err == nil {
+		t.Fatal("expected an error for a hash that looks like an option")
+	}
+}
+
+func TestParseCommitLog(t *testing.T) {
+	commits := parseCommitLog("abc123\x1fabc\x1fAgent\x1f1700000000\x1fsubject with \x1f inside\nbogus line")
+	if len(commits) != 1 {
+		t.Fatalf("expected one commit, got %+v", commits)
+	}
+	if c := commits[0]; c.Hash != "abc123" || c.Subject != "subject with \x1f inside" || c.When.Unix() != 1700000000 {
+		t.Errorf("unexpected commit %+v", c)
+	}
+}
@@ -8,15 +8,19 @@ import (
 
 // GitDiffResult holds the combined diff output and summary stats
 type GitDiffResult struct {
-	Diff      string // combined unified diff (unstaged + staged)
-	StatLines string // human-readable stat summary (like git diff --stat)
-	FileCount int    // number of files changed
-	Additions int    // total lines added
-	Deletions int    // total lines removed
+	Diff      string           // combined unified diff (unstaged + staged + untracked)
+	StatLines string           // human-readable stat summary (like git diff --stat)
+	FileCount int              // number of files changed, including untracked files
+	Additions int              // total lines added
+	Deletions int              // total lines removed
+	Untracked []string         // files git does not track yet
+	Branch    *GitBranchResult // committed work since the default branch; nil when unknown
 }
 
 // FetchSessionGitDiff runs git diff in the given working directory,
-// combining both unstaged and staged changes.
+// combining both unstaged and staged changes with untracked files rendered
+// as new files. It also compares the branch against its merge-base with
+// the default branch, so committed work stays visible.
 func FetchSessionGitDiff(workDir string) (*GitDiffResult, error) {
 	if workDir == "" {
 		return nil, fmt.Errorf("no working directory specified")
@@ -52,6 +56,22 @@ func FetchSessionGitDiff(workDir string) (*GitDiffResult, error) {
 	}
 	result.FileCount, result.Additions, result.Deletions = parseNumstat(workDir)
 
+	if result.Untracked = untrackedFiles(workDir); len(result.Untracked) > 0 {
+		diff, adds := untrackedDiff(workDir, result.Untracked)
+		if diff != "" {
+			if result.Diff != "" {
+				result.Diff += "\n"
+			}
+			result.Diff += diff
+		}
+		result.FileCount += len(result.Untracked)
+		result.Additions += adds
+	}
+
+	// A repository without a default branch still has uncommitted changes
+	// worth showing, so a failed comparison only leaves Branch nil.
+	result.Branch, _ = FetchSessionBranch(workDir)
+
 	return result, nil
 }
 
@@ -99,7 +119,13 @@ func parseNumstat(workDir string) (files, adds, dels int) {
 			return 0, 0, 0
 		}
 	}
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return sumNumstat(string(out))
+}
+
+// sumNumstat totals git diff --numstat output; binary files count as
+// changed files with no line changes.
+func sumNumstat(out string) (files, adds, dels int) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
 	for _, line := range lines {
 		if line == "" {
 			continue
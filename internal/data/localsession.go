@@ -52,7 +52,8 @@ func ResetLocalSessionCache() {
 	localSessionCacheTime = time.Time{}
 }
 
-// FetchLocalSessions retrieves local Copilot CLI sessions from ~/.copilot/session-state/
+// FetchLocalSessions retrieves local Copilot CLI sessions from the session-state
+// directory of every session root (by default ~/.copilot/session-state/).
 // Results are cached for 15 seconds.
 func FetchLocalSessions() ([]Session, error) {
 	localSessionCacheMu.Lock()
@@ -74,14 +75,46 @@ func FetchLocalSessions() ([]Session, error) {
 	return sessions, nil
 }
 
-// fetchLocalSessionsUncached retrieves local Copilot CLI sessions from ~/.copilot/session-state/
+// fetchLocalSessionsUncached retrieves local Copilot CLI sessions from every
+// session root. A session found in more than one root is taken from the first.
 func fetchLocalSessionsUncached() ([]Session, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	roots := SessionRoots()
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("failed to get home directory")
 	}
 
-	sessionDir := filepath.Join(homeDir, ".copilot", "session-state")
+	var sessions []Session
+	var firstErr error
+	seen := map[string]bool{}
+	for _, root := range roots {
+		found, err := fetchLocalSessionsFromRoot(root)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, session := range found {
+			if seen[session.ID] {
+				continue
+			}
+			seen[session.ID] = true
+			sessions = append(sessions, session)
+		}
+	}
+	if sessions == nil && firstErr != nil {
+		return nil, firstErr
+	}
+	if sessions == nil {
+		sessions = []Session{}
+	}
+	return sessions, nil
+}
+
+// fetchLocalSessionsFromRoot reads the sessions under one root, labeling
+// each with the root it came from.
+func fetchLocalSessionsFromRoot(root SessionRoot) ([]Session, error) {
+	sessionDir := root.SessionStateDir
 
 	// Check if directory exists
 	if _, err := os.Stat(sessionDir); os.IsNotExist(err) {
@@ -131,6 +164,7 @@ func fetchLocalSessionsUncached() ([]Session, error) {
 		if lastMsg != "" {
 			session.LastAssistantMessage = lastMsg
 		}
+		session.Origin = root.Label
 
 		sessions = append(sessions, session)
 	}
@@ -339,7 +373,7 @@ func DeriveLocalSessionStatus(rawStatus string, lastActivity time.Time) string {
 	}
 
 	// If last activity was more than 24 hours ago, consider stale
-	if time.Since(lastActivity) > 24*time.Hour {
+	if Since(lastActivity) > 24*time.Hour {
 		return "completed"
 	}
 
@@ -502,11 +536,7 @@ func deriveStatusFromEvents(sessionDir string) (status string, lastMsg string) {
 			continue
 		}
 		if event.Type == "assistant.message" && event.Data.Content != "" {
-			lastMsg = strings.TrimSpace(event.Data.Content)
-			// Get just the last paragraph/sentence for display
-			if idx := strings.LastIndex(lastMsg, "\n\n"); idx >= 0 {
-				lastMsg = strings.TrimSpace(lastMsg[idx+2:])
-			}
+			lastMsg = lastParagraph(event.Data.Content)
 			break
 		}
 	}
@@ -528,8 +558,9 @@ func deriveStatusFromEvents(sessionDir string) (status string, lastMsg string) {
 	return status, lastMsg
 }
 
-// isProcessAlive checks if a process with the given PID is running.
-func isProcessAlive(pid int) bool {
+// isProcessAlive checks if a process with the given PID is running. It is a
+// variable so fixtures can replay the processes that were alive when recorded.
+var isProcessAlive = func(pid int) bool {
 	process, err := os.FindProcess(pid)
 	if err != nil {
 		return false
@@ -566,14 +597,11 @@ func FetchLocalSessionLog(sessionID string) (string, error) {
 	if sessionID == "" {
 		return "", fmt.Errorf("session ID is required")
 	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+	if rs, ok := remoteHostFor(sessionID); ok {
+		return fetchRemoteLog(rs, sessionID)
 	}
 
-	eventsFile := filepath.Join(homeDir, ".copilot", "session-state", sessionID, "events.jsonl")
-	f, err := os.Open(eventsFile)
+	f, err := openSessionEvents(sessionID)
 	if err != nil {
 		return "", fmt.Errorf("no event log found for this session")
 	}
@@ -685,14 +713,11 @@ func FetchSessionEvents(sessionID string) ([]SessionEvent, error) {
 	if sessionID == "" {
 		return nil, fmt.Errorf("session ID is required")
 	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	if rs, ok := remoteHostFor(sessionID); ok {
+		return fetchRemoteEvents(rs, sessionID)
 	}
 
-	eventsFile := filepath.Join(homeDir, ".copilot", "session-state", sessionID, "events.jsonl")
-	f, err := os.Open(eventsFile)
+	f, err := openSessionEvents(sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("no event log found for this session")
 	}
@@ -749,68 +774,17 @@ func FetchSessionEvents(sessionID string) ([]SessionEvent, error) {
 	return events, nil
 }
 
-// FetchLastSessionAction returns a brief description of the session's most recent action.
-// It reads the last lines of events.jsonl to find the latest tool execution or message.
-func FetchLastSessionAction(session Session) string {
-	if session.Source != SourceLocalCopilot || session.ID == "" {
-		return ""
-	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-
-	eventsFile := filepath.Join(homeDir, ".copilot", "session-state", session.ID, "events.jsonl")
-	f, err := os.Open(eventsFile)
-	if err != nil {
-		return ""
-	}
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
-
-	var lastToolName string
-	for scanner.Scan() {
-		var event struct {
-			Type string          `json:"type"`
-			Data json.RawMessage `json:"data"`
-		}
-		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
-			continue
-		}
-
-		if event.Type == "tool.execution_start" {
-			var d struct {
-				ToolName string `json:"toolName"`
-			}
-			if json.Unmarshal(event.Data, &d) == nil && d.ToolName != "" {
-				lastToolName = d.ToolName
-			}
-		}
-	}
-
-	if lastToolName != "" {
-		return "🔧 " + lastToolName
-	}
-	return ""
-}
-
 // FetchLastAssistantMessage returns the last assistant message content from
 // the session's events.jsonl, or empty string if not found.
 func FetchLastAssistantMessage(sessionID string) string {
 	if sessionID == "" {
 		return ""
 	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
+	if rs, ok := remoteHostFor(sessionID); ok {
+		return rs.lastMsg
 	}
 
-	eventsFile := filepath.Join(homeDir, ".copilot", "session-state", sessionID, "events.jsonl")
-	f, err := os.Open(eventsFile)
+	f, err := openSessionEvents(sessionID)
 	if err != nil {
 		return ""
 	}
@@ -867,5 +841,13 @@ func FetchAllSessions(repo string) ([]Session, error) {
 	}
 	// Don't fail completely if local sessions fail - we might still have agent tasks
 
+	// Fetch sessions from remote hosts; unreachable hosts are skipped
+	remoteSessions, _, _ := FetchRemoteSessions()
+	for _, session := range remoteSessions {
+		if repo == "" || session.Repository == repo {
+			allSessions = append(allSessions, session)
+		}
+	}
+
 	return allSessions, nil
 }
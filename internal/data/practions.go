@@ -0,0 +1,59 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MergeMethod is how a pull request's commits land on its base branch.
+type MergeMethod string
+
+const (
+	MergeCommit MergeMethod = "merge"
+	MergeSquash MergeMethod = "squash"
+	MergeRebase MergeMethod = "rebase"
+)
+
+// MarkPRReady marks a draft pull request as ready for review.
+func MarkPRReady(repo string, prNumber int) error {
+	return runPRAction("mark PR ready", repo, prNumber, "ready")
+}
+
+// MergePR merges a pull request with the given method, deleting its head
+// branch afterwards when deleteBranch is set. With -R, gh only deletes the
+// remote branch and never touches a local checkout.
+func MergePR(repo string, prNumber int, method MergeMethod, deleteBranch bool) error {
+	switch method {
+	case MergeCommit, MergeSquash, MergeRebase:
+	default:
+		return fmt.Errorf("unknown merge method %q", method)
+	}
+	args := []string{"merge", "--" + string(method)}
+	if deleteBranch {
+		args = append(args, "--delete-branch")
+	}
+	return runPRAction("merge PR", repo, prNumber, args...)
+}
+
+// ClosePR closes a pull request without merging it.
+func ClosePR(repo string, prNumber int) error {
+	return runPRAction("close PR", repo, prNumber, "close")
+}
+
+// runPRAction runs `gh pr <subcommand> <number> -R <repo> [flags]`.
+func runPRAction(what, repo string, prNumber int, subcommand ...string) error {
+	if prNumber <= 0 {
+		return fmt.Errorf("valid PR number is required")
+	}
+	if repo == "" {
+		return fmt.Errorf("repository is required")
+	}
+	args := append([]string{"pr", subcommand[0], strconv.Itoa(prNumber), "-R", repo}, subcommand[1:]...)
+	output, err := runGH(args...)
+	if err != nil {
+		return fmt.Errorf("failed to %s: %s", what, strings.TrimSpace(string(output)))
+	}
+	ForgetPRStatus(repo, prNumber)
+	return nil
+}
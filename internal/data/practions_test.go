@@ -0,0 +1,67 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPRActions_RunGHPR(t *testing.T) {
+	got := captureGH(t, "", nil)
+	cases := []struct {
+		run  func() error
+		want string
+	}{
+		{func() error { return MarkPRReady("org/repo", 4) }, "pr ready 4 -R org/repo"},
+		{func() error { return MergePR("org/repo", 4, MergeSquash, true) }, "pr merge 4 -R org/repo --squash --delete-branch"},
+		{func() error { return MergePR("org/repo", 4, MergeRebase, false) }, "pr merge 4 -R org/repo --rebase"},
+		{func() error { return ClosePR("org/repo", 4) }, "pr close 4 -R org/repo"},
+	}
+	for _, c := range cases {
+		if err := c.run(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.want, err)
+		}
+		if args := strings.Join(*got, " "); args != c.want {
+			t.Errorf("got gh %s, want gh %s", args, c.want)
+		}
+	}
+}
+
+func TestPRActions_Validate(t *testing.T) {
+	got := captureGH(t, "", nil)
+	if err := MergePR("org/repo", 4, MergeMethod("fast-forward"), false); err == nil {
+		t.Error("expected an unknown merge method to be refused")
+	}
+	if err := ClosePR("", 4); err == nil {
+		t.Error("expected an error without a repository")
+	}
+	if err := MarkPRReady("org/repo", 0); err == nil {
+		t.Error("expected an error without a PR number")
+	}
+	if *got != nil {
+		t.Errorf("gh should not run for invalid actions, got %v", *got)
+	}
+}
+
+func TestMergePR_ReportsGHOutputAndKeepsCache(t *testing.T) {
+	ResetPRStatusCache()
+	t.Cleanup(ResetPRStatusCache)
+	prStatusCache["org/repo#4"] = cachedPRStatus{status: PRStatus{Number: 4, State: "OPEN"}, found: true, fetchedAt: Now()}
+
+	captureGH(t, "Pull request org/repo#4 is not mergeable", errors.New("exit status 1"))
+	err := MergePR("org/repo", 4, MergeSquash, false)
+	if err == nil || !strings.Contains(err.Error(), "not mergeable") {
+		t.Errorf("expected the gh output in the error, got %v", err)
+	}
+	if _, ok := prStatusCache["org/repo#4"]; !ok {
+		t.Error("a failed merge should keep the cached status")
+	}
+
+	captureGH(t, "", nil)
+	if err := MergePR("org/repo", 4, MergeSquash, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := prStatusCache["org/repo#4"]; ok {
+		t.Error("a merge should drop the cached status so the next refresh shows it")
+	}
+}
@@ -0,0 +1,346 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// clockTicks is the kernel's USER_HZ, the unit of CPU and start times in
+// /proc/<pid>/stat. It is 100 on every mainstream Linux build.
+const clockTicks = 100
+
+// procDir is where process details are read from. It is a variable so tests
+// can point it at a fake /proc.
+var procDir = "/proc"
+
+// SessionProcess is a running process that holds a local session's
+// inuse.{PID}.lock file. Everything but PID is read from /proc and left
+// zero where that is unavailable.
+type SessionProcess struct {
+	PID     int
+	Command string        // command line
+	Uptime  time.Duration // time since the process started
+	CPUTime time.Duration // user plus system CPU time
+	RSS     int64         // resident memory in bytes
+}
+
+// CPUPercent is the process's average CPU use since it started.
+func (p SessionProcess) CPUPercent() float64 {
+	if p.Uptime <= 0 {
+		return 0
+	}
+	return 100 * p.CPUTime.Seconds() / p.Uptime.Seconds()
+}
+
+// SessionLocks lists the lock files in a local session's directory.
+type SessionLocks struct {
+	Live  []SessionProcess // processes still running
+	Stale []string         // paths of lock files whose process is gone, even if its PID was reused
+}
+
+// StopResult describes how StopSessionProcess ended a process.
+type StopResult struct {
+	PID          int
+	Signal       string // signal the process exited on: SIGINT or SIGTERM
+	LocksRemoved int    // stale lock files cleaned up afterwards
+}
+
+// signalProcess sends sig to pid. It is a variable so tests can stand in for
+// real processes.
+var signalProcess = func(pid int, sig os.Signal) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Signal(sig)
+}
+
+// processExitPoll is how often StopSessionProcess checks whether a signalled
+// process has exited.
+var processExitPoll = 100 * time.Millisecond
+
+// lockStartSlack allows for the rounding of process start times, which are
+// kept in clock ticks after a boot time in whole seconds.
+const lockStartSlack = 2 * time.Second
+
+// holdsLock reports whether pid is the process that wrote the lock file at
+// path: a Copilot CLI process that started no later than the lock was
+// written. A process that crashed leaves its lock behind, and its PID may
+// since belong to an unrelated process. It is a variable so tests can stand
+// in for real processes.
+var holdsLock = func(pid int, path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	command, started, ok := processIdentity(pid)
+	if !ok || !strings.Contains(strings.ToLower(command), "copilot") {
+		return false
+	}
+	return !started.After(info.ModTime().Add(lockStartSlack))
+}
+
+// processIdentity returns a process's command line and start time, read
+// from /proc or, where there is none, from ps.
+func processIdentity(pid int) (command string, started time.Time, ok bool) {
+	if st, ok := readProcStat(pid); ok {
+		if boot := bootTime(); !boot.IsZero() {
+			return readCmdline(pid), boot.Add(st.start), true
+		}
+	}
+	out, err := exec.Command("ps", "-o", "etime=", "-o", "command=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	etime, command, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	elapsed, ok := parseElapsed(etime)
+	if !ok {
+		return "", time.Time{}, false
+	}
+	return strings.TrimSpace(command), time.Now().Add(-elapsed), true
+}
+
+// parseElapsed parses the elapsed time ps prints, [[dd-]hh:]mm:ss.
+func parseElapsed(s string) (time.Duration, bool) {
+	var days int64
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		n, err := strconv.ParseInt(d, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		days, s = n, rest
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+	var secs int64
+	for _, p := range parts {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		secs = secs*60 + n
+	}
+	return time.Duration(days*86400+secs) * time.Second, true
+}
+
+// InspectSessionLocks reads the lock files of a local session on this
+// machine, describing the processes that hold them and listing the stale
+// ones left behind by processes that have exited.
+func InspectSessionLocks(sessionID string) (SessionLocks, error) {
+	dir, err := processSessionDir(sessionID)
+	if err != nil {
+		return SessionLocks{}, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return SessionLocks{}, err
+	}
+	var locks SessionLocks
+	for _, e := range entries {
+		pid, ok := lockPID(e.Name())
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if isProcessAlive(pid) && holdsLock(pid, path) {
+			locks.Live = append(locks.Live, readProcess(pid))
+		} else {
+			locks.Stale = append(locks.Stale, path)
+		}
+	}
+	return locks, nil
+}
+
+// CleanStaleLocks removes a local session's lock files whose process has
+// exited and returns how many were removed.
+func CleanStaleLocks(sessionID string) (int, error) {
+	locks, err := InspectSessionLocks(sessionID)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, path := range locks.Stale {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed++
+	}
+	if removed > 0 {
+		ResetLocalSessionCache()
+	}
+	return removed, nil
+}
+
+// StopSessionProcess asks the process holding a session's lock to exit with
+// SIGINT, then SIGTERM if it is still running after timeout, and cleans up
+// the stale lock files left behind. pid must still hold one of the
+// session's locks, as holdsLock checks, so a Copilot process is not
+// mistaken for one that has exited and left its PID to another.
+func StopSessionProcess(sessionID string, pid int, timeout time.Duration) (StopResult, error) {
+	locks, err := InspectSessionLocks(sessionID)
+	if err != nil {
+		return StopResult{}, err
+	}
+	owned := false
+	for _, p := range locks.Live {
+		owned = owned || p.PID == pid
+	}
+	if !owned {
+		return StopResult{}, fmt.Errorf("process %d no longer holds this session's lock", pid)
+	}
+
+	result := StopResult{PID: pid}
+	for _, step := range []struct {
+		name string
+		sig  os.Signal
+	}{{"SIGINT", os.Interrupt}, {"SIGTERM", syscall.SIGTERM}} {
+		if err := signalProcess(pid, step.sig); err != nil && isProcessAlive(pid) {
+			return result, fmt.Errorf("%s to process %d: %w", step.name, pid, err)
+		}
+		if waitForExit(pid, timeout) {
+			result.Signal = step.name
+			result.LocksRemoved, err = CleanStaleLocks(sessionID)
+			ResetLocalSessionCache()
+			return result, err
+		}
+	}
+	return result, fmt.Errorf("process %d is still running %s after SIGTERM", pid, timeout)
+}
+
+// waitForExit polls until pid has exited or timeout passes, and reports
+// whether it exited.
+func waitForExit(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !isProcessAlive(pid) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(processExitPoll)
+	}
+}
+
+// processSessionDir returns the directory of a session whose processes can
+// be managed from here: one in the default ~/.copilot root, outside a
+// replayed fixture. Other roots hold sessions synced from other machines,
+// devcontainers or CI, whose PIDs mean nothing here.
+func processSessionDir(sessionID string) (string, error) {
+	if sessionID == "" {
+		return "", fmt.Errorf("session ID is required")
+	}
+	if fixtureActive() {
+		return "", fmt.Errorf("processes are not available while replaying a fixture")
+	}
+	if rs, ok := remoteHostFor(sessionID); ok {
+		return "", fmt.Errorf("session runs on %s; only sessions on this machine can be managed", rs.host.Name)
+	}
+	for _, root := range SessionRoots() {
+		if root.Label != "" || root.SessionStateDir == "" {
+			continue
+		}
+		dir := filepath.Join(root.SessionStateDir, sessionID)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+	if sessionStateDir(sessionID) != "" {
+		return "", fmt.Errorf("session was read from another Copilot root; only sessions in ~/.copilot can be managed")
+	}
+	return "", fmt.Errorf("no session directory found for %s", sessionID)
+}
+
+// readProcess describes pid from /proc. Fields that cannot be read are left
+// zero, e.g. on systems without /proc.
+func readProcess(pid int) SessionProcess {
+	p := SessionProcess{PID: pid, Command: readCmdline(pid)}
+	st, ok := readProcStat(pid)
+	if !ok {
+		return p
+	}
+	p.CPUTime = st.cpu
+	p.RSS = st.rss
+	if boot := bootTime(); !boot.IsZero() {
+		if started := boot.Add(st.start); time.Now().After(started) {
+			p.Uptime = time.Since(started)
+		}
+	}
+	return p
+}
+
+// procStat is what is used from /proc/<pid>/stat.
+type procStat struct {
+	ppid  int
+	comm  string        // executable name
+	cpu   time.Duration // user plus system CPU time
+	start time.Duration // start time after boot
+	rss   int64         // resident memory in bytes
+}
+
+// readProcStat parses /proc/<pid>/stat.
+func readProcStat(pid int) (procStat, bool) {
+	raw, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return procStat{}, false
+	}
+	// The command name in parentheses may contain spaces, so fields are
+	// counted from the closing parenthesis: state is field 3.
+	open, end := bytes.IndexByte(raw, '('), bytes.LastIndexByte(raw, ')')
+	if open < 0 || end < open {
+		return procStat{}, false
+	}
+	fields := strings.Fields(string(raw[end+1:]))
+	field := func(n int) int64 {
+		if n-3 >= len(fields) {
+			return 0
+		}
+		v, _ := strconv.ParseInt(fields[n-3], 10, 64)
+		return v
+	}
+	return procStat{
+		ppid:  int(field(4)),
+		comm:  string(raw[open+1 : end]),
+		cpu:   ticks(field(14) + field(15)),
+		start: ticks(field(22)),
+		rss:   field(24) * int64(os.Getpagesize()),
+	}, true
+}
+
+// readCmdline returns a process's command line, or "" when unreadable.
+func readCmdline(pid int) string {
+	raw, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return ""
+	}
+	return strings.Join(strings.Split(string(bytes.TrimRight(raw, "\x00")), "\x00"), " ")
+}
+
+// bootTime reads the system boot time from /proc/stat.
+func bootTime() time.Time {
+	raw, err := os.ReadFile(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return time.Time{}
+	}
+	for _, line := range strings.Split(string(raw), "\n") {
+		if v, ok := strings.CutPrefix(line, "btime "); ok {
+			if secs, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				return time.Unix(secs, 0)
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func ticks(n int64) time.Duration {
+	return time.Duration(n) * time.Second / clockTicks
+}
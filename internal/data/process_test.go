@@ -0,0 +1,278 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeProcesses stands in for the processes holding session locks. Each
+// live PID exits when it receives the signal given for it.
+func fakeProcesses(t *testing.T, exitOn map[int]os.Signal) *[]os.Signal {
+	t.Helper()
+	alive := map[int]bool{}
+	for pid := range exitOn {
+		alive[pid] = true
+	}
+	var sent []os.Signal
+	origAlive, origHolds, origSignal, origPoll := isProcessAlive, holdsLock, signalProcess, processExitPoll
+	isProcessAlive = func(pid int) bool { return alive[pid] }
+	holdsLock = func(pid int, _ string) bool { return alive[pid] }
+	signalProcess = func(pid int, sig os.Signal) error {
+		sent = append(sent, sig)
+		if exitOn[pid] == sig {
+			alive[pid] = false
+		}
+		return nil
+	}
+	processExitPoll = time.Millisecond
+	t.Cleanup(func() {
+		isProcessAlive, holdsLock, signalProcess, processExitPoll = origAlive, origHolds, origSignal, origPoll
+	})
+	return &sent
+}
+
+func setupLockedSession(t *testing.T, pids ...int) string {
+	t.Helper()
+	root := CopilotRoot("", filepath.Join(t.TempDir(), ".copilot"))
+	SetSessionRoots([]SessionRoot{root})
+	t.Cleanup(func() { SetSessionRoots(nil) })
+	writeRootSession(t, root, "hung", "Hung work")
+	dir := filepath.Join(root.SessionStateDir, "hung")
+	for _, pid := range pids {
+		if err := os.WriteFile(filepath.Join(dir, "inuse."+strconv.Itoa(pid)+".lock"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestInspectSessionLocks(t *testing.T) {
+	fakeProcesses(t, map[int]os.Signal{4242: os.Interrupt})
+	dir := setupLockedSession(t, 4242, 1111)
+
+	locks, err := InspectSessionLocks("hung")
+	if err != nil {
+		t.Fatalf("InspectSessionLocks: %v", err)
+	}
+	if len(locks.Live) != 1 || locks.Live[0].PID != 4242 {
+		t.Errorf("expected PID 4242 live, got %+v", locks.Live)
+	}
+	if len(locks.Stale) != 1 || locks.Stale[0] != filepath.Join(dir, "inuse.1111.lock") {
+		t.Errorf("expected the 1111 lock to be stale, got %v", locks.Stale)
+	}
+
+	if _, err := InspectSessionLocks("missing"); err == nil {
+		t.Error("expected an error for a session that does not exist")
+	}
+}
+
+func TestStopSessionProcess_SIGINT(t *testing.T) {
+	sent := fakeProcesses(t, map[int]os.Signal{4242: os.Interrupt})
+	dir := setupLockedSession(t, 4242, 1111)
+
+	result, err := StopSessionProcess("hung", 4242, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("StopSessionProcess: %v", err)
+	}
+	if result.Signal != "SIGINT" || len(*sent) != 1 {
+		t.Errorf("expected the process to stop on SIGINT alone, got %+v after %v", result, *sent)
+	}
+	if result.LocksRemoved != 2 {
+		t.Errorf("expected both locks cleaned up, got %d", result.LocksRemoved)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "inuse.*.lock")); len(matches) != 0 {
+		t.Errorf("expected no lock files left, got %v", matches)
+	}
+}
+
+func TestStopSessionProcess_EscalatesToSIGTERM(t *testing.T) {
+	sent := fakeProcesses(t, map[int]os.Signal{4242: syscall.SIGTERM})
+	setupLockedSession(t, 4242)
+
+	result, err := StopSessionProcess("hung", 4242, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("StopSessionProcess: %v", err)
+	}
+	if result.Signal != "SIGTERM" || len(*sent) != 2 || (*sent)[0] != os.Interrupt {
+		t.Errorf("expected SIGINT then SIGTERM, got %+v after %v", result, *sent)
+	}
+}
+
+func TestStopSessionProcess_OutlivesSignals(t *testing.T) {
+	fakeProcesses(t, map[int]os.Signal{4242: syscall.SIGKILL})
+	dir := setupLockedSession(t, 4242)
+
+	if _, err := StopSessionProcess("hung", 4242, 5*time.Millisecond); err == nil {
+		t.Fatal("expected an error for a process that ignores SIGINT and SIGTERM")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "inuse.4242.lock")); err != nil {
+		t.Errorf("expected the live lock to stay: %v", err)
+	}
+}
+
+func TestStopSessionProcess_RefusesForeignPID(t *testing.T) {
+	sent := fakeProcesses(t, map[int]os.Signal{4242: os.Interrupt, 5555: os.Interrupt})
+	setupLockedSession(t, 4242)
+
+	if _, err := StopSessionProcess("hung", 5555, time.Millisecond); err == nil {
+		t.Fatal("expected a PID without the session's lock to be refused")
+	}
+	if len(*sent) != 0 {
+		t.Errorf("expected no signal sent, got %v", *sent)
+	}
+}
+
+func TestStopSessionProcess_RefusesReusedPID(t *testing.T) {
+	sent := fakeProcesses(t, map[int]os.Signal{4242: os.Interrupt})
+	holdsLock = func(int, string) bool { return false }
+	setupLockedSession(t, 4242)
+
+	locks, err := InspectSessionLocks("hung")
+	if err != nil || len(locks.Live) != 0 || len(locks.Stale) != 1 {
+		t.Errorf("expected the lock of a reused PID to be stale, got %+v, %v", locks, err)
+	}
+	if _, err := StopSessionProcess("hung", 4242, time.Millisecond); err == nil {
+		t.Fatal("expected a reused PID to be refused")
+	}
+	if len(*sent) != 0 {
+		t.Errorf("expected no signal sent, got %v", *sent)
+	}
+}
+
+func TestStopSessionProcess_RefusesOtherRoots(t *testing.T) {
+	fakeProcesses(t, map[int]os.Signal{4242: os.Interrupt})
+	root := CopilotRoot("devbox", filepath.Join(t.TempDir(), ".copilot"))
+	SetSessionRoots([]SessionRoot{root})
+	t.Cleanup(func() { SetSessionRoots(nil) })
+	writeRootSession(t, root, "synced", "Synced work")
+	if err := os.WriteFile(filepath.Join(root.SessionStateDir, "synced", "inuse.4242.lock"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := InspectSessionLocks("synced"); err == nil {
+		t.Error("expected the locks of a session from another root to be left alone")
+	}
+	if _, err := CleanStaleLocks("synced"); err == nil {
+		t.Error("expected no cleanup in another root")
+	}
+}
+
+func TestHoldsLock(t *testing.T) {
+	fake := t.TempDir()
+	orig := procDir
+	procDir = fake
+	t.Cleanup(func() { procDir = orig })
+
+	// Booted an hour ago; both processes started 1800s after boot.
+	boot := time.Now().Add(-time.Hour).Unix()
+	if err := os.WriteFile(filepath.Join(fake, "stat"), []byte("btime "+strconv.FormatInt(boot, 10)+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for pid, cmdline := range map[string]string{"4242": "node\x00/usr/lib/copilot/index.js\x00", "5555": "vim\x00notes.txt\x00"} {
+		dir := filepath.Join(fake, pid)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		stat := pid + " (node) S 1 1 1 0 -1 0 0 0 0 0 300 200 0 0 20 0 1 0 180000 1000 256 0"
+		if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lock := filepath.Join(t.TempDir(), "inuse.4242.lock")
+	if err := os.WriteFile(lock, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !holdsLock(4242, lock) {
+		t.Error("expected the Copilot process that started before its lock to hold it")
+	}
+	if holdsLock(5555, lock) {
+		t.Error("expected a process that isn't Copilot not to hold the lock")
+	}
+	old := time.Now().Add(-50 * time.Minute)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if holdsLock(4242, lock) {
+		t.Error("expected a process started after the lock was written not to hold it")
+	}
+}
+
+func TestParseElapsed(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"05:09":      5*time.Minute + 9*time.Second,
+		"02:05:09":   2*time.Hour + 5*time.Minute + 9*time.Second,
+		"3-02:05:09": 74*time.Hour + 5*time.Minute + 9*time.Second,
+	} {
+		if got, ok := parseElapsed(in); !ok || got != want {
+			t.Errorf("parseElapsed(%q) = %s, %v; want %s", in, got, ok, want)
+		}
+	}
+	if _, ok := parseElapsed("soon"); ok {
+		t.Error("expected an unparseable time refused")
+	}
+}
+
+func TestCleanStaleLocks(t *testing.T) {
+	fakeProcesses(t, map[int]os.Signal{4242: os.Interrupt})
+	dir := setupLockedSession(t, 4242, 1111, 2222)
+
+	n, err := CleanStaleLocks("hung")
+	if err != nil || n != 2 {
+		t.Fatalf("expected two stale locks removed, got %d, %v", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "inuse.4242.lock")); err != nil {
+		t.Errorf("expected the live lock to stay: %v", err)
+	}
+}
+
+func TestReadProcess(t *testing.T) {
+	fake := t.TempDir()
+	orig := procDir
+	procDir = fake
+	t.Cleanup(func() { procDir = orig })
+
+	boot := time.Now().Add(-time.Hour).Unix()
+	if err := os.WriteFile(filepath.Join(fake, "stat"), []byte("cpu  1 2 3\nbtime "+strconv.FormatInt(boot, 10)+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pidDir := filepath.Join(fake, "4242")
+	if err := os.MkdirAll(pidDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pidDir, "cmdline"), []byte("node\x00copilot\x00--resume\x00"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// utime 300, stime 200 ticks; started 1800s after boot; rss 256 pages.
+	// The command name has a space and a parenthesis to exercise field
+	// counting.
+	stat := "4242 (node (x) y) S 1 1 1 0 -1 0 0 0 0 0 300 200 0 0 20 0 1 0 180000 1000 256 0"
+	if err := os.WriteFile(filepath.Join(pidDir, "stat"), []byte(stat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := readProcess(4242)
+	if p.Command != "node copilot --resume" {
+		t.Errorf("unexpected command %q", p.Command)
+	}
+	if p.CPUTime != 5*time.Second {
+		t.Errorf("expected 5s of CPU time, got %s", p.CPUTime)
+	}
+	if p.RSS != 256*int64(os.Getpagesize()) {
+		t.Errorf("unexpected RSS %d", p.RSS)
+	}
+	if p.Uptime < 29*time.Minute || p.Uptime > 31*time.Minute {
+		t.Errorf("expected about 30m of uptime, got %s", p.Uptime)
+	}
+
+	if missing := readProcess(9999); missing.PID != 9999 || missing.Command != "" || missing.Uptime != 0 {
+		t.Errorf("expected only the PID for a process without /proc entries, got %+v", missing)
+	}
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReviewEvent is the verdict of a pull request review.
+type ReviewEvent string
+
+const (
+	ReviewApprove        ReviewEvent = "APPROVE"
+	ReviewRequestChanges ReviewEvent = "REQUEST_CHANGES"
+	ReviewComment        ReviewEvent = "COMMENT"
+)
+
+// copilotMention addresses the Copilot coding agent in a PR comment, which
+// asks it to pick the PR up again.
+const copilotMention = "@copilot"
+
+// defaultCopilotRequest is posted when no instructions are given.
+const defaultCopilotRequest = "please address the review feedback on this pull request."
+
+// LineComment is a comment on one line of a pull request's diff.
+type LineComment struct {
+	Path string
+	Line int
+	Side string // LEFT for removed lines, RIGHT otherwise
+	Body string
+}
+
+// SubmitPRReview submits a review of a pull request with its line comments
+// in one request. Request-changes reviews need a body, and comment reviews
+// need a body or at least one line comment.
+func SubmitPRReview(repo string, prNumber int, event ReviewEvent, body string, comments []LineComment) error {
+	if prNumber <= 0 {
+		return fmt.Errorf("valid PR number is required")
+	}
+	if repo == "" {
+		return fmt.Errorf("repository is required")
+	}
+	body = strings.TrimSpace(body)
+	switch event {
+	case ReviewApprove:
+	case ReviewRequestChanges:
+		if body == "" {
+			return fmt.Errorf("requesting changes needs a review body")
+		}
+	case ReviewComment:
+		if body == "" && len(comments) == 0 {
+			return fmt.Errorf("a comment review needs a body or line comments")
+		}
+	default:
+		return fmt.Errorf("unknown review event %q", event)
+	}
+
+	args := []string{"api", "--method", "POST",
+		fmt.Sprintf("repos/%s/pulls/%d/reviews", repo, prNumber),
+		"-f", "event=" + string(event)}
+	if body != "" {
+		args = append(args, "-f", "body="+body)
+	}
+	for _, c := range comments {
+		if c.Path == "" || c.Line <= 0 || strings.TrimSpace(c.Body) == "" {
+			return fmt.Errorf("line comment on %s:%d is incomplete", c.Path, c.Line)
+		}
+		side := c.Side
+		if side == "" {
+			side = "RIGHT"
+		}
+		args = append(args,
+			"-f", "comments[][path]="+c.Path,
+			"-F", "comments[][line]="+strconv.Itoa(c.Line),
+			"-f", "comments[][side]="+side,
+			"-f", "comments[][body]="+c.Body)
+	}
+	output, err := runGH(args...)
+	if err != nil {
+		return fmt.Errorf("failed to submit review: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RequestCopilotIteration asks the Copilot coding agent to work on a pull
+// request again by mentioning it in a PR comment with the instructions.
+func RequestCopilotIteration(repo string, prNumber int, instructions string) error {
+	if prNumber <= 0 {
+		return fmt.Errorf("valid PR number is required")
+	}
+	if repo == "" {
+		return fmt.Errorf("repository is required")
+	}
+	instructions = strings.TrimSpace(instructions)
+	if instructions == "" {
+		instructions = defaultCopilotRequest
+	}
+	body := instructions
+	if !strings.Contains(strings.ToLower(instructions), copilotMention) {
+		body = copilotMention + " " + instructions
+	}
+	output, err := runGH("api", "--method", "POST",
+		fmt.Sprintf("repos/%s/issues/%d/comments", repo, prNumber),
+		"-f", "body="+body)
+	if err != nil {
+		return fmt.Errorf("failed to ask Copilot: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
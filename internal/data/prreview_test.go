@@ -0,0 +1,106 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// captureGH replaces gh with a stub that records its arguments and returns
+// output and err.
+func captureGH(t *testing.T, output string, err error) *[]string {
+	t.Helper()
+	orig := ghOutput
+	t.Cleanup(func() { ghOutput = orig })
+	var got []string
+	ghOutput = func(args ...string) ([]byte, error) {
+		got = args
+		return []byte(output), err
+	}
+	return &got
+}
+
+func TestSubmitPRReview_SendsEventBodyAndComments(t *testing.T) {
+	got := captureGH(t, "{}", nil)
+
+	err := SubmitPRReview("org/repo", 42, ReviewRequestChanges, " please fix ", []LineComment{
+		{Path: "main.go", Line: 12, Side: "RIGHT", Body: "nil check?"},
+		{Path: "old.go", Line: 3, Side: "LEFT", Body: "why remove this"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "api --method POST repos/org/repo/pulls/42/reviews -f event=REQUEST_CHANGES -f body=please fix " +
+		"-f comments[][path]=main.go -F comments[][line]=12 -f comments[][side]=RIGHT -f comments[][body]=nil check? " +
+		"-f comments[][path]=old.go -F comments[][line]=3 -f comments[][side]=LEFT -f comments[][body]=why remove this"
+	if strings.Join(*got, " ") != want {
+		t.Errorf("unexpected gh args:\n got %s\nwant %s", strings.Join(*got, " "), want)
+	}
+}
+
+func TestSubmitPRReview_Validates(t *testing.T) {
+	got := captureGH(t, "", nil)
+	cases := []struct {
+		name     string
+		event    ReviewEvent
+		body     string
+		comments []LineComment
+	}{
+		{"request changes without body", ReviewRequestChanges, "  ", nil},
+		{"empty comment review", ReviewComment, "", nil},
+		{"unknown event", ReviewEvent("MERGE"), "x", nil},
+		{"incomplete line comment", ReviewComment, "", []LineComment{{Path: "a.go", Line: 1}}},
+	}
+	for _, c := range cases {
+		if err := SubmitPRReview("org/repo", 1, c.event, c.body, c.comments); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+	if err := SubmitPRReview("", 1, ReviewApprove, "", nil); err == nil {
+		t.Error("expected an error without a repository")
+	}
+	if *got != nil {
+		t.Errorf("gh should not run for invalid reviews, got %v", *got)
+	}
+
+	if err := SubmitPRReview("org/repo", 1, ReviewApprove, "", nil); err != nil {
+		t.Errorf("an approval needs no body: %v", err)
+	}
+	if strings.Contains(strings.Join(*got, " "), "body=") {
+		t.Errorf("an empty body should be left out, got %v", *got)
+	}
+}
+
+func TestSubmitPRReview_ReportsGHOutput(t *testing.T) {
+	captureGH(t, "Unprocessable Entity (HTTP 422)", errors.New("exit status 1"))
+	err := SubmitPRReview("org/repo", 1, ReviewApprove, "", nil)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 422") {
+		t.Errorf("expected the gh output in the error, got %v", err)
+	}
+}
+
+func TestRequestCopilotIteration(t *testing.T) {
+	got := captureGH(t, "{}", nil)
+
+	if err := RequestCopilotIteration("org/repo", 7, "add tests for the parser"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "api --method POST repos/org/repo/issues/7/comments -f body=@copilot add tests for the parser"
+	if strings.Join(*got, " ") != want {
+		t.Errorf("unexpected gh args: %s", strings.Join(*got, " "))
+	}
+
+	if err := RequestCopilotIteration("org/repo", 7, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body := (*got)[len(*got)-1]; body != "body=@copilot "+defaultCopilotRequest {
+		t.Errorf("expected the default request, got %q", body)
+	}
+
+	if err := RequestCopilotIteration("org/repo", 7, "@Copilot rebase please"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body := (*got)[len(*got)-1]; body != "body=@Copilot rebase please" {
+		t.Errorf("an existing mention should not be repeated, got %q", body)
+	}
+}
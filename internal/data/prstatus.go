@@ -0,0 +1,332 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PRStatus is the state of a session's pull request: whether it is merged,
+// how its checks are doing and what reviewers decided.
+type PRStatus struct {
+	Number         int    `json:"number"`
+	URL            string `json:"url"`
+	State          string `json:"state"` // OPEN, MERGED or CLOSED
+	Draft          bool   `json:"draft,omitempty"`
+	Mergeable      string `json:"mergeable,omitempty"`      // MERGEABLE, CONFLICTING or UNKNOWN
+	ReviewDecision string `json:"reviewDecision,omitempty"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED or empty
+	Checks         string `json:"checks,omitempty"`         // rollup of the head commit: SUCCESS, FAILURE, ERROR, PENDING, EXPECTED or empty
+	Approvals      int    `json:"approvals,omitempty"`      // approving reviews, which count even when the repository requires none
+}
+
+// Open reports whether the PR is neither merged nor closed.
+func (p PRStatus) Open() bool { return p.State == "OPEN" }
+
+// Merged reports whether the PR was merged.
+func (p PRStatus) Merged() bool { return p.State == "MERGED" }
+
+// Closed reports whether the PR was closed without merging.
+func (p PRStatus) Closed() bool { return p.State == "CLOSED" }
+
+// Approved reports whether reviewers approved the PR. Without review rules
+// GitHub makes no review decision, so any approving review counts.
+func (p PRStatus) Approved() bool {
+	return p.ReviewDecision == "APPROVED" || p.ReviewDecision == "" && p.Approvals > 0
+}
+
+// ChecksFailing reports whether a check on the head commit failed.
+func (p PRStatus) ChecksFailing() bool { return p.Checks == "FAILURE" || p.Checks == "ERROR" }
+
+// ChecksPending reports whether checks on the head commit are still running.
+func (p PRStatus) ChecksPending() bool { return p.Checks == "PENDING" || p.Checks == "EXPECTED" }
+
+// ChecksPassed reports whether all checks on the head commit passed.
+func (p PRStatus) ChecksPassed() bool { return p.Checks == "SUCCESS" }
+
+// HasConflicts reports whether the PR cannot be merged without resolving
+// conflicts.
+func (p PRStatus) HasConflicts() bool { return p.Mergeable == "CONFLICTING" }
+
+// Summary describes the PR in a few words, e.g. "open · checks failing ·
+// changes requested".
+func (p PRStatus) Summary() string {
+	var parts []string
+	switch {
+	case p.Merged():
+		return "merged"
+	case p.Closed():
+		return "closed"
+	case p.Draft:
+		parts = append(parts, "draft")
+	default:
+		parts = append(parts, "open")
+	}
+	switch {
+	case p.ChecksFailing():
+		parts = append(parts, "checks failing")
+	case p.ChecksPending():
+		parts = append(parts, "checks running")
+	case p.ChecksPassed():
+		parts = append(parts, "checks passed")
+	}
+	switch {
+	case p.Approved():
+		parts = append(parts, "approved")
+	case p.ReviewDecision == "CHANGES_REQUESTED":
+		parts = append(parts, "changes requested")
+	case p.ReviewDecision == "REVIEW_REQUIRED":
+		parts = append(parts, "review required")
+	}
+	if p.HasConflicts() {
+		parts = append(parts, "conflicts")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// PRAttention returns why a session's PR needs the user, or "" when it does
+// not: "checks failing" for an open PR with failed checks, and "needs
+// review" for a ready PR nobody approved or asked changes on once the agent
+// stopped working.
+func PRAttention(session Session) string {
+	pr := session.PR
+	if pr == nil || !pr.Open() {
+		return ""
+	}
+	if pr.ChecksFailing() {
+		return "checks failing"
+	}
+	if pr.Draft || pr.ChecksPending() || StatusIsActive(session.Status) {
+		return ""
+	}
+	if !pr.Approved() && pr.ReviewDecision != "CHANGES_REQUESTED" {
+		return "needs review"
+	}
+	return ""
+}
+
+// PRStatusTTL is how long a fetched status of an open PR is reused. Merged
+// and closed PRs do not change and are never fetched again.
+const PRStatusTTL = 2 * time.Minute
+
+// prStatusBatch is the number of PRs asked for in one GraphQL query.
+const prStatusBatch = 50
+
+type cachedPRStatus struct {
+	status    PRStatus
+	found     bool
+	fetchedAt time.Time
+}
+
+var (
+	prStatusMu    sync.Mutex
+	prStatusCache = map[string]cachedPRStatus{}
+)
+
+// ResetPRStatusCache forgets all fetched PR statuses.
+func ResetPRStatusCache() {
+	prStatusMu.Lock()
+	defer prStatusMu.Unlock()
+	clear(prStatusCache)
+}
+
+// ForgetPRStatus drops the cached status of one PR, so the next fetch
+// reads it again, e.g. after it was merged from here.
+func ForgetPRStatus(repo string, prNumber int) {
+	prStatusMu.Lock()
+	defer prStatusMu.Unlock()
+	delete(prStatusCache, fmt.Sprintf("%s#%d", repo, prNumber))
+}
+
+var repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// prLookup is how one session's PR is found: by number when the session
+// knows it, otherwise by the session's branch.
+type prLookup struct {
+	key    string
+	owner  string
+	name   string
+	number int
+	branch string
+}
+
+func lookupFor(session Session) (prLookup, bool) {
+	repo := strings.TrimSpace(session.Repository)
+	if !repoNamePattern.MatchString(repo) {
+		return prLookup{}, false
+	}
+	owner, name, _ := strings.Cut(repo, "/")
+	if session.PRNumber > 0 {
+		return prLookup{key: fmt.Sprintf("%s#%d", repo, session.PRNumber), owner: owner, name: name, number: session.PRNumber}, true
+	}
+	branch := strings.TrimSpace(session.Branch)
+	if branch == "" || IsDefaultBranch(branch) {
+		return prLookup{}, false
+	}
+	return prLookup{key: repo + "@" + branch, owner: owner, name: name, branch: branch}, true
+}
+
+// FetchPRStatuses returns the PR status of every session that has a PR,
+// keyed by session ID. Sessions without a PR number are matched to the
+// latest PR of their branch. Statuses are fetched with batched GraphQL
+// queries and cached; cached statuses are returned even when a query
+// failed.
+func FetchPRStatuses(sessions []Session) (map[string]PRStatus, error) {
+	now := Now()
+	lookups := map[string]prLookup{}
+	bySession := map[string]string{}
+	var missing []prLookup
+
+	prStatusMu.Lock()
+	for _, s := range sessions {
+		l, ok := lookupFor(s)
+		if !ok {
+			continue
+		}
+		bySession[s.ID] = l.key
+		if _, seen := lookups[l.key]; seen {
+			continue
+		}
+		lookups[l.key] = l
+		c, ok := prStatusCache[l.key]
+		if ok && (c.found && !c.status.Open() || now.Sub(c.fetchedAt) < PRStatusTTL) {
+			continue
+		}
+		missing = append(missing, l)
+	}
+	prStatusMu.Unlock()
+
+	var firstErr error
+	for start := 0; start < len(missing); start += prStatusBatch {
+		batch := missing[start:min(start+prStatusBatch, len(missing))]
+		found, err := queryPRStatuses(batch)
+		if err != nil {
+			// Unknown rather than absent; try again next time
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		prStatusMu.Lock()
+		for i, l := range batch {
+			status, ok := found[i]
+			prStatusCache[l.key] = cachedPRStatus{status: status, found: ok, fetchedAt: now}
+		}
+		prStatusMu.Unlock()
+	}
+
+	statuses := map[string]PRStatus{}
+	prStatusMu.Lock()
+	defer prStatusMu.Unlock()
+	for id, key := range bySession {
+		if c, ok := prStatusCache[key]; ok && c.found {
+			statuses[id] = c.status
+		}
+	}
+	return statuses, firstErr
+}
+
+const prStatusFragment = `fragment pr on PullRequest { number url state isDraft mergeable reviewDecision ` +
+	`reviews(states: APPROVED) { totalCount } ` +
+	`commits(last: 1) { nodes { commit { statusCheckRollup { state } } } } }`
+
+// prStatusQuery builds one GraphQL query with an aliased field per lookup.
+func prStatusQuery(batch []prLookup) string {
+	var b strings.Builder
+	b.WriteString("query {")
+	for i, l := range batch {
+		fmt.Fprintf(&b, " p%d: repository(owner: %s, name: %s) {", i, graphQLString(l.owner), graphQLString(l.name))
+		if l.number > 0 {
+			fmt.Fprintf(&b, " pullRequest(number: %d) { ...pr } }", l.number)
+		} else {
+			fmt.Fprintf(&b, " pullRequests(headRefName: %s, first: 1, orderBy: {field: CREATED_AT, direction: DESC}) { nodes { ...pr } } }", graphQLString(l.branch))
+		}
+	}
+	b.WriteString(" } ")
+	b.WriteString(prStatusFragment)
+	return b.String()
+}
+
+// graphQLString quotes s as a GraphQL string literal, whose escapes match
+// JSON's.
+func graphQLString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+type prNode struct {
+	Number         int    `json:"number"`
+	URL            string `json:"url"`
+	State          string `json:"state"`
+	IsDraft        bool   `json:"isDraft"`
+	Mergeable      string `json:"mergeable"`
+	ReviewDecision string `json:"reviewDecision"`
+	Reviews        struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"reviews"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+func (n prNode) status() PRStatus {
+	s := PRStatus{
+		Number:         n.Number,
+		URL:            n.URL,
+		State:          n.State,
+		Draft:          n.IsDraft,
+		Mergeable:      n.Mergeable,
+		ReviewDecision: n.ReviewDecision,
+		Approvals:      n.Reviews.TotalCount,
+	}
+	if len(n.Commits.Nodes) > 0 && n.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		s.Checks = n.Commits.Nodes[0].Commit.StatusCheckRollup.State
+	}
+	return s
+}
+
+// queryPRStatuses runs one batched query and returns the PRs it found,
+// keyed by their index in batch. Lookups that failed to resolve, e.g. for a
+// deleted repository, are left out like lookups without a PR.
+func queryPRStatuses(batch []prLookup) (map[int]PRStatus, error) {
+	output, err := runGH("api", "graphql", "-f", "query="+prStatusQuery(batch))
+
+	// gh prints the response even when some fields failed to resolve, e.g.
+	// for a deleted repository, followed by its own error message.
+	var resp struct {
+		Data map[string]*struct {
+			PullRequest  *prNode `json:"pullRequest"`
+			PullRequests *struct {
+				Nodes []prNode `json:"nodes"`
+			} `json:"pullRequests"`
+		} `json:"data"`
+	}
+	if decodeErr := json.NewDecoder(bytes.NewReader(output)).Decode(&resp); decodeErr != nil {
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch PR status: %s", strings.TrimSpace(string(output)))
+		}
+		return nil, fmt.Errorf("failed to parse PR status: %w", decodeErr)
+	}
+
+	found := map[int]PRStatus{}
+	for i := range batch {
+		repo := resp.Data[fmt.Sprintf("p%d", i)]
+		switch {
+		case repo == nil:
+		case repo.PullRequest != nil:
+			found[i] = repo.PullRequest.status()
+		case repo.PullRequests != nil && len(repo.PullRequests.Nodes) > 0:
+			found[i] = repo.PullRequests.Nodes[0].status()
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const prStatusResponse = `{"data":{
+	"p0":{"pullRequest":{"number":12,"url":"https://github.com/org/repo/pull/12","state":"OPEN","isDraft":false,"mergeable":"CONFLICTING","reviewDecision":"REVIEW_REQUIRED","reviews":{"totalCount":0},
+		"commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"FAILURE"}}}]}}},
+	"p1":{"pullRequests":{"nodes":[{"number":3,"url":"https://github.com/org/repo/pull/3","state":"MERGED","isDraft":false,"mergeable":"UNKNOWN","reviewDecision":null,"reviews":{"totalCount":1},
+		"commits":{"nodes":[{"commit":{"statusCheckRollup":null}}]}}]}},
+	"p2":null}}
+gh: Could not resolve to a Repository with the name 'gone/repo'.`
+
+func TestFetchPRStatuses_BatchesAndParses(t *testing.T) {
+	ResetPRStatusCache()
+	t.Cleanup(ResetPRStatusCache)
+	got := captureGH(t, prStatusResponse, errors.New("exit status 1"))
+
+	sessions := []Session{
+		{ID: "a", Repository: "org/repo", PRNumber: 12},
+		{ID: "b", Repository: "org/repo", Branch: "fix-bug"},
+		{ID: "c", Repository: "gone/repo", PRNumber: 1},
+		{ID: "d", Repository: "org/repo", Branch: "main"},
+		{ID: "e", Repository: "org/repo", PRNumber: 12},
+	}
+	statuses, err := FetchPRStatuses(sessions)
+	if err != nil {
+		t.Fatalf("unresolved repositories should not fail the fetch: %v", err)
+	}
+	query := strings.Join(*got, " ")
+	if !strings.Contains(query, `p0: repository(owner: "org", name: "repo") { pullRequest(number: 12)`) ||
+		!strings.Contains(query, `p1: repository(owner: "org", name: "repo") { pullRequests(headRefName: "fix-bug"`) ||
+		strings.Count(query, "repository(") != 3 {
+		t.Errorf("expected one aliased field per distinct PR, got %s", query)
+	}
+
+	a := statuses["a"]
+	if !a.Open() || !a.ChecksFailing() || !a.HasConflicts() || a.ReviewDecision != "REVIEW_REQUIRED" {
+		t.Errorf("unexpected status for a: %+v", a)
+	}
+	if statuses["e"] != a {
+		t.Error("sessions sharing a PR should share its status")
+	}
+	if b := statuses["b"]; !b.Merged() || b.Number != 3 || b.Checks != "" || !b.Approved() {
+		t.Errorf("expected the branch's PR for b, got %+v", b)
+	}
+	for _, id := range []string{"c", "d"} {
+		if _, ok := statuses[id]; ok {
+			t.Errorf("expected no status for %s", id)
+		}
+	}
+
+	// Everything is cached, so gh does not run again
+	*got = nil
+	if _, err := FetchPRStatuses(sessions); err != nil || *got != nil {
+		t.Errorf("expected cached statuses, got err %v and gh %v", err, *got)
+	}
+}
+
+func TestFetchPRStatuses_RefetchesOpenPRsAfterTTL(t *testing.T) {
+	ResetPRStatusCache()
+	t.Cleanup(ResetPRStatusCache)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	orig := Now
+	t.Cleanup(func() { Now = orig })
+	Now = func() time.Time { return now }
+
+	got := captureGH(t, prStatusResponse, nil)
+	sessions := []Session{
+		{ID: "a", Repository: "org/repo", PRNumber: 12},
+		{ID: "b", Repository: "org/repo", Branch: "fix-bug"},
+	}
+	if _, err := FetchPRStatuses(sessions); err != nil {
+		t.Fatal(err)
+	}
+	*got = nil
+	now = now.Add(PRStatusTTL + time.Second)
+	if _, err := FetchPRStatuses(sessions); err != nil {
+		t.Fatal(err)
+	}
+	query := strings.Join(*got, " ")
+	if !strings.Contains(query, "pullRequest(number: 12)") || strings.Contains(query, "fix-bug") {
+		t.Errorf("expected only the open PR to be fetched again, got %s", query)
+	}
+}
+
+func TestFetchPRStatuses_KeepsCacheOnFailure(t *testing.T) {
+	ResetPRStatusCache()
+	t.Cleanup(ResetPRStatusCache)
+	captureGH(t, "HTTP 502", errors.New("exit status 1"))
+	statuses, err := FetchPRStatuses([]Session{{ID: "a", Repository: "org/repo", PRNumber: 12}})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 502") || len(statuses) != 0 {
+		t.Errorf("expected the gh output in the error, got %v and %v", statuses, err)
+	}
+}
+
+func TestPRStatusQuery_QuotesBranches(t *testing.T) {
+	q := prStatusQuery([]prLookup{{owner: "org", name: "repo", branch: `evil") { x } #`}})
+	if !strings.Contains(q, `headRefName: "evil\") { x } #"`) {
+		t.Errorf("expected the branch quoted as a string literal, got %s", q)
+	}
+}
+
+func TestPRAttention(t *testing.T) {
+	cases := []struct {
+		name   string
+		status string
+		pr     *PRStatus
+		want   string
+	}{
+		{"no PR", "completed", nil, ""},
+		{"failing checks", "running", &PRStatus{State: "OPEN", Checks: "FAILURE"}, "checks failing"},
+		{"unreviewed", "completed", &PRStatus{State: "OPEN", Checks: "SUCCESS", ReviewDecision: "REVIEW_REQUIRED"}, "needs review"},
+		{"no review rules", "completed", &PRStatus{State: "OPEN"}, "needs review"},
+		{"approved without review rules", "completed", &PRStatus{State: "OPEN", Approvals: 1}, ""},
+		{"approval dismissed by required review", "completed", &PRStatus{State: "OPEN", ReviewDecision: "REVIEW_REQUIRED", Approvals: 1}, "needs review"},
+		{"changes requested", "completed", &PRStatus{State: "OPEN", ReviewDecision: "CHANGES_REQUESTED"}, ""},
+		{"agent still working", "running", &PRStatus{State: "OPEN", ReviewDecision: "REVIEW_REQUIRED"}, ""},
+		{"draft", "completed", &PRStatus{State: "OPEN", Draft: true}, ""},
+		{"checks running", "completed", &PRStatus{State: "OPEN", Checks: "PENDING"}, ""},
+		{"approved", "completed", &PRStatus{State: "OPEN", ReviewDecision: "APPROVED"}, ""},
+		{"merged", "completed", &PRStatus{State: "MERGED", Checks: "FAILURE"}, ""},
+	}
+	for _, c := range cases {
+		s := Session{Status: c.status, PR: c.pr}
+		if got := PRAttention(s); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+		wantLevel := AttentionNone
+		if c.want != "" {
+			wantLevel = AttentionWarning
+		}
+		if got := SessionAttentionLevel(s); got != wantLevel {
+			t.Errorf("%s: attention level %v, want %v", c.name, got, wantLevel)
+		}
+	}
+}
+
+func TestPRStatusSummary(t *testing.T) {
+	pr := PRStatus{State: "OPEN", Checks: "SUCCESS", ReviewDecision: "CHANGES_REQUESTED", Mergeable: "CONFLICTING"}
+	if got := pr.Summary(); got != "open · checks passed · changes requested · conflicts" {
+		t.Errorf("unexpected summary %q", got)
+	}
+	if got := (PRStatus{State: "OPEN", Approvals: 2}).Summary(); got != "open · approved" {
+		t.Errorf("expected an approving review to count without review rules, got %q", got)
+	}
+	if got := (PRStatus{State: "MERGED", Checks: "FAILURE"}).Summary(); got != "merged" {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
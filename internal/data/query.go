@@ -0,0 +1,604 @@
+package data
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryFields lists the field names understood by ParseQuery, in the order
+// they are offered for autocompletion.
+var QueryFields = []string{"repo", "status", "source", "origin", "branch", "model", "title", "id", "age", "updated", "cost", "tag", "note", "pinned"}
+
+// Query is a compiled session filter expression. A nil *Query matches every
+// session, so callers can hold one unconditionally.
+type Query struct {
+	root queryNode
+	now  func() time.Time
+}
+
+// Match reports whether the session satisfies the query.
+func (q *Query) Match(s Session) bool {
+	if q == nil || q.root == nil {
+		return true
+	}
+	return q.root.match(s, q.now())
+}
+
+// Filter returns the sessions that satisfy the query.
+func (q *Query) Filter(sessions []Session) []Session {
+	if q == nil || q.root == nil {
+		return sessions
+	}
+	out := make([]Session, 0, len(sessions))
+	for _, s := range sessions {
+		if q.Match(s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// ParseQuery compiles a filter expression such as
+//
+//	repo:org/api status:failed age:<2h OR (cost:>1.50 NOT source:local)
+//
+// Terms are ANDed by default; AND, OR and NOT (or a leading "-") combine
+// them and parentheses group. Bare words fall back to a case-insensitive
+// substring match over title, repository, branch and status. "@name" expands
+// to the saved filter of that name.
+func ParseQuery(input string, saved map[string]string) (*Query, error) {
+	p := &queryParser{saved: saved}
+	root, err := p.parseExpanded(input, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{root: root, now: time.Now}, nil
+}
+
+// SessionCost returns the estimated dollar cost of a session from its
+// token telemetry, or 0 when no usage has been recorded.
+func SessionCost(s Session) float64 {
+	if s.Telemetry == nil {
+		return 0
+	}
+	return computeCost(s.Telemetry.Model, s.Telemetry.InputTokens, s.Telemetry.OutputTokens)
+}
+
+// CompleteQuery returns candidate replacements for the last token of input:
+// field names while the token has no colon, otherwise values for that field
+// drawn from sessions. Each candidate is the full input with the last token
+// completed.
+func CompleteQuery(input string, sessions []Session, saved map[string]string) []string {
+	prefix := input
+	token := input
+	if i := strings.LastIndexAny(input, " ("); i >= 0 {
+		prefix = input[:i+1]
+		token = input[i+1:]
+	} else {
+		prefix = ""
+	}
+	negate := ""
+	if strings.HasPrefix(token, "-") {
+		negate = "-"
+		token = token[1:]
+	}
+
+	var candidates []string
+	if strings.HasPrefix(token, "@") {
+		names := make([]string, 0, len(saved))
+		for name := range saved {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if strings.HasPrefix(strings.ToLower(name), strings.ToLower(token[1:])) {
+				candidates = append(candidates, "@"+name)
+			}
+		}
+	} else if field, value, ok := strings.Cut(token, ":"); ok {
+		for _, v := range queryFieldValues(strings.ToLower(field), sessions) {
+			if strings.HasPrefix(strings.ToLower(v), strings.ToLower(value)) && !strings.EqualFold(v, value) {
+				candidates = append(candidates, field+":"+quoteQueryValue(v))
+			}
+		}
+	} else if token != "" {
+		for _, f := range QueryFields {
+			if strings.HasPrefix(f, strings.ToLower(token)) {
+				candidates = append(candidates, f+":")
+			}
+		}
+	}
+
+	out := make([]string, len(candidates))
+	for i, c := range candidates {
+		out[i] = prefix + negate + c
+	}
+	return out
+}
+
+// queryFieldValues returns the distinct values of a completable field across
+// sessions, sorted for stable presentation.
+func queryFieldValues(field string, sessions []Session) []string {
+	seen := map[string]struct{}{}
+	add := func(v string) {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			seen[v] = struct{}{}
+		}
+	}
+	switch field {
+	case "status":
+		for _, v := range []string{"active", "attention", "running", "queued", "needs-input", "completed", "failed"} {
+			add(v)
+		}
+	case "source":
+		add("local")
+		add("agent")
+	case "pinned":
+		add("yes")
+		add("no")
+	}
+	for _, s := range sessions {
+		switch field {
+		case "repo", "repository":
+			add(s.Repository)
+		case "status":
+			add(strings.ToLower(s.Status))
+		case "branch":
+			add(s.Branch)
+		case "origin":
+			add(s.Origin)
+		case "model":
+			if s.Telemetry != nil {
+				add(s.Telemetry.Model)
+			}
+		case "tag":
+			if s.Annotation != nil {
+				for _, t := range s.Annotation.Tags {
+					add(t)
+				}
+			}
+		}
+	}
+	values := make([]string, 0, len(seen))
+	for v := range seen {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func quoteQueryValue(v string) string {
+	if strings.ContainsAny(v, " ()") {
+		return strconv.Quote(v)
+	}
+	return v
+}
+
+// maxQueryExpansionDepth bounds nested "@name" expansion so a saved filter
+// that refers to itself fails instead of recursing forever.
+const maxQueryExpansionDepth = 8
+
+type queryNode interface {
+	match(s Session, now time.Time) bool
+}
+
+type andNode struct{ left, right queryNode }
+type orNode struct{ left, right queryNode }
+type notNode struct{ inner queryNode }
+
+func (n andNode) match(s Session, now time.Time) bool {
+	return n.left.match(s, now) && n.right.match(s, now)
+}
+
+func (n orNode) match(s Session, now time.Time) bool {
+	return n.left.match(s, now) || n.right.match(s, now)
+}
+
+func (n notNode) match(s Session, now time.Time) bool {
+	return !n.inner.match(s, now)
+}
+
+// textNode is a bare word: substring match over the common text fields.
+type textNode struct{ text string }
+
+func (n textNode) match(s Session, _ time.Time) bool {
+	return strings.Contains(strings.ToLower(s.Title), n.text) ||
+		strings.Contains(strings.ToLower(s.Repository), n.text) ||
+		strings.Contains(strings.ToLower(s.Branch), n.text) ||
+		strings.Contains(strings.ToLower(s.Status), n.text)
+}
+
+// stringFieldNode matches a text field by glob (when the pattern contains
+// '*' or '?') or case-insensitive substring.
+type stringFieldNode struct {
+	get     func(Session) string
+	pattern string
+}
+
+func (n stringFieldNode) match(s Session, _ time.Time) bool {
+	return matchQueryString(strings.ToLower(n.get(s)), n.pattern)
+}
+
+func matchQueryString(value, pattern string) bool {
+	if strings.ContainsAny(pattern, "*?[") {
+		ok, err := path.Match(pattern, value)
+		return err == nil && ok
+	}
+	return strings.Contains(value, pattern)
+}
+
+type statusNode struct{ status string }
+
+func (n statusNode) match(s Session, _ time.Time) bool {
+	switch n.status {
+	case "active":
+		return StatusIsActive(s.Status) || strings.EqualFold(s.Status, "needs-input")
+	case "attention":
+		return SessionNeedsAnyAttention(s)
+	case "idle":
+		return StatusIsActive(s.Status) && !SessionIsActiveNotIdle(s)
+	}
+	return matchQueryString(strings.ToLower(strings.TrimSpace(s.Status)), n.status)
+}
+
+type sourceNode struct{ source SessionSource }
+
+func (n sourceNode) match(s Session, _ time.Time) bool {
+	return s.Source == n.source
+}
+
+// tagNode matches sessions carrying a tag, by glob or exact name.
+type tagNode struct{ tag string }
+
+func (n tagNode) match(s Session, _ time.Time) bool {
+	if s.Annotation == nil {
+		return false
+	}
+	for _, t := range s.Annotation.Tags {
+		t = strings.ToLower(t)
+		if strings.ContainsAny(n.tag, "*?[") {
+			if ok, err := path.Match(n.tag, t); err == nil && ok {
+				return true
+			}
+		} else if t == n.tag {
+			return true
+		}
+	}
+	return false
+}
+
+type pinnedNode struct{ pinned bool }
+
+func (n pinnedNode) match(s Session, _ time.Time) bool {
+	return s.Annotation.IsPinned() == n.pinned
+}
+
+// compareNode compares a numeric session property against a threshold.
+type compareNode struct {
+	get   func(Session, time.Time) (float64, bool)
+	op    string
+	value float64
+}
+
+func (n compareNode) match(s Session, now time.Time) bool {
+	v, ok := n.get(s, now)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return v < n.value
+	case "<=":
+		return v <= n.value
+	case ">":
+		return v > n.value
+	case ">=":
+		return v >= n.value
+	default:
+		return v == n.value
+	}
+}
+
+type queryParser struct {
+	saved  map[string]string
+	tokens []string
+	pos    int
+	depth  int
+}
+
+// parseExpanded tokenizes and parses input as a complete expression.
+func (p *queryParser) parseExpanded(input string, depth int) (queryNode, error) {
+	if depth > maxQueryExpansionDepth {
+		return nil, fmt.Errorf("saved filters nest too deeply")
+	}
+	tokens, err := tokenizeQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	sub := &queryParser{saved: p.saved, tokens: tokens, depth: depth}
+	node, err := sub.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if sub.pos < len(sub.tokens) {
+		return nil, fmt.Errorf("unexpected %q", sub.tokens[sub.pos])
+	}
+	return node, nil
+}
+
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" || p.peek() == "|" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok == "" || tok == ")" || tok == "OR" || tok == "|" {
+			return left, nil
+		}
+		if tok == "AND" {
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	tok := p.peek()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("expected a term")
+	case tok == "NOT":
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	case tok == "(":
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	case tok == ")" || tok == "AND" || tok == "OR" || tok == "|":
+		return nil, fmt.Errorf("unexpected %q", tok)
+	case len(tok) > 1 && tok[0] == '-':
+		p.pos++
+		inner, err := p.parseTerm(tok[1:])
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	p.pos++
+	return p.parseTerm(tok)
+}
+
+func (p *queryParser) parseTerm(tok string) (queryNode, error) {
+	if strings.HasPrefix(tok, "@") {
+		name := tok[1:]
+		expr, ok := p.saved[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown saved filter @%s", name)
+		}
+		node, err := p.parseExpanded(expr, p.depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("@%s: %w", name, err)
+		}
+		if node == nil {
+			return textNode{}, nil
+		}
+		return node, nil
+	}
+
+	field, value, ok := strings.Cut(tok, ":")
+	if !ok || !isQueryField(field) {
+		// Colons in free text, as in "TODO:" or a URL, don't make a field.
+		return textNode{text: strings.ToLower(unquoteQueryValue(tok))}, nil
+	}
+	value = strings.ToLower(unquoteQueryValue(value))
+	if value == "" {
+		return nil, fmt.Errorf("%s: missing value", field)
+	}
+
+	switch strings.ToLower(field) {
+	case "repo", "repository":
+		return stringFieldNode{func(s Session) string { return s.Repository }, value}, nil
+	case "branch":
+		return stringFieldNode{func(s Session) string { return s.Branch }, value}, nil
+	case "origin":
+		return stringFieldNode{func(s Session) string { return s.Origin }, value}, nil
+	case "title":
+		return stringFieldNode{func(s Session) string { return s.Title }, value}, nil
+	case "id":
+		return stringFieldNode{func(s Session) string { return s.ID }, value}, nil
+	case "model":
+		return stringFieldNode{func(s Session) string {
+			if s.Telemetry == nil {
+				return ""
+			}
+			return s.Telemetry.Model
+		}, value}, nil
+	case "note":
+		return stringFieldNode{func(s Session) string {
+			if s.Annotation == nil {
+				return ""
+			}
+			return s.Annotation.Note
+		}, value}, nil
+	case "tag":
+		return tagNode{strings.TrimPrefix(value, "#")}, nil
+	case "pinned":
+		switch value {
+		case "yes", "true":
+			return pinnedNode{true}, nil
+		case "no", "false":
+			return pinnedNode{false}, nil
+		}
+		return nil, fmt.Errorf("pinned: expected yes or no, got %q", value)
+	case "status":
+		return statusNode{value}, nil
+	case "source":
+		switch value {
+		case "local", "local-copilot", "cli":
+			return sourceNode{SourceLocalCopilot}, nil
+		case "agent", "agent-task", "remote":
+			return sourceNode{SourceAgentTask}, nil
+		}
+		return nil, fmt.Errorf("source: expected local or agent, got %q", value)
+	case "age":
+		return parseDurationCompare(field, value, func(s Session) time.Time {
+			if s.CreatedAt.IsZero() {
+				return s.UpdatedAt
+			}
+			return s.CreatedAt
+		})
+	case "updated":
+		return parseDurationCompare(field, value, func(s Session) time.Time { return s.UpdatedAt })
+	case "cost":
+		op, rest := splitCompareOp(value)
+		amount, err := strconv.ParseFloat(strings.TrimPrefix(rest, "$"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("cost: invalid amount %q", rest)
+		}
+		return compareNode{func(s Session, _ time.Time) (float64, bool) {
+			return SessionCost(s), true
+		}, op, amount}, nil
+	}
+	return nil, fmt.Errorf("unknown field %q", field)
+}
+
+// isQueryField reports whether name is one of QueryFields, or an alias.
+func isQueryField(name string) bool {
+	name = strings.ToLower(name)
+	return name == "repository" || slices.Contains(QueryFields, name)
+}
+
+func parseDurationCompare(field, value string, at func(Session) time.Time) (queryNode, error) {
+	op, rest := splitCompareOp(value)
+	d, err := ParseDuration(rest)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return compareNode{func(s Session, now time.Time) (float64, bool) {
+		t := at(s)
+		if t.IsZero() {
+			return 0, false
+		}
+		return float64(now.Sub(t)), true
+	}, op, float64(d)}, nil
+}
+
+func splitCompareOp(value string) (string, string) {
+	for _, op := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, op) {
+			return op, value[len(op):]
+		}
+	}
+	// A bare duration or amount reads most naturally as an upper bound
+	// ("age:2h" means "within the last two hours").
+	return "<=", value
+}
+
+// ParseDuration extends time.ParseDuration with day ("d") and week ("w")
+// units. Search queries and archive thresholds use it.
+func ParseDuration(s string) (time.Duration, error) {
+	for _, unit := range []struct {
+		suffix string
+		scale  time.Duration
+	}{{"d", 24 * time.Hour}, {"w", 7 * 24 * time.Hour}} {
+		if strings.HasSuffix(s, unit.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(s, unit.suffix), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(n * float64(unit.scale)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// tokenizeQuery splits input on whitespace, keeping quoted strings intact and
+// emitting parentheses as their own tokens.
+func tokenizeQuery(input string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuote := false
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case inQuote:
+			cur.WriteRune(r)
+		case r == ' ' || r == '\t':
+			flush()
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+func unquoteQueryValue(v string) string {
+	return strings.ReplaceAll(v, `"`, "")
+}
@@ -0,0 +1,189 @@
+package data
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func querySessions() []Session {
+	now := time.Now()
+	return []Session{
+		{
+			ID: "a", Status: "failed", Title: "Fix login", Repository: "org/api",
+			Branch: "copilot/fix-login", Source: SourceAgentTask,
+			CreatedAt: now.Add(-30 * time.Minute), UpdatedAt: now.Add(-10 * time.Minute),
+			Annotation: &Annotation{Pinned: true, Note: "Blocked on SSO", Tags: []string{"auth", "release"}},
+		},
+		{
+			ID: "b", Status: "running", Title: "Refactor cache", Repository: "org/web",
+			Branch: "main", Source: SourceLocalCopilot,
+			CreatedAt: now.Add(-5 * time.Hour), UpdatedAt: now.Add(-1 * time.Minute),
+			Telemetry:  &SessionTelemetry{Model: "claude-opus-4", InputTokens: 100_000, OutputTokens: 10_000},
+			Annotation: &Annotation{Tags: []string{"perf"}},
+		},
+		{
+			ID: "c", Status: "completed", Title: "Docs update", Repository: "org/api",
+			Branch: "copilot/docs", Source: SourceLocalCopilot, Origin: "devbox",
+			CreatedAt: now.Add(-3 * 24 * time.Hour), UpdatedAt: now.Add(-2 * 24 * time.Hour),
+			Telemetry: &SessionTelemetry{Model: "claude-haiku", InputTokens: 1000, OutputTokens: 100},
+		},
+	}
+}
+
+func matchIDs(t *testing.T, expr string, saved map[string]string) string {
+	t.Helper()
+	q, err := ParseQuery(expr, saved)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) error: %v", expr, err)
+	}
+	var ids []string
+	for _, s := range q.Filter(querySessions()) {
+		ids = append(ids, s.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestParseQuery_Matches(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{"", "a,b,c"},
+		{"login", "a"},
+		{"repo:org/api", "a,c"},
+		{"status:failed", "a"},
+		{"status:active", "b"},
+		{"source:local", "b,c"},
+		{"source:agent", "a"},
+		{"origin:devbox", "c"},
+		{"branch:copilot/*", "a,c"},
+		{"model:opus", "b"},
+		{"age:<2h", "a"},
+		{"age:>1d", "c"},
+		{"updated:5m", "b"},
+		{"cost:>1.50", "b"},
+		{"cost:<$0.01", "a,c"},
+		{"repo:org/api status:completed", "c"},
+		{"repo:org/api AND status:completed", "c"},
+		{"status:failed OR model:opus", "a,b"},
+		{"NOT source:local", "a"},
+		{"-source:local", "a"},
+		{"repo:org/api (status:failed OR status:running)", "a"},
+		{`title:"docs update"`, "c"},
+		{"tag:auth", "a"},
+		{"tag:#perf", "b"},
+		{"tag:re*", "a"},
+		{"tag:aut", ""},
+		{"note:sso", "a"},
+		{"pinned:yes", "a"},
+		{"pinned:no", "b,c"},
+	}
+	for _, tc := range cases {
+		if got := matchIDs(t, tc.expr, nil); got != tc.want {
+			t.Errorf("%q: got %q, want %q", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestParseQuery_SavedFilters(t *testing.T) {
+	saved := map[string]string{
+		"mine":   "repo:org/api",
+		"broken": "@mine status:failed",
+		"loop":   "@loop",
+	}
+	if got := matchIDs(t, "@broken", saved); got != "a" {
+		t.Errorf("@broken: got %q, want %q", got, "a")
+	}
+	if _, err := ParseQuery("@loop", saved); err == nil {
+		t.Error("expected error for self-referencing saved filter")
+	}
+	if _, err := ParseQuery("@missing", saved); err == nil {
+		t.Error("expected error for unknown saved filter")
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"age:<abc",
+		"cost:>lots",
+		"source:mars",
+		"pinned:maybe",
+		"status:",
+		"(repo:x",
+		"repo:x )",
+		"OR repo:x",
+		`title:"open`,
+	} {
+		if _, err := ParseQuery(expr, nil); err == nil {
+			t.Errorf("ParseQuery(%q): expected error", expr)
+		}
+	}
+}
+
+func TestParseQuery_UnknownFieldsAreText(t *testing.T) {
+	for _, expr := range []string{"todo:", "fix:retries", "https://github.com/org/api", "Color:Red"} {
+		q, err := ParseQuery(expr, nil)
+		if err != nil {
+			t.Errorf("ParseQuery(%q): %v", expr, err)
+			continue
+		}
+		title := "Notes on " + expr + " later"
+		if !q.Match(Session{Title: title}) || q.Match(Session{Title: "something else"}) {
+			t.Errorf("%q: expected a substring search of the whole token", expr)
+		}
+	}
+}
+
+func TestNilQueryMatchesEverything(t *testing.T) {
+	var q *Query
+	if !q.Match(Session{}) {
+		t.Error("nil query should match")
+	}
+	if got := len(q.Filter(querySessions())); got != 3 {
+		t.Errorf("nil query Filter returned %d sessions, want 3", got)
+	}
+}
+
+func TestCompleteQuery(t *testing.T) {
+	sessions := querySessions()
+
+	got := CompleteQuery("status:failed re", sessions, nil)
+	if len(got) != 1 || got[0] != "status:failed repo:" {
+		t.Errorf("field completion: got %v", got)
+	}
+
+	got = CompleteQuery("repo:org/a", sessions, nil)
+	if len(got) != 1 || got[0] != "repo:org/api" {
+		t.Errorf("value completion: got %v", got)
+	}
+
+	got = CompleteQuery("-model:claude", sessions, nil)
+	if len(got) != 2 || got[0] != "-model:claude-haiku" {
+		t.Errorf("negated value completion: got %v", got)
+	}
+
+	got = CompleteQuery("tag:re", sessions, nil)
+	if len(got) != 1 || got[0] != "tag:release" {
+		t.Errorf("tag completion: got %v", got)
+	}
+
+	got = CompleteQuery("@m", sessions, map[string]string{"mine": "repo:x", "other": "y"})
+	if len(got) != 1 || got[0] != "@mine" {
+		t.Errorf("saved filter completion: got %v", got)
+	}
+
+	if got := CompleteQuery("", sessions, nil); len(got) != 0 {
+		t.Errorf("empty input should have no completions, got %v", got)
+	}
+}
+
+func TestSessionCost(t *testing.T) {
+	if SessionCost(Session{}) != 0 {
+		t.Error("session without telemetry should cost 0")
+	}
+	s := Session{Telemetry: &SessionTelemetry{Model: "claude-opus", InputTokens: 1_000_000}}
+	if got := SessionCost(s); got != 15 {
+		t.Errorf("SessionCost = %v, want 15", got)
+	}
+}
@@ -0,0 +1,310 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteHost is a machine whose local Copilot sessions are read through the
+// agent helper (`gh agent-viz agent`). Command starts the helper with its
+// stdin and stdout connected to this process, usually over ssh.
+type RemoteHost struct {
+	Name    string   // label shown on the host's sessions
+	Command []string // e.g. ssh -o BatchMode=yes devbox gh agent-viz agent
+}
+
+// SSHAgentCommand returns the command that runs the agent helper on
+// destination over ssh. BatchMode stops ssh from prompting inside the TUI.
+func SSHAgentCommand(destination string) []string {
+	return []string{"ssh", "-o", "BatchMode=yes", destination, "gh", "agent-viz", "agent"}
+}
+
+// remoteTimeout bounds one exchange with a remote helper.
+var remoteTimeout = 20 * time.Second
+
+var (
+	remoteMu    sync.RWMutex
+	remoteHosts []RemoteHost
+	// remoteSessions maps the ID of each session fetched from a remote host
+	// to the host, so log and event fetches are answered by that host.
+	remoteSessions map[string]remoteSession
+	remoteUsage    map[string]*TokenUsage
+)
+
+type remoteSession struct {
+	host    RemoteHost
+	lastMsg string
+}
+
+// SetRemoteHosts replaces the remote hosts sessions are read from.
+func SetRemoteHosts(hosts []RemoteHost) {
+	remoteMu.Lock()
+	defer remoteMu.Unlock()
+	remoteHosts = append([]RemoteHost(nil), hosts...)
+	remoteSessions = nil
+	remoteUsage = nil
+}
+
+// RemoteHosts returns the configured remote hosts, or none while a fixture
+// is replayed.
+func RemoteHosts() []RemoteHost {
+	if fixtureActive() {
+		return nil
+	}
+	remoteMu.RLock()
+	defer remoteMu.RUnlock()
+	return append([]RemoteHost(nil), remoteHosts...)
+}
+
+// remoteHostFor returns the remote session a session ID was fetched from.
+func remoteHostFor(sessionID string) (remoteSession, bool) {
+	remoteMu.RLock()
+	defer remoteMu.RUnlock()
+	rs, ok := remoteSessions[sessionID]
+	return rs, ok
+}
+
+// FetchRemoteSessions reads the local sessions and token usage of every
+// remote host concurrently. Each session is labeled with its host: Host
+// names it and Origin is the host, followed by the remote session root's
+// label when there is one. Hosts that fail are reported in the error while
+// the others' sessions are still returned.
+func FetchRemoteSessions() ([]Session, map[string]*TokenUsage, error) {
+	hosts := RemoteHosts()
+	if len(hosts) == 0 {
+		return nil, nil, nil
+	}
+
+	type result struct {
+		host     RemoteHost
+		sessions []Session
+		usage    map[string]*TokenUsage
+		err      error
+	}
+	results := make([]result, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host RemoteHost) {
+			defer wg.Done()
+			resps, err := callRemote(host, agentRequest{Op: agentOpSessions}, agentRequest{Op: agentOpTokenUsage})
+			r := result{host: host, err: err}
+			if err == nil {
+				r.sessions = resps[0].sessionList()
+				r.usage = resps[1].TokenUsage
+			}
+			results[i] = r
+		}(i, host)
+	}
+	wg.Wait()
+
+	var sessions []Session
+	var errs []error
+	usage := map[string]*TokenUsage{}
+	index := map[string]remoteSession{}
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.host.Name, r.err))
+			continue
+		}
+		for _, s := range r.sessions {
+			if s.Origin == "" {
+				s.Origin = r.host.Name
+			} else {
+				s.Origin = r.host.Name + "/" + s.Origin
+			}
+			s.Host = r.host.Name
+			index[s.ID] = remoteSession{host: r.host, lastMsg: s.LastAssistantMessage}
+			sessions = append(sessions, s)
+		}
+		for id, u := range r.usage {
+			usage[id] = u
+		}
+	}
+
+	remoteMu.Lock()
+	remoteSessions = index
+	remoteUsage = usage
+	remoteMu.Unlock()
+	return sessions, usage, errors.Join(errs...)
+}
+
+// mergeRemoteUsage returns usage plus the token usage last fetched from
+// remote hosts. usage itself is left untouched because it may be the cache.
+func mergeRemoteUsage(usage map[string]*TokenUsage) map[string]*TokenUsage {
+	remoteMu.RLock()
+	defer remoteMu.RUnlock()
+	if len(remoteUsage) == 0 {
+		return usage
+	}
+	out := make(map[string]*TokenUsage, len(usage)+len(remoteUsage))
+	for id, u := range remoteUsage {
+		dup := *u
+		out[id] = &dup
+	}
+	for id, u := range usage {
+		out[id] = u
+	}
+	return out
+}
+
+// fetchRemoteLog fetches a session's formatted log from its host.
+func fetchRemoteLog(rs remoteSession, sessionID string) (string, error) {
+	resps, err := callRemote(rs.host, agentRequest{Op: agentOpLog, ID: sessionID})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", rs.host.Name, err)
+	}
+	return resps[0].Log, nil
+}
+
+// fetchRemoteEvents fetches a session's structured events from its host.
+func fetchRemoteEvents(rs remoteSession, sessionID string) ([]SessionEvent, error) {
+	resps, err := callRemote(rs.host, agentRequest{Op: agentOpEvents, ID: sessionID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rs.host.Name, err)
+	}
+	return resps[0].Events, nil
+}
+
+// The agent protocol is newline-delimited JSON over the helper's stdio: the
+// client writes requests and closes stdin, and the helper answers each in
+// order, then exits.
+const (
+	agentOpSessions   = "sessions"
+	agentOpTokenUsage = "tokenUsage"
+	agentOpLog        = "log"
+	agentOpEvents     = "events"
+)
+
+type agentRequest struct {
+	Op string `json:"op"`
+	ID string `json:"id,omitempty"`
+}
+
+type agentResponse struct {
+	Sessions   []SessionRecord        `json:"sessions,omitempty"`
+	TokenUsage map[string]*TokenUsage `json:"tokenUsage,omitempty"`
+	Log        string                 `json:"log,omitempty"`
+	Events     []SessionEvent         `json:"events,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+func (r agentResponse) sessionList() []Session {
+	out := make([]Session, len(r.Sessions))
+	for i, rec := range r.Sessions {
+		out[i] = rec.Restore()
+	}
+	return out
+}
+
+// callRemote runs the host's helper command, sends reqs and returns one
+// response per request. A response carrying an error fails the call.
+func callRemote(host RemoteHost, reqs ...agentRequest) ([]agentResponse, error) {
+	if len(host.Command) == 0 {
+		return nil, fmt.Errorf("no command configured")
+	}
+	cmd := execCommand(host.Command[0], host.Command[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start %s: %w", host.Command[0], err)
+	}
+	timer := time.AfterFunc(remoteTimeout, func() { _ = cmd.Process.Kill() })
+	defer timer.Stop()
+
+	enc := json.NewEncoder(stdin)
+	for _, req := range reqs {
+		if err := enc.Encode(req); err != nil {
+			break // the helper exited early; Wait reports why
+		}
+	}
+	stdin.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(stdout))
+	resps := make([]agentResponse, 0, len(reqs))
+	var decodeErr error
+	for range reqs {
+		var resp agentResponse
+		if decodeErr = dec.Decode(&resp); decodeErr != nil {
+			break
+		}
+		resps = append(resps, resp)
+	}
+	_, _ = io.Copy(io.Discard, stdout)
+	waitErr := cmd.Wait()
+
+	if len(resps) < len(reqs) {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		if waitErr != nil {
+			return nil, waitErr
+		}
+		return nil, fmt.Errorf("incomplete response from agent helper: %v", decodeErr)
+	}
+	for _, resp := range resps {
+		if resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+	}
+	return resps, nil
+}
+
+// ServeAgent answers agent protocol requests read from r with this machine's
+// local sessions, writing responses to w until r is exhausted. It backs the
+// `gh agent-viz agent` helper that remote hosts run.
+func ServeAgent(r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(r)
+	enc := json.NewEncoder(w)
+	for {
+		var req agentRequest
+		if err := dec.Decode(&req); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("read request: %w", err)
+		}
+		if err := enc.Encode(serveAgentRequest(req)); err != nil {
+			return fmt.Errorf("write response: %w", err)
+		}
+	}
+}
+
+func serveAgentRequest(req agentRequest) agentResponse {
+	var resp agentResponse
+	var err error
+	switch req.Op {
+	case agentOpSessions:
+		var sessions []Session
+		sessions, err = FetchLocalSessions()
+		resp.Sessions = make([]SessionRecord, len(sessions))
+		for i, s := range sessions {
+			resp.Sessions[i] = NewSessionRecord(s)
+		}
+	case agentOpTokenUsage:
+		resp.TokenUsage, err = FetchTokenUsage()
+	case agentOpLog:
+		resp.Log, err = FetchLocalSessionLog(req.ID)
+	case agentOpEvents:
+		resp.Events, err = FetchSessionEvents(req.ID)
+	default:
+		err = fmt.Errorf("unknown request %q", req.Op)
+	}
+	if err != nil {
+		return agentResponse{Error: err.Error()}
+	}
+	return resp
+}
@@ -0,0 +1,121 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRemoteAgentHelper stands in for `ssh <host> gh agent-viz agent`: when
+// re-run as a subprocess it serves the Copilot root named by
+// REMOTE_AGENT_ROOT over stdio, like the helper on a remote machine.
+func TestRemoteAgentHelper(t *testing.T) {
+	if os.Getenv("GO_WANT_REMOTE_AGENT") != "1" {
+		return
+	}
+	SetSessionRoots([]SessionRoot{CopilotRoot("", os.Getenv("REMOTE_AGENT_ROOT"))})
+	if err := ServeAgent(os.Stdin, os.Stdout); err != nil {
+		os.Stderr.WriteString(err.Error())
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// loopbackHost returns a remote host whose helper is this test binary
+// serving dir.
+func loopbackHost(t *testing.T, name, dir string) RemoteHost {
+	t.Helper()
+	t.Setenv("GO_WANT_REMOTE_AGENT", "1")
+	t.Setenv("REMOTE_AGENT_ROOT", dir)
+	return RemoteHost{Name: name, Command: []string{os.Args[0], "-test.run=^TestRemoteAgentHelper$"}}
+}
+
+func TestFetchRemoteSessions_Loopback(t *testing.T) {
+	base := t.TempDir()
+	remote := CopilotRoot("", filepath.Join(base, "devbox"))
+	writeRootSession(t, remote, "remote-001", "Devbox work")
+	// The local machine has no sessions of its own.
+	SetSessionRoots([]SessionRoot{CopilotRoot("", filepath.Join(base, "local"))})
+	SetRemoteHosts([]RemoteHost{
+		loopbackHost(t, "devbox", filepath.Join(base, "devbox")),
+		{Name: "offline", Command: []string{filepath.Join(base, "no-such-ssh")}},
+	})
+	t.Cleanup(func() { SetRemoteHosts(nil); SetSessionRoots(nil) })
+
+	sessions, _, err := FetchRemoteSessions()
+	if err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("expected an error naming the unreachable host, got %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected the devbox session despite the offline host, got %d", len(sessions))
+	}
+	s := sessions[0]
+	if s.ID != "remote-001" || s.Host != "devbox" || s.Origin != "devbox" || s.Title != "Devbox work" {
+		t.Errorf("unexpected remote session: %+v", s)
+	}
+	if !s.HasLog {
+		t.Error("expected HasLog to survive the trip")
+	}
+
+	out, err := FetchLocalSessionLog("remote-001")
+	if err != nil || !strings.Contains(out, "hello from Devbox work") {
+		t.Errorf("expected the log from the remote host, got %q, %v", out, err)
+	}
+	events, err := FetchSessionEvents("remote-001")
+	if err != nil || len(events) != 1 || events[0].Role != "assistant" {
+		t.Errorf("expected the remote events, got %+v, %v", events, err)
+	}
+	if msg := FetchLastAssistantMessage("remote-001"); msg != "hello from Devbox work" {
+		t.Errorf("expected the cached remote message, got %q", msg)
+	}
+
+	all, err := FetchAllSessions("")
+	if err != nil {
+		t.Fatalf("FetchAllSessions: %v", err)
+	}
+	var found bool
+	for _, s := range all {
+		found = found || s.Host == "devbox"
+	}
+	if !found {
+		t.Error("expected FetchAllSessions to include remote sessions")
+	}
+}
+
+func TestServeAgent(t *testing.T) {
+	root := CopilotRoot("", t.TempDir())
+	writeRootSession(t, root, "session-001", "Served")
+	SetSessionRoots([]SessionRoot{root})
+	t.Cleanup(func() { SetSessionRoots(nil) })
+
+	in := strings.NewReader(`{"op":"sessions"}` + "\n" + `{"op":"log","id":"missing"}` + "\n" + `{"op":"bogus"}` + "\n")
+	var out bytes.Buffer
+	if err := ServeAgent(in, &out); err != nil {
+		t.Fatalf("ServeAgent: %v", err)
+	}
+
+	dec := json.NewDecoder(&out)
+	var resps []agentResponse
+	for dec.More() {
+		var r agentResponse
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		resps = append(resps, r)
+	}
+	if len(resps) != 3 {
+		t.Fatalf("expected one response per request, got %d", len(resps))
+	}
+	if len(resps[0].Sessions) != 1 || resps[0].Sessions[0].Title != "Served" {
+		t.Errorf("unexpected sessions response: %+v", resps[0])
+	}
+	if resps[1].Error == "" {
+		t.Error("expected an error for a missing session log")
+	}
+	if !strings.Contains(resps[2].Error, "unknown request") {
+		t.Errorf("expected an unknown-request error, got %q", resps[2].Error)
+	}
+}
@@ -0,0 +1,188 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ResourceHistoryLen is how many samples of each session's totals are kept.
+const ResourceHistoryLen = 60
+
+// ProcessUsage is one process in a session's process tree.
+type ProcessUsage struct {
+	PID        int
+	Depth      int     // 0 for the lock holder, 1 for its children, and so on
+	Command    string  // command line, or the executable name
+	CPUPercent float64 // since the previous sample; 0 on the first
+	RSS        int64   // resident memory in bytes
+	OpenFiles  int     // open file descriptors; 0 when unreadable
+}
+
+// ResourceSample totals a session's process tree at one point in time.
+type ResourceSample struct {
+	At         time.Time
+	CPUPercent float64
+	RSS        int64
+	OpenFiles  int
+	Processes  int
+}
+
+// SessionResources is what a session's processes cost the machine: the
+// process tree at the latest sample and the history of its totals.
+type SessionResources struct {
+	Processes []ProcessUsage   // depth-first from each lock holder
+	History   []ResourceSample // oldest first
+}
+
+// Latest returns the most recent totals.
+func (r SessionResources) Latest() (ResourceSample, bool) {
+	if len(r.History) == 0 {
+		return ResourceSample{}, false
+	}
+	return r.History[len(r.History)-1], true
+}
+
+// ResourceMonitor samples the process trees of local sessions: the
+// processes holding each session's inuse.{PID}.lock file and everything
+// they spawned, such as test runners and builds started by tools. CPU use
+// is measured between consecutive samples, so the first sample of a
+// process reports none.
+type ResourceMonitor struct {
+	mu       sync.Mutex
+	cpu      map[int]cpuMark
+	sessions map[string]*SessionResources
+}
+
+// cpuMark is a process's CPU time when last sampled. start tells a reused
+// PID apart from the process it was measured for.
+type cpuMark struct {
+	start time.Duration
+	cpu   time.Duration
+	at    time.Time
+}
+
+// NewResourceMonitor creates a monitor with no history.
+func NewResourceMonitor() *ResourceMonitor {
+	return &ResourceMonitor{cpu: map[int]cpuMark{}, sessions: map[string]*SessionResources{}}
+}
+
+// Sample reads the process tree of each session in ~/.copilot on this
+// machine and returns the resources of those whose lock is held by a live
+// process, as InspectSessionLocks finds them. History is dropped
+// for sessions that are no longer sampled or whose processes have exited.
+func (r *ResourceMonitor) Sample(sessionIDs []string) map[string]SessionResources {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	roots := map[string][]int{}
+	for _, id := range sessionIDs {
+		if locks, err := InspectSessionLocks(id); err == nil && len(locks.Live) > 0 {
+			for _, p := range locks.Live {
+				roots[id] = append(roots[id], p.PID)
+			}
+		}
+	}
+
+	out := make(map[string]SessionResources, len(roots))
+	seen := map[int]bool{}
+	if len(roots) > 0 {
+		children := processChildren()
+		for id, pids := range roots {
+			res := r.sessions[id]
+			if res == nil {
+				res = &SessionResources{}
+			}
+			res.Processes = nil
+			total := ResourceSample{At: now}
+			for _, pid := range pids {
+				r.walk(pid, 0, children, now, seen, res, &total)
+			}
+			res.History = append(res.History, total)
+			if n := len(res.History) - ResourceHistoryLen; n > 0 {
+				res.History = res.History[n:]
+			}
+			r.sessions[id] = res
+			out[id] = SessionResources{
+				Processes: append([]ProcessUsage(nil), res.Processes...),
+				History:   append([]ResourceSample(nil), res.History...),
+			}
+		}
+	}
+
+	for id := range r.sessions {
+		if _, ok := out[id]; !ok {
+			delete(r.sessions, id)
+		}
+	}
+	for pid := range r.cpu {
+		if !seen[pid] {
+			delete(r.cpu, pid)
+		}
+	}
+	return out
+}
+
+// walk records pid and its descendants into res, adding them to total.
+func (r *ResourceMonitor) walk(pid, depth int, children map[int][]int, now time.Time, seen map[int]bool, res *SessionResources, total *ResourceSample) {
+	if seen[pid] {
+		return
+	}
+	st, ok := readProcStat(pid)
+	if !ok {
+		return
+	}
+	seen[pid] = true
+	usage := ProcessUsage{PID: pid, Depth: depth, Command: readCmdline(pid), RSS: st.rss, OpenFiles: openFileCount(pid)}
+	if usage.Command == "" {
+		usage.Command = st.comm
+	}
+	if prev, ok := r.cpu[pid]; ok && prev.start == st.start && now.After(prev.at) {
+		usage.CPUPercent = 100 * (st.cpu - prev.cpu).Seconds() / now.Sub(prev.at).Seconds()
+	}
+	r.cpu[pid] = cpuMark{start: st.start, cpu: st.cpu, at: now}
+
+	res.Processes = append(res.Processes, usage)
+	total.CPUPercent += usage.CPUPercent
+	total.RSS += usage.RSS
+	total.OpenFiles += usage.OpenFiles
+	total.Processes++
+	for _, child := range children[pid] {
+		r.walk(child, depth+1, children, now, seen, res, total)
+	}
+}
+
+// processChildren maps each process to its children, in PID order.
+func processChildren() map[int][]int {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil
+	}
+	children := map[int][]int{}
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		if st, ok := readProcStat(pid); ok {
+			children[st.ppid] = append(children[st.ppid], pid)
+		}
+	}
+	for _, pids := range children {
+		sort.Ints(pids)
+	}
+	return children
+}
+
+// openFileCount counts a process's open file descriptors.
+func openFileCount(pid int) int {
+	entries, err := os.ReadDir(filepath.Join(procDir, strconv.Itoa(pid), "fd"))
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// writeFakeProc adds a process to a fake /proc with the given parent, CPU
+// ticks, resident pages and open file count.
+func writeFakeProc(t *testing.T, proc string, pid, ppid, cpuTicks, rssPages, files int, cmdline string) {
+	t.Helper()
+	dir := filepath.Join(proc, strconv.Itoa(pid))
+	if err := os.MkdirAll(filepath.Join(dir, "fd"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stat := fmt.Sprintf("%d (proc) S %d 1 1 0 -1 0 0 0 0 0 %d 0 0 0 20 0 1 0 100 1000 %d 0", pid, ppid, cpuTicks, rssPages)
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < files; i++ {
+		if err := os.WriteFile(filepath.Join(dir, "fd", strconv.Itoa(i)), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestResourceMonitor_SamplesProcessTree(t *testing.T) {
+	proc := t.TempDir()
+	orig := procDir
+	procDir = proc
+	t.Cleanup(func() { procDir = orig })
+	fakeProcesses(t, map[int]os.Signal{4242: os.Interrupt})
+	setupLockedSession(t, 4242)
+
+	writeFakeProc(t, proc, 4242, 1, 100, 100, 3, "node\x00copilot\x00")
+	writeFakeProc(t, proc, 5001, 4242, 50, 50, 2, "go\x00test\x00./...\x00")
+	writeFakeProc(t, proc, 5002, 5001, 10, 10, 1, "")
+	writeFakeProc(t, proc, 6000, 1, 999, 999, 9, "unrelated")
+
+	mon := NewResourceMonitor()
+	first := mon.Sample([]string{"hung", "missing"})
+	res, ok := first["hung"]
+	if !ok || len(first) != 1 {
+		t.Fatalf("expected resources for the locked session only, got %+v", first)
+	}
+	if len(res.Processes) != 3 {
+		t.Fatalf("expected the lock holder and its two descendants, got %+v", res.Processes)
+	}
+	if p := res.Processes[1]; p.PID != 5001 || p.Depth != 1 || p.Command != "go test ./..." || p.OpenFiles != 2 {
+		t.Errorf("unexpected child %+v", p)
+	}
+	if p := res.Processes[2]; p.PID != 5002 || p.Depth != 2 || p.Command != "proc" {
+		t.Errorf("expected the grandchild named after its executable, got %+v", p)
+	}
+	total, _ := res.Latest()
+	if total.Processes != 3 || total.OpenFiles != 6 || total.RSS != 160*int64(os.Getpagesize()) || total.CPUPercent != 0 {
+		t.Errorf("unexpected first totals %+v", total)
+	}
+
+	writeFakeProc(t, proc, 5001, 4242, 150, 50, 0, "go\x00test\x00./...\x00")
+	second := mon.Sample([]string{"hung"})["hung"]
+	if len(second.History) != 2 {
+		t.Fatalf("expected two samples of history, got %d", len(second.History))
+	}
+	if total, _ := second.Latest(); total.CPUPercent <= 0 {
+		t.Errorf("expected CPU use between samples, got %+v", total)
+	}
+
+	holdsLock = func(int, string) bool { return false }
+	if got := mon.Sample([]string{"hung"}); len(got) != 0 {
+		t.Errorf("expected no resources once the lock's PID was reused, got %+v", got)
+	}
+	holdsLock = func(pid int, _ string) bool { return pid == 4242 }
+	isProcessAlive = func(int) bool { return false }
+	if got := mon.Sample([]string{"hung"}); len(got) != 0 {
+		t.Errorf("expected no resources once the process exited, got %+v", got)
+	}
+	isProcessAlive = func(pid int) bool { return pid == 4242 }
+	if again := mon.Sample([]string{"hung"})["hung"]; len(again.History) != 1 {
+		t.Errorf("expected history to restart after the process exited, got %d samples", len(again.History))
+	}
+}
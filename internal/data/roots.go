@@ -0,0 +1,111 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SessionRoot is a directory of Copilot CLI state laid out like ~/.copilot:
+// one directory per session under SessionStateDir and process logs under
+// LogDir. Either may be empty. Extra roots let the tool read sessions synced from another
+// machine, a devcontainer volume or a CI artifact.
+type SessionRoot struct {
+	Label           string // shown on sessions from this root; empty for ~/.copilot
+	SessionStateDir string
+	LogDir          string
+}
+
+var (
+	sessionRootsMu sync.RWMutex
+	sessionRoots   []SessionRoot
+	// fixtureRoots replaces every other root while a fixture is replayed.
+	fixtureRoots []SessionRoot
+)
+
+// SetSessionRoots replaces the roots local sessions and token usage are read
+// from. An empty list restores the default, ~/.copilot.
+func SetSessionRoots(roots []SessionRoot) {
+	sessionRootsMu.Lock()
+	sessionRoots = append([]SessionRoot(nil), roots...)
+	sessionRootsMu.Unlock()
+	ResetLocalSessionCache()
+	ResetTokenUsageCache()
+}
+
+// setFixtureRoots installs the roots of a replayed fixture, which take
+// precedence over configured roots; nil removes them.
+func setFixtureRoots(roots []SessionRoot) {
+	sessionRootsMu.Lock()
+	fixtureRoots = roots
+	sessionRootsMu.Unlock()
+	ResetLocalSessionCache()
+	ResetTokenUsageCache()
+}
+
+// fixtureActive reports whether a replayed fixture is standing in for the
+// local machine.
+func fixtureActive() bool {
+	sessionRootsMu.RLock()
+	defer sessionRootsMu.RUnlock()
+	return fixtureRoots != nil
+}
+
+// SessionRoots returns the roots local sessions and token usage are read
+// from, in priority order.
+func SessionRoots() []SessionRoot {
+	sessionRootsMu.RLock()
+	defer sessionRootsMu.RUnlock()
+	if fixtureRoots != nil {
+		return append([]SessionRoot(nil), fixtureRoots...)
+	}
+	if len(sessionRoots) > 0 {
+		return append([]SessionRoot(nil), sessionRoots...)
+	}
+	if root, ok := DefaultSessionRoot(); ok {
+		return []SessionRoot{root}
+	}
+	return nil
+}
+
+// DefaultSessionRoot returns the ~/.copilot root of the current user.
+func DefaultSessionRoot() (SessionRoot, bool) {
+	home, err := userHomeDir()
+	if err != nil {
+		return SessionRoot{}, false
+	}
+	return CopilotRoot("", filepath.Join(home, ".copilot")), true
+}
+
+// CopilotRoot returns the root for a directory laid out like ~/.copilot.
+func CopilotRoot(label, dir string) SessionRoot {
+	return SessionRoot{
+		Label:           label,
+		SessionStateDir: filepath.Join(dir, "session-state"),
+		LogDir:          filepath.Join(dir, "logs"),
+	}
+}
+
+// sessionEventsPath returns the events.jsonl path of a local session in the
+// first root that has the session, or "" when none does.
+func sessionEventsPath(sessionID string) string {
+	if dir := sessionStateDir(sessionID); dir != "" {
+		return filepath.Join(dir, "events.jsonl")
+	}
+	return ""
+}
+
+// sessionStateDir returns the directory of a local session in the first root
+// that has the session, or "" when none does.
+func sessionStateDir(sessionID string) string {
+	for _, root := range SessionRoots() {
+		if root.SessionStateDir == "" {
+			continue
+		}
+		dir := filepath.Join(root.SessionStateDir, sessionID)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeRootSession creates a session directory with a workspace file and a
+// one-message event log under root.
+func writeRootSession(t *testing.T, root SessionRoot, id, title string) {
+	t.Helper()
+	dir := filepath.Join(root.SessionStateDir, id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	workspace := "session_id: \"" + id + "\"\ntitle: \"" + title + "\"\nstatus: \"completed\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "workspace.yaml"), []byte(workspace), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	events := `{"type":"assistant.message","timestamp":"2026-02-15T03:29:00Z","data":{"content":"hello from ` + title + `"}}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "events.jsonl"), []byte(events), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSessionRoots_Default(t *testing.T) {
+	home := t.TempDir()
+	orig := userHomeDir
+	userHomeDir = func() (string, error) { return home, nil }
+	t.Cleanup(func() { userHomeDir = orig; SetSessionRoots(nil) })
+	SetSessionRoots(nil)
+
+	roots := SessionRoots()
+	want := CopilotRoot("", filepath.Join(home, ".copilot"))
+	if len(roots) != 1 || roots[0] != want {
+		t.Errorf("SessionRoots() = %+v, want [%+v]", roots, want)
+	}
+}
+
+func TestFetchLocalSessions_MultipleRoots(t *testing.T) {
+	base := t.TempDir()
+	laptop := CopilotRoot("", filepath.Join(base, "laptop"))
+	devbox := CopilotRoot("devbox", filepath.Join(base, "devbox"))
+	writeRootSession(t, laptop, "session-001", "Laptop work")
+	writeRootSession(t, devbox, "session-002", "Devbox work")
+	writeRootSession(t, devbox, "session-001", "Synced copy")
+	if err := os.MkdirAll(devbox.LogDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(devbox.LogDir, "process-1.log"), []byte(sampleLog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	SetSessionRoots([]SessionRoot{laptop, devbox, {Label: "missing", SessionStateDir: filepath.Join(base, "nope")}})
+	t.Cleanup(func() { SetSessionRoots(nil) })
+
+	sessions, err := FetchLocalSessions()
+	if err != nil {
+		t.Fatalf("FetchLocalSessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions (duplicate skipped), got %d", len(sessions))
+	}
+	origins := map[string]string{}
+	for _, s := range sessions {
+		origins[s.ID] = s.Origin
+		if s.ID == "session-001" && s.Title != "Laptop work" {
+			t.Errorf("expected the first root to win for a duplicate session, got %q", s.Title)
+		}
+	}
+	if origins["session-001"] != "" || origins["session-002"] != "devbox" {
+		t.Errorf("unexpected origins: %v", origins)
+	}
+
+	log, err := FetchLocalSessionLog("session-002")
+	if err != nil || !strings.Contains(log, "hello from Devbox work") {
+		t.Errorf("expected the log from the devbox root, got %q, %v", log, err)
+	}
+	if msg := FetchLastAssistantMessage("session-002"); msg != "hello from Devbox work" {
+		t.Errorf("FetchLastAssistantMessage = %q", msg)
+	}
+	if _, err := FetchSessionEvents("session-404"); err == nil {
+		t.Error("expected an error for a session in no root")
+	}
+
+	usage, err := FetchTokenUsage()
+	if err != nil {
+		t.Fatalf("FetchTokenUsage: %v", err)
+	}
+	if len(usage) != 2 {
+		t.Errorf("expected token usage from the devbox logs, got %d sessions", len(usage))
+	}
+}
@@ -48,9 +48,16 @@ type Session struct {
 	UpdatedAt  time.Time     `json:"updatedAt"`
 	Source     SessionSource `json:"source"`
 	WorkDir    string        `json:"workDir,omitempty"` // local filesystem path (git_root or cwd)
+	Origin     string        `json:"origin,omitempty"`  // label of the session root the session was read from
+	Host       string        `json:"host,omitempty"`    // remote host the session runs on; empty for this machine
+	Archived   bool          `json:"archived,omitempty"` // read back from the archive; read-only
 	Telemetry  *SessionTelemetry `json:"telemetry,omitempty"`
+	PR         *PRStatus         `json:"pr,omitempty"` // checks, reviews and state of the PR; attached by the TUI
 	HasLog               bool              `json:"-"` // true when a viewable log exists (e.g. events.jsonl)
 	LastAssistantMessage string            `json:"-"` // last assistant message (for attention display)
+	LastAction           string            `json:"-"` // latest tool started; set by SessionActivity.ApplyTo
+	LogTail              []string          `json:"-"` // recent log entries; set by SessionActivity.ApplyTo
+	Annotation           *Annotation       `json:"-"` // user's pin, note and tags; attached by the TUI
 }
 
 // FromAgentTask converts an AgentTask to a Session
@@ -128,12 +135,17 @@ func SessionAttentionLevel(session Session) AttentionLevel {
 	if status == "needs-input" || status == "failed" {
 		// Skip stale sessions — if last activity was over AttentionMaxAge ago,
 		// you've moved on and don't need to be nagged about it.
-		if !session.UpdatedAt.IsZero() && time.Since(session.UpdatedAt) > AttentionMaxAge {
+		if !session.UpdatedAt.IsZero() && Since(session.UpdatedAt) > AttentionMaxAge {
 			return AttentionNone
 		}
 		return AttentionUrgent
 	}
 
+	// Warning: the agent's PR has failing checks or waits for a review
+	if PRAttention(session) != "" {
+		return AttentionWarning
+	}
+
 	return AttentionNone
 }
 
@@ -163,7 +175,7 @@ func SessionIsActiveNotIdle(session Session) bool {
 	if session.UpdatedAt.IsZero() {
 		return true
 	}
-	return time.Since(session.UpdatedAt) < AttentionStaleThreshold
+	return Since(session.UpdatedAt) < AttentionStaleThreshold
 }
 
 // IsDefaultBranch returns true for main/master/empty branch names.
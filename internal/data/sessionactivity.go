@@ -0,0 +1,119 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+)
+
+// LogTailLen is how many recent log entries are kept for a session.
+const LogTailLen = 20
+
+// SessionActivity is what a session has been doing lately, read from its
+// events.jsonl off the render path so views only format it.
+type SessionActivity struct {
+	LastAction  string   // latest tool started, e.g. "🔧 bash"; empty if none
+	LastMessage string   // last paragraph of the latest assistant message
+	LogTail     []string // recent tool calls and messages, oldest first
+}
+
+// ApplyTo copies the activity onto s. A session keeps the last message it
+// was loaded with when the events file has none.
+func (a SessionActivity) ApplyTo(s *Session) {
+	s.LastAction = a.LastAction
+	s.LogTail = a.LogTail
+	if a.LastMessage != "" {
+		s.LastAssistantMessage = a.LastMessage
+	}
+}
+
+// ReadSessionActivity reads a local session's events.jsonl in one pass. It
+// reports false for sessions whose events are not on this machine: agent
+// tasks, remote and archived sessions, or a missing events file.
+func ReadSessionActivity(s Session) (SessionActivity, bool) {
+	if s.Source != SourceLocalCopilot || s.ID == "" || s.Host != "" || s.Archived {
+		return SessionActivity{}, false
+	}
+	f, err := openSessionEvents(s.ID)
+	if err != nil {
+		return SessionActivity{}, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var a SessionActivity
+	var tail []string
+	for scanner.Scan() {
+		var event struct {
+			Type string `json:"type"`
+			Data struct {
+				ToolName string `json:"toolName"`
+				Content  string `json:"content"`
+			} `json:"data"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &event) != nil {
+			continue
+		}
+		switch event.Type {
+		case "tool.execution_start":
+			if event.Data.ToolName != "" {
+				a.LastAction = "🔧 " + event.Data.ToolName
+			}
+		case "assistant.message":
+			if msg := lastParagraph(event.Data.Content); msg != "" {
+				a.LastMessage = msg
+			}
+		}
+		if line := logTailLine(event.Type, event.Data.ToolName, event.Data.Content); line != "" {
+			tail = append(tail, line)
+			if len(tail) > 2*LogTailLen {
+				tail = append(tail[:0], tail[len(tail)-LogTailLen:]...)
+			}
+		}
+	}
+	if len(tail) > LogTailLen {
+		tail = tail[len(tail)-LogTailLen:]
+	}
+	a.LogTail = tail
+	return a, true
+}
+
+// logTailLine summarizes an event for the log tail, or returns "" for
+// events not worth showing there.
+func logTailLine(eventType, toolName, content string) string {
+	switch eventType {
+	case "tool.execution_start":
+		return "🔧 " + toolName
+	case "tool.execution_end":
+		return "✓ " + toolName + " done"
+	case "assistant.message":
+		if content == "" {
+			return ""
+		}
+		if len(content) > 80 {
+			content = content[:77] + "..."
+		}
+		return "💬 " + content
+	case "user.message":
+		if content == "" {
+			return ""
+		}
+		if len(content) > 60 {
+			content = content[:57] + "..."
+		}
+		return "👤 " + content
+	}
+	return ""
+}
+
+// lastParagraph trims an assistant message to its last paragraph, which is
+// usually the question or summary worth showing.
+func lastParagraph(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if idx := strings.LastIndex(msg, "\n\n"); idx >= 0 {
+		msg = strings.TrimSpace(msg[idx+2:])
+	}
+	return msg
+}
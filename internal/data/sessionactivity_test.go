@@ -0,0 +1,59 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadSessionActivity(t *testing.T) {
+	root := CopilotRoot("", filepath.Join(t.TempDir(), ".copilot"))
+	SetSessionRoots([]SessionRoot{root})
+	t.Cleanup(func() { SetSessionRoots(nil) })
+	writeRootSession(t, root, "busy", "Busy work")
+
+	var events strings.Builder
+	events.WriteString(`{"type":"user.message","data":{"content":"fix the tests"}}` + "\n")
+	events.WriteString(`{"type":"assistant.message","data":{"content":"Looking.\n\nShould I also update the docs?"}}` + "\n")
+	for i := 0; i < LogTailLen; i++ {
+		fmt.Fprintf(&events, `{"type":"tool.execution_start","data":{"toolName":"tool%d"}}`+"\n", i)
+	}
+	events.WriteString("not json\n")
+	path := filepath.Join(root.SessionStateDir, "busy", "events.jsonl")
+	if err := os.WriteFile(path, []byte(events.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := Session{ID: "busy", Source: SourceLocalCopilot, LastAssistantMessage: "stale"}
+	a, ok := ReadSessionActivity(s)
+	if !ok {
+		t.Fatal("expected activity for a local session")
+	}
+	if a.LastAction != fmt.Sprintf("🔧 tool%d", LogTailLen-1) {
+		t.Errorf("unexpected last action %q", a.LastAction)
+	}
+	if a.LastMessage != "Should I also update the docs?" {
+		t.Errorf("expected the last paragraph of the last message, got %q", a.LastMessage)
+	}
+	if len(a.LogTail) != LogTailLen || a.LogTail[0] != "🔧 tool0" {
+		t.Errorf("expected the last %d entries, got %v", LogTailLen, a.LogTail)
+	}
+
+	a.ApplyTo(&s)
+	if s.LastAction != a.LastAction || s.LastAssistantMessage != a.LastMessage || len(s.LogTail) != LogTailLen {
+		t.Errorf("expected the activity on the session, got %+v", s)
+	}
+
+	for _, other := range []Session{
+		{ID: "busy", Source: SourceAgentTask},
+		{ID: "busy", Source: SourceLocalCopilot, Host: "devbox"},
+		{ID: "busy", Source: SourceLocalCopilot, Archived: true},
+		{ID: "missing", Source: SourceLocalCopilot},
+	} {
+		if _, ok := ReadSessionActivity(other); ok {
+			t.Errorf("expected no activity for %+v", other)
+		}
+	}
+}
@@ -2,12 +2,18 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"time"
 )
 
+// SnapshotVersion is the current snapshot format. Version 2 adds State,
+// which holds everything needed to replay the snapshot.
+const SnapshotVersion = 2
+
 // Snapshot captures the TUI state as a machine-readable artifact.
 type Snapshot struct {
+	Version        int               `json:"version,omitempty"`
 	ViewMode       string            `json:"view_mode"`
 	TerminalSize   SnapshotSize      `json:"terminal_size"`
 	RenderedOutput string            `json:"rendered_output"`
@@ -16,6 +22,80 @@ type Snapshot struct {
 	Sessions       []SnapshotSession `json:"sessions"`
 	FocusedPanel   string            `json:"focused_panel"`
 	Timestamp      string            `json:"timestamp"`
+	State          *SnapshotState    `json:"state,omitempty"`
+}
+
+// SnapshotState is the full-fidelity part of a snapshot: the complete
+// session list, token usage and config the TUI was rendering from.
+type SnapshotState struct {
+	Sessions   []SessionRecord        `json:"sessions"`
+	TokenUsage map[string]*TokenUsage `json:"token_usage,omitempty"`
+	Dismissed  []string               `json:"dismissed,omitempty"`
+	// Annotations holds the snoozes, pins, notes and tags in effect. Older
+	// snapshots carry only Dismissed.
+	Annotations  map[string]*Annotation `json:"annotations,omitempty"`
+	StatusFilter string                 `json:"status_filter,omitempty"`
+	Repo         string                 `json:"repo,omitempty"`
+	// Config is the YAML config in effect, as it would appear in
+	// ~/.gh-agent-viz.yml.
+	Config string `json:"config,omitempty"`
+}
+
+// SessionRecord is a Session with the fields Session leaves out of its own
+// JSON form, so snapshots keep everything the views render.
+type SessionRecord struct {
+	Session
+	HasLog               bool   `json:"hasLog,omitempty"`
+	LastAssistantMessage string `json:"lastAssistantMessage,omitempty"`
+}
+
+// NewSessionRecord captures s for a snapshot.
+func NewSessionRecord(s Session) SessionRecord {
+	return SessionRecord{Session: s, HasLog: s.HasLog, LastAssistantMessage: s.LastAssistantMessage}
+}
+
+// Restore returns the recorded session.
+func (r SessionRecord) Restore() Session {
+	s := r.Session
+	s.HasLog = r.HasLog
+	s.LastAssistantMessage = r.LastAssistantMessage
+	return s
+}
+
+// SessionList returns the recorded sessions.
+func (st *SnapshotState) SessionList() []Session {
+	out := make([]Session, len(st.Sessions))
+	for i, r := range st.Sessions {
+		out[i] = r.Restore()
+	}
+	return out
+}
+
+// CapturedAt returns when the snapshot was taken, or the zero time when
+// the timestamp is missing or malformed.
+func (snap *Snapshot) CapturedAt() time.Time {
+	t, err := time.Parse(time.RFC3339, snap.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// ReadSnapshot loads a snapshot written by WriteSnapshot. Snapshots without
+// State (written before version 2) cannot be replayed and are rejected.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, fmt.Errorf("parse snapshot %s: %w", path, err)
+	}
+	if snap.State == nil {
+		return nil, fmt.Errorf("snapshot %s has no session state to replay; capture it again with --snapshot", path)
+	}
+	return &snap, nil
 }
 
 // SnapshotSize holds terminal dimensions.
@@ -46,8 +126,11 @@ type SnapshotSession struct {
 
 // WriteSnapshot serialises a Snapshot to path as indented JSON.
 func WriteSnapshot(path string, snap *Snapshot) error {
+	if snap.Version == 0 {
+		snap.Version = SnapshotVersion
+	}
 	if snap.Timestamp == "" {
-		snap.Timestamp = time.Now().UTC().Format(time.RFC3339)
+		snap.Timestamp = Now().UTC().Format(time.RFC3339)
 	}
 	b, err := json.MarshalIndent(snap, "", "  ")
 	if err != nil {
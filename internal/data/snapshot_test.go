@@ -0,0 +1,70 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+	created := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	snap := &Snapshot{
+		ViewMode:  "dashboard",
+		Timestamp: "2026-05-01T13:00:00Z",
+		State: &SnapshotState{
+			Sessions: []SessionRecord{NewSessionRecord(Session{
+				ID: "s1", Status: "needs-input", Title: "Fix it", CreatedAt: created,
+				Source: SourceLocalCopilot, HasLog: true, LastAssistantMessage: "Which branch?",
+				Telemetry: &SessionTelemetry{Model: "claude", InputTokens: 42},
+			})},
+			TokenUsage:   map[string]*TokenUsage{"s1": {SessionID: "s1", InputTokens: 42}},
+			StatusFilter: "attention",
+			Config:       "refreshInterval: 10\n",
+		},
+	}
+	if err := WriteSnapshot(path, snap); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+
+	got, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshot: %v", err)
+	}
+	if got.Version != SnapshotVersion {
+		t.Errorf("expected version %d, got %d", SnapshotVersion, got.Version)
+	}
+	sessions := got.State.SessionList()
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+	s := sessions[0]
+	if !s.HasLog || s.LastAssistantMessage != "Which branch?" {
+		t.Errorf("expected fields hidden from Session JSON to survive, got %+v", s)
+	}
+	if !s.CreatedAt.Equal(created) || s.Telemetry == nil || s.Telemetry.InputTokens != 42 {
+		t.Errorf("expected timestamps and telemetry to survive, got %+v", s)
+	}
+	if got.State.TokenUsage["s1"].InputTokens != 42 {
+		t.Error("expected token usage to survive")
+	}
+	if got.State.StatusFilter != "attention" || got.State.Config != "refreshInterval: 10\n" {
+		t.Errorf("unexpected state: %+v", got.State)
+	}
+	if want := time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC); !got.CapturedAt().Equal(want) {
+		t.Errorf("CapturedAt() = %v, want %v", got.CapturedAt(), want)
+	}
+}
+
+func TestReadSnapshot_RejectsSummaryOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.json")
+	if err := os.WriteFile(path, []byte(`{"view_mode":"list","sessions":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := ReadSnapshot(path)
+	if err == nil || !strings.Contains(err.Error(), "no session state") {
+		t.Errorf("expected a missing-state error, got %v", err)
+	}
+}
@@ -14,13 +14,13 @@ import (
 
 // TokenUsage holds aggregated token usage for a single session.
 type TokenUsage struct {
-	SessionID     string
-	Model         string
-	InputTokens   int64
-	OutputTokens  int64
-	CachedTokens  int64
-	Calls         int
-	EstimatedCost float64
+	SessionID     string  `json:"sessionId"`
+	Model         string  `json:"model"`
+	InputTokens   int64   `json:"inputTokens"`
+	OutputTokens  int64   `json:"outputTokens"`
+	CachedTokens  int64   `json:"cachedTokens"`
+	Calls         int     `json:"calls"`
+	EstimatedCost float64 `json:"estimatedCost"`
 }
 
 // modelPricing holds per-million-token input/output rates.
@@ -107,7 +107,8 @@ var (
 )
 
 // FetchTokenUsage parses recent Copilot CLI log files and returns per-session
-// token usage. Results are cached for 60 seconds.
+// token usage, including the usage last fetched from remote hosts. Results
+// are cached for 60 seconds.
 func FetchTokenUsage() (map[string]*TokenUsage, error) {
 	tokenUsageCacheMu.Lock()
 	defer tokenUsageCacheMu.Unlock()
@@ -119,7 +120,7 @@ func FetchTokenUsage() (map[string]*TokenUsage, error) {
 			dup := *v
 			out[k] = &dup
 		}
-		return out, nil
+		return mergeRemoteUsage(out), nil
 	}
 
 	usage, err := fetchTokenUsageUncached()
@@ -128,7 +129,7 @@ func FetchTokenUsage() (map[string]*TokenUsage, error) {
 	}
 	tokenUsageCache = usage
 	tokenUsageCacheTime = time.Now()
-	return usage, nil
+	return mergeRemoteUsage(usage), nil
 }
 
 // ResetTokenUsageCache clears the token usage cache, forcing the next
@@ -141,36 +142,36 @@ func ResetTokenUsageCache() {
 }
 
 func fetchTokenUsageUncached() (map[string]*TokenUsage, error) {
-	return fetchTokenUsageFromDir(defaultLogDir())
-}
-
-func defaultLogDir() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ""
+	var dirs []string
+	for _, root := range SessionRoots() {
+		dirs = append(dirs, root.LogDir)
 	}
-	return filepath.Join(home, ".copilot", "logs")
+	return fetchTokenUsageFromDir(dirs...)
 }
 
-func fetchTokenUsageFromDir(logDir string) (map[string]*TokenUsage, error) {
-	if logDir == "" {
-		return map[string]*TokenUsage{}, nil
-	}
-
-	files, err := filepath.Glob(filepath.Join(logDir, "process-*.log"))
-	if err != nil {
-		return map[string]*TokenUsage{}, nil
-	}
+// tokenUsageWindow is how far back log files are parsed for token usage.
+const tokenUsageWindow = 7 * 24 * time.Hour
 
-	cutoff := time.Now().Add(-7 * 24 * time.Hour)
+// fetchTokenUsageFromDir parses the recent process logs in each of logDirs.
+func fetchTokenUsageFromDir(logDirs ...string) (map[string]*TokenUsage, error) {
+	cutoff := Now().Add(-tokenUsageWindow)
 	result := map[string]*TokenUsage{}
 
-	for _, f := range files {
-		info, err := os.Stat(f)
-		if err != nil || info.ModTime().Before(cutoff) {
+	for _, logDir := range logDirs {
+		if logDir == "" {
+			continue
+		}
+		files, err := filepath.Glob(filepath.Join(logDir, "process-*.log"))
+		if err != nil {
 			continue
 		}
-		parseLogFile(f, result)
+		for _, f := range files {
+			info, err := os.Stat(f)
+			if err != nil || info.ModTime().Before(cutoff) {
+				continue
+			}
+			parseLogFile(f, result)
+		}
 	}
 
 	return result, nil
@@ -0,0 +1,144 @@
+package data
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WorktreeRequest describes the branch to check out and where.
+type WorktreeRequest struct {
+	Repository string // "owner/name"
+	Branch     string
+	Dir        string // directory holding worktrees (default: ~/.gh-agent-viz/worktrees)
+	// Clones are local clones of Repository to add the worktree to, tried
+	// in order. When none is usable the repository is cloned under Dir.
+	Clones []string
+}
+
+// Worktree is a checked-out session branch.
+type Worktree struct {
+	Path    string
+	Clone   string // repository the worktree belongs to
+	Created bool   // false when an existing worktree was reused
+}
+
+// DefaultWorktreeDir returns ~/.gh-agent-viz/worktrees.
+func DefaultWorktreeDir() string {
+	home, err := userHomeDir()
+	if err != nil {
+		return filepath.Join(".gh-agent-viz", "worktrees")
+	}
+	return filepath.Join(home, ".gh-agent-viz", "worktrees")
+}
+
+// WorktreePath returns where a branch of a repository is checked out:
+// <dir>/<owner>/<name>/<branch>, with the branch escaped like a URL path
+// segment so that, say, a/b and a-b get directories of their own.
+func WorktreePath(dir, repo, branch string) string {
+	owner, name, _ := strings.Cut(repo, "/")
+	return filepath.Join(dir, owner, name, url.PathEscape(branch))
+}
+
+// PrepareWorktree checks the request's branch out into its own worktree,
+// fetching the latest commits of the branch first. An existing worktree at
+// the same path, or wherever the clone already has the branch checked out,
+// is reused as it is, so local changes in it are kept.
+func PrepareWorktree(req WorktreeRequest) (Worktree, error) {
+	repo := strings.TrimSpace(req.Repository)
+	branch := strings.TrimSpace(req.Branch)
+	if !repoNamePattern.MatchString(repo) {
+		return Worktree{}, fmt.Errorf("invalid repository %q", req.Repository)
+	}
+	if branch == "" || strings.HasPrefix(branch, "-") {
+		return Worktree{}, fmt.Errorf("invalid branch %q", req.Branch)
+	}
+	if err := execCommand("git", "check-ref-format", "--branch", branch).Run(); err != nil {
+		return Worktree{}, fmt.Errorf("invalid branch %q", branch)
+	}
+	dir := req.Dir
+	if dir == "" {
+		dir = DefaultWorktreeDir()
+	}
+	path := WorktreePath(dir, repo, branch)
+	if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+		clone, _ := runGit(path, "rev-parse", "--path-format=absolute", "--git-common-dir")
+		return Worktree{Path: path, Clone: filepath.Dir(clone)}, nil
+	}
+
+	clone, err := worktreeClone(repo, dir, req.Clones)
+	if err != nil {
+		return Worktree{}, err
+	}
+	if existing := checkedOutAt(clone, branch); existing != "" {
+		// git refuses to check a branch out twice.
+		return Worktree{Path: existing, Clone: clone}, nil
+	}
+	_, localErr := runGit(clone, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	remoteRef := "refs/remotes/origin/" + branch
+	if _, err := runGit(clone, "fetch", "--quiet", "origin", "+refs/heads/"+branch+":"+remoteRef); err != nil && localErr != nil {
+		// A branch that only exists locally was never pushed, and
+		// needs no fetching.
+		return Worktree{}, fmt.Errorf("failed to fetch %s: %w", branch, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Worktree{}, err
+	}
+	args := []string{"worktree", "add", "--quiet"}
+	if localErr == nil {
+		// The branch exists locally; check it out as it is rather than
+		// resetting it and losing local commits.
+		args = append(args, path, branch)
+	} else {
+		args = append(args, "--track", "-b", branch, path, remoteRef)
+	}
+	if _, err := runGit(clone, args...); err != nil {
+		return Worktree{}, fmt.Errorf("failed to add worktree: %w", err)
+	}
+	return Worktree{Path: path, Clone: clone, Created: true}, nil
+}
+
+// checkedOutAt returns the worktree of clone that has branch checked out,
+// or "" when none does.
+func checkedOutAt(clone, branch string) string {
+	out, err := runGit(clone, "worktree", "list", "--porcelain")
+	if err != nil {
+		return ""
+	}
+	var path string
+	for _, line := range strings.Split(out, "\n") {
+		if p, ok := strings.CutPrefix(line, "worktree "); ok {
+			path = p
+		} else if line == "branch refs/heads/"+branch {
+			return path
+		}
+	}
+	return ""
+}
+
+// worktreeClone returns the first usable local clone of repo, cloning it
+// under dir when there is none.
+func worktreeClone(repo, dir string, candidates []string) (string, error) {
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		if top, err := runGit(c, "rev-parse", "--path-format=absolute", "--git-common-dir"); err == nil {
+			return filepath.Dir(top), nil
+		}
+	}
+	owner, name, _ := strings.Cut(repo, "/")
+	clone := filepath.Join(dir, owner, name, ".clone")
+	if _, err := os.Stat(filepath.Join(clone, ".git")); err == nil {
+		return clone, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(clone), 0o755); err != nil {
+		return "", err
+	}
+	if output, err := runGH("repo", "clone", repo, clone, "--", "--quiet", "--no-checkout"); err != nil {
+		return "", fmt.Errorf("failed to clone %s: %s", repo, strings.TrimSpace(string(output)))
+	}
+	return clone, nil
+}
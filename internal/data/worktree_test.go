@@ -0,0 +1,121 @@
+package data
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// gitRepoWithBranch creates an origin repository with a commit on branch
+// and a clone of it, returning the clone.
+func gitRepoWithBranch(t *testing.T, branch string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git(root, "init", "-q", "-b", "main", origin)
+	git(origin, "commit", "-q", "--allow-empty", "-m", "init")
+	git(origin, "checkout", "-q", "-b", branch)
+	if err := os.WriteFile(filepath.Join(origin, "fix.txt"), []byte("fixed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(origin, "add", "fix.txt")
+	git(origin, "commit", "-q", "-m", "fix")
+	git(origin, "checkout", "-q", "main")
+	git(root, "clone", "-q", origin, filepath.Join(root, "clone"))
+	return filepath.Join(root, "clone")
+}
+
+func TestPrepareWorktree_CreatesThenReuses(t *testing.T) {
+	clone := gitRepoWithBranch(t, "copilot/fix-bug")
+	dir := t.TempDir()
+	req := WorktreeRequest{Repository: "org/repo", Branch: "copilot/fix-bug", Dir: dir, Clones: []string{"", clone}}
+
+	wt, err := PrepareWorktree(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "org", "repo", "copilot%2Ffix-bug"); wt.Path != want || !wt.Created {
+		t.Fatalf("expected a new worktree at %s, got %+v", want, wt)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "fix.txt")); err != nil {
+		t.Errorf("expected the branch checked out: %v", err)
+	}
+	if branch, _ := runGit(wt.Path, "rev-parse", "--abbrev-ref", "HEAD"); branch != "copilot/fix-bug" {
+		t.Errorf("expected the worktree on the branch, got %q", branch)
+	}
+
+	again, err := PrepareWorktree(req)
+	if err != nil || again.Created || again.Path != wt.Path {
+		t.Errorf("expected the worktree reused, got %+v, %v", again, err)
+	}
+}
+
+func TestPrepareWorktree_UsesCheckoutsOfTheClone(t *testing.T) {
+	clone := gitRepoWithBranch(t, "copilot/fix-bug")
+	// A branch made in the clone and never pushed, and the pushed one
+	// checked out in the clone itself, as a local session would have it.
+	if _, err := runGit(clone, "branch", "local-only"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runGit(clone, "checkout", "-q", "copilot/fix-bug"); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	wt, err := PrepareWorktree(WorktreeRequest{Repository: "org/repo", Branch: "copilot/fix-bug", Dir: dir, Clones: []string{clone}})
+	if err != nil || wt.Created || !sameDir(wt.Path, clone) {
+		t.Errorf("expected the clone's own checkout reused, got %+v, %v", wt, err)
+	}
+	wt, err = PrepareWorktree(WorktreeRequest{Repository: "org/repo", Branch: "local-only", Dir: dir, Clones: []string{clone}})
+	if err != nil || !wt.Created {
+		t.Fatalf("expected an unpushed branch checked out, got %+v, %v", wt, err)
+	}
+	if branch, _ := runGit(wt.Path, "rev-parse", "--abbrev-ref", "HEAD"); branch != "local-only" {
+		t.Errorf("expected the worktree on the branch, got %q", branch)
+	}
+}
+
+// sameDir reports whether a and b name the same directory, through any
+// symlinks in the temporary directory's path.
+func sameDir(a, b string) bool {
+	ia, errA := os.Stat(a)
+	ib, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(ia, ib)
+}
+
+func TestWorktreePath_KeepsBranchesApart(t *testing.T) {
+	if WorktreePath("/w", "org/repo", "a/b") == WorktreePath("/w", "org/repo", "a-b") {
+		t.Error("expected a/b and a-b in different directories")
+	}
+}
+
+func TestPrepareWorktree_RejectsBadInput(t *testing.T) {
+	got := captureGH(t, "", nil)
+	for _, req := range []WorktreeRequest{
+		{Repository: "not a repo", Branch: "fix"},
+		{Repository: "org/repo", Branch: ""},
+		{Repository: "org/repo", Branch: "--upload-pack=evil"},
+		{Repository: "org/repo", Branch: "bad..name"},
+	} {
+		req.Dir = t.TempDir()
+		if _, err := PrepareWorktree(req); err == nil {
+			t.Errorf("expected %+v to be refused", req)
+		}
+	}
+	if *got != nil {
+		t.Errorf("nothing should be cloned for bad input, got %v", *got)
+	}
+}
@@ -0,0 +1,48 @@
+// Package a11y holds the rendering mode shared by every TUI component.
+// Plain mode drops color and non-ASCII glyphs for terminals and SSH sessions
+// that mangle them; accessible mode additionally spells statuses out in
+// words, stops animations and lays the dashboard out as one linear column so
+// screen readers read it top to bottom. Like the color palette, the mode is
+// read while rendering.
+package a11y
+
+// Mode selects how components render.
+type Mode struct {
+	Plain      bool // no color, ASCII glyphs, no powerline separators
+	Accessible bool // ASCII glyphs, statuses in words, no animation, linear layout
+}
+
+// ASCII reports whether glyphs should be restricted to ASCII.
+func (m Mode) ASCII() bool {
+	return m.Plain || m.Accessible
+}
+
+var current Mode
+
+// Current returns the active rendering mode.
+func Current() Mode {
+	return current
+}
+
+// Set installs m as the active rendering mode.
+func Set(m Mode) {
+	current = m
+}
+
+// StatusLabel returns a status spelled out in words, e.g. "needs input".
+func StatusLabel(status string) string {
+	switch status {
+	case "running":
+		return "running"
+	case "queued":
+		return "queued"
+	case "needs-input":
+		return "needs input"
+	case "completed":
+		return "done"
+	case "failed":
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,41 @@
+package a11y
+
+import "testing"
+
+func TestModeASCII(t *testing.T) {
+	tests := []struct {
+		mode Mode
+		want bool
+	}{
+		{Mode{}, false},
+		{Mode{Plain: true}, true},
+		{Mode{Accessible: true}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.mode.ASCII(); got != tt.want {
+			t.Errorf("%+v.ASCII() = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	defer Set(Mode{})
+	Set(Mode{Accessible: true})
+	if !Current().Accessible {
+		t.Error("expected accessible mode to be active")
+	}
+}
+
+func TestStatusLabel(t *testing.T) {
+	tests := map[string]string{
+		"running":     "running",
+		"needs-input": "needs input",
+		"completed":   "done",
+		"bogus":       "unknown",
+	}
+	for status, want := range tests {
+		if got := StatusLabel(status); got != want {
+			t.Errorf("StatusLabel(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
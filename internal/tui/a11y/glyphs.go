@@ -0,0 +1,105 @@
+package a11y
+
+import (
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Glyph returns fancy, or ascii when glyphs are restricted to ASCII.
+func Glyph(fancy, ascii string) string {
+	if Current().ASCII() {
+		return ascii
+	}
+	return fancy
+}
+
+// Border returns b, or a border drawn with +, - and | when glyphs are
+// restricted to ASCII.
+func Border(b lipgloss.Border) lipgloss.Border {
+	if Current().ASCII() {
+		return lipgloss.ASCIIBorder()
+	}
+	return b
+}
+
+// Ellipsis marks text cut short: "…", or "..." in ASCII.
+func Ellipsis() string {
+	return Glyph("…", "...")
+}
+
+// Truncate shortens s to at most width cells, ending it with Ellipsis when
+// anything was cut. Styling in s is kept.
+func Truncate(s string, width int) string {
+	return ansi.Truncate(s, max(width, 0), Ellipsis())
+}
+
+// asciiSymbols are ASCII stand-ins for the symbols the UI draws: status
+// markers, box drawing, bars, arrows and punctuation.
+var asciiSymbols = strings.NewReplacer(
+	// Status markers, as StatusIcon draws them in plain mode.
+	"●", "*", "○", "o", "◐", "*", "◓", "*", "◑", "*", "◒", "*",
+	"✋", "!", "✅", "+", "✓", "+", "❌", "x", "✗", "x", "⚪", "?", "❓", "?",
+	"⚠", "!", "⏳", "o", "💤", "z", "🔴", "!", "🟡", "~", "🟢", "-", "👀", "?",
+	// Panel numbers.
+	"❶", "1", "❷", "2", "❸", "3", "❹", "4", "❺", "5", "❻", "6", "❼", "7", "❽", "8", "❾", "9",
+	// Box drawing.
+	"─", "-", "━", "-", "═", "=", "│", "|", "┃", "|", "║", "|",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	// Bars and markers.
+	"█", "#", "▓", "=", "▒", ":", "░", ".", "▎", "|", "▍", "|", "▌", "|",
+	"▸", ">", "▶", ">", "▲", "^", "▼", "v", "↑", "^", "↓", "v", "→", "->", "←", "<-",
+	// Punctuation.
+	"—", "-", "–", "-", "−", "-", "…", "...", "·", "-", "•", "*", "⎵", "space",
+	"“", "\"", "”", "\"", "‘", "'", "’", "'",
+)
+
+// Text returns s with ASCII in place of the UI's symbols when glyphs are
+// restricted to ASCII. Emoji that only decorate a label are dropped with the
+// space after them. Use it on text laid out afterwards, such as a panel's
+// lines or a log, since replacements may change its width; letters in other
+// scripts are kept.
+func Text(s string) string {
+	if !Current().ASCII() {
+		return s
+	}
+	s = asciiSymbols.Replace(s)
+	var b strings.Builder
+	dropSpace, inEscape := false, false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			// Styling around an emoji doesn't separate it from its space.
+			inEscape = r < 0x40 || r > 0x7e || r == '['
+		case r == '\x1b':
+			inEscape = true
+		case dropSpace && r == ' ':
+			dropSpace = false
+			continue
+		case isEmoji(r):
+			dropSpace = true
+			continue
+		default:
+			dropSpace = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isEmoji reports whether r is a pictograph, or one of the invisible runes
+// that combine with them.
+func isEmoji(r rune) bool {
+	switch {
+	case r == '\ufe0f' || r == '\u200d' || r == 'ℹ':
+		return true
+	case r >= 0x1f000:
+		return true
+	case r >= 0x2190 && r <= 0x2bff:
+		return unicode.Is(unicode.So, r)
+	}
+	return false
+}
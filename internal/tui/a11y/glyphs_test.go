@@ -0,0 +1,44 @@
+package a11y
+
+import "testing"
+
+func TestText(t *testing.T) {
+	defer Set(Mode{})
+	tests := map[string]string{
+		"● 3 active  ✋ 1":            "* 3 active  ! 1",
+		"🪙 12k tokens":               "12k tokens",
+		"\x1b[1m📄\x1b[0m view.go":    "\x1b[1m\x1b[0mview.go",
+		"╭─❶ Attention…":             "+-1 Attention...",
+		"Timeline: ▓▓█  5m → now":    "Timeline: ==#  5m -> now",
+		"naïve 日本語":                  "naïve 日本語",
+		"✏️ edit":                    "edit",
+		"PR #4 · needs review 👀":     "PR #4 - needs review ?",
+		"All quiet — nothing here ✨": "All quiet - nothing here ",
+	}
+	Set(Mode{})
+	for in := range tests {
+		if got := Text(in); got != in {
+			t.Errorf("Text(%q) changed the text outside ASCII mode: %q", in, got)
+		}
+	}
+	Set(Mode{Plain: true})
+	for in, want := range tests {
+		if got := Text(in); got != want {
+			t.Errorf("Text(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	defer Set(Mode{})
+	if got := Truncate("refactor the login flow", 10); got != "refactor …" {
+		t.Errorf("Truncate = %q", got)
+	}
+	Set(Mode{Accessible: true})
+	if got := Truncate("refactor the login flow", 10); got != "refacto..." {
+		t.Errorf("Truncate in ASCII mode = %q", got)
+	}
+	if got := Truncate("short", 10); got != "short" {
+		t.Errorf("Truncate kept %q", got)
+	}
+}
@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// Session actions shared by the per-mode key handlers and the command
+// palette. Each takes the session to act on, so callers decide whether that
+// is the list, dashboard, or active-view selection.
+
+// hasLocalLog reports whether the session's conversation and tool timeline
+// can be read from disk.
+func hasLocalLog(s *data.Session) bool {
+	return s != nil && s.Source == data.SourceLocalCopilot && s.HasLog
+}
+
+// hasWorkDir reports whether git activity is available for the session,
+// which needs a working directory on this machine.
+func hasWorkDir(s *data.Session) bool {
+	return s != nil && s.Source == data.SourceLocalCopilot && s.WorkDir != "" && s.Host == "" && !s.Archived
+}
+
+// showMission switches to the mission control dashboard.
+func (m *Model) showMission() {
+	m.viewMode = ViewModeMission
+	m.mission.SetSessions(m.visibleSessions())
+	m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+}
+
+// showActive switches to the active sessions view.
+func (m *Model) showActive() {
+	m.viewMode = ViewModeActive
+	m.activeView.SetSessions(m.visibleSessions())
+	m.activeView.SetSize(m.ctx.Width, m.ctx.Height-6)
+}
+
+// showList switches to the session table.
+func (m *Model) showList() {
+	m.viewMode = ViewModeList
+	m.lastFingerprint = ""
+	m.recomputeAndDisplay(m.visibleSessions())
+}
+
+// openDetail shows the detail view for a session, fetching remote detail
+// for agent tasks.
+func (m *Model) openDetail(s *data.Session) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	m.viewMode = ViewModeDetail
+	if s.Source == data.SourceLocalCopilot {
+		m.ctx.Error = nil
+		m.taskDetail.SetTask(s)
+		return nil
+	}
+	return m.fetchTaskDetail(s.ID, s.Repository)
+}
+
+// openLogs shows the session log, tailing it when the session is running.
+func (m *Model) openLogs(s *data.Session) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	m.viewMode = ViewModeLog
+	m.logSessionID = s.ID
+	if isSessionRunning(s) {
+		m.logView.SetLive(true)
+		m.logView.SetFollowMode(true)
+		return tea.Batch(m.fetchTaskLog(s.ID, s.Repository), m.logPollTick())
+	}
+	return m.fetchTaskLog(s.ID, s.Repository)
+}
+
+// openConversation shows the chat view for a local session.
+func (m *Model) openConversation(s *data.Session) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	if !hasLocalLog(s) {
+		m.toast.Push("ℹ️", "Conversation", "only available for local Copilot sessions")
+		return nil
+	}
+	m.viewMode = ViewModeLog
+	m.logSessionID = s.ID
+	m.showConversation = true
+	return m.fetchConversation(s.ID)
+}
+
+// openToolTimeline shows the tool call timeline for a local session.
+func (m *Model) openToolTimeline(s *data.Session) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	if !hasLocalLog(s) {
+		m.toast.Push("ℹ️", "Tool Timeline", "only available for local Copilot sessions")
+		return nil
+	}
+	m.viewMode = ViewModeToolTimeline
+	m.toolTimeline.SetSize(m.ctx.Width-4, m.ctx.Height-8)
+	return m.fetchToolTimeline(s.ID)
+}
+
+// openDiff shows the PR diff for a session.
+func (m *Model) openDiff(s *data.Session) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	if !canShowDiff(s) {
+		m.toast.Push("⚠️", s.Title, "no PR — session is on "+s.Branch)
+		return nil
+	}
+	m.diffView.SetLoading()
+	m.diffSessionID = s.ID
+	m.viewMode = ViewModeDiff
+	return m.fetchPRDiff(s)
+}
+
+// openGitActivity shows live working-tree changes, and the work committed
+// on the branch, for a local session.
+func (m *Model) openGitActivity(s *data.Session) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	if !hasWorkDir(s) {
+		m.toast.Push("ℹ️", "Git Activity", "only available for local sessions with a working directory")
+		return nil
+	}
+	m.gitActivity.Reset()
+	m.gitActivity.SetLoading(true)
+	m.gitActivity.SetSize(m.ctx.Width-4, m.ctx.Height-8)
+	m.gitWorkDir = s.WorkDir
+	m.viewMode = ViewModeGitActivity
+	return tea.Batch(m.fetchGitDiff(s.WorkDir), m.gitDiffPollTick())
+}
+
+// dismissSession hides a session and persists the dismissal.
+func (m *Model) dismissSession(s *data.Session) {
+	if s == nil || s.ID == "" {
+		return
+	}
+	if s.Archived {
+		m.toast.Push("ℹ️", "Archive", "archived sessions are read-only")
+		return
+	}
+	m.taskList.DismissByID(s.ID)
+	m.lastFingerprint = ""
+	m.lastSplitTaskID = ""
+	m.recomputeAndDisplay(m.visibleSessions())
+}
+
+// dismissCompleted hides every completed session in the list and reports
+// the count as a toast.
+func (m *Model) dismissCompleted() {
+	count := m.taskList.DismissCompleted()
+	if count == 0 {
+		m.toast.Push("ℹ️", "Nothing to dismiss", "no completed sessions found")
+		return
+	}
+	m.lastFingerprint = ""
+	m.recomputeAndDisplay(m.visibleSessions())
+	m.toast.Push("🧹", "Dismissed", fmt.Sprintf("%d completed session(s) cleared", count))
+}
+
+// saveSnapshot writes a debug snapshot to a timestamped file in /tmp and
+// reports the path as a toast.
+func (m *Model) saveSnapshot() {
+	ts := time.Now().UTC().Format("2006-01-02T150405Z")
+	path := fmt.Sprintf("/tmp/gh-agent-viz-snapshot-%s.json", ts)
+	origPath := m.snapshotPath
+	m.snapshotPath = path
+	m.writeSnapshot()
+	m.snapshotPath = origPath
+	m.toast.Push("📸", "Snapshot", path)
+}
+
+// selectedSession returns the session the current view is pointing at.
+func (m Model) selectedSession() *data.Session {
+	switch m.viewMode {
+	case ViewModeMission:
+		return m.mission.SelectedSession()
+	case ViewModeActive:
+		return m.activeView.SelectedSession()
+	case ViewModeDetail:
+		if s := m.taskDetail.Session(); s != nil && s.ID != "" {
+			return s
+		}
+	}
+	return m.taskList.SelectedTask()
+}
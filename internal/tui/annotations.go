@@ -0,0 +1,255 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// snoozeUntilStatusChange is the snooze picker value for snoozing until the
+// session's status changes rather than for a fixed time.
+const snoozeUntilStatusChange = "status"
+
+// snoozeChoices are the snooze picker entries; values are durations
+// understood by data.ParseDuration.
+var snoozeChoices = []picker.Item{
+	{Label: "1 hour", Value: "1h"},
+	{Label: "4 hours", Value: "4h"},
+	{Label: "1 day", Value: "1d"},
+	{Label: "1 week", Value: "1w"},
+	{Label: "Until the status changes", Value: snoozeUntilStatusChange},
+}
+
+// annotateSessions attaches each session's annotation and leaves out the
+// dismissed and snoozed ones. Snoozes that have run out are cleared on the
+// way.
+func (m Model) annotateSessions(sessions []data.Session) []data.Session {
+	if m.annotations == nil {
+		return sessions
+	}
+	all := m.annotations.All()
+	visible := make([]data.Session, 0, len(sessions))
+	for _, s := range sessions {
+		a := all[s.ID]
+		if a.Dismissed() {
+			continue
+		}
+		if a.Snoozed() {
+			if m.annotations.Snoozed(s) {
+				continue
+			}
+			a = m.annotations.Get(s.ID)
+		}
+		s.Annotation = a
+		visible = append(visible, s)
+	}
+	return visible
+}
+
+// refreshAnnotations redraws every view after an annotation changed. The
+// session shown in the detail view picks up its new annotation, or the view
+// returns to mission control when the session was snoozed away.
+func (m *Model) refreshAnnotations() {
+	m.lastFingerprint = ""
+	m.lastSplitTaskID = ""
+	visible := m.visibleSessions()
+	m.recomputeAndDisplay(visible)
+	if m.viewMode != ViewModeDetail {
+		return
+	}
+	if shown := m.taskDetail.Session(); shown != nil {
+		for i := range visible {
+			if visible[i].ID == shown.ID {
+				m.taskDetail.SetTask(&visible[i])
+				return
+			}
+		}
+		m.showMission()
+	}
+}
+
+// handleAnnotationKeys handles the snooze, pin, note and tag keys in the
+// session views. It reports whether msg was one of them.
+func (m *Model) handleAnnotationKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if !slices.Contains(sessionModes, m.viewMode) {
+		return nil, false
+	}
+	switch {
+	case key.Matches(msg, m.keys.SnoozeSession):
+		m.openSnoozePicker(m.selectedSession())
+	case key.Matches(msg, m.keys.PinSession):
+		m.togglePin(m.selectedSession())
+	case key.Matches(msg, m.keys.EditNote):
+		m.openNotePrompt(m.selectedSession())
+	case key.Matches(msg, m.keys.EditTags):
+		m.openTagsPrompt(m.selectedSession())
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// togglePin pins or unpins a session; pinned sessions sort to the top.
+func (m *Model) togglePin(s *data.Session) {
+	if s == nil || s.ID == "" || m.annotations == nil {
+		return
+	}
+	if m.annotations.TogglePin(s.ID) {
+		m.toast.Push("📌", "Pinned", sessionLabel(s))
+	} else {
+		m.toast.Push("📌", "Unpinned", sessionLabel(s))
+	}
+	m.refreshAnnotations()
+}
+
+// openSnoozePicker offers how long to hide the session for.
+func (m *Model) openSnoozePicker(s *data.Session) {
+	if s == nil || s.ID == "" || m.annotations == nil {
+		return
+	}
+	m.promptSessionID = s.ID
+	m.openChooser("Snooze", snoozeChoices, chooser{
+		closeKeys: []key.Binding{m.keys.SnoozeSession},
+		choose: func(m *Model, item picker.Item) tea.Cmd {
+			m.snoozeSession(item)
+			return nil
+		},
+	})
+}
+
+// snoozeSession snoozes the session the picker was opened for.
+func (m *Model) snoozeSession(choice picker.Item) {
+	s := m.findSession(m.promptSessionID)
+	if s == nil {
+		return
+	}
+	if choice.Value == snoozeUntilStatusChange {
+		m.annotations.SnoozeUntilStatusChange(s.ID, s.Status)
+		m.toast.Push("😴", "Snoozed", fmt.Sprintf("%s until its status changes", sessionLabel(s)))
+	} else {
+		d, err := data.ParseDuration(choice.Value)
+		if err != nil {
+			return
+		}
+		m.annotations.Snooze(s.ID, data.Now().Add(d))
+		m.toast.Push("😴", "Snoozed", fmt.Sprintf("%s for %s", sessionLabel(s), strings.ToLower(choice.Label)))
+	}
+	m.refreshAnnotations()
+}
+
+// hasSnoozed reports whether any session is snoozed.
+func (m *Model) hasSnoozed() bool {
+	if m.annotations == nil {
+		return false
+	}
+	for _, a := range m.annotations.All() {
+		if a.Snoozed() {
+			return true
+		}
+	}
+	return false
+}
+
+// unsnoozeAll brings every snoozed session back.
+func (m *Model) unsnoozeAll() {
+	if m.annotations == nil {
+		return
+	}
+	n := m.annotations.UnsnoozeAll()
+	m.toast.Push("⏰", "Unsnoozed", fmt.Sprintf("%d session(s) back in the list", n))
+	m.refreshAnnotations()
+}
+
+// openNotePrompt edits the session's free-text note.
+func (m *Model) openNotePrompt(s *data.Session) {
+	if s == nil || s.ID == "" || m.annotations == nil {
+		return
+	}
+	note := ""
+	if a := m.annotations.Get(s.ID); a != nil {
+		note = a.Note
+	}
+	m.openAnnotationPrompt(s, "note", "Note for "+sessionLabel(s), "Leave empty to remove the note.", note)
+}
+
+// openTagsPrompt edits the session's tags.
+func (m *Model) openTagsPrompt(s *data.Session) {
+	if s == nil || s.ID == "" || m.annotations == nil {
+		return
+	}
+	tags := ""
+	if a := m.annotations.Get(s.ID); a != nil {
+		tags = strings.Join(a.Tags, " ")
+	}
+	m.openAnnotationPrompt(s, "tags", "Tags for "+sessionLabel(s), "Separate tags with spaces or commas; search with tag:<name>.", tags)
+}
+
+func (m *Model) openAnnotationPrompt(s *data.Session, field, title, hint, value string) {
+	m.promptSessionID = s.ID
+	m.promptField = field
+	m.annotationPrompt.SetSize(m.ctx.Width, m.ctx.Height)
+	m.annotationPrompt.Open(title, hint, value)
+}
+
+// handleAnnotationPromptKeys captures text for the note or tags prompt.
+func (m Model) handleAnnotationPromptKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	case msg.Code == tea.KeyEscape:
+		m.annotationPrompt.Close()
+	case msg.Code == tea.KeyEnter:
+		m.annotationPrompt.Close()
+		m.saveAnnotationPrompt()
+	case msg.Code == tea.KeyBackspace:
+		m.annotationPrompt.Backspace()
+	case msg.String() == "ctrl+u":
+		m.annotationPrompt.Clear()
+	case msg.Text != "":
+		m.annotationPrompt.Insert(msg.Text)
+	}
+	return m, nil
+}
+
+// saveAnnotationPrompt stores what was typed into the prompt.
+func (m *Model) saveAnnotationPrompt() {
+	id, value := m.promptSessionID, m.annotationPrompt.Value()
+	if id == "" || m.annotations == nil {
+		return
+	}
+	switch m.promptField {
+	case "note":
+		m.annotations.SetNote(id, value)
+	case "tags":
+		m.annotations.SetTags(id, strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }))
+	}
+	m.refreshAnnotations()
+}
+
+// findSession returns the loaded session with the given ID.
+func (m Model) findSession(id string) *data.Session {
+	for i := range m.allSessions {
+		if m.allSessions[i].ID == id {
+			s := m.allSessions[i]
+			return &s
+		}
+	}
+	return nil
+}
+
+// sessionLabel is a short name for a session in toasts and prompt titles.
+func sessionLabel(s *data.Session) string {
+	title := strings.TrimSpace(s.Title)
+	if title == "" {
+		return s.ID
+	}
+	if r := []rune(title); len(r) > 40 {
+		return string(r[:39]) + "…"
+	}
+	return title
+}
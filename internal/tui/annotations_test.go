@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func annotationTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := newModel(config.DefaultConfig(), nil, nil, "", false, false, "", "test")
+	m.viewMode = ViewModeList
+	m.ctx.StatusFilter = "all"
+	m.statusPinned = true
+	now := time.Now()
+	m.mergeSessions([]data.Session{
+		{ID: "a", Title: "Fix login", Status: "running", UpdatedAt: now},
+		{ID: "b", Title: "Old docs", Status: "completed", UpdatedAt: now.Add(-time.Hour)},
+	})
+	return m
+}
+
+func pressKeys(t *testing.T, m Model, keys ...string) Model {
+	t.Helper()
+	for _, k := range keys {
+		var msg tea.KeyPressMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyPressMsg{Code: tea.KeyEnter}
+		case "down":
+			msg = tea.KeyPressMsg{Code: tea.KeyDown}
+		default:
+			r := []rune(k)[0]
+			msg = tea.KeyPressMsg{Code: r, Text: k}
+		}
+		next, _ := m.handleKeyPress(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func TestPinNoteAndTagsFromKeys(t *testing.T) {
+	m := annotationTestModel(t)
+
+	// Pin the older session: it moves to the top of the list.
+	m = pressKeys(t, m, "down", "P")
+	if s := m.taskList.SelectedTask(); s == nil || s.ID != "b" || !s.Annotation.IsPinned() {
+		t.Fatalf("expected the pinned session selected at the top, got %+v", s)
+	}
+	m.taskList.MoveCursor(-len(m.allSessions))
+	if top := m.taskList.SelectedTask(); top.ID != "b" {
+		t.Errorf("expected pinned session first, got %s", top.ID)
+	}
+
+	m = pressKeys(t, m, "n", "c", "h", "e", "c", "k", "enter")
+	m = pressKeys(t, m, "#", "d", "o", "c", "s", ",", "q", "enter")
+	a := m.annotations.Get("b")
+	if a.Note != "check" || len(a.Tags) != 2 || a.Tags[0] != "docs" || a.Tags[1] != "q" {
+		t.Fatalf("unexpected annotation: %+v", a)
+	}
+
+	m.setSearchQuery("tag:docs")
+	if got := m.visibleSessions(); len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("expected tag search to find the tagged session, got %+v", got)
+	}
+}
+
+func TestSnoozeHidesUntilStatusChange(t *testing.T) {
+	m := annotationTestModel(t)
+
+	m = pressKeys(t, m, "z")
+	if !m.pickerOpen("Snooze") {
+		t.Fatal("expected the snooze picker to open")
+	}
+	m = pressKeys(t, m, "5") // until the status changes
+	for _, s := range m.visibleSessions() {
+		if s.ID == "a" {
+			t.Fatal("expected the snoozed session to be hidden")
+		}
+	}
+	if !m.hasSnoozed() {
+		t.Error("expected a snooze to be recorded")
+	}
+
+	m.mergeSessions([]data.Session{{ID: "a", Title: "Fix login", Status: "needs-input", UpdatedAt: time.Now()}})
+	var back bool
+	for _, s := range m.visibleSessions() {
+		back = back || s.ID == "a"
+	}
+	if !back || m.hasSnoozed() {
+		t.Error("expected the session back once its status changed")
+	}
+}
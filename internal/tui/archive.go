@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// archiveDoneMsg reports an archive run started from the palette.
+type archiveDoneMsg struct {
+	result  data.ArchiveResult
+	expired int // dismissals expired
+	err     error
+}
+
+// archivedSessionsLoadedMsg carries the archive's sessions after archived
+// browsing is switched on.
+type archivedSessionsLoadedMsg struct {
+	sessions []data.Session
+	err      error
+}
+
+// ApplyArchiveDir points archive reads and writes at the archive.dir config,
+// or the default when it is unset.
+func ApplyArchiveDir(cfg *config.Config) {
+	data.SetArchiveDir(cfg.ArchiveDirPath())
+}
+
+// canArchive reports whether archiving applies: it changes files on disk,
+// so it is off for demo data and replays.
+func canArchive(m *Model, _ *data.Session) bool {
+	return !m.demo && m.replay == nil
+}
+
+// archiveSessions archives finished sessions and expires old dismissals
+// using the archive config.
+func (m Model) archiveSessions() tea.Msg {
+	cfg := m.ctx.Config
+	olderThan, err := data.ParseDuration(cfg.ArchiveOlderThan())
+	if err != nil {
+		return archiveDoneMsg{err: fmt.Errorf("archive.olderThan: %w", err)}
+	}
+	expireAfter, err := data.ParseDuration(cfg.ArchiveExpireDismissed())
+	if err != nil {
+		return archiveDoneMsg{err: fmt.Errorf("archive.expireDismissed: %w", err)}
+	}
+	result, err := data.ArchiveSessions(data.ArchiveOptions{OlderThan: olderThan, MoveTo: cfg.ArchiveMoveToPath()})
+	expired := 0
+	if m.annotations != nil {
+		expired = m.annotations.Expire(expireAfter)
+	}
+	return archiveDoneMsg{result: result, expired: expired, err: err}
+}
+
+// fetchArchivedSessions loads the archive for read-only browsing.
+func (m Model) fetchArchivedSessions() tea.Msg {
+	sessions, err := data.FetchArchivedSessions()
+	return archivedSessionsLoadedMsg{sessions: m.filterRepo(sessions), err: err}
+}
+
+// filterRepo keeps the sessions of the repository the TUI is scoped to.
+func (m Model) filterRepo(sessions []data.Session) []data.Session {
+	if m.repo == "" {
+		return sessions
+	}
+	out := sessions[:0:0]
+	for _, s := range sessions {
+		if s.Repository == m.repo {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// toggleArchived shows or hides archived sessions.
+func (m *Model) toggleArchived() tea.Cmd {
+	m.showArchived = !m.showArchived
+	if m.showArchived {
+		return m.fetchArchivedSessions
+	}
+	m.dropSessions(func(s data.Session) bool { return s.Archived })
+	m.toast.Push("🗄️", "Archive", "archived sessions hidden")
+	return nil
+}
+
+// dropSessions removes the matching sessions and redraws.
+func (m *Model) dropSessions(drop func(data.Session) bool) {
+	kept := m.allSessions[:0]
+	for _, s := range m.allSessions {
+		if !drop(s) {
+			kept = append(kept, s)
+		}
+	}
+	m.allSessions = kept
+	m.lastFingerprint = ""
+	m.lastSplitTaskID = ""
+	m.recomputeAndDisplay(m.visibleSessions())
+}
+
+// handleArchiveDone reports an archive run and reloads the sessions that
+// moved into the archive.
+func (m *Model) handleArchiveDone(msg archiveDoneMsg) tea.Cmd {
+	var parts []string
+	if n := len(msg.result.Sessions); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d session(s) archived", n))
+	} else if msg.err == nil {
+		parts = append(parts, "no sessions old enough to archive")
+	}
+	if msg.expired > 0 {
+		parts = append(parts, fmt.Sprintf("%d dismissal(s) expired", msg.expired))
+	}
+	if msg.err != nil {
+		parts = append(parts, msg.err.Error())
+		m.toast.Push("⚠️", "Archive", strings.Join(parts, "; "))
+	} else {
+		m.toast.Push("🗄️", "Archive", strings.Join(parts, "; "))
+	}
+	if len(msg.result.Sessions) == 0 && msg.expired == 0 {
+		return nil
+	}
+	archived := make(map[string]bool, len(msg.result.Sessions))
+	for _, s := range msg.result.Sessions {
+		archived[s.ID] = true
+	}
+	m.dropSessions(func(s data.Session) bool { return archived[s.ID] && !s.Archived })
+	if m.showArchived {
+		return tea.Batch(m.fetchArchivedSessions, m.fetchTasks)
+	}
+	return m.fetchTasks
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// writeArchivableSession lays out a completed session idle for 40 days.
+func writeArchivableSession(t *testing.T, stateDir, id string) {
+	t.Helper()
+	dir := filepath.Join(stateDir, id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"workspace.yaml": "session_id: \"" + id + "\"\ntitle: \"Old work\"\nstatus: \"completed\"\n",
+		"events.jsonl":   `{"type":"assistant.message","timestamp":"2026-01-01T00:00:00Z","data":{"content":"done"}}` + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "events.jsonl"), old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArchiveAndBrowseArchived(t *testing.T) {
+	base := t.TempDir()
+	// Only the default root, ~/.copilot, is archived.
+	t.Setenv("HOME", base)
+	t.Setenv(config.CopilotRootsEnv, filepath.Join(base, ".copilot"))
+	t.Cleanup(func() { data.SetSessionRoots(nil); data.SetArchiveDir("") })
+	writeArchivableSession(t, filepath.Join(base, ".copilot", "session-state"), "old-1")
+
+	cfg := config.DefaultConfig()
+	cfg.Archive.Dir = filepath.Join(base, "archive")
+	m := newModel(cfg, nil, nil, "", false, false, "", "test")
+	m.annotations = data.NewAnnotationStoreFromPath(filepath.Join(base, "annotations.json"))
+	m.allSessions = []data.Session{{ID: "old-1", Status: "completed", Source: data.SourceLocalCopilot}}
+
+	msg := m.archiveSessions()
+	done, ok := msg.(archiveDoneMsg)
+	if !ok || done.err != nil || len(done.result.Sessions) != 1 {
+		t.Fatalf("expected one session archived, got %#v", msg)
+	}
+	next, _ := m.Update(done)
+	m = next.(Model)
+	if len(m.allSessions) != 0 {
+		t.Errorf("expected the archived session dropped from the live list, got %+v", m.allSessions)
+	}
+	if !strings.Contains(ansi.Strip(m.toast.View()), "1 session(s) archived") {
+		t.Errorf("expected an archive toast, got %q", ansi.Strip(m.toast.View()))
+	}
+
+	// Browsing the archive shows the session read-only.
+	cmd := m.toggleArchived()
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	if len(m.allSessions) != 1 || !m.allSessions[0].Archived {
+		t.Fatalf("expected the archived session listed, got %+v", m.allSessions)
+	}
+	s := m.allSessions[0]
+	m.dismissSession(&s)
+	if _, dismissed := m.annotations.DismissedIDs()["old-1"]; dismissed {
+		t.Error("expected archived sessions to refuse dismissal")
+	}
+	if hasWorkDir(&data.Session{Source: data.SourceLocalCopilot, WorkDir: "/src", Archived: true}) {
+		t.Error("expected git activity to be unavailable for archived sessions")
+	}
+
+	m.toggleArchived()
+	if len(m.allSessions) != 0 {
+		t.Errorf("expected archived sessions hidden again, got %+v", m.allSessions)
+	}
+}
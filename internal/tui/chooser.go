@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// chooser is the picker open over the current view, if any, with what
+// choosing from it does. Only one is open at a time; opening another
+// replaces it.
+type chooser struct {
+	picker.Model
+	closeKeys []key.Binding                            // close it besides back and quit, e.g. the key that opened it
+	choose    func(m *Model, item picker.Item) tea.Cmd // runs the chosen item
+	moved     func(m *Model)                           // runs when the cursor moves; may be nil
+	cancelled func(m *Model)                           // runs when closed without a choice; may be nil
+}
+
+// openChooser shows items under title, numbered so 1-9 choose directly.
+func (m *Model) openChooser(title string, items []picker.Item, c chooser) {
+	c.Model = picker.New(title, false)
+	c.SetSize(m.ctx.Width, m.ctx.Height)
+	c.Open(items)
+	m.chooser = c
+}
+
+// handleChooserKeys handles keys while a chooser is open: moving, closing
+// and choosing are the picker's, running the choice the chooser's.
+func (m Model) handleChooserKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	before, _ := m.chooser.Selected()
+	item, chosen, closed := m.chooser.HandleKey(msg, picker.Keys{
+		Close:  append([]key.Binding{m.keys.NavigateBack, m.keys.ExitApp}, m.chooser.closeKeys...),
+		Down:   m.keys.MoveDown,
+		Up:     m.keys.MoveUp,
+		Choose: m.keys.SelectTask,
+	})
+	switch {
+	case chosen:
+		return m, m.chooser.choose(&m, item)
+	case closed:
+		if m.chooser.cancelled != nil {
+			m.chooser.cancelled(&m)
+		}
+	default:
+		if after, _ := m.chooser.Selected(); after != before && m.chooser.moved != nil {
+			m.chooser.moved(&m)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,305 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// clipboardCopiedMsg signals that a value was copied to the clipboard.
+type clipboardCopiedMsg struct {
+	what  string // e.g. "branch"
+	value string
+	via   string // clipboard tool, or "OSC 52"
+}
+
+// copyRequestMsg carries text that was loaded in the background to copy.
+type copyRequestMsg struct {
+	what string
+	text string
+}
+
+// Clipboard methods other than tool names and custom commands.
+const (
+	clipboardAuto  = "auto"
+	clipboardOSC52 = "osc52"
+)
+
+// clipboardTool is a program that sets the clipboard from its stdin.
+type clipboardTool struct {
+	name string
+	args []string
+	env  string // variable that must be set for the tool to work, e.g. a display
+}
+
+// clipboardTools are tried in order by the auto method.
+var clipboardTools = []clipboardTool{
+	{name: "wl-copy", env: "WAYLAND_DISPLAY"},
+	{name: "xclip", args: []string{"-selection", "clipboard"}, env: "DISPLAY"},
+	{name: "xsel", args: []string{"--clipboard", "--input"}, env: "DISPLAY"},
+	{name: "pbcopy"},
+	{name: "clip.exe"},
+}
+
+// lookPath and runClipboardCommand are variables so tests can stub them.
+var (
+	lookPath = exec.LookPath
+
+	runClipboardCommand = func(name string, args []string, text string) error {
+		c := exec.Command(name, args...)
+		c.Stdin = strings.NewReader(text)
+		// Output is discarded rather than captured: wl-copy and xclip leave a
+		// process behind serving the selection, which would hold a pipe open.
+		return c.Run()
+	}
+)
+
+// osc52Sequence returns the OSC 52 escape sequence setting the system
+// clipboard to text, which the terminal applies even over ssh. Inside tmux
+// it is sent both as is, for set-clipboard on, and wrapped for
+// allow-passthrough; inside screen it is wrapped in screen's passthrough.
+func osc52Sequence(text string) string {
+	seq := ansi.SetSystemClipboard(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		return seq + ansi.TmuxPassthrough(seq)
+	case os.Getenv("STY") != "":
+		return ansi.ScreenPassthrough(seq, 768)
+	}
+	return seq
+}
+
+// copyWithTool copies text with the clipboard method's tool or command.
+// The auto method uses the first tool installed and usable here, and
+// returns "" without an error when there is none.
+func copyWithTool(method, text string) (string, error) {
+	if method == "" || method == clipboardAuto {
+		for _, t := range clipboardTools {
+			if t.env != "" && os.Getenv(t.env) == "" {
+				continue
+			}
+			if _, err := lookPath(t.name); err != nil {
+				continue
+			}
+			if runClipboardCommand(t.name, t.args, text) == nil {
+				return t.name, nil
+			}
+		}
+		return "", nil
+	}
+	for _, t := range clipboardTools {
+		if t.name == method {
+			if err := runClipboardCommand(t.name, t.args, text); err != nil {
+				return "", fmt.Errorf("%s: %w", t.name, err)
+			}
+			return t.name, nil
+		}
+	}
+	if err := runClipboardCommand("sh", []string{"-c", method}, text); err != nil {
+		return "", fmt.Errorf("%s: %w", method, err)
+	}
+	return strings.Fields(method)[0], nil
+}
+
+// copyToClipboard copies text, described as what in the confirmation, with
+// the configured clipboard method. The default sends OSC 52 to the terminal
+// first, then also runs the first clipboard tool available, so copying
+// works both locally and over ssh.
+func (m Model) copyToClipboard(what, text string) tea.Cmd {
+	method := strings.TrimSpace(m.ctx.Config.Clipboard)
+	if method == clipboardOSC52 {
+		return tea.Batch(tea.Raw(osc52Sequence(text)), func() tea.Msg {
+			return clipboardCopiedMsg{what: what, value: text, via: "OSC 52"}
+		})
+	}
+	viaTool := func() tea.Msg {
+		via, err := copyWithTool(method, text)
+		if err != nil {
+			return errMsg{fmt.Errorf("clipboard copy failed: %w", err)}
+		}
+		if via == "" {
+			via = "OSC 52"
+		}
+		return clipboardCopiedMsg{what: what, value: text, via: via}
+	}
+	if method == "" || method == clipboardAuto {
+		return tea.Batch(tea.Raw(osc52Sequence(text)), viaTool)
+	}
+	return viaTool
+}
+
+// clipboardPreview shortens copied text for the confirmation toast.
+func clipboardPreview(text string) string {
+	first, rest, multiline := strings.Cut(strings.TrimSpace(text), "\n")
+	preview := ansi.Truncate(first, 48, "…")
+	if multiline {
+		preview += fmt.Sprintf(" (+%d lines)", strings.Count(rest, "\n")+1)
+	}
+	return preview
+}
+
+// Things about a session the copy picker offers.
+const (
+	copyID          = "id"
+	copyBranch      = "branch"
+	copyRepository  = "repository"
+	copyPRURL       = "PR URL"
+	copyResume      = "resume command"
+	copyLastMessage = "last message"
+)
+
+// sessionPRURL returns the URL of the session's PR, "" when it has none.
+func sessionPRURL(s *data.Session) string {
+	switch {
+	case s.PRURL != "":
+		return s.PRURL
+	case s.PRNumber > 0 && s.Repository != "":
+		return fmt.Sprintf("https://github.com/%s/pull/%d", s.Repository, s.PRNumber)
+	}
+	return ""
+}
+
+// resumeCommand returns the command resuming a local session in Copilot
+// CLI, "" for other sessions.
+func resumeCommand(s *data.Session) string {
+	if s.Source != data.SourceLocalCopilot || s.ID == "" {
+		return ""
+	}
+	return "gh copilot -- --resume " + s.ID
+}
+
+// copySessionValue returns what of the session to copy, "" when the
+// session has none.
+func copySessionValue(s *data.Session, what string) string {
+	switch what {
+	case copyID:
+		return s.ID
+	case copyBranch:
+		return s.Branch
+	case copyRepository:
+		return s.Repository
+	case copyPRURL:
+		return sessionPRURL(s)
+	case copyResume:
+		return resumeCommand(s)
+	}
+	return ""
+}
+
+// copySession copies what of the session, loading the last assistant
+// message from the session's events first.
+func (m *Model) copySession(s *data.Session, what string) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	if what == copyLastMessage {
+		if !hasLocalLog(s) {
+			m.toast.Push("ℹ️", "Copy", "only available for local Copilot sessions")
+			return nil
+		}
+		return m.fetchLastAssistantMessage(s.ID)
+	}
+	value := copySessionValue(s, what)
+	if value == "" {
+		m.toast.Push("ℹ️", "Copy", "this session has no "+what)
+		return nil
+	}
+	return m.copyToClipboard(what, value)
+}
+
+// fetchLastAssistantMessage loads the latest assistant message of a local
+// session to copy it.
+func (m Model) fetchLastAssistantMessage(sessionID string) tea.Cmd {
+	return func() tea.Msg {
+		if m.replay != nil {
+			return errMsg{replayUnavailable("conversation")}
+		}
+		events, err := data.FetchSessionEvents(sessionID)
+		if err != nil {
+			return errMsg{err}
+		}
+		for i := len(events) - 1; i >= 0; i-- {
+			if events[i].Type == "assistant.message" && events[i].Content != "" {
+				return copyRequestMsg{what: copyLastMessage, text: events[i].Content}
+			}
+		}
+		return errMsg{fmt.Errorf("no assistant message in this session yet")}
+	}
+}
+
+// openCopyPicker offers what of the session can be copied, with a preview.
+func (m *Model) openCopyPicker(s *data.Session) {
+	if s == nil {
+		return
+	}
+	var items []picker.Item
+	for _, what := range []string{copyID, copyBranch, copyRepository, copyPRURL, copyResume} {
+		if value := copySessionValue(s, what); value != "" {
+			items = append(items, picker.Item{Label: "Copy " + what, Detail: clipboardPreview(value), Value: what})
+		}
+	}
+	if hasLocalLog(s) {
+		items = append(items, picker.Item{Label: "Copy " + copyLastMessage, Detail: "latest assistant reply", Value: copyLastMessage})
+	}
+	m.promptSessionID = s.ID
+	m.openChooser("Copy", items, chooser{
+		choose: func(m *Model, item picker.Item) tea.Cmd {
+			return m.copySession(m.findSession(m.promptSessionID), item.Value)
+		},
+	})
+}
+
+// copyViewSelection copies what the log, conversation or diff view has
+// selected: the lines selected in the log or conversation, ending the
+// selection, or else the page shown; or the hunk under the diff cursor.
+func (m *Model) copyViewSelection() tea.Cmd {
+	switch m.viewMode {
+	case ViewModeLog:
+		if m.logSelecting() {
+			what, text := m.logSelection()
+			m.clearLogSelection()
+			if text == "" {
+				return nil
+			}
+			return m.copyToClipboard(what, text)
+		}
+		if m.showConversation {
+			if text := m.conversationView.PageText(); text != "" {
+				return m.copyToClipboard("conversation", text)
+			}
+			return nil
+		}
+		if text := m.logView.PageText(); text != "" {
+			return m.copyToClipboard("log lines", text)
+		}
+	case ViewModeDiff:
+		if path, hunk, ok := m.diffView.SelectedHunk(); ok {
+			return m.copyToClipboard("hunk of "+path, hunk)
+		}
+	}
+	return nil
+}
+
+// handleCopyKeys handles the copy key: the copy picker in the session
+// views, the current selection in the log, conversation and diff views. It
+// reports whether msg was that key.
+func (m *Model) handleCopyKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if !key.Matches(msg, m.keys.Copy) {
+		return nil, false
+	}
+	switch m.viewMode {
+	case ViewModeLog, ViewModeDiff:
+		return m.copyViewSelection(), true
+	case ViewModeList, ViewModeDetail, ViewModeMission, ViewModeActive:
+		m.openCopyPicker(m.selectedSession())
+		return nil, true
+	}
+	return nil, false
+}
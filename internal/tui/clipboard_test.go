@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
+)
+
+// stubClipboardTools makes only the named tools available and records the
+// commands run.
+func stubClipboardTools(t *testing.T, installed ...string) *[]string {
+	t.Helper()
+	var ran []string
+	origLook, origRun := lookPath, runClipboardCommand
+	t.Cleanup(func() { lookPath, runClipboardCommand = origLook, origRun })
+	lookPath = func(name string) (string, error) {
+		for _, n := range installed {
+			if n == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+	runClipboardCommand = func(name string, args []string, text string) error {
+		ran = append(ran, strings.TrimSpace(name+" "+strings.Join(args, " ")))
+		if name == "sh" && strings.Contains(args[1], "false") {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+	return &ran
+}
+
+func TestOSC52Sequence(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
+	plain := ansi.SetSystemClipboard("hello")
+	if got := osc52Sequence("hello"); got != plain {
+		t.Errorf("expected the plain sequence, got %q", got)
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	if got := osc52Sequence("hello"); !strings.HasPrefix(got, plain+"\x1bPtmux;") {
+		t.Errorf("expected the plain and tmux-wrapped sequences, got %q", got)
+	}
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "1234.pts-0.host")
+	if got := osc52Sequence("hello"); !strings.HasPrefix(got, "\x1bP") || !strings.Contains(got, plain) {
+		t.Errorf("expected screen's passthrough, got %q", got)
+	}
+}
+
+func TestCopyWithTool(t *testing.T) {
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("DISPLAY", ":0")
+	ran := stubClipboardTools(t, "wl-copy", "xsel")
+
+	if via, err := copyWithTool("auto", "x"); err != nil || via != "xsel" {
+		t.Errorf("expected xsel without a Wayland display, got %q, %v", via, err)
+	}
+	if via, err := copyWithTool("wl-copy", "x"); err != nil || via != "wl-copy" {
+		t.Errorf("expected the configured tool, got %q, %v", via, err)
+	}
+	if via, err := copyWithTool("tmux load-buffer -w -", "x"); err != nil || via != "tmux" {
+		t.Errorf("expected the custom command, got %q, %v", via, err)
+	}
+	if _, err := copyWithTool("false", "x"); err == nil {
+		t.Error("expected a failing command reported")
+	}
+	want := "xsel --clipboard --input|wl-copy|sh -c tmux load-buffer -w -|sh -c false"
+	if got := strings.Join(*ran, "|"); got != want {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+
+	t.Setenv("DISPLAY", "")
+	if via, err := copyWithTool("", "x"); err != nil || via != "" {
+		t.Errorf("expected no tool over ssh, got %q, %v", via, err)
+	}
+}
+
+func TestCopyToClipboard_SendsOSC52(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("DISPLAY", "")
+	stubClipboardTools(t)
+	m := annotationTestModel(t)
+
+	batch, ok := m.copyToClipboard(copyBranch, "copilot/fix")().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected the escape sequence and the tools batched, got %T", batch)
+	}
+	if raw, ok := batch[0]().(tea.RawMsg); !ok || raw.Msg != ansi.SetSystemClipboard("copilot/fix") {
+		t.Errorf("expected OSC 52 first, got %+v", raw)
+	}
+	if msg, ok := batch[1]().(clipboardCopiedMsg); !ok || msg.via != "OSC 52" {
+		t.Errorf("expected OSC 52 reported without a tool, got %+v", msg)
+	}
+
+	m.ctx.Config.Clipboard = "false"
+	if msg, ok := m.copyToClipboard(copyBranch, "copilot/fix")().(errMsg); !ok || !strings.Contains(msg.err.Error(), "clipboard copy failed") {
+		t.Errorf("expected a failing command to skip OSC 52 and report the error, got %+v", msg)
+	}
+
+	next, _ := m.Update(clipboardCopiedMsg{what: copyBranch, value: "copilot/fix", via: "xsel"})
+	m = next.(Model)
+	if view := ansi.Strip(m.toast.View()); !strings.Contains(view, "Copied branch") || !strings.Contains(view, "copilot/fix · via xsel") {
+		t.Errorf("expected the copy confirmed, got %q", view)
+	}
+}
+
+func TestCopyPicker(t *testing.T) {
+	stubClipboardTools(t)
+	m := annotationTestModel(t)
+	m.mergeSessions([]data.Session{
+		{ID: "task", Title: "Fix", Status: "completed", Source: data.SourceAgentTask, Repository: "org/repo", Branch: "copilot/fix", PRNumber: 7, UpdatedAt: time.Now()},
+		{ID: "local-1", Title: "Hack", Status: "running", Source: data.SourceLocalCopilot, HasLog: true, UpdatedAt: time.Now()},
+	})
+
+	m.openCopyPicker(m.findSession("task"))
+	var labels []string
+	for i := 1; ; i++ {
+		item, ok := m.chooser.ItemAt(i)
+		if !ok {
+			break
+		}
+		labels = append(labels, item.Value)
+	}
+	if got := strings.Join(labels, ","); got != "id,branch,repository,PR URL" {
+		t.Errorf("unexpected copy choices %q", got)
+	}
+	next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: '4', Text: "4"})
+	m = next.(Model)
+	if cmd == nil || m.pickerOpen("Copy") {
+		t.Error("expected the PR URL copied")
+	}
+	if got := sessionPRURL(m.findSession("task")); got != "https://github.com/org/repo/pull/7" {
+		t.Errorf("unexpected PR URL %q", got)
+	}
+
+	if got := resumeCommand(m.findSession("local-1")); got != "gh copilot -- --resume local-1" {
+		t.Errorf("unexpected resume command %q", got)
+	}
+	if m.copySession(m.findSession("task"), copyLastMessage) != nil ||
+		!strings.Contains(ansi.Strip(m.toast.View()), "only available for local Copilot sessions") {
+		t.Error("expected the last message refused for an agent task")
+	}
+	if m.copySession(m.findSession("task"), copyResume) != nil || !strings.Contains(ansi.Strip(m.toast.View()), "no resume command") {
+		t.Error("expected an agent task to have no resume command")
+	}
+}
+
+func TestCopyViewSelection(t *testing.T) {
+	stubClipboardTools(t)
+	m := annotationTestModel(t)
+	m.viewMode = ViewModeDiff
+	if m.copyViewSelection() != nil {
+		t.Error("expected nothing to copy from an empty diff")
+	}
+	m.diffView.SetDiffs(diffview.ParseUnifiedDiff("diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-old\n+new\n"))
+	next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	m = next.(Model)
+	if cmd == nil {
+		t.Fatal("expected the hunk copied")
+	}
+	batch := cmd().(tea.BatchMsg)
+	if msg := batch[1]().(clipboardCopiedMsg); msg.what != "hunk of a.go" || msg.value != "@@ -1 +1 @@\n-old\n+new\n" {
+		t.Errorf("unexpected copy %+v", msg)
+	}
+}
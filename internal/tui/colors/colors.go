@@ -0,0 +1,264 @@
+// Package colors holds the semantic colors shared by every TUI component.
+// The active theme installs its palette with Set; components read Current
+// while rendering, so a theme switch takes effect on the next frame.
+package colors
+
+import (
+	"image/color"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/compat"
+)
+
+// Palette is the set of colors components draw with.
+type Palette struct {
+	Text      color.Color // primary body text
+	Muted     color.Color // secondary text, counts, hints
+	Subtle    color.Color // borders, separators, faint chrome
+	Accent    color.Color // overlay and panel titles
+	Highlight color.Color // headings and the selected row
+	Section   color.Color // section headers and detail borders
+	Focus     color.Color // focused panel borders and group headers
+	Key       color.Color // key names in hints
+	Inverse   color.Color // text drawn on an accent background
+	TabBg     color.Color // active tab background in compact layouts
+
+	Diff      DiffColors
+	Status    StatusColors
+	Attention AttentionColors
+	Powerline Powerline
+	// Sparkline is a low-to-high gradient for sparklines and heatmaps.
+	// Empty means sparklines render uncolored.
+	Sparkline []color.Color
+}
+
+// DiffColors colors unified diffs.
+type DiffColors struct {
+	Added   color.Color
+	Removed color.Color
+	Hunk    color.Color
+
+	// Syntax highlighting of the code in diff lines
+	Keyword color.Color
+	String  color.Color
+	Comment color.Color
+	Number  color.Color
+
+	// Backgrounds marking the words that changed within a line
+	AddedWord   color.Color
+	RemovedWord color.Color
+}
+
+// StatusColors colors session statuses.
+type StatusColors struct {
+	Running    color.Color
+	Queued     color.Color
+	NeedsInput color.Color
+	Completed  color.Color
+	Failed     color.Color
+	Idle       color.Color
+	Unknown    color.Color
+}
+
+// AttentionColors colors the graduated attention levels.
+type AttentionColors struct {
+	Urgent  color.Color
+	Warning color.Color
+	Info    color.Color
+	None    color.Color
+}
+
+// Powerline colors the footer bar and the active-sessions view cards.
+type Powerline struct {
+	Base     color.Color // bar background
+	Surface0 color.Color
+	Surface1 color.Color // hint segments, card borders
+	Surface2 color.Color // trailing hint segments, detail badges
+	Overlay  color.Color // dim card text
+	Text     color.Color
+	Subtext  color.Color
+	Key      color.Color // key names, panel titles
+	Accent   color.Color // last hint segment, mission badge
+
+	BadgeMission color.Color
+	BadgeActive  color.Color
+	BadgeList    color.Color
+	BadgeDetail  color.Color
+	BadgeLog     color.Color
+
+	StatusRunning    color.Color
+	StatusFailed     color.Color
+	StatusNeedsInput color.Color
+}
+
+func adaptive(light, dark string) color.Color {
+	return compat.AdaptiveColor{Light: lipgloss.Color(light), Dark: lipgloss.Color(dark)}
+}
+
+// CatppuccinPowerline is the Catppuccin Mocha footer palette.
+func CatppuccinPowerline() Powerline {
+	return Powerline{
+		Base:             lipgloss.Color("#1e1e2e"),
+		Surface0:         lipgloss.Color("#313244"),
+		Surface1:         lipgloss.Color("#45475a"),
+		Surface2:         lipgloss.Color("#585b70"),
+		Overlay:          lipgloss.Color("#6c7086"),
+		Text:             lipgloss.Color("#cdd6f4"),
+		Subtext:          lipgloss.Color("#a6adc8"),
+		Key:              lipgloss.Color("#b4befe"),
+		Accent:           lipgloss.Color("#cba6f7"),
+		BadgeMission:     lipgloss.Color("#cba6f7"),
+		BadgeActive:      lipgloss.Color("#a6e3a1"),
+		BadgeList:        lipgloss.Color("#b4befe"),
+		BadgeDetail:      lipgloss.Color("#585b70"),
+		BadgeLog:         lipgloss.Color("#585b70"),
+		StatusRunning:    lipgloss.Color("#94e2d5"),
+		StatusFailed:     lipgloss.Color("#f38ba8"),
+		StatusNeedsInput: lipgloss.Color("#f9e2af"),
+	}
+}
+
+// Default returns the adaptive palette that works on light and dark
+// terminals.
+func Default() Palette {
+	return Palette{
+		Text:      adaptive("236", "252"),
+		Muted:     adaptive("244", "245"),
+		Subtle:    adaptive("249", "240"),
+		Accent:    adaptive("55", "99"),
+		Highlight: adaptive("24", "75"),
+		Section:   adaptive("27", "63"),
+		Focus:     adaptive("30", "73"),
+		Key:       adaptive("28", "42"),
+		Inverse:   adaptive("15", "15"),
+		TabBg:     adaptive("24", "62"),
+		Diff: DiffColors{
+			Added:       adaptive("28", "42"),
+			Removed:     adaptive("160", "196"),
+			Hunk:        adaptive("31", "45"),
+			Keyword:     adaptive("127", "176"),
+			String:      adaptive("136", "180"),
+			Comment:     adaptive("245", "243"),
+			Number:      adaptive("166", "209"),
+			AddedWord:   adaptive("194", "22"),
+			RemovedWord: adaptive("224", "52"),
+		},
+		Status: StatusColors{
+			Running:    adaptive("28", "42"),
+			Queued:     adaptive("178", "222"),
+			NeedsInput: adaptive("172", "214"),
+			Completed:  adaptive("30", "72"),
+			Failed:     adaptive("160", "203"),
+			Idle:       adaptive("245", "243"),
+			Unknown:    adaptive("244", "245"),
+		},
+		Attention: AttentionColors{
+			Urgent:  adaptive("160", "203"),
+			Warning: adaptive("172", "214"),
+			Info:    adaptive("30", "72"),
+			None:    adaptive("244", "245"),
+		},
+		Powerline: CatppuccinPowerline(),
+	}
+}
+
+var current = Default()
+
+// Current returns the active palette.
+func Current() *Palette {
+	return &current
+}
+
+// Set installs p as the active palette. Unset colors fall back to the
+// default palette.
+func Set(p Palette) {
+	current = Merge(Default(), p)
+}
+
+// Merge returns base with every color set in override applied on top.
+func Merge(base, override Palette) Palette {
+	pick := func(dst *color.Color, src color.Color) {
+		if src != nil {
+			*dst = src
+		}
+	}
+	out := base
+	pick(&out.Text, override.Text)
+	pick(&out.Muted, override.Muted)
+	pick(&out.Subtle, override.Subtle)
+	pick(&out.Accent, override.Accent)
+	pick(&out.Highlight, override.Highlight)
+	pick(&out.Section, override.Section)
+	pick(&out.Focus, override.Focus)
+	pick(&out.Key, override.Key)
+	pick(&out.Inverse, override.Inverse)
+	pick(&out.TabBg, override.TabBg)
+
+	pick(&out.Diff.Added, override.Diff.Added)
+	pick(&out.Diff.Removed, override.Diff.Removed)
+	pick(&out.Diff.Hunk, override.Diff.Hunk)
+	pick(&out.Diff.Keyword, override.Diff.Keyword)
+	pick(&out.Diff.String, override.Diff.String)
+	pick(&out.Diff.Comment, override.Diff.Comment)
+	pick(&out.Diff.Number, override.Diff.Number)
+	pick(&out.Diff.AddedWord, override.Diff.AddedWord)
+	pick(&out.Diff.RemovedWord, override.Diff.RemovedWord)
+
+	pick(&out.Status.Running, override.Status.Running)
+	pick(&out.Status.Queued, override.Status.Queued)
+	pick(&out.Status.NeedsInput, override.Status.NeedsInput)
+	pick(&out.Status.Completed, override.Status.Completed)
+	pick(&out.Status.Failed, override.Status.Failed)
+	pick(&out.Status.Idle, override.Status.Idle)
+	pick(&out.Status.Unknown, override.Status.Unknown)
+
+	pick(&out.Attention.Urgent, override.Attention.Urgent)
+	pick(&out.Attention.Warning, override.Attention.Warning)
+	pick(&out.Attention.Info, override.Attention.Info)
+	pick(&out.Attention.None, override.Attention.None)
+
+	pl, o := &out.Powerline, override.Powerline
+	pick(&pl.Base, o.Base)
+	pick(&pl.Surface0, o.Surface0)
+	pick(&pl.Surface1, o.Surface1)
+	pick(&pl.Surface2, o.Surface2)
+	pick(&pl.Overlay, o.Overlay)
+	pick(&pl.Text, o.Text)
+	pick(&pl.Subtext, o.Subtext)
+	pick(&pl.Key, o.Key)
+	pick(&pl.Accent, o.Accent)
+	pick(&pl.BadgeMission, o.BadgeMission)
+	pick(&pl.BadgeActive, o.BadgeActive)
+	pick(&pl.BadgeList, o.BadgeList)
+	pick(&pl.BadgeDetail, o.BadgeDetail)
+	pick(&pl.BadgeLog, o.BadgeLog)
+	pick(&pl.StatusRunning, o.StatusRunning)
+	pick(&pl.StatusFailed, o.StatusFailed)
+	pick(&pl.StatusNeedsInput, o.StatusNeedsInput)
+
+	if len(override.Sparkline) > 0 {
+		out.Sparkline = override.Sparkline
+	}
+	return out
+}
+
+// StatusColor returns the color for a session status.
+func (p *Palette) StatusColor(status string) color.Color {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "running", "in_progress":
+		return p.Status.Running
+	case "queued", "pending":
+		return p.Status.Queued
+	case "needs-input":
+		return p.Status.NeedsInput
+	case "completed":
+		return p.Status.Completed
+	case "failed":
+		return p.Status.Failed
+	case "idle":
+		return p.Status.Idle
+	default:
+		return p.Status.Unknown
+	}
+}
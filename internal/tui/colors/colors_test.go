@@ -0,0 +1,48 @@
+package colors
+
+import (
+	"image/color"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestSet_MergesOverDefault(t *testing.T) {
+	defer Set(Default())
+
+	red := lipgloss.Color("#ff0000")
+	Set(Palette{Status: StatusColors{Failed: red}})
+
+	p := Current()
+	if p.Status.Failed != red {
+		t.Errorf("expected overridden failed color, got %v", p.Status.Failed)
+	}
+	if p.Status.Running != Default().Status.Running {
+		t.Errorf("unset colors should fall back to the default palette")
+	}
+	if p.Powerline.Base == nil {
+		t.Error("powerline colors should fall back to the default palette")
+	}
+}
+
+func TestStatusColor(t *testing.T) {
+	p := Default()
+	tests := []struct {
+		status string
+		want   color.Color
+	}{
+		{"running", p.Status.Running},
+		{" Running ", p.Status.Running},
+		{"queued", p.Status.Queued},
+		{"needs-input", p.Status.NeedsInput},
+		{"completed", p.Status.Completed},
+		{"failed", p.Status.Failed},
+		{"idle", p.Status.Idle},
+		{"bogus", p.Status.Unknown},
+	}
+	for _, tt := range tests {
+		if got := p.StatusColor(tt.status); got != tt.want {
+			t.Errorf("StatusColor(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
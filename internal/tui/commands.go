@@ -14,10 +14,8 @@ import (
 
 // Message types
 type tasksLoadedMsg struct {
-	tasks       []data.Session
-	allSessions []data.Session // unfiltered, for mission
-	counts      FilterCounts
-	tokenUsage  map[string]*data.TokenUsage
+	sessions   []data.Session // every session not dismissed or snoozed
+	tokenUsage map[string]*data.TokenUsage
 }
 
 // Phase 1: local sessions loaded (fast, filesystem only)
@@ -35,6 +33,13 @@ type tokenUsageLoadedMsg struct {
 	usage map[string]*data.TokenUsage
 }
 
+// Sessions and token usage from remote hosts, loaded alongside phase 2
+type remoteSessionsLoadedMsg struct {
+	sessions []data.Session
+	usage    map[string]*data.TokenUsage
+	err      error
+}
+
 type taskDetailLoadedMsg struct {
 	task *data.Session
 }
@@ -48,7 +53,9 @@ type toolTimelineLoadedMsg struct {
 }
 
 type diffLoadedMsg struct {
-	files []diffview.FileDiff
+	files    []diffview.FileDiff
+	repo     string // repository of the PR the diff belongs to
+	prNumber int
 }
 
 type refreshTickMsg struct{}
@@ -82,6 +89,11 @@ type gitDiffLoadedMsg struct {
 
 type gitDiffPollTickMsg struct{}
 
+type gitCommitDiffLoadedMsg struct {
+	hash string
+	diff string
+}
+
 // fetchTasks fetches the list of sessions (both agent tasks and local sessions)
 func (m Model) fetchTasks() tea.Msg {
 	var sessions []data.Session
@@ -89,25 +101,19 @@ func (m Model) fetchTasks() tea.Msg {
 
 	if m.demo {
 		sessions = data.DemoSessions()
+	} else if m.replay != nil {
+		sessions = m.replaySessions()
+		tokenUsage = m.replay.State.TokenUsage
 	} else {
 		var err error
 		sessions, err = data.FetchAllSessions(m.repo)
 		if err != nil {
 			return errMsg{err}
 		}
-
-		// Exclude dismissed sessions before computing anything
-		dismissedIDs := map[string]struct{}{}
-		if m.dismissedStore != nil {
-			dismissedIDs = m.dismissedStore.IDs()
-		}
-		visible := make([]data.Session, 0, len(sessions))
-		for _, session := range sessions {
-			if _, dismissed := dismissedIDs[session.ID]; !dismissed {
-				visible = append(visible, session)
-			}
+		if m.showArchived {
+			archived, _ := data.FetchArchivedSessions()
+			sessions = append(sessions, m.filterRepo(archived)...)
 		}
-		sessions = visible
 
 		// Enrich sessions with token usage from CLI logs
 		tokenUsage, _ = data.FetchTokenUsage()
@@ -125,41 +131,9 @@ func (m Model) fetchTasks() tea.Msg {
 		}
 	}
 
-	// Compute counts across all visible (non-dismissed) sessions
-	allSessions := make([]data.Session, len(sessions))
-	copy(allSessions, sessions)
-	counts := FilterCounts{All: len(sessions)}
-	for _, session := range sessions {
-		if data.SessionNeedsAttention(session) {
-			counts.Attention++
-		}
-		if data.StatusIsActive(session.Status) || strings.EqualFold(session.Status, "needs-input") {
-			counts.Active++
-		}
-		if strings.EqualFold(session.Status, "completed") {
-			counts.Completed++
-		}
-		if strings.EqualFold(session.Status, "failed") {
-			counts.Failed++
-		}
-	}
-
-	// Filter sessions based on status filter
-	if m.ctx.StatusFilter != "all" {
-		filtered := []data.Session{}
-		for _, session := range sessions {
-			if m.ctx.StatusFilter == "attention" && data.SessionNeedsAttention(session) {
-				filtered = append(filtered, session)
-			} else if m.ctx.StatusFilter == "active" && (data.StatusIsActive(session.Status) || strings.EqualFold(session.Status, "needs-input")) {
-				filtered = append(filtered, session)
-			} else if strings.EqualFold(session.Status, m.ctx.StatusFilter) {
-				filtered = append(filtered, session)
-			}
-		}
-		sessions = filtered
-	}
-
-	return tasksLoadedMsg{sessions, allSessions, counts, tokenUsage}
+	// Exclude dismissed and snoozed sessions; the search and status tab are
+	// applied when the sessions are displayed.
+	return tasksLoadedMsg{m.annotateSessions(sessions), tokenUsage}
 }
 
 // fetchLocalSessions loads local sessions quickly (filesystem only)
@@ -168,6 +142,9 @@ func (m Model) fetchLocalSessions() tea.Msg {
 		sessions := data.DemoSessions()
 		return localSessionsLoadedMsg{sessions}
 	}
+	if m.replay != nil {
+		return localSessionsLoadedMsg{m.replaySessions()}
+	}
 	sessions, _ := data.FetchLocalSessions()
 	return localSessionsLoadedMsg{sessions}
 }
@@ -177,6 +154,9 @@ func (m Model) fetchAgentTasks() tea.Msg {
 	if m.demo {
 		return agentTasksLoadedMsg{nil} // demo already loaded everything
 	}
+	if m.replay != nil {
+		return agentTasksLoadedMsg{nil} // the snapshot is loaded with local sessions
+	}
 	tasks, err := data.FetchAgentTasks(m.repo)
 	if err != nil {
 		return agentTasksLoadedMsg{nil} // non-fatal
@@ -188,11 +168,21 @@ func (m Model) fetchAgentTasks() tea.Msg {
 	return agentTasksLoadedMsg{sessions}
 }
 
+// fetchRemoteSessions loads sessions from the configured remote hosts over
+// ssh. Hosts that fail are reported in the message's err.
+func (m Model) fetchRemoteSessions() tea.Msg {
+	sessions, usage, err := data.FetchRemoteSessions()
+	return remoteSessionsLoadedMsg{sessions: sessions, usage: usage, err: err}
+}
+
 // fetchTokenUsage parses log files for token data (deferred)
 func (m Model) fetchTokenUsage() tea.Msg {
 	if m.demo {
 		return tokenUsageLoadedMsg{nil}
 	}
+	if m.replay != nil {
+		return tokenUsageLoadedMsg{m.replay.State.TokenUsage}
+	}
 	usage, _ := data.FetchTokenUsage()
 	return tokenUsageLoadedMsg{usage}
 }
@@ -209,6 +199,14 @@ func (m Model) fetchTaskDetail(id string, repo string) tea.Cmd {
 			}
 			return errMsg{fmt.Errorf("demo session not found")}
 		}
+		if m.replay != nil {
+			for _, s := range m.replaySessions() {
+				if s.ID == id {
+					return taskDetailLoadedMsg{&s}
+				}
+			}
+			return errMsg{fmt.Errorf("session %s not in snapshot", id)}
+		}
 		// For now, we only support detail view for agent-task sessions
 		// Local sessions don't have a detail API yet
 		task, err := data.FetchAgentTaskDetail(id, repo)
@@ -224,6 +222,9 @@ func (m Model) fetchTaskDetail(id string, repo string) tea.Cmd {
 func (m Model) fetchTaskLog(id string, repo string) tea.Cmd {
 	session := m.taskList.SelectedTask()
 	return func() tea.Msg {
+		if m.replay != nil {
+			return errMsg{replayUnavailable("logs")}
+		}
 		// Route to local session log reader for local-copilot sessions
 		if session != nil && session.Source == data.SourceLocalCopilot {
 			log, err := data.FetchLocalSessionLog(id)
@@ -244,82 +245,94 @@ func (m Model) fetchTaskLog(id string, repo string) tea.Cmd {
 // fetchToolTimeline fetches tool execution events for the timeline view
 func (m Model) fetchToolTimeline(sessionID string) tea.Cmd {
 	return func() tea.Msg {
+		if m.replay != nil {
+			return errMsg{replayUnavailable("tool events")}
+		}
 		events, err := data.FetchSessionEvents(sessionID)
 		if err != nil {
 			return errMsg{err}
 		}
+		return toolTimelineLoadedMsg{events: toolEvents(events)}
+	}
+}
 
-		var toolEvents []tooltimeline.ToolEvent
-		for _, ev := range events {
-			if ev.Type == "tool.execution_start" && ev.ToolName != "" {
-				toolEvents = append(toolEvents, tooltimeline.ToolEvent{
-					Timestamp: ev.Timestamp,
-					ToolName:  ev.ToolName,
-					Icon:      tooltimeline.ToolIcon(ev.ToolName),
-				})
-			}
+// toolEvents picks the tool executions out of session events.
+func toolEvents(events []data.SessionEvent) []tooltimeline.ToolEvent {
+	var tools []tooltimeline.ToolEvent
+	for _, ev := range events {
+		if ev.Type == "tool.execution_start" && ev.ToolName != "" {
+			tools = append(tools, tooltimeline.ToolEvent{
+				Timestamp: ev.Timestamp,
+				ToolName:  ev.ToolName,
+				Icon:      tooltimeline.ToolIcon(ev.ToolName),
+			})
 		}
-
-		return toolTimelineLoadedMsg{events: toolEvents}
 	}
+	return tools
 }
 
 // fetchConversation loads events for a local session and converts them to chat messages.
 func (m Model) fetchConversation(sessionID string) tea.Cmd {
 	return func() tea.Msg {
+		if m.replay != nil {
+			return errMsg{replayUnavailable("conversation")}
+		}
 		events, err := data.FetchSessionEvents(sessionID)
 		if err != nil {
 			return errMsg{err}
 		}
+		return conversationLoadedMsg{messages: conversationMessages(events)}
+	}
+}
 
-		var messages []conversation.ChatMessage
-		var pendingTools []string
+// conversationMessages converts session events to chat messages, attaching
+// the tools run during each turn to the assistant reply that ends it.
+func conversationMessages(events []data.SessionEvent) []conversation.ChatMessage {
+	var messages []conversation.ChatMessage
+	var pendingTools []string
 
-		for _, ev := range events {
+	for _, ev := range events {
 		switch ev.Type {
-			case "session.start":
+		case "session.start":
+			messages = append(messages, conversation.ChatMessage{
+				Role:      conversation.RoleSystem,
+				Content:   "Session started",
+				Timestamp: ev.Timestamp,
+			})
+		case "user.message":
+			if ev.Content != "" {
 				messages = append(messages, conversation.ChatMessage{
-					Role:      conversation.RoleSystem,
-					Content:   "Session started",
+					Role:      conversation.RoleUser,
+					Content:   ev.Content,
 					Timestamp: ev.Timestamp,
 				})
-			case "user.message":
-				if ev.Content != "" {
-					messages = append(messages, conversation.ChatMessage{
-						Role:      conversation.RoleUser,
-						Content:   ev.Content,
-						Timestamp: ev.Timestamp,
-					})
-				}
-			case "tool.execution_start":
-				if ev.ToolName != "" {
-					pendingTools = append(pendingTools, ev.ToolName)
-				}
-			case "assistant.message":
-				if ev.Content != "" {
-					messages = append(messages, conversation.ChatMessage{
-						Role:      conversation.RoleAssistant,
-						Content:   ev.Content,
-						Timestamp: ev.Timestamp,
-						Tools:     pendingTools,
-					})
-					pendingTools = nil
-				}
-			case "abort":
+			}
+		case "tool.execution_start":
+			if ev.ToolName != "" {
+				pendingTools = append(pendingTools, ev.ToolName)
+			}
+		case "assistant.message":
+			if ev.Content != "" {
 				messages = append(messages, conversation.ChatMessage{
-					Role:      conversation.RoleSystem,
-					Content:   "Session aborted",
+					Role:      conversation.RoleAssistant,
+					Content:   ev.Content,
 					Timestamp: ev.Timestamp,
+					Tools:     pendingTools,
 				})
+				pendingTools = nil
 			}
+		case "abort":
+			messages = append(messages, conversation.ChatMessage{
+				Role:      conversation.RoleSystem,
+				Content:   "Session aborted",
+				Timestamp: ev.Timestamp,
+			})
 		}
-
-		return conversationLoadedMsg{messages: messages}
 	}
+	return messages
 }
 
-func (m Model) openSessionRepo() tea.Cmd {
-	session := m.taskList.SelectedTask()
+func (m Model) openSessionRepo(session *data.Session) tea.Cmd {
 	if session == nil || session.Repository == "" {
 		return func() tea.Msg {
 			return errMsg{fmt.Errorf("no session or repository selected")}
@@ -354,6 +367,9 @@ func (m Model) openTaskPR(session *data.Session) tea.Cmd {
 		if m.demo {
 			return errMsg{fmt.Errorf("demo mode — PR not available")}
 		}
+		if m.replay != nil {
+			return errMsg{replayUnavailable("PR")}
+		}
 
 		// If we already have PR info, use it
 		if session.PRURL != "" {
@@ -398,6 +414,12 @@ func resumeSessionErr(session *data.Session) tea.Cmd {
 	if session.Source != data.SourceLocalCopilot {
 		return func() tea.Msg { return errMsg{fmt.Errorf("only local Copilot CLI sessions can be resumed")} }
 	}
+	if session.Host != "" {
+		return func() tea.Msg { return errMsg{fmt.Errorf("session runs on %s — resume it there", session.Host)} }
+	}
+	if session.Archived {
+		return func() tea.Msg { return errMsg{fmt.Errorf("archived sessions are read-only")} }
+	}
 	normalizedStatus := strings.ToLower(strings.TrimSpace(session.Status))
 	if normalizedStatus != "running" && normalizedStatus != "queued" && normalizedStatus != "needs-input" {
 		return func() tea.Msg {
@@ -429,6 +451,9 @@ func (m Model) resumeSession(session *data.Session) tea.Cmd {
 
 func (m Model) fetchLogPoll(id string, repo string, source data.SessionSource) tea.Cmd {
 	return func() tea.Msg {
+		if m.replay != nil {
+			return logPollResultMsg{err: replayUnavailable("logs")}
+		}
 		var log string
 		var err error
 		if source == data.SourceLocalCopilot {
@@ -446,6 +471,9 @@ func (m Model) fetchPRDiff(session *data.Session) tea.Cmd {
 	repo := session.Repository
 	branch := session.Branch
 	return func() tea.Msg {
+		if m.replay != nil {
+			return errMsg{replayUnavailable("PR diff")}
+		}
 		// Try to discover PR if not already known
 		if prNumber == 0 && repo != "" && branch != "" {
 			n, url, _ := data.FetchPRForBranch(repo, branch)
@@ -463,13 +491,16 @@ func (m Model) fetchPRDiff(session *data.Session) tea.Cmd {
 			return errMsg{err}
 		}
 		files := diffview.ParseUnifiedDiff(raw)
-		return diffLoadedMsg{files}
+		return diffLoadedMsg{files: files, repo: repo, prNumber: prNumber}
 	}
 }
 
 // fetchGitDiff fetches the current git diff for a session's working directory
 func (m Model) fetchGitDiff(workDir string) tea.Cmd {
 	return func() tea.Msg {
+		if m.replay != nil {
+			return errMsg{replayUnavailable("git activity")}
+		}
 		result, err := data.FetchSessionGitDiff(workDir)
 		if err != nil {
 			return errMsg{err}
@@ -478,6 +509,17 @@ func (m Model) fetchGitDiff(workDir string) tea.Cmd {
 	}
 }
 
+// fetchCommitDiff fetches the diff of one commit on a session's branch
+func (m Model) fetchCommitDiff(workDir, hash string) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := data.FetchCommitDiff(workDir, hash)
+		if err != nil {
+			return errMsg{err}
+		}
+		return gitCommitDiffLoadedMsg{hash: hash, diff: diff}
+	}
+}
+
 // checkLatestVersion queries GitHub for the latest release tag.
 func checkLatestVersion() tea.Msg {
 	out, err := exec.Command("gh", "api",
@@ -490,25 +532,3 @@ func checkLatestVersion() tea.Msg {
 	tag := strings.TrimSpace(string(out))
 	return latestVersionMsg{version: tag}
 }
-
-// clipboardCopiedMsg signals that a value was copied to the clipboard.
-type clipboardCopiedMsg struct {
-	value string
-}
-
-// copyToClipboard copies text to the system clipboard.
-func (m Model) copyToClipboard(text string) tea.Cmd {
-	return func() tea.Msg {
-		cmd := exec.Command("pbcopy")
-		cmd.Stdin = strings.NewReader(text)
-		if err := cmd.Run(); err != nil {
-			// Fallback for Linux
-			cmd = exec.Command("xclip", "-selection", "clipboard")
-			cmd.Stdin = strings.NewReader(text)
-			if err := cmd.Run(); err != nil {
-				return errMsg{fmt.Errorf("clipboard copy failed: %w", err)}
-			}
-		}
-		return clipboardCopiedMsg{value: text}
-	}
-}
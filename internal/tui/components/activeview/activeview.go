@@ -8,7 +8,10 @@ import (
 
 	"charm.land/lipgloss/v2"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/mission"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/resources"
 )
 
 // Model represents the active sessions focused view (lazygit-style split panels).
@@ -23,6 +26,7 @@ type Model struct {
 	statusIcon     func(string) string
 	animStatusIcon func(string, int) string
 	animFrame      int
+	resources      map[string]data.SessionResources // by session ID
 }
 
 // New creates a new active sessions view model.
@@ -50,6 +54,11 @@ func (m *Model) SetAnimFrame(frame int) {
 	m.animFrame = frame
 }
 
+// SetResources updates the sampled process resources, keyed by session ID.
+func (m *Model) SetResources(resources map[string]data.SessionResources) {
+	m.resources = resources
+}
+
 // isActiveForView returns true for sessions that belong in this view:
 // actively working (not idle), needs-input, or recently failed.
 // Idle sessions (running but stale >20min) are excluded — this view is
@@ -199,23 +208,13 @@ func (m *Model) useHorizontalLayout() bool {
 	return m.width >= 100
 }
 
-// ── Palette (Catppuccin Mocha-inspired, matching gh-inbox) ──
-
-var (
-	colorBase     = lipgloss.Color("#1e1e2e")
-	colorSurface0 = lipgloss.Color("#313244")
-	colorSurface1 = lipgloss.Color("#45475a")
-	colorSurface2 = lipgloss.Color("#585b70")
-	colorOverlay0 = lipgloss.Color("#6c7086")
-	colorText     = lipgloss.Color("#cdd6f4")
-	colorSubtext0 = lipgloss.Color("#a6adc8")
-	colorLavender = lipgloss.Color("#b4befe")
-	colorGreen    = lipgloss.Color("#a6e3a1")
-	colorYellow   = lipgloss.Color("#f9e2af")
-	colorRed      = lipgloss.Color("#f38ba8")
-	colorMauve    = lipgloss.Color("#cba6f7")
-	colorTeal     = lipgloss.Color("#94e2d5")
-)
+// ── Palette ──
+
+// powerline returns the active theme's card colors (Catppuccin Mocha by
+// default, matching gh-inbox).
+func powerline() colors.Powerline {
+	return colors.Current().Powerline
+}
 
 // ── View ──
 
@@ -266,7 +265,7 @@ func (m *Model) statusBreakdown() string {
 	if len(parts) == 0 {
 		return " Sessions "
 	}
-	return " " + strings.Join(parts, " · ") + " "
+	return " " + strings.Join(parts, a11y.Glyph(" · ", " - ")) + " "
 }
 func (m *Model) viewHorizontal() string {
 	totalW := m.width - 1
@@ -299,8 +298,8 @@ func (m *Model) viewVertical() string {
 }
 
 func (m *Model) renderListPanel(width, contentHeight int) string {
-	panelTitle := lipgloss.NewStyle().Bold(true).Foreground(colorLavender)
-	dim := lipgloss.NewStyle().Foreground(colorOverlay0)
+	panelTitle := lipgloss.NewStyle().Bold(true).Foreground(powerline().Key)
+	dim := lipgloss.NewStyle().Foreground(powerline().Overlay)
 
 	title := m.statusBreakdown()
 
@@ -336,8 +335,8 @@ func (m *Model) renderListPanel(width, contentHeight int) string {
 
 	content := strings.Join(rows, "\n")
 	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(colorSurface1).
+		Border(a11y.Border(lipgloss.RoundedBorder())).
+		BorderForeground(powerline().Surface1).
 		Width(width - 2).
 		Height(contentHeight).
 		Render(content)
@@ -346,8 +345,8 @@ func (m *Model) renderListPanel(width, contentHeight int) string {
 }
 
 func (m *Model) renderListItem(s data.Session, selected bool, innerW int) string {
-	dim := lipgloss.NewStyle().Foreground(colorOverlay0)
-	text := lipgloss.NewStyle().Foreground(colorText)
+	dim := lipgloss.NewStyle().Foreground(powerline().Overlay)
+	text := lipgloss.NewStyle().Foreground(powerline().Text)
 
 	icon := m.statusIcon(s.Status)
 	if m.animStatusIcon != nil && data.SessionIsActiveNotIdle(s) {
@@ -360,13 +359,11 @@ func (m *Model) renderListItem(s data.Session, selected bool, innerW int) string
 	if maxTitle < 10 {
 		maxTitle = 10
 	}
-	if len(title) > maxTitle {
-		title = title[:maxTitle-1] + "…"
-	}
+	title = a11y.Truncate(title, maxTitle)
 
 	line1Style := text
 	if selected {
-		line1Style = lipgloss.NewStyle().Bold(true).Foreground(colorText).Background(colorSurface1)
+		line1Style = lipgloss.NewStyle().Bold(true).Foreground(powerline().Text).Background(powerline().Surface1)
 	}
 	line1 := fmt.Sprintf(" %s %s", icon, line1Style.Render(title))
 	if selected {
@@ -388,32 +385,41 @@ func (m *Model) renderListItem(s data.Session, selected bool, innerW int) string
 		if maxB < 15 {
 			maxB = 15
 		}
-		if len(branch) > maxB {
-			branch = branch[:maxB-1] + "…"
-		}
+		branch = a11y.Truncate(branch, maxB)
 		meta = append(meta, branch)
 	}
-	line2 := "   " + dim.Render(strings.Join(meta, " • "))
+	if total, ok := m.resources[s.ID].Latest(); ok {
+		usage := fmt.Sprintf("%.0f%% %s", total.CPUPercent, resources.FormatBytes(total.RSS))
+		if lipgloss.Width(strings.Join(append(meta, usage), metaSep()))+3 <= innerW {
+			meta = append(meta, usage)
+		}
+	}
+	line2 := "   " + dim.Render(strings.Join(meta, metaSep()))
 
 	return line1 + "\n" + line2
 }
 
+// metaSep separates the details under a session's title.
+func metaSep() string {
+	return a11y.Glyph(" • ", " - ")
+}
+
 func (m *Model) renderDetailPanel(width, contentHeight int) string {
-	panelTitle := lipgloss.NewStyle().Bold(true).Foreground(colorLavender)
+	panelTitle := lipgloss.NewStyle().Bold(true).Foreground(powerline().Key)
 	title := " Detail "
 
 	s := m.SelectedSession()
 	var content string
 	if s == nil {
-		content = lipgloss.NewStyle().Foreground(colorOverlay0).Render(" No session selected")
+		content = lipgloss.NewStyle().Foreground(powerline().Overlay).Render(" No session selected")
 	} else {
 		content = m.renderDetail(*s, width-4, contentHeight)
 	}
 
 	box := lipgloss.NewStyle().
 		BorderLeft(true).
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(colorSurface1).
+		BorderStyle(a11y.Border(a11y.Border(lipgloss.RoundedBorder()))).
+		BorderForeground(powerline().Surface1).
 		Width(width - 2).
 		Height(contentHeight).
 		Padding(0, 1).
@@ -423,11 +429,11 @@ func (m *Model) renderDetailPanel(width, contentHeight int) string {
 }
 
 func (m *Model) renderDetail(s data.Session, innerW, maxLines int) string {
-	dim := lipgloss.NewStyle().Foreground(colorOverlay0)
-	label := lipgloss.NewStyle().Foreground(colorSubtext0)
-	text := lipgloss.NewStyle().Foreground(colorText)
-	urgent := lipgloss.NewStyle().Bold(true).Foreground(colorRed)
-	active := lipgloss.NewStyle().Foreground(colorGreen)
+	dim := lipgloss.NewStyle().Foreground(powerline().Overlay)
+	label := lipgloss.NewStyle().Foreground(powerline().Subtext)
+	text := lipgloss.NewStyle().Foreground(powerline().Text)
+	urgent := lipgloss.NewStyle().Bold(true).Foreground(powerline().StatusFailed)
+	active := lipgloss.NewStyle().Foreground(powerline().BadgeActive)
 
 	var lines []string
 
@@ -462,7 +468,7 @@ func (m *Model) renderDetail(s data.Session, innerW, maxLines int) string {
 		addField("PR:", fmt.Sprintf("#%d", s.PRNumber))
 	}
 	if !s.CreatedAt.IsZero() {
-		addField("elapsed:", formatDuration(time.Since(s.CreatedAt)))
+		addField("elapsed:", formatDuration(data.Since(s.CreatedAt)))
 	}
 	if s.Telemetry != nil {
 		if s.Telemetry.Model != "" {
@@ -478,20 +484,31 @@ func (m *Model) renderDetail(s data.Session, innerW, maxLines int) string {
 	// Current activity
 	action := mission.DeriveLastAction(s)
 	lines = append(lines, " "+label.Render("activity:"))
-	lines = append(lines, " "+text.Render(action))
+	lines = append(lines, " "+text.Render(a11y.Text(action)))
 	lines = append(lines, "")
 
+	// Process resources of local sessions on this machine
+	if res, ok := m.resources[s.ID]; ok {
+		if panel := resources.Lines(res, innerW-1, 4); len(panel) > 0 && len(lines)+len(panel)+2 < maxLines {
+			lines = append(lines, " "+label.Render("resources:"))
+			for _, l := range panel {
+				lines = append(lines, " "+l)
+			}
+			lines = append(lines, "")
+		}
+	}
+
 	// Log tail — try to fill remaining space
 	remaining := maxLines - len(lines)
 	if remaining > 2 && s.Source == data.SourceLocalCopilot {
 		lines = append(lines, " "+label.Render("recent log:"))
-		logLines := m.fetchLogTail(s, remaining-1)
+		logLines := s.LogTail
+		if len(logLines) > remaining-1 {
+			logLines = logLines[len(logLines)-(remaining-1):]
+		}
 		if len(logLines) > 0 {
 			for _, l := range logLines {
-				if len(l) > innerW-2 {
-					l = l[:innerW-3] + "…"
-				}
-				lines = append(lines, " "+dim.Render(l))
+				lines = append(lines, " "+dim.Render(a11y.Truncate(a11y.Text(l), innerW-2)))
 			}
 		} else {
 			lines = append(lines, " "+dim.Render("(no log data)"))
@@ -501,73 +518,25 @@ func (m *Model) renderDetail(s data.Session, innerW, maxLines int) string {
 	return strings.Join(lines, "\n")
 }
 
-// fetchLogTail returns the last N meaningful events from the session log.
-func (m *Model) fetchLogTail(s data.Session, maxLines int) []string {
-	events, err := data.FetchSessionEvents(s.ID)
-	if err != nil || len(events) == 0 {
-		return nil
-	}
-
-	// Collect the last meaningful events
-	var entries []string
-	for _, ev := range events {
-		var line string
-		switch ev.Type {
-		case "tool.execution_start":
-			line = "🔧 " + ev.ToolName
-		case "tool.execution_end":
-			line = "✓ " + ev.ToolName + " done"
-		case "assistant.message":
-			msg := ev.Content
-			if len(msg) > 80 {
-				msg = msg[:77] + "..."
-			}
-			if msg != "" {
-				line = "💬 " + msg
-			}
-		case "user.message":
-			msg := ev.Content
-			if len(msg) > 60 {
-				msg = msg[:57] + "..."
-			}
-			if msg != "" {
-				line = "👤 " + msg
-			}
-		}
-		if line != "" {
-			entries = append(entries, line)
-		}
-	}
-
-	// Return the tail
-	if len(entries) > maxLines {
-		entries = entries[len(entries)-maxLines:]
-	}
-	return entries
-}
-
 func (m *Model) viewEmpty() string {
-	dim := lipgloss.NewStyle().Foreground(colorOverlay0)
-	panelTitle := lipgloss.NewStyle().Bold(true).Foreground(colorLavender)
-	text := lipgloss.NewStyle().Foreground(colorText)
+	dim := lipgloss.NewStyle().Foreground(powerline().Overlay)
+	panelTitle := lipgloss.NewStyle().Bold(true).Foreground(powerline().Key)
+	text := lipgloss.NewStyle().Foreground(powerline().Text)
 
 	var content []string
 	content = append(content, "")
-	content = append(content, dim.Render(" All quiet — no active sessions ✨"))
+	content = append(content, dim.Render(a11y.Text(" All quiet — no active sessions ✨")))
 	content = append(content, "")
 
 	recent := m.recentCompletions(3)
 	if len(recent) > 0 {
-		content = append(content, " "+lipgloss.NewStyle().Foreground(colorSubtext0).Render("Just finished:"))
+		content = append(content, " "+lipgloss.NewStyle().Foreground(powerline().Subtext).Render("Just finished:"))
 		for _, s := range recent {
-			icon := "✅"
+			icon := a11y.Glyph("✅", "+")
 			if strings.EqualFold(s.Status, "failed") {
-				icon = "❌"
-			}
-			title := s.Title
-			if len(title) > 50 {
-				title = title[:47] + "…"
+				icon = a11y.Glyph("❌", "x")
 			}
+			title := a11y.Truncate(s.Title, 50)
 			ago := formatAge(s.UpdatedAt)
 			pr := ""
 			if s.PRNumber > 0 {
@@ -579,8 +548,8 @@ func (m *Model) viewEmpty() string {
 
 	contentH := m.panelContentHeight()
 	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(colorSurface1).
+		Border(a11y.Border(lipgloss.RoundedBorder())).
+		BorderForeground(powerline().Surface1).
 		Width(m.width - 2).
 		Height(contentH).
 		Render(strings.Join(content, "\n"))
@@ -637,5 +606,5 @@ func formatAge(t time.Time) string {
 	if t.IsZero() {
 		return ""
 	}
-	return formatDuration(time.Since(t)) + " ago"
+	return formatDuration(data.Since(t)) + " ago"
 }
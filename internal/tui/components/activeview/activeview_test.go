@@ -6,6 +6,7 @@ import (
 "testing"
 "time"
 
+"github.com/charmbracelet/x/ansi"
 "github.com/maxbeizer/gh-agent-viz/internal/data"
 )
 
@@ -278,3 +279,34 @@ t.Logf("  session %d: %s (%s)", i, s.Title, s.Status)
 }
 }
 }
+
+func TestView_ShowsResources(t *testing.T) {
+m := New(plainIcon, nil)
+m.SetSize(140, 40)
+m.SetSessions([]data.Session{{ID: "s1", Status: "running", Title: "Task", Repository: "org/repo", UpdatedAt: time.Now(), Source: data.SourceLocalCopilot}})
+m.SetResources(map[string]data.SessionResources{"s1": {
+Processes: []data.ProcessUsage{{PID: 4242, Command: "node copilot", RSS: 300 << 20}, {PID: 5001, Depth: 1, Command: "npm test", CPUPercent: 80, RSS: 100 << 20}},
+History:   []data.ResourceSample{{CPUPercent: 20, RSS: 380 << 20}, {CPUPercent: 80, RSS: 400 << 20, OpenFiles: 30, Processes: 2}},
+}})
+view := ansi.Strip(m.View())
+for _, want := range []string{"80% 400 MB", "resources:", "CPU   80.0%", "└ 5001 npm test"} {
+if !strings.Contains(view, want) {
+t.Errorf("expected %q in the active view, got:\n%s", want, view)
+}
+}
+}
+
+func TestView_ShowsSessionActivity(t *testing.T) {
+m := New(plainIcon, nil)
+m.SetSize(140, 40)
+m.SetSessions([]data.Session{{
+ID: "s1", Status: "running", Title: "Task", Repository: "org/repo", UpdatedAt: time.Now(), Source: data.SourceLocalCopilot,
+LastAction: "🔧 bash", LogTail: []string{"👤 fix the tests", "🔧 bash"},
+}})
+view := ansi.Strip(m.View())
+for _, want := range []string{"activity:", "🔧 bash", "recent log:", "👤 fix the tests"} {
+if !strings.Contains(view, want) {
+t.Errorf("expected %q in the active view, got:\n%s", want, view)
+}
+}
+}
@@ -8,9 +8,16 @@ import (
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/find"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/selection"
 )
 
+// filterContext is how many lines around each match filter mode keeps.
+const filterContext = 2
+
 // MessageRole identifies who sent a chat message.
 type MessageRole string
 
@@ -31,12 +38,24 @@ type ChatMessage struct {
 // Model is the Bubble Tea model for the conversation view.
 type Model struct {
 	messages []ChatMessage
+	spans    []messageSpan // where each message is in the rendered content
+	rendered []string      // the rendered content's lines
+	lines    []string      // lines shown: rendered, or its matches in filter mode
+	selected selection.Model
+	find     find.Model
+	origin   int // top line when the search started
 	viewport viewport.Model
 	width    int
 	height   int
 	ready    bool
 }
 
+// messageSpan is the range of rendered lines [start, end) showing a message.
+type messageSpan struct {
+	start, end int
+	msg        int
+}
+
 // New creates a new conversation view model.
 func New(width, height int) Model {
 	vp := viewport.New(viewport.WithWidth(width), viewport.WithHeight(height))
@@ -50,6 +69,7 @@ func New(width, height int) Model {
 // SetMessages replaces the displayed messages and re-renders.
 func (m *Model) SetMessages(messages []ChatMessage) {
 	m.messages = messages
+	m.selected.Stop()
 	m.renderContent()
 }
 
@@ -76,23 +96,229 @@ func (m Model) View() string {
 	if !m.ready || len(m.messages) == 0 {
 		return lipgloss.NewStyle().Faint(true).Render("No conversation events found for this session.")
 	}
+	if m.find.Filtering() && len(m.lines) == 0 {
+		return lipgloss.NewStyle().Faint(true).Render("No lines match " + m.find.Query())
+	}
 	return m.viewport.View()
 }
 
-// Scrolling helpers
+// Scrolling helpers. While a selection is active they extend it instead.
+
+func (m *Model) LineUp()       { m.scroll(-1, m.viewport.ScrollUp) }
+func (m *Model) LineDown()     { m.scroll(1, m.viewport.ScrollDown) }
+func (m *Model) HalfPageUp()   { m.scroll(-m.viewport.Height()/2, m.viewport.ScrollUp) }
+func (m *Model) HalfPageDown() { m.scroll(m.viewport.Height()/2, m.viewport.ScrollDown) }
+func (m *Model) GotoTop()      { m.scroll(-len(m.lines), m.viewport.ScrollUp) }
+func (m *Model) GotoBottom()   { m.scroll(len(m.lines), m.viewport.ScrollDown) }
+
+// scroll moves the selection by delta lines, or scrolls the view.
+func (m *Model) scroll(delta int, scroll func(int)) {
+	if m.selected.Active() {
+		m.MoveSelection(delta)
+		return
+	}
+	if delta < 0 {
+		delta = -delta
+	}
+	scroll(delta)
+}
+
+// StartSelection starts a line-wise selection at the top line shown.
+func (m *Model) StartSelection() {
+	if len(m.messages) == 0 {
+		return
+	}
+	m.selected.Start(m.viewport.YOffset())
+	m.refresh()
+}
+
+// ClearSelection ends the selection.
+func (m *Model) ClearSelection() {
+	if !m.selected.Active() {
+		return
+	}
+	m.selected.Stop()
+	m.refresh()
+}
+
+// Selecting reports whether a selection is active.
+func (m Model) Selecting() bool {
+	return m.selected.Active()
+}
+
+// SelectionLen returns the number of lines selected.
+func (m Model) SelectionLen() int {
+	return m.selected.Len()
+}
+
+// MoveSelection moves the selection's cursor by delta lines, scrolling to
+// keep it visible.
+func (m *Model) MoveSelection(delta int) {
+	m.selected.Move(delta, len(m.lines))
+	m.refresh()
+	m.viewport.SetYOffset(m.selected.ScrollOffset(m.viewport.YOffset(), m.viewport.Height()))
+}
 
-func (m *Model) LineUp()       { m.viewport.ScrollUp(1) }
-func (m *Model) LineDown()     { m.viewport.ScrollDown(1) }
-func (m *Model) HalfPageUp()   { m.viewport.HalfPageUp() }
-func (m *Model) HalfPageDown() { m.viewport.HalfPageDown() }
-func (m *Model) GotoTop()      { m.viewport.GotoTop() }
-func (m *Model) GotoBottom()   { m.viewport.GotoBottom() }
+// SelectedText returns the selected lines as plain text, without the
+// bubbles' borders and padding.
+func (m Model) SelectedText() string {
+	lines := m.selected.Lines(m.lines)
+	plain := make([]string, len(lines))
+	for i, l := range lines {
+		plain[i] = plainLine(l)
+	}
+	return strings.Trim(strings.Join(plain, "\n"), "\n")
+}
+
+// plainLine strips a rendered line's styling, and the border and padding
+// of the bubble it belongs to.
+func plainLine(line string) string {
+	s := strings.TrimRight(ansi.Strip(line), " ")
+	if t, ok := strings.CutSuffix(s, bubbleBorder().Right); ok { // agent bubbles are bordered on the right
+		return strings.TrimSpace(t)
+	}
+	if t, ok := strings.CutPrefix(strings.TrimLeft(s, " "), bubbleBorder().Left); ok {
+		return strings.TrimPrefix(t, " ")
+	}
+	return strings.TrimSpace(s)
+}
+
+// rebuild derives the lines shown from the rendered content, collapsing
+// them to the search matches in filter mode, and finds the matches in them.
+func (m *Model) rebuild() {
+	m.lines = m.rendered
+	if m.find.Filtering() {
+		m.lines = m.find.FilterLines(m.lines, filterContext)
+	}
+	m.find.Find(m.lines)
+	m.selected.Clamp(len(m.lines))
+	m.refresh()
+}
+
+// refresh pushes the lines into the viewport, highlighting the search
+// matches and the selection.
+func (m *Model) refresh() {
+	lines := m.find.Highlight(m.lines)
+	if m.selected.Active() {
+		m.viewport.SetContent(m.selected.Highlight(lines, m.width))
+		return
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// StartSearch notes where a new search starts: matches are sought from
+// the top line shown.
+func (m *Model) StartSearch() {
+	m.origin = m.viewport.YOffset()
+}
+
+// Search highlights the matches of query, a regular expression matched
+// case-insensitively unless it has a capital letter, and scrolls to the
+// first one from where the search started.
+func (m *Model) Search(query string) {
+	m.find.SetQuery(query)
+	m.rebuild()
+	origin := m.origin
+	if m.find.Filtering() {
+		origin = 0
+	}
+	if match, ok := m.find.SeekFrom(origin); ok {
+		m.showLine(match.Line)
+	}
+}
+
+// NextMatch moves to the match delta matches away, wrapping around.
+func (m *Model) NextMatch(delta int) {
+	if match, ok := m.find.Next(delta); ok {
+		m.refresh()
+		m.showLine(match.Line)
+	}
+}
+
+// SetFilter turns filter mode, showing only the lines matching the search
+// with the lines around them, on or off.
+func (m *Model) SetFilter(on bool) {
+	m.find.SetFilter(on)
+	m.rebuild()
+	if match, ok := m.find.Current(); ok {
+		m.showLine(match.Line)
+	} else {
+		m.viewport.GotoTop()
+	}
+}
+
+// ClearSearch drops the search and filter mode.
+func (m *Model) ClearSearch() {
+	m.find.Clear()
+	m.rebuild()
+}
+
+// SearchState returns the search, for showing its query and match count.
+func (m Model) SearchState() find.Model {
+	return m.find
+}
+
+// showLine scrolls line into view when it isn't, putting it a third of the
+// way down.
+func (m *Model) showLine(line int) {
+	top, height := m.viewport.YOffset(), m.viewport.Height()
+	if line >= top && line < top+height {
+		return
+	}
+	m.viewport.SetYOffset(max(0, line-height/3))
+}
+
+// PageText returns the messages shown on the current page as plain text,
+// each headed by its sender and time. In filter mode it returns the lines
+// shown instead.
+func (m Model) PageText() string {
+	top := m.viewport.YOffset()
+	bottom := top + m.viewport.Height()
+	if m.find.Filtering() {
+		plain := make([]string, 0, m.viewport.Height())
+		for _, l := range m.lines[min(top, len(m.lines)):min(bottom, len(m.lines))] {
+			plain = append(plain, plainLine(l))
+		}
+		return strings.Trim(strings.Join(plain, "\n"), "\n")
+	}
+	var parts []string
+	for _, s := range m.spans {
+		if s.end > top && s.start < bottom {
+			parts = append(parts, FormatMessage(m.messages[s.msg]))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// FormatMessage renders a message as plain text: a "You" or "Agent" line
+// with its time, the content, and the tools the turn used.
+func FormatMessage(msg ChatMessage) string {
+	var hdr string
+	switch msg.Role {
+	case RoleUser:
+		hdr = "You"
+	case RoleAssistant:
+		hdr = "Agent"
+	default:
+		return "⚡ " + msg.Content
+	}
+	if ts := formatShortTimestamp(msg.Timestamp); ts != "" {
+		hdr += " · " + ts
+	}
+	text := hdr + "\n" + msg.Content
+	if len(msg.Tools) > 0 {
+		text += "\n" + formatToolLine(msg.Tools)
+	}
+	return text
+}
 
 // ---- rendering ----
 
 // renderContent builds the full conversation view and pushes it into the viewport.
 func (m *Model) renderContent() {
 	if len(m.messages) == 0 {
+		m.rendered = nil
+		m.rebuild()
 		m.ready = true
 		return
 	}
@@ -100,28 +326,38 @@ func (m *Model) renderContent() {
 	bubbleWidth := m.bubbleWidth()
 	var sections []string
 	var prevTime time.Time
+	m.spans = nil
+	line := 0
+	add := func(section string, msg int) {
+		n := strings.Count(section, "\n") + 1
+		if msg >= 0 {
+			m.spans = append(m.spans, messageSpan{start: line, end: line + n, msg: msg})
+		}
+		sections = append(sections, section)
+		line += n + 1 // sections are separated by a blank line
+	}
 
-	for _, msg := range m.messages {
+	for i, msg := range m.messages {
 		// Insert timestamp separator when gap > 5 minutes
 		if ts, ok := parseTimestamp(msg.Timestamp); ok {
 			if !prevTime.IsZero() && ts.Sub(prevTime) > 5*time.Minute {
-				sep := renderTimeSeparator(ts, m.width)
-				sections = append(sections, sep)
+				add(renderTimeSeparator(ts, m.width), -1)
 			}
 			prevTime = ts
 		}
 
 		switch msg.Role {
 		case RoleUser:
-			sections = append(sections, renderUserBubble(msg, bubbleWidth))
+			add(renderUserBubble(msg, bubbleWidth), i)
 		case RoleAssistant:
-			sections = append(sections, renderAgentBubble(msg, bubbleWidth, m.width))
+			add(renderAgentBubble(msg, bubbleWidth, m.width), i)
 		case RoleSystem:
-			sections = append(sections, renderSystemBubble(msg, m.width))
+			add(renderSystemBubble(msg, m.width), i)
 		}
 	}
 
-	m.viewport.SetContent(strings.Join(sections, "\n\n"))
+	m.rendered = strings.Split(strings.Join(sections, "\n\n"), "\n")
+	m.rebuild()
 	m.ready = true
 }
 
@@ -140,21 +376,17 @@ func (m *Model) bubbleWidth() int {
 
 var (
 	userBorderStyle = lipgloss.NewStyle().
-			BorderStyle(lipgloss.ThickBorder()).
 			BorderLeft(true).
 			BorderRight(false).
 			BorderTop(false).
 			BorderBottom(false).
-			BorderForeground(compat.AdaptiveColor{Light: lipgloss.Color("27"), Dark: lipgloss.Color("69")}).
 			PaddingLeft(1)
 
 	agentBorderStyle = lipgloss.NewStyle().
-				BorderStyle(lipgloss.ThickBorder()).
 				BorderLeft(false).
 				BorderRight(true).
 				BorderTop(false).
 				BorderBottom(false).
-				BorderForeground(compat.AdaptiveColor{Light: lipgloss.Color("28"), Dark: lipgloss.Color("42")}).
 				PaddingRight(1)
 
 	headerStyle = lipgloss.NewStyle().Bold(true)
@@ -166,6 +398,11 @@ var (
 	separatorStyle = lipgloss.NewStyle().Faint(true).Align(lipgloss.Center)
 )
 
+// bubbleBorder is the border drawn beside a bubble.
+func bubbleBorder() lipgloss.Border {
+	return a11y.Border(lipgloss.ThickBorder())
+}
+
 func renderUserBubble(msg ChatMessage, bubbleWidth int) string {
 	ts := formatShortTimestamp(msg.Timestamp)
 
@@ -181,7 +418,7 @@ func renderUserBubble(msg ChatMessage, bubbleWidth int) string {
 	body := wordWrap(msg.Content, bubbleWidth-2)
 	inner := hdr + "\n" + body
 
-	return userBorderStyle.Width(bubbleWidth).Render(inner)
+	return userBorderStyle.BorderStyle(bubbleBorder()).BorderForeground(colors.Current().Section).Width(bubbleWidth).Render(inner)
 }
 
 func renderAgentBubble(msg ChatMessage, bubbleWidth, totalWidth int) string {
@@ -200,11 +437,11 @@ func renderAgentBubble(msg ChatMessage, bubbleWidth, totalWidth int) string {
 
 	// Append tool line if any
 	if len(msg.Tools) > 0 {
-		body += "\n" + toolStyle.Render(formatToolLine(msg.Tools))
+		body += "\n" + toolStyle.Render(a11y.Text(formatToolLine(msg.Tools)))
 	}
 
 	inner := hdr + "\n" + body
-	bubble := agentBorderStyle.Width(bubbleWidth).Render(inner)
+	bubble := agentBorderStyle.BorderStyle(bubbleBorder()).BorderForeground(colors.Current().Key).Width(bubbleWidth).Render(inner)
 
 	// Right-align: indent from left
 	indent := totalWidth - lipgloss.Width(bubble)
@@ -215,12 +452,13 @@ func renderAgentBubble(msg ChatMessage, bubbleWidth, totalWidth int) string {
 }
 
 func renderSystemBubble(msg ChatMessage, totalWidth int) string {
-	text := "⚡ " + msg.Content
+	text := a11y.Glyph("⚡ ", "* ") + msg.Content
 	return separatorStyle.Width(totalWidth).Render(text)
 }
 
 func renderTimeSeparator(ts time.Time, totalWidth int) string {
-	text := fmt.Sprintf("── %s ──", ts.Format("15:04"))
+	rule := a11y.Glyph("──", "--")
+	text := fmt.Sprintf("%s %s %s", rule, ts.Format("15:04"), rule)
 	return separatorStyle.Width(totalWidth).Render(text)
 }
 
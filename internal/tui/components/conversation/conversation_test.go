@@ -3,6 +3,8 @@ package conversation
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestNew(t *testing.T) {
@@ -183,3 +185,75 @@ func TestWordWrap_PreservesNewlines(t *testing.T) {
 		t.Error("expected both lines preserved")
 	}
 }
+
+func TestPageText_MessagesInView(t *testing.T) {
+	m := New(80, 6)
+	m.SetMessages([]ChatMessage{
+		{Role: RoleUser, Content: "Fix the auth bug", Timestamp: "2026-01-15T09:15:00Z"},
+		{Role: RoleAssistant, Content: "Done", Timestamp: "2026-01-15T09:16:00Z", Tools: []string{"edit"}},
+		{Role: RoleUser, Content: "Now add tests", Timestamp: "2026-01-15T09:17:00Z"},
+		{Role: RoleAssistant, Content: "Added", Timestamp: "2026-01-15T09:18:00Z"},
+	})
+	text := m.PageText()
+	if !strings.HasPrefix(text, "You · ") || !strings.Contains(text, "Fix the auth bug") || !strings.Contains(text, "Done\n✏️ edit") {
+		t.Errorf("expected the first turns as plain text, got %q", text)
+	}
+	if strings.Contains(text, "Added") {
+		t.Errorf("expected messages below the page left out, got %q", text)
+	}
+	m.GotoBottom()
+	if text := m.PageText(); !strings.HasSuffix(text, "Added") || strings.Contains(text, "Fix the auth bug") {
+		t.Errorf("expected the last turns after scrolling, got %q", text)
+	}
+}
+
+func TestSelectedText_WithoutBubbleBorders(t *testing.T) {
+	m := New(80, 30)
+	m.SetMessages([]ChatMessage{
+		{Role: RoleUser, Content: "Fix the auth bug", Timestamp: "2026-01-15T09:15:00Z"},
+		{Role: RoleAssistant, Content: "Done", Timestamp: "2026-01-15T09:16:00Z", Tools: []string{"edit"}},
+	})
+	m.StartSelection()
+	m.GotoBottom()
+	text := m.SelectedText()
+	if strings.Contains(text, "┃") || strings.Contains(text, "\x1b") {
+		t.Errorf("expected borders and styling removed, got %q", text)
+	}
+	if !strings.HasPrefix(text, "You") || !strings.Contains(text, "\nFix the auth bug\n") || !strings.HasSuffix(text, "Done\n✏️ edit") {
+		t.Errorf("expected both messages selected, got %q", text)
+	}
+	m.SetMessages(nil)
+	if m.Selecting() {
+		t.Error("expected new messages to end the selection")
+	}
+}
+
+func TestSearch_AcrossMessages(t *testing.T) {
+	m := New(80, 6)
+	m.SetMessages([]ChatMessage{
+		{Role: RoleUser, Content: "Fix the auth bug", Timestamp: "2026-01-15T09:15:00Z"},
+		{Role: RoleAssistant, Content: "Done", Timestamp: "2026-01-15T09:16:00Z"},
+		{Role: RoleUser, Content: "Now add auth tests", Timestamp: "2026-01-15T09:17:00Z"},
+		{Role: RoleAssistant, Content: "Added", Timestamp: "2026-01-15T09:18:00Z"},
+	})
+	m.StartSearch()
+	m.Search("auth")
+	if got := m.SearchState().Counter(); got != "1/2" {
+		t.Fatalf("expected 2 matches, got %s", got)
+	}
+	m.NextMatch(1)
+	if !strings.Contains(ansi.Strip(m.View()), "Now add auth tests") {
+		t.Errorf("expected the view scrolled to the second match, got %q", m.View())
+	}
+	m.SetFilter(true)
+	if text := ansi.Strip(strings.Join(m.lines, "\n")); strings.Contains(text, "Added") || !strings.Contains(text, "Fix the auth bug") {
+		t.Errorf("expected only the lines around matches, got %q", text)
+	}
+	if text := m.PageText(); !strings.HasPrefix(text, "--") || strings.Contains(text, "┃") {
+		t.Errorf("expected the filtered lines shown as plain text, got %q", text)
+	}
+	m.ClearSearch()
+	if len(m.lines) != len(m.rendered) {
+		t.Error("expected every line back after clearing the search")
+	}
+}
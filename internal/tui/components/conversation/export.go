@@ -0,0 +1,108 @@
+package conversation
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// roleName returns how a message's sender is shown in exports.
+func roleName(role MessageRole) string {
+	switch role {
+	case RoleUser:
+		return "You"
+	case RoleAssistant:
+		return "Agent"
+	}
+	return "Session"
+}
+
+// formatLongTimestamp formats a message time with the date for exports,
+// falling back to the raw value when it doesn't parse.
+func formatLongTimestamp(ts string) string {
+	if t, ok := parseTimestamp(ts); ok {
+		return t.Format("2006-01-02 15:04:05 MST")
+	}
+	return ts
+}
+
+// ExportMarkdown renders the conversation as a Markdown document titled
+// title: a heading per message with its sender and time, the message as
+// written, and the tools the turn used. Session events become italic
+// lines.
+func ExportMarkdown(title string, messages []ChatMessage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, msg := range messages {
+		ts := formatLongTimestamp(msg.Timestamp)
+		if msg.Role == RoleSystem {
+			fmt.Fprintf(&b, "\n_⚡ %s", msg.Content)
+			if ts != "" {
+				fmt.Fprintf(&b, " · %s", ts)
+			}
+			b.WriteString("_\n")
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s", roleName(msg.Role))
+		if ts != "" {
+			fmt.Fprintf(&b, " · %s", ts)
+		}
+		fmt.Fprintf(&b, "\n\n%s\n", strings.TrimSpace(msg.Content))
+		if len(msg.Tools) > 0 {
+			fmt.Fprintf(&b, "\n> Tools: %s\n", formatToolLine(msg.Tools))
+		}
+	}
+	return b.String()
+}
+
+// exportCSS styles the HTML export after the terminal view: your messages
+// on the left, the agent's on the right, session events centered.
+const exportCSS = `body{font-family:system-ui,sans-serif;max-width:56rem;margin:2rem auto;padding:0 1rem;line-height:1.5}
+.message{margin:1rem 0;padding:.25rem 1rem;border-left:4px solid #0969da}
+.message.assistant{margin-left:15%;border-left:none;border-right:4px solid #8250df}
+.message header{font-weight:bold}
+.message time{font-weight:normal;color:#57606a;margin-left:.5rem}
+.tools{color:#57606a;font-size:.9em}
+.system{text-align:center;color:#57606a}
+pre{overflow-x:auto;background:#f6f8fa;padding:.5rem}`
+
+// markdown renders message content; raw HTML in it is escaped rather than
+// passed through.
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// ExportHTML renders the conversation as a standalone HTML page titled
+// title, with each message's markdown rendered, its sender and time in a
+// header, and the tools the turn used beneath it.
+func ExportHTML(title string, messages []ChatMessage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n<h1>%s</h1>\n",
+		html.EscapeString(title), exportCSS, html.EscapeString(title))
+	for _, msg := range messages {
+		ts := formatLongTimestamp(msg.Timestamp)
+		timeTag := ""
+		if ts != "" {
+			timeTag = fmt.Sprintf("<time datetime=\"%s\">%s</time>", html.EscapeString(msg.Timestamp), html.EscapeString(ts))
+		}
+		if msg.Role == RoleSystem {
+			fmt.Fprintf(&b, "<p class=\"system\">⚡ %s %s</p>\n", html.EscapeString(msg.Content), timeTag)
+			continue
+		}
+		fmt.Fprintf(&b, "<section class=\"message %s\">\n<header>%s %s</header>\n", msg.Role, roleName(msg.Role), timeTag)
+		var body bytes.Buffer
+		if err := markdown.Convert([]byte(msg.Content), &body); err != nil {
+			body.Reset()
+			fmt.Fprintf(&body, "<pre>%s</pre>\n", html.EscapeString(msg.Content))
+		}
+		b.Write(body.Bytes())
+		if len(msg.Tools) > 0 {
+			fmt.Fprintf(&b, "<p class=\"tools\">Tools: %s</p>\n", html.EscapeString(formatToolLine(msg.Tools)))
+		}
+		b.WriteString("</section>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package conversation
+
+import (
+	"strings"
+	"testing"
+)
+
+var exportMessages = []ChatMessage{
+	{Role: RoleSystem, Content: "Session started", Timestamp: "2026-01-15T09:14:00Z"},
+	{Role: RoleUser, Content: "Why does <Login> fail?", Timestamp: "2026-01-15T09:15:00Z"},
+	{Role: RoleAssistant, Content: "The token **expired**.", Timestamp: "2026-01-15T09:16:00Z", Tools: []string{"grep", "view"}},
+}
+
+func TestExportMarkdown(t *testing.T) {
+	got := ExportMarkdown("Fix login", exportMessages)
+	for _, want := range []string{
+		"# Fix login\n",
+		"_⚡ Session started · 2026-01-15 09:14:00 UTC_",
+		"## You · 2026-01-15 09:15:00 UTC\n\nWhy does <Login> fail?\n",
+		"## Agent · 2026-01-15 09:16:00 UTC\n\nThe token **expired**.\n",
+		"> Tools: 🔍 grep • 👁️ view",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in the export, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	got := ExportHTML("Fix <login>", exportMessages)
+	for _, want := range []string{
+		"<title>Fix &lt;login&gt;</title>",
+		`<section class="message user">`,
+		`<time datetime="2026-01-15T09:15:00Z">2026-01-15 09:15:00 UTC</time>`,
+		`<section class="message assistant">`,
+		"<strong>expired</strong>",
+		`<p class="tools">Tools: 🔍 grep • 👁️ view</p>`,
+		`<p class="system">⚡ Session started`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in the export, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<Login>") {
+		t.Error("expected raw HTML in messages escaped")
+	}
+}
@@ -2,11 +2,16 @@ package diffview
 
 import (
 	"fmt"
+	"image/color"
 	"strings"
 
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 // FileDiff represents a single file's diff content
@@ -17,7 +22,16 @@ type FileDiff struct {
 	Patch     string // unified diff content
 }
 
-// Model represents the diff view component state
+// Layout limits. The file tree needs room beside the patch, and split
+// mode needs enough width for two readable columns.
+const (
+	minSidebarWidth = 80
+	minSplitWidth   = 100
+	headerHeight    = 2
+)
+
+// Model represents the diff view component state. One file is shown at a
+// time, beside a tree of all files, with a cursor over its lines.
 type Model struct {
 	files    []FileDiff
 	viewport viewport.Model
@@ -25,16 +39,52 @@ type Model struct {
 	height   int
 	ready    bool
 	loading  bool
+
+	file     int          // index of the shown file
+	cursor   int          // selected row of the shown file
+	split    bool         // side-by-side preferred over unified
+	folded   map[int]bool // collapsed files, generated ones by default
+	lines    []Line       // parsed patch of the shown file
+	rows     []row        // display rows of the shown file
+	rendered []string     // rendered rows, without the cursor marker
+	notes    [][]string   // rendered pending comments below each row
+	comments []Comment    // pending review comments on any file
 }
 
-// Styles for diff rendering
-var (
-	addStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))  // green
-	delStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // red
-	hunkStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("45")).Faint(true)
-	headerStyle = lipgloss.NewStyle().Bold(true)
-	sepStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
-)
+// Comment is a pending review comment on one line of the diff.
+type Comment struct {
+	Path string
+	Line Line
+	Body string
+}
+
+// on reports whether the comment is attached to line l of the file at path.
+func (c Comment) on(path string, l Line) bool {
+	return c.Path == path && c.Line.Side() == l.Side() && c.Line.Number() == l.Number()
+}
+
+// Styles for diff rendering, built from the active theme
+var headerStyle = lipgloss.NewStyle().Bold(true)
+
+func addStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Diff.Added)
+}
+
+func delStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Diff.Removed)
+}
+
+func hunkStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Diff.Hunk).Faint(true)
+}
+
+func sepStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Subtle)
+}
+
+func cursorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Diff.Hunk).Bold(true)
+}
 
 // New creates a new diff view model
 func New(width, height int) Model {
@@ -46,13 +96,19 @@ func New(width, height int) Model {
 	}
 }
 
-// SetDiffs updates the file diffs and re-renders the viewport content
+// SetDiffs updates the file diffs and shows the first file
 func (m *Model) SetDiffs(files []FileDiff) {
 	m.files = files
 	m.loading = false
-	m.viewport.SetContent(m.renderDiffs())
-	m.viewport.GotoTop()
+	m.comments = nil
+	m.folded = make(map[int]bool)
+	for i, f := range files {
+		if IsGenerated(f.Path) {
+			m.folded[i] = true
+		}
+	}
 	m.ready = true
+	m.showFile(0)
 }
 
 // SetLoading puts the diff view in a loading state
@@ -65,22 +121,44 @@ func (m *Model) SetLoading() {
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	m.viewport.SetWidth(width)
-	m.viewport.SetHeight(height)
-	if m.ready {
-		m.viewport.SetContent(m.renderDiffs())
+	m.viewport.SetWidth(m.mainWidth())
+	m.viewport.SetHeight(max(height-headerHeight, 1))
+	if m.hasFile() {
+		m.render()
+	}
+}
+
+// Refresh re-renders the shown file, picking up a new theme.
+func (m *Model) Refresh() {
+	if m.hasFile() {
+		m.render()
 	}
 }
 
 // View renders the diff view
 func (m Model) View() string {
 	if m.loading {
-		return lipgloss.NewStyle().Padding(1, 2).Render("🔄 Loading diff...")
+		return lipgloss.NewStyle().Padding(1, 2).Render(a11y.Text("🔄 Loading diff..."))
 	}
 	if !m.ready || len(m.files) == 0 {
 		return lipgloss.NewStyle().Padding(1, 2).Render("No diffs available")
 	}
-	return m.viewport.View()
+	main := m.renderHeader() + "\n" + m.viewport.View()
+	sidebar := m.sidebarWidth()
+	if sidebar == 0 {
+		return main
+	}
+	mainLines := strings.Split(main, "\n")
+	tree := renderTree(m.files, m.file, m.folded, sidebar, len(mainLines))
+	sep := sepStyle().Render(a11y.Glyph("│", "|"))
+	var sb strings.Builder
+	for i, line := range mainLines {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(tree[i] + sep + " " + line)
+	}
+	return sb.String()
 }
 
 // Update handles messages for the diff view
@@ -90,25 +168,443 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
-// renderDiffs produces the colored diff output for all files
-func (m Model) renderDiffs() string {
+// MoveCursor moves the line cursor by delta rows, clamped to the file.
+func (m *Model) MoveCursor(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	m.cursor = max(0, min(m.cursor+delta, len(m.rows)-1))
+	m.refreshCursor()
+}
+
+// GotoTop moves the cursor to the first row.
+func (m *Model) GotoTop() {
+	m.MoveCursor(-len(m.rows))
+}
+
+// GotoBottom moves the cursor to the last row.
+func (m *Model) GotoBottom() {
+	m.MoveCursor(len(m.rows))
+}
+
+// PageSize is the number of rows visible at once.
+func (m Model) PageSize() int {
+	return max(m.viewport.Height(), 1)
+}
+
+// NextHunk moves the cursor to the next hunk header, continuing into the
+// following files. Collapsed files are skipped.
+func (m *Model) NextHunk() {
+	if r := m.hunkRow(m.cursor+1, 1); r >= 0 {
+		m.cursor = r
+		m.refreshCursor()
+		return
+	}
+	for f := m.file + 1; f < len(m.files); f++ {
+		if m.folded[f] || !strings.Contains(m.files[f].Patch, "@@") {
+			continue
+		}
+		m.showFile(f)
+		if r := m.hunkRow(0, 1); r >= 0 {
+			m.cursor = r
+			m.refreshCursor()
+		}
+		return
+	}
+}
+
+// PrevHunk moves the cursor to the previous hunk header, continuing into
+// the preceding files. Collapsed files are skipped.
+func (m *Model) PrevHunk() {
+	if r := m.hunkRow(m.cursor-1, -1); r >= 0 {
+		m.cursor = r
+		m.refreshCursor()
+		return
+	}
+	for f := m.file - 1; f >= 0; f-- {
+		if m.folded[f] || !strings.Contains(m.files[f].Patch, "@@") {
+			continue
+		}
+		m.showFile(f)
+		if r := m.hunkRow(len(m.rows)-1, -1); r >= 0 {
+			m.cursor = r
+			m.refreshCursor()
+		}
+		return
+	}
+}
+
+// NextFile shows the next file in the tree.
+func (m *Model) NextFile() {
+	if m.file+1 < len(m.files) {
+		m.showFile(m.file + 1)
+	}
+}
+
+// PrevFile shows the previous file in the tree.
+func (m *Model) PrevFile() {
+	if m.file > 0 {
+		m.showFile(m.file - 1)
+	}
+}
+
+// ToggleSplit switches between unified and side-by-side layouts. Split
+// takes effect only while the terminal is wide enough for it.
+func (m *Model) ToggleSplit() {
+	m.split = !m.split
+	if m.hasFile() {
+		line := m.selectedIndex()
+		m.render()
+		m.cursorToLine(line)
+	}
+}
+
+// ToggleFold collapses or expands the shown file.
+func (m *Model) ToggleFold() {
+	if len(m.files) == 0 {
+		return
+	}
+	m.folded[m.file] = !m.folded[m.file]
+	m.cursor = 0
+	m.render()
+}
+
+// AddComment adds a pending comment on the line under the cursor. Hunk
+// headers and collapsed files cannot be commented on.
+func (m *Model) AddComment(body string) bool {
+	path, line, ok := m.SelectedLine()
+	body = strings.TrimSpace(body)
+	if !ok || !Commentable(line) || body == "" {
+		return false
+	}
+	m.comments = append(m.comments, Comment{Path: path, Line: line, Body: body})
+	m.render()
+	return true
+}
+
+// RemoveComments drops the pending comments on the line under the cursor
+// and returns how many there were.
+func (m *Model) RemoveComments() int {
+	path, line, ok := m.SelectedLine()
+	if !ok {
+		return 0
+	}
+	kept := m.comments[:0]
+	for _, c := range m.comments {
+		if !c.on(path, line) {
+			kept = append(kept, c)
+		}
+	}
+	removed := len(m.comments) - len(kept)
+	m.comments = kept
+	if removed > 0 {
+		m.render()
+	}
+	return removed
+}
+
+// Comments returns the pending review comments.
+func (m Model) Comments() []Comment {
+	return m.comments
+}
+
+// ClearComments drops every pending comment, once they were submitted.
+func (m *Model) ClearComments() {
+	m.comments = nil
+	if m.hasFile() {
+		m.render()
+	}
+}
+
+// Commentable reports whether a review comment can be left on the line.
+func Commentable(l Line) bool {
+	return l.Kind == LineContext || l.Kind == LineAdded || l.Kind == LineRemoved
+}
+
+// SelectedLine returns the path of the shown file and the patch line under
+// the cursor.
+func (m Model) SelectedLine() (string, Line, bool) {
+	i := m.selectedIndex()
+	if i < 0 {
+		return "", Line{}, false
+	}
+	return m.files[m.file].Path, m.lines[i], true
+}
+
+// SelectedFileLine returns the path of the shown file and the line of its
+// new version under the cursor, 0 when the cursor is on no line.
+func (m Model) SelectedFileLine() (string, int, bool) {
+	if m.file < 0 || m.file >= len(m.files) {
+		return "", 0, false
+	}
+	line := 0
+	if i := m.selectedIndex(); i >= 0 {
+		line = newLineAt(m.lines, i)
+	}
+	return m.files[m.file].Path, line, true
+}
+
+// SelectedHunk returns the path of the shown file and the hunk under the
+// cursor as a patch, from its @@ header to the line before the next one.
+func (m Model) SelectedHunk() (string, string, bool) {
+	i := m.selectedIndex()
+	if i < 0 {
+		return "", "", false
+	}
+	start := i
+	for start > 0 && m.lines[start].Kind != LineHunk {
+		start--
+	}
 	var sb strings.Builder
-	for i, f := range m.files {
-		if i > 0 {
-			sb.WriteString("\n")
+	for j := start; j < len(m.lines); j++ {
+		l := m.lines[j]
+		if j > start && l.Kind == LineHunk {
+			break
 		}
-		sb.WriteString(renderFileHeader(f))
-		sb.WriteString("\n")
-		sb.WriteString(renderPatch(f.Patch))
+		switch l.Kind {
+		case LineAdded:
+			sb.WriteString("+")
+		case LineRemoved:
+			sb.WriteString("-")
+		case LineContext:
+			sb.WriteString(" ")
+		}
+		sb.WriteString(l.Text + "\n")
 	}
-	return sb.String()
+	return m.files[m.file].Path, sb.String(), true
+}
+
+// selectedIndex returns the index into lines of the cursor row, or -1.
+func (m Model) selectedIndex() int {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return -1
+	}
+	return m.rows[m.cursor].line()
+}
+
+// cursorToLine places the cursor on the row showing the given line.
+func (m *Model) cursorToLine(line int) {
+	for i, r := range m.rows {
+		if r.left == line || r.right == line {
+			m.cursor = i
+			break
+		}
+	}
+	m.refreshCursor()
+}
+
+// hunkRow finds the first hunk header row from start in direction step,
+// or -1.
+func (m Model) hunkRow(start, step int) int {
+	for i := start; i >= 0 && i < len(m.rows); i += step {
+		if m.lines[m.rows[i].line()].Kind == LineHunk {
+			return i
+		}
+	}
+	return -1
+}
+
+// showFile switches the main pane to file i with the cursor at the top.
+func (m *Model) showFile(i int) {
+	if i < 0 || i >= len(m.files) {
+		m.lines, m.rows, m.rendered = nil, nil, nil
+		return
+	}
+	m.file = i
+	m.cursor = 0
+	m.render()
+}
+
+// sidebarWidth is the width of the file tree, 0 when it is hidden.
+func (m Model) sidebarWidth() int {
+	if m.width < minSidebarWidth || len(m.files) < 2 {
+		return 0
+	}
+	return max(20, min(m.width/4, 36))
+}
+
+// mainWidth is the width left for the shown file.
+func (m Model) mainWidth() int {
+	if sw := m.sidebarWidth(); sw > 0 {
+		return max(m.width-sw-2, 1)
+	}
+	return max(m.width, 1)
+}
+
+// splitActive reports whether rows are laid out side by side.
+func (m Model) splitActive() bool {
+	return m.split && m.mainWidth() >= minSplitWidth
+}
+
+// renderHeader renders the shown file's title and the layout in use.
+func (m Model) renderHeader() string {
+	f := m.files[m.file]
+	mode := "unified"
+	switch {
+	case m.splitActive():
+		mode = "split"
+	case m.split:
+		mode = "unified (too narrow to split)"
+	}
+	sep := a11y.Glyph(" · ", " - ")
+	info := fmt.Sprintf("  file %d/%d%s%s", m.file+1, len(m.files), sep, mode)
+	if n := len(m.comments); n > 0 {
+		info += fmt.Sprintf("%s%d pending comment(s)", sep, n)
+	}
+	return renderFileHeader(f) + sepStyle().Render(info)
+}
+
+// hasFile reports whether a file is shown, which a PR without changes
+// doesn't have.
+func (m Model) hasFile() bool {
+	return m.ready && len(m.files) > 0
+}
+
+// render lays out and renders every row of the shown file.
+func (m *Model) render() {
+	m.viewport.SetWidth(m.mainWidth())
+	if len(m.files) == 0 {
+		m.lines, m.rows, m.rendered = nil, nil, nil
+		return
+	}
+	f := m.files[m.file]
+	if m.folded[m.file] {
+		label := "Collapsed"
+		if IsGenerated(f.Path) {
+			label = "Generated file collapsed"
+		}
+		m.lines, m.rows, m.notes, m.cursor = nil, nil, nil, 0
+		m.rendered = []string{sepStyle().Render(fmt.Sprintf("%s %s %s", a11y.Glyph("⊟", "[-]"), label, formatStats(f.Additions, f.Deletions)))}
+		m.refreshCursor()
+		return
+	}
+
+	m.lines = parsePatch(f.Patch)
+	if m.splitActive() {
+		m.rows = splitRows(m.lines)
+	} else {
+		m.rows = unifiedRows(m.lines)
+	}
+	texts := make([]string, len(m.lines))
+	for i, l := range m.lines {
+		texts[i] = strings.ReplaceAll(l.Text, "\t", "    ")
+	}
+	masks := wordMasks(m.lines, texts)
+	lexer := lexerFor(f.Path)
+	width := m.mainWidth() - 2 // room for the cursor marker
+	m.rendered = make([]string, len(m.rows))
+	m.notes = make([][]string, len(m.rows))
+	for i, r := range m.rows {
+		m.rendered[i] = m.renderRow(r, texts, masks, lexer, width)
+		m.notes[i] = m.renderNotes(f.Path, r, width)
+	}
+	m.cursor = max(0, min(m.cursor, len(m.rows)-1))
+	m.refreshCursor()
+}
+
+// refreshCursor marks the cursor row and scrolls it into view. Rows are
+// rendered once per file, so moving the cursor only swaps markers.
+func (m *Model) refreshCursor() {
+	content := make([]string, 0, len(m.rendered))
+	cursorLine := 0
+	for i, line := range m.rendered {
+		marker := "  "
+		if i == m.cursor && len(m.rows) > 0 {
+			marker = cursorStyle().Render(a11y.Glyph("▸ ", "> "))
+			cursorLine = len(content)
+		}
+		content = append(content, marker+line)
+		if i < len(m.notes) {
+			for _, note := range m.notes[i] {
+				content = append(content, "  "+note)
+			}
+		}
+	}
+	m.viewport.SetContentLines(content)
+	if h := m.PageSize(); cursorLine < m.viewport.YOffset() {
+		m.viewport.SetYOffset(cursorLine)
+	} else if cursorLine >= m.viewport.YOffset()+h {
+		m.viewport.SetYOffset(cursorLine - h + 1)
+	}
+}
+
+// renderNotes renders the pending comments on the lines of a row.
+func (m Model) renderNotes(path string, r row, width int) []string {
+	if len(m.comments) == 0 {
+		return nil
+	}
+	style := lipgloss.NewStyle().Foreground(colors.Current().Accent)
+	var notes []string
+	indexes := []int{r.left}
+	if r.right != r.left {
+		indexes = append(indexes, r.right)
+	}
+	for _, i := range indexes {
+		if i < 0 {
+			continue
+		}
+		for _, c := range m.comments {
+			if c.on(path, m.lines[i]) {
+				notes = append(notes, ansi.Truncate(style.Render(a11y.Text("           💬 pending: ")+c.Body), width, a11y.Ellipsis()))
+			}
+		}
+	}
+	return notes
+}
+
+// renderRow renders one display row within width cells.
+func (m Model) renderRow(r row, texts []string, masks [][]bool, lexer chroma.Lexer, width int) string {
+	l := m.lines[r.line()]
+	switch l.Kind {
+	case LineHunk:
+		return ansi.Truncate(hunkStyle().Render(l.Text), width, a11y.Ellipsis())
+	case LineMeta:
+		return ansi.Truncate(sepStyle().Render(l.Text), width, a11y.Ellipsis())
+	}
+	cell := func(i int, number func(Line) int) string {
+		if i < 0 {
+			return ""
+		}
+		return renderCell(m.lines[i], texts[i], masks[i], lexer, lineNumber(number(m.lines[i])))
+	}
+	if !m.splitActive() {
+		gutter := lineNumber(l.Old) + lineNumber(l.New)
+		return ansi.Truncate(renderCell(l, texts[r.left], masks[r.left], lexer, gutter), width, a11y.Ellipsis())
+	}
+	half := (width - 1) / 2
+	left := cell(r.left, func(l Line) int { return l.Old })
+	right := cell(r.right, func(l Line) int { return l.New })
+	return pad(left, half) + sepStyle().Render(a11y.Glyph("│", "|")) + ansi.Truncate(right, width-half-1, a11y.Ellipsis())
+}
+
+// renderCell renders a code line after its line number gutter, colored by
+// kind, syntax and changed words.
+func renderCell(l Line, text string, mask []bool, lexer chroma.Lexer, gutter string) string {
+	base := lipgloss.NewStyle()
+	marker := " "
+	var bg color.Color
+	switch l.Kind {
+	case LineAdded:
+		base, marker, bg = addStyle(), "+", colors.Current().Diff.AddedWord
+	case LineRemoved:
+		base, marker, bg = delStyle(), "-", colors.Current().Diff.RemovedWord
+	}
+	return sepStyle().Render(gutter) + base.Render(marker) + highlight(lexer, text, base, mask, bg)
+}
+
+// lineNumber formats a gutter line number, blank for 0.
+func lineNumber(n int) string {
+	if n == 0 {
+		return "     "
+	}
+	return fmt.Sprintf("%4d ", n)
 }
 
 // renderFileHeader renders the file name with addition/deletion counts
 func renderFileHeader(f FileDiff) string {
 	stats := formatStats(f.Additions, f.Deletions)
-	title := headerStyle.Render(fmt.Sprintf("📄 %s  %s", f.Path, stats))
-	sep := sepStyle.Render(strings.Repeat("─", 40))
+	title := headerStyle.Render(a11y.Text(fmt.Sprintf("📄 %s  %s", f.Path, stats)))
+	sep := sepStyle().Render(strings.Repeat(a11y.Glyph("─", "-"), 40))
 	return title + "\n" + sep
 }
 
@@ -149,13 +645,13 @@ func renderDiffLine(line string) string {
 	switch {
 	case strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- "):
 		// file header lines in unified diff — render dimmed
-		return hunkStyle.Render(line)
+		return hunkStyle().Render(line)
 	case strings.HasPrefix(line, "+"):
-		return addStyle.Render(line)
+		return addStyle().Render(line)
 	case strings.HasPrefix(line, "-"):
-		return delStyle.Render(line)
+		return delStyle().Render(line)
 	case strings.HasPrefix(line, "@@"):
-		return hunkStyle.Render(line)
+		return hunkStyle().Render(line)
 	default:
 		return line
 	}
@@ -3,6 +3,9 @@ package diffview
 import (
 	"strings"
 	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestParseUnifiedDiff_BasicDiff(t *testing.T) {
@@ -152,6 +155,18 @@ func TestModel_SetDiffs(t *testing.T) {
 	}
 }
 
+func TestModel_NoFilesSurvivesRerender(t *testing.T) {
+	m := New(80, 24)
+	m.SetDiffs(nil)
+	m.SetSize(100, 30)
+	m.Refresh()
+	m.ToggleSplit()
+	m.ClearComments()
+	if view := m.View(); !strings.Contains(view, "No diffs available") {
+		t.Errorf("expected empty state for a PR without changes, got %q", view)
+	}
+}
+
 func TestModel_SetLoading_ThenView(t *testing.T) {
 	m := New(80, 24)
 	m.SetLoading()
@@ -198,3 +213,267 @@ func TestExtractPath(t *testing.T) {
 		}
 	}
 }
+
+const twoFileDiff = `diff --git a/src/auth/handler.go b/src/auth/handler.go
+--- a/src/auth/handler.go
++++ b/src/auth/handler.go
+@@ -15,4 +15,5 @@ func HandleAuth(...)
+ func HandleAuth(w http.ResponseWriter) {
+-	token := r.Header.Get("Authorization")
++	token, err := extractToken(r)
++	return
+ }
+@@ -40,2 +41,2 @@
+-	log.Print("done")
++	log.Printf("done %s", id)
+ }
+diff --git a/go.sum b/go.sum
+--- a/go.sum
++++ b/go.sum
+@@ -1 +1 @@
+-a v1
++a v2
+diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,2 +1,2 @@
+-package old
++package main
+`
+
+func TestParsePatch_NumbersLines(t *testing.T) {
+	lines := parsePatch(ParseUnifiedDiff(twoFileDiff)[0].Patch)
+	if len(lines) != 10 {
+		t.Fatalf("expected 10 lines without file headers, got %d: %+v", len(lines), lines)
+	}
+	want := []Line{
+		{Kind: LineHunk, Text: "@@ -15,4 +15,5 @@ func HandleAuth(...)"},
+		{Kind: LineContext, Old: 15, New: 15, Text: "func HandleAuth(w http.ResponseWriter) {"},
+		{Kind: LineRemoved, Old: 16, Text: "\ttoken := r.Header.Get(\"Authorization\")"},
+		{Kind: LineAdded, New: 16, Text: "\ttoken, err := extractToken(r)"},
+		{Kind: LineAdded, New: 17, Text: "\treturn"},
+		{Kind: LineContext, Old: 17, New: 18, Text: "}"},
+		{Kind: LineHunk, Text: "@@ -40,2 +41,2 @@"},
+		{Kind: LineRemoved, Old: 40, Text: "\tlog.Print(\"done\")"},
+	}
+	for i, w := range want {
+		if lines[i] != w {
+			t.Errorf("line %d = %+v, want %+v", i, lines[i], w)
+		}
+	}
+	if lines[2].Side() != "LEFT" || lines[2].Number() != 16 || lines[4].Side() != "RIGHT" || lines[4].Number() != 17 {
+		t.Errorf("unexpected sides %s:%d %s:%d", lines[2].Side(), lines[2].Number(), lines[4].Side(), lines[4].Number())
+	}
+}
+
+func TestSplitRows_PairsChanges(t *testing.T) {
+	lines := parsePatch(ParseUnifiedDiff(twoFileDiff)[0].Patch)
+	rows := splitRows(lines)
+	want := []row{{0, -1}, {1, 1}, {2, 3}, {-1, 4}, {5, 5}, {6, -1}, {7, 8}, {9, 9}}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestModel_HunkAndFileNavigation(t *testing.T) {
+	m := New(80, 24)
+	m.SetSize(120, 20)
+	m.SetDiffs(ParseUnifiedDiff(twoFileDiff))
+
+	m.NextHunk()
+	if path, line, _ := m.SelectedLine(); path != "src/auth/handler.go" || line.Text != "@@ -40,2 +41,2 @@" {
+		t.Fatalf("expected the second hunk, got %s %+v", path, line)
+	}
+	// go.sum is collapsed, so the next hunk is in main.go
+	m.NextHunk()
+	if path, line, _ := m.SelectedLine(); path != "main.go" || line.Kind != LineHunk {
+		t.Fatalf("expected main.go's hunk past the collapsed lockfile, got %s %+v", path, line)
+	}
+	m.PrevHunk()
+	if path, line, _ := m.SelectedLine(); path != "src/auth/handler.go" || line.Text != "@@ -40,2 +41,2 @@" {
+		t.Fatalf("expected to return to the last hunk of the first file, got %s %+v", path, line)
+	}
+
+	m.GotoTop()
+	m.MoveCursor(2)
+	if _, line, _ := m.SelectedLine(); line.Kind != LineRemoved || line.Old != 16 {
+		t.Errorf("expected the removed line under the cursor, got %+v", line)
+	}
+
+	m.NextFile()
+	if _, _, ok := m.SelectedLine(); ok {
+		t.Error("a collapsed file should have no selectable line")
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Generated file collapsed") {
+		t.Errorf("expected the collapsed lockfile notice, got:\n%s", view)
+	}
+	m.ToggleFold()
+	if _, line, ok := m.SelectedLine(); !ok || line.Kind != LineHunk {
+		t.Errorf("expected the expanded lockfile's hunk, got %+v", line)
+	}
+	m.NextFile()
+	m.NextFile()
+	if path, _, _ := m.SelectedLine(); path != "main.go" {
+		t.Errorf("expected to stay on the last file, got %s", path)
+	}
+}
+
+func TestModel_SelectedFileLine(t *testing.T) {
+	m := New(80, 24)
+	m.SetSize(120, 20)
+	files := ParseUnifiedDiff(twoFileDiff)
+	m.SetDiffs(files)
+
+	// A removed line points at the line that replaced it
+	m.MoveCursor(2)
+	if path, line, ok := m.SelectedFileLine(); !ok || path != "src/auth/handler.go" || line != 16 {
+		t.Errorf("expected handler.go:16, got %s:%d", path, line)
+	}
+	m.NextHunk()
+	if _, line, _ := m.SelectedFileLine(); line != 41 {
+		t.Errorf("expected a hunk header to point at its first change, got %d", line)
+	}
+	if path, hunk, ok := m.SelectedHunk(); !ok || path != "src/auth/handler.go" ||
+		hunk != "@@ -40,2 +41,2 @@\n-\tlog.Print(\"done\")\n+\tlog.Printf(\"done %s\", id)\n }\n" {
+		t.Errorf("expected the second hunk as a patch, got %s %q", path, hunk)
+	}
+	if line := FirstChangedLine(files[0]); line != 16 {
+		t.Errorf("expected the first change at 16, got %d", line)
+	}
+	if line := FirstChangedLine(FileDiff{Path: "new.txt"}); line != 0 {
+		t.Errorf("expected 0 for a file without hunks, got %d", line)
+	}
+}
+
+func TestModel_SidebarAndSplit(t *testing.T) {
+	m := New(80, 24)
+	m.SetSize(160, 20)
+	m.SetDiffs(ParseUnifiedDiff(twoFileDiff))
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"src/auth/", "▸ handler.go +3 -2", "⊟ go.sum +1 -1", "main.go +1 -1", "file 1/3 · unified"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the view, got:\n%s", want, view)
+		}
+	}
+
+	m.MoveCursor(2)
+	m.ToggleSplit()
+	view = ansi.Strip(m.View())
+	if !strings.Contains(view, "file 1/3 · split") {
+		t.Errorf("expected split mode, got:\n%s", view)
+	}
+	if !strings.Contains(view, "-    token := r.Header.Get") || !strings.Contains(view, "│  16 +    token, err") {
+		t.Errorf("expected the removed and added lines side by side, got:\n%s", view)
+	}
+	if _, line, _ := m.SelectedLine(); line.Kind != LineAdded || line.New != 16 {
+		t.Errorf("expected the cursor to stay on the changed pair, got %+v", line)
+	}
+
+	m.SetSize(90, 20)
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "too narrow to split") {
+		t.Errorf("expected split to fall back to unified when narrow, got:\n%s", view)
+	}
+	m.SetSize(60, 20)
+	if view := ansi.Strip(m.View()); strings.Contains(view, "handler.go +3 -2") {
+		t.Errorf("expected no sidebar on a narrow terminal, got:\n%s", view)
+	}
+}
+
+func TestChangedBytes_MarksChangedWords(t *testing.T) {
+	oldMask, newMask := changedBytes(`log.Print("done")`, `log.Printf("done %s", id)`)
+	marked := func(s string, mask []bool) string {
+		var sb strings.Builder
+		for i := range s {
+			if i < len(mask) && mask[i] {
+				sb.WriteByte(s[i])
+			}
+		}
+		return sb.String()
+	}
+	if got := marked(`log.Print("done")`, oldMask); got != "Print" {
+		t.Errorf("old changed bytes = %q", got)
+	}
+	if got := marked(`log.Printf("done %s", id)`, newMask); got != "Printf%s,id" {
+		t.Errorf("new changed bytes = %q", got)
+	}
+	if o, n := changedBytes("alpha", "beta"); o != nil || n != nil {
+		t.Error("lines with nothing in common should not be word-highlighted")
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	for path, want := range map[string]bool{
+		"go.sum":                   true,
+		"web/package-lock.json":    true,
+		"assets/app.min.js":        true,
+		"api/v1/service.pb.go":     true,
+		"vendor/github.com/x/y.go": true,
+		"ui/__snapshots__/a.snap":  true,
+		"internal/data/session.go": false,
+		"docs/vendoring.md":        false,
+	} {
+		if got := IsGenerated(path); got != want {
+			t.Errorf("IsGenerated(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestHighlight_ColorsSyntax(t *testing.T) {
+	colored := highlight(lexerFor("main.go"), "return 42 // done", lipgloss.NewStyle(), nil, nil)
+	if ansi.Strip(colored) != "return 42 // done" {
+		t.Errorf("highlighting must keep the text, got %q", ansi.Strip(colored))
+	}
+	if colored == "return 42 // done" {
+		t.Error("expected Go syntax to be colored")
+	}
+	if plain := highlight(lexerFor("notes.unknownext"), "return 42", lipgloss.NewStyle(), nil, nil); ansi.Strip(plain) != "return 42" {
+		t.Errorf("unknown languages should render as plain text, got %q", plain)
+	}
+}
+
+func TestModel_PendingComments(t *testing.T) {
+	m := New(80, 24)
+	m.SetSize(120, 20)
+	m.SetDiffs(ParseUnifiedDiff(twoFileDiff))
+
+	if m.AddComment("on a hunk header") {
+		t.Error("hunk headers should not take comments")
+	}
+	m.MoveCursor(3)
+	if !m.AddComment("  handle err  ") || m.AddComment("   ") {
+		t.Fatal("expected a comment on the added line and none for a blank body")
+	}
+	comments := m.Comments()
+	if len(comments) != 1 || comments[0].Path != "src/auth/handler.go" || comments[0].Body != "handle err" ||
+		comments[0].Line.Side() != "RIGHT" || comments[0].Line.Number() != 16 {
+		t.Fatalf("unexpected comments %+v", comments)
+	}
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "💬 pending: handle err") || !strings.Contains(view, "1 pending comment(s)") {
+		t.Errorf("expected the pending comment inline, got:\n%s", view)
+	}
+
+	// The comment follows its line into split mode and stays put across files
+	m.ToggleSplit()
+	m.NextFile()
+	m.PrevFile()
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "💬 pending: handle err") {
+		t.Errorf("expected the comment to survive layout and file changes, got:\n%s", view)
+	}
+
+	m.cursorToLine(3)
+	if n := m.RemoveComments(); n != 1 || len(m.Comments()) != 0 {
+		t.Errorf("expected the comment to be discarded, got %d left %+v", n, m.Comments())
+	}
+	m.AddComment("again")
+	m.SetDiffs(ParseUnifiedDiff(twoFileDiff))
+	if len(m.Comments()) != 0 {
+		t.Error("loading another diff should drop pending comments")
+	}
+}
@@ -0,0 +1,49 @@
+package diffview
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// generatedNames are lockfiles and other files written by tools, which are
+// rarely worth reading line by line in a review.
+var generatedNames = map[string]bool{
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"go.sum":            true,
+	"Cargo.lock":        true,
+	"Gemfile.lock":      true,
+	"poetry.lock":       true,
+	"composer.lock":     true,
+	"Pipfile.lock":      true,
+	"flake.lock":        true,
+}
+
+// generatedSuffixes match generated code and bundled or snapshot output.
+var generatedSuffixes = []string{
+	".min.js", ".min.css", ".map", ".pb.go", "_pb2.py", ".gen.go",
+	"_generated.go", ".generated.ts", ".snap",
+}
+
+// generatedDirs are directories of vendored or built files.
+var generatedDirs = []string{"vendor/", "node_modules/", "dist/"}
+
+// IsGenerated reports whether path looks like a lockfile or generated
+// file. The diff view collapses these by default.
+func IsGenerated(path string) bool {
+	if generatedNames[filepath.Base(path)] {
+		return true
+	}
+	for _, suffix := range generatedSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	for _, dir := range generatedDirs {
+		if strings.HasPrefix(path, dir) || strings.Contains(path, "/"+dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package diffview
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LineKind classifies a line of a file's patch
+type LineKind int
+
+const (
+	LineContext LineKind = iota
+	LineAdded
+	LineRemoved
+	LineHunk
+	LineMeta // "\ No newline at end of file" and similar markers
+)
+
+// Line is one line of a file's patch with its position in the old and new
+// versions of the file
+type Line struct {
+	Kind LineKind
+	Old  int    // line number in the old file, 0 for added lines
+	New  int    // line number in the new file, 0 for removed lines
+	Text string // content without the leading +, - or space
+}
+
+// Side reports the side of the diff the line belongs to, as GitHub names
+// it for review comments: LEFT for removed lines, RIGHT otherwise.
+func (l Line) Side() string {
+	if l.Kind == LineRemoved {
+		return "LEFT"
+	}
+	return "RIGHT"
+}
+
+// Number returns the line number on the line's side.
+func (l Line) Number() int {
+	if l.Kind == LineRemoved {
+		return l.Old
+	}
+	return l.New
+}
+
+// parsePatch splits a file's patch into lines numbered from the hunk
+// headers. The ---/+++ file headers are dropped; the view shows the path.
+func parsePatch(patch string) []Line {
+	var lines []Line
+	oldNo, newNo := 0, 0
+	inHunk := false
+	for _, raw := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(raw, "@@"):
+			oldNo, newNo = parseHunkHeader(raw)
+			inHunk = true
+			lines = append(lines, Line{Kind: LineHunk, Text: raw})
+		case !inHunk:
+			// file headers before the first hunk
+		case strings.HasPrefix(raw, "+"):
+			lines = append(lines, Line{Kind: LineAdded, New: newNo, Text: raw[1:]})
+			newNo++
+		case strings.HasPrefix(raw, "-"):
+			lines = append(lines, Line{Kind: LineRemoved, Old: oldNo, Text: raw[1:]})
+			oldNo++
+		case strings.HasPrefix(raw, "\\"):
+			lines = append(lines, Line{Kind: LineMeta, Text: raw})
+		default:
+			lines = append(lines, Line{Kind: LineContext, Old: oldNo, New: newNo, Text: strings.TrimPrefix(raw, " ")})
+			oldNo++
+			newNo++
+		}
+	}
+	// A trailing newline in the patch leaves an empty context line behind
+	if n := len(lines); n > 0 && lines[n-1].Kind == LineContext && lines[n-1].Text == "" {
+		lines = lines[:n-1]
+	}
+	return lines
+}
+
+// newLineAt returns the line of the new file that lines[i] sits at: its own
+// number for context and added lines, and for removed lines the line that
+// now follows them. It returns 0 when the hunk leaves nothing to point at.
+func newLineAt(lines []Line, i int) int {
+	start := i
+	if start < len(lines) && lines[start].Kind == LineHunk {
+		start++
+	}
+	for j := start; j < len(lines) && lines[j].Kind != LineHunk; j++ {
+		if lines[j].New > 0 {
+			return lines[j].New
+		}
+	}
+	for j := i - 1; j >= 0 && lines[j].Kind != LineHunk; j-- {
+		if lines[j].New > 0 {
+			return lines[j].New + 1
+		}
+	}
+	return 0
+}
+
+// FirstChangedLine returns the line of the new file at the first change in
+// f's patch, or 0 when the patch has no hunks.
+func FirstChangedLine(f FileDiff) int {
+	lines := parsePatch(f.Patch)
+	for i, l := range lines {
+		if l.Kind == LineAdded || l.Kind == LineRemoved {
+			return newLineAt(lines, i)
+		}
+	}
+	return 0
+}
+
+// parseHunkHeader returns the starting old and new line numbers of a
+// "@@ -a,b +c,d @@" header.
+func parseHunkHeader(header string) (oldStart, newStart int) {
+	for _, field := range strings.Fields(header) {
+		if len(field) < 2 {
+			continue
+		}
+		start, _, _ := strings.Cut(field[1:], ",")
+		n, err := strconv.Atoi(start)
+		if err != nil {
+			continue
+		}
+		switch field[0] {
+		case '-':
+			oldStart = n
+		case '+':
+			newStart = n
+		}
+	}
+	return oldStart, newStart
+}
+
+// row is one display row referencing lines by index, -1 meaning empty. In
+// unified mode only left is set; in split mode removed lines sit on the
+// left beside the added lines that replaced them, and context lines fill
+// both sides.
+type row struct {
+	left, right int
+}
+
+// unifiedRows lays out one row per line.
+func unifiedRows(lines []Line) []row {
+	rows := make([]row, len(lines))
+	for i := range lines {
+		rows[i] = row{left: i, right: -1}
+	}
+	return rows
+}
+
+// splitRows pairs each block of removed lines with the added lines that
+// follow it.
+func splitRows(lines []Line) []row {
+	var rows []row
+	for i := 0; i < len(lines); {
+		switch lines[i].Kind {
+		case LineContext:
+			rows = append(rows, row{left: i, right: i})
+			i++
+		case LineRemoved, LineAdded:
+			var dels, adds []int
+			for ; i < len(lines) && lines[i].Kind == LineRemoved; i++ {
+				dels = append(dels, i)
+			}
+			for ; i < len(lines) && lines[i].Kind == LineAdded; i++ {
+				adds = append(adds, i)
+			}
+			for j := 0; j < len(dels) || j < len(adds); j++ {
+				r := row{left: -1, right: -1}
+				if j < len(dels) {
+					r.left = dels[j]
+				}
+				if j < len(adds) {
+					r.right = adds[j]
+				}
+				rows = append(rows, r)
+			}
+		default:
+			rows = append(rows, row{left: i, right: -1})
+			i++
+		}
+	}
+	return rows
+}
+
+// line returns the line a row stands for: the new side when it has one.
+func (r row) line() int {
+	if r.right >= 0 {
+		return r.right
+	}
+	return r.left
+}
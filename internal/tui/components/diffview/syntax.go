@@ -0,0 +1,82 @@
+package diffview
+
+import (
+	"image/color"
+	"path/filepath"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+)
+
+// lexerFor picks a syntax lexer from the file's name or extension, or nil
+// when the language is unknown.
+func lexerFor(path string) chroma.Lexer {
+	lexer := lexers.Match(filepath.Base(path))
+	if lexer == nil {
+		return nil
+	}
+	return chroma.Coalesce(lexer)
+}
+
+// highlight renders one line of code. Keywords, strings, comments and
+// numbers get their syntax colors, other text the base style, and bytes
+// set in mask the bg background. Lines are tokenized on their own, so a
+// construct spanning lines is only colored where it is recognizable.
+func highlight(lexer chroma.Lexer, text string, base lipgloss.Style, mask []bool, bg color.Color) string {
+	type span struct {
+		text  string
+		style lipgloss.Style
+	}
+	var spans []span
+	if lexer != nil {
+		if it, err := lexer.Tokenise(nil, text); err == nil {
+			for _, tok := range it.Tokens() {
+				spans = append(spans, span{strings.TrimRight(tok.Value, "\n"), tokenStyle(tok.Type, base)})
+			}
+		}
+	}
+	if spans == nil {
+		spans = []span{{text, base}}
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for _, s := range spans {
+		// Split each token where the changed-word mask flips
+		for len(s.text) > 0 && pos < len(text) {
+			changed := pos < len(mask) && mask[pos]
+			n := 1
+			for n < len(s.text) && pos+n < len(text) && (pos+n < len(mask) && mask[pos+n]) == changed {
+				n++
+			}
+			style := s.style
+			if changed {
+				style = style.Background(bg)
+			}
+			sb.WriteString(style.Render(s.text[:n]))
+			s.text = s.text[n:]
+			pos += n
+		}
+	}
+	return sb.String()
+}
+
+// tokenStyle colors a token by its category.
+func tokenStyle(t chroma.TokenType, base lipgloss.Style) lipgloss.Style {
+	c := colors.Current().Diff
+	switch {
+	case t.InCategory(chroma.Keyword):
+		return base.Foreground(c.Keyword)
+	case t.InCategory(chroma.Comment):
+		return base.Foreground(c.Comment).Italic(true)
+	case t.InSubCategory(chroma.LiteralString):
+		return base.Foreground(c.String)
+	case t.InSubCategory(chroma.LiteralNumber):
+		return base.Foreground(c.Number)
+	default:
+		return base
+	}
+}
@@ -0,0 +1,100 @@
+package diffview
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+)
+
+// treeEntry is one sidebar row: a directory heading or a file.
+type treeEntry struct {
+	dir  string
+	file int // index into files, -1 for directory rows
+}
+
+// treeEntries lists files under a heading for each directory, in diff
+// order. Diffs list paths sorted, so files of a directory stay together.
+func treeEntries(files []FileDiff) []treeEntry {
+	var entries []treeEntry
+	prevDir := ""
+	for i, f := range files {
+		dir := path.Dir(f.Path)
+		if dir == "." {
+			dir = ""
+		}
+		if dir != prevDir && dir != "" {
+			entries = append(entries, treeEntry{dir: dir, file: -1})
+		}
+		prevDir = dir
+		entries = append(entries, treeEntry{dir: dir, file: i})
+	}
+	return entries
+}
+
+// renderTree renders the file sidebar, scrolled to keep the selected file
+// visible. Every line is padded to width.
+func renderTree(files []FileDiff, selected int, folded map[int]bool, width, height int) []string {
+	entries := treeEntries(files)
+	sel := 0
+	for i, e := range entries {
+		if e.file == selected {
+			sel = i
+		}
+	}
+	offset := 0
+	if sel >= height {
+		offset = sel - height + 1
+	}
+
+	subtle := lipgloss.NewStyle().Foreground(colors.Current().Subtle)
+	var lines []string
+	for i := offset; i < len(entries) && len(lines) < height; i++ {
+		e := entries[i]
+		var line string
+		if e.file < 0 {
+			line = subtle.Render(ansi.Truncate(e.dir+"/", width, a11y.Ellipsis()))
+		} else {
+			f := files[e.file]
+			marker := "  "
+			name := path.Base(f.Path)
+			if e.file == selected {
+				marker = a11y.Glyph("▸ ", "> ")
+				name = headerStyle.Render(name)
+			}
+			if folded[e.file] {
+				name = subtle.Render(a11y.Glyph("⊟ ", "[-] ")) + name
+			}
+			if e.dir != "" {
+				marker = " " + marker
+			}
+			stats := treeStats(f)
+			avail := width - lipgloss.Width(marker) - lipgloss.Width(stats) - 1
+			line = marker + ansi.Truncate(name, max(avail, 1), a11y.Ellipsis()) + " " + stats
+		}
+		lines = append(lines, pad(line, width))
+	}
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+	return lines
+}
+
+// treeStats renders a file's counts as colored "+a -d".
+func treeStats(f FileDiff) string {
+	return addStyle().Render(fmt.Sprintf("+%d", f.Additions)) + " " +
+		delStyle().Render(fmt.Sprintf("-%d", f.Deletions))
+}
+
+// pad truncates or space-fills s to exactly width cells.
+func pad(s string, width int) string {
+	s = ansi.Truncate(s, width, "")
+	if w := lipgloss.Width(s); w < width {
+		s += strings.Repeat(" ", width-w)
+	}
+	return s
+}
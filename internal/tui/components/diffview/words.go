@@ -0,0 +1,123 @@
+package diffview
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxWordDiffCells bounds the LCS table built for one pair of lines, so a
+// minified line does not stall rendering.
+const maxWordDiffCells = 40000
+
+// wordMasks marks, for each line, the bytes of words that changed against
+// the line it replaced. Removed and added lines are paired in order within
+// each change block; unpaired lines and pairs sharing nothing get no mask.
+func wordMasks(lines []Line, texts []string) [][]bool {
+	masks := make([][]bool, len(lines))
+	for i := 0; i < len(lines); {
+		if lines[i].Kind != LineRemoved {
+			i++
+			continue
+		}
+		var dels, adds []int
+		for ; i < len(lines) && lines[i].Kind == LineRemoved; i++ {
+			dels = append(dels, i)
+		}
+		for ; i < len(lines) && lines[i].Kind == LineAdded; i++ {
+			adds = append(adds, i)
+		}
+		for j := 0; j < len(dels) && j < len(adds); j++ {
+			masks[dels[j]], masks[adds[j]] = changedBytes(texts[dels[j]], texts[adds[j]])
+		}
+	}
+	return masks
+}
+
+// changedBytes diffs two lines word by word and marks the bytes of the
+// words that are not common to both.
+func changedBytes(old, new string) (oldMask, newMask []bool) {
+	a, b := splitWords(old), splitWords(new)
+	if len(a) == 0 || len(b) == 0 || len(a)*len(b) > maxWordDiffCells {
+		return nil, nil
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	commonA, commonB := make([]bool, len(a)), make([]bool, len(b))
+	shared := false
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			commonA[i], commonB[j] = true, true
+			if strings.TrimSpace(a[i]) != "" {
+				shared = true
+			}
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	if !shared {
+		return nil, nil
+	}
+	return wordMask(a, commonA), wordMask(b, commonB)
+}
+
+// wordMask expands per-word commonality into a per-byte changed mask.
+func wordMask(words []string, common []bool) []bool {
+	var mask []bool
+	for i, w := range words {
+		changed := !common[i] && strings.TrimSpace(w) != ""
+		for range len(w) {
+			mask = append(mask, changed)
+		}
+	}
+	return mask
+}
+
+// splitWords cuts a line into runs of identifier characters, runs of
+// whitespace and single punctuation characters.
+func splitWords(s string) []string {
+	var words []string
+	start := 0
+	class := func(r rune) int {
+		switch {
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		default:
+			return 0
+		}
+	}
+	prev := -1
+	for i, r := range s {
+		c := class(r)
+		if i > start && (c != prev || c == 0) {
+			words = append(words, s[start:i])
+			start = i
+		}
+		prev = c
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
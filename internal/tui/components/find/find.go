@@ -0,0 +1,228 @@
+// Package find searches the rendered lines of the read-only log and
+// conversation views: it highlights matches of a query, steps between them
+// and can collapse the lines to the matching ones, as grep -C does.
+package find
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+)
+
+// Separator stands for lines left out between filtered groups.
+const Separator = "--"
+
+// Match is one occurrence of the query: a line and the byte range of the
+// match in that line's plain text.
+type Match struct {
+	Line       int
+	Start, End int
+}
+
+// Model holds a query and its matches in the lines last searched.
+type Model struct {
+	query   string
+	re      *regexp.Regexp
+	literal bool   // the query isn't a valid regexp and is matched as text
+	reason  string // why it isn't
+	filter  bool
+	matches []Match
+	current int
+}
+
+// compile turns a query into a regexp: the query itself when it is a valid
+// regular expression, else the query as literal text. Like vim's
+// smartcase, it ignores case unless the query has a capital letter.
+func compile(query string) (re *regexp.Regexp, literal bool, reason string) {
+	flags := ""
+	if !strings.ContainsFunc(query, unicode.IsUpper) {
+		flags = "(?i)"
+	}
+	re, err := regexp.Compile(flags + query)
+	if err == nil {
+		return re, false, ""
+	}
+	reason = err.Error()
+	var se *syntax.Error
+	if errors.As(err, &se) {
+		reason = se.Code.String()
+	}
+	return regexp.MustCompile(flags + regexp.QuoteMeta(query)), true, reason
+}
+
+// SetQuery replaces the query; an empty one clears the search. Matches are
+// found by the next call to Find.
+func (m *Model) SetQuery(query string) {
+	m.query = query
+	m.re, m.literal, m.reason = nil, false, ""
+	m.matches = nil
+	m.current = 0
+	if query != "" {
+		m.re, m.literal, m.reason = compile(query)
+	}
+}
+
+// Clear drops the query and leaves filter mode.
+func (m *Model) Clear() {
+	*m = Model{}
+}
+
+// Query returns the query.
+func (m Model) Query() string {
+	return m.query
+}
+
+// Active reports whether there is a query.
+func (m Model) Active() bool {
+	return m.re != nil
+}
+
+// Literal reports whether the query is matched as text because it isn't a
+// valid regular expression, and why.
+func (m Model) Literal() (bool, string) {
+	return m.literal, m.reason
+}
+
+// SetFilter turns filter mode, showing only matching lines, on or off.
+func (m *Model) SetFilter(on bool) {
+	m.filter = on
+}
+
+// Filtering reports whether filter mode is on with a query to filter by.
+func (m Model) Filtering() bool {
+	return m.filter && m.re != nil
+}
+
+// FilterMode reports whether filter mode is on, with or without a query.
+func (m Model) FilterMode() bool {
+	return m.filter
+}
+
+// Find finds the query's matches in lines, keeping the current match
+// where it was when it still exists.
+func (m *Model) Find(lines []string) {
+	m.matches = nil
+	if m.re == nil {
+		return
+	}
+	for i, l := range lines {
+		for _, loc := range m.re.FindAllStringIndex(ansi.Strip(l), -1) {
+			if loc[1] > loc[0] {
+				m.matches = append(m.matches, Match{Line: i, Start: loc[0], End: loc[1]})
+			}
+		}
+	}
+	if m.current >= len(m.matches) {
+		m.current = 0
+	}
+}
+
+// Count returns the number of matches.
+func (m Model) Count() int {
+	return len(m.matches)
+}
+
+// Current returns the current match, if there are any.
+func (m Model) Current() (Match, bool) {
+	if len(m.matches) == 0 {
+		return Match{}, false
+	}
+	return m.matches[m.current], true
+}
+
+// SeekFrom makes the first match on or after line current, wrapping to the
+// first match when there is none below.
+func (m *Model) SeekFrom(line int) (Match, bool) {
+	m.current = 0
+	for i, match := range m.matches {
+		if match.Line >= line {
+			m.current = i
+			break
+		}
+	}
+	return m.Current()
+}
+
+// Next moves the current match by delta, wrapping around the ends.
+func (m *Model) Next(delta int) (Match, bool) {
+	if n := len(m.matches); n > 0 {
+		m.current = ((m.current+delta)%n + n) % n
+	}
+	return m.Current()
+}
+
+// Counter describes the position in the matches, e.g. "3/17".
+func (m Model) Counter() string {
+	if len(m.matches) == 0 {
+		return "no matches"
+	}
+	return fmt.Sprintf("%d/%d", m.current+1, len(m.matches))
+}
+
+// Highlight returns lines with the matches found in them highlighted, the
+// current one more strongly. The rest of each line keeps its styling.
+func (m Model) Highlight(lines []string) []string {
+	if len(m.matches) == 0 {
+		return lines
+	}
+	matchStyle := lipgloss.NewStyle().Reverse(true)
+	currentStyle := matchStyle.Bold(true).Foreground(colors.Current().Attention.Warning)
+	out := make([]string, len(lines))
+	copy(out, lines)
+	for i := len(m.matches) - 1; i >= 0; i-- { // right to left keeps earlier columns valid
+		match := m.matches[i]
+		if match.Line >= len(out) {
+			continue
+		}
+		plain := ansi.Strip(lines[match.Line])
+		if match.End > len(plain) {
+			continue
+		}
+		left := ansi.StringWidth(plain[:match.Start])
+		right := left + ansi.StringWidth(plain[match.Start:match.End])
+		style := matchStyle
+		if i == m.current {
+			style = currentStyle
+		}
+		line := out[match.Line]
+		out[match.Line] = ansi.Cut(line, 0, left) + style.Render(plain[match.Start:match.End]) + ansi.Cut(line, right, ansi.StringWidth(line))
+	}
+	return out
+}
+
+// FilterLines returns the lines matching the query with context lines
+// around each, and a Separator wherever lines were left out between them.
+func (m Model) FilterLines(lines []string, context int) []string {
+	if m.re == nil {
+		return lines
+	}
+	keep := make([]bool, len(lines))
+	for i, l := range lines {
+		if !m.re.MatchString(ansi.Strip(l)) {
+			continue
+		}
+		for j := max(0, i-context); j <= min(len(lines)-1, i+context); j++ {
+			keep[j] = true
+		}
+	}
+	var out []string
+	last := -1
+	for i, k := range keep {
+		if !k {
+			continue
+		}
+		if last >= 0 && i > last+1 {
+			out = append(out, Separator)
+		}
+		out = append(out, lines[i])
+		last = i
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package find
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var lines = []string{
+	"starting build",
+	"\x1b[1mERROR\x1b[0m: auth token expired",
+	"retrying",
+	"error again: token expired",
+	"done",
+}
+
+func TestFind_SmartCaseRegex(t *testing.T) {
+	var m Model
+	m.SetQuery("error")
+	m.Find(lines)
+	if m.Count() != 2 {
+		t.Errorf("expected a lowercase query to ignore case, got %d matches", m.Count())
+	}
+	m.SetQuery("ERROR")
+	m.Find(lines)
+	if m.Count() != 1 {
+		t.Errorf("expected a query with capitals to match case, got %d matches", m.Count())
+	}
+	m.SetQuery(`tok\w+ exp`)
+	m.Find(lines)
+	if literal, _ := m.Literal(); literal || m.Count() != 2 {
+		t.Errorf("expected the query used as a regexp, got %d matches", m.Count())
+	}
+	m.SetQuery("token (")
+	m.Find(lines)
+	if literal, reason := m.Literal(); !literal || reason != "missing closing )" || m.Count() != 0 {
+		t.Errorf("expected an invalid regexp matched as text, got %v %q", literal, reason)
+	}
+	m.SetQuery("q*")
+	m.Find(lines)
+	if m.Count() != 0 {
+		t.Errorf("expected empty matches skipped, got %d", m.Count())
+	}
+}
+
+func TestNext_WrapsAndCounts(t *testing.T) {
+	var m Model
+	if m.Counter() != "no matches" {
+		t.Errorf("unexpected counter %q", m.Counter())
+	}
+	m.SetQuery("e")
+	m.Find(lines)
+	if match, _ := m.SeekFrom(3); match.Line != 3 || m.Counter() != "6/10" {
+		t.Errorf("expected the first match from line 3, got line %d, %s", match.Line, m.Counter())
+	}
+	m.Next(-6)
+	if m.Counter() != "10/10" {
+		t.Errorf("expected moving back past the first match to wrap, got %s", m.Counter())
+	}
+	if match, _ := m.Next(1); match.Line != 1 || m.Counter() != "1/10" {
+		t.Errorf("expected wrapping forward to the first match, got line %d, %s", match.Line, m.Counter())
+	}
+}
+
+func TestHighlight_KeepsLineText(t *testing.T) {
+	var m Model
+	m.SetQuery("token")
+	m.Find(lines)
+	out := m.Highlight(lines)
+	for i := range lines {
+		if ansi.Strip(out[i]) != ansi.Strip(lines[i]) {
+			t.Errorf("line %d: highlighting changed the text to %q", i, ansi.Strip(out[i]))
+		}
+	}
+	if out[0] != lines[0] || out[1] == lines[1] || !strings.HasPrefix(out[1], "\x1b[1mERROR") {
+		t.Errorf("expected only matching lines changed, keeping their styling: %q", out[:2])
+	}
+}
+
+func TestFilterLines_KeepsContext(t *testing.T) {
+	var m Model
+	if got := m.FilterLines(lines, 1); len(got) != len(lines) {
+		t.Error("expected all lines kept without a query")
+	}
+	m.SetQuery("starting|done")
+	got := strings.Join(m.FilterLines(lines, 1), "|")
+	if want := "starting build|\x1b[1mERROR\x1b[0m: auth token expired|--|error again: token expired|done"; got != want {
+		t.Errorf("FilterLines() = %q, want %q", got, want)
+	}
+	m.SetFilter(true)
+	if !m.Filtering() {
+		t.Error("expected filtering with a query")
+	}
+	m.Clear()
+	if m.Filtering() || m.FilterMode() || m.Active() {
+		t.Error("expected Clear to drop the query and filter mode")
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 // Powerline separator characters
@@ -15,20 +17,26 @@ const (
 	sepLeft  = "\ue0b2" // 
 )
 
-// Palette colors (Catppuccin Mocha)
-var (
-	colorBase     = lipgloss.Color("#1e1e2e")
-	colorSurface0 = lipgloss.Color("#313244")
-	colorSurface1 = lipgloss.Color("#45475a")
-	colorSurface2 = lipgloss.Color("#585b70")
-	colorText     = lipgloss.Color("#cdd6f4")
-	colorLavender = lipgloss.Color("#b4befe")
-	colorMauve    = lipgloss.Color("#cba6f7")
-	colorGreen    = lipgloss.Color("#a6e3a1")
-	colorYellow   = lipgloss.Color("#f9e2af")
-	colorRed      = lipgloss.Color("#f38ba8")
-	colorTeal     = lipgloss.Color("#94e2d5")
-)
+// separators returns the segment separators for the active rendering
+// mode. Plain and accessible modes swap the powerline glyphs for a bar.
+func separators() (right, left string) {
+	if a11y.Current().ASCII() {
+		return "|", "|"
+	}
+	return sepRight, sepLeft
+}
+
+// asciiText drops non-ASCII glyphs such as emoji from s, keeping a single
+// space of padding on each side.
+func asciiText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 128 {
+			b.WriteRune(r)
+		}
+	}
+	return " " + strings.Join(strings.Fields(b.String()), " ") + " "
+}
 
 // segment holds a powerline segment's content and colors.
 type segment struct {
@@ -50,9 +58,8 @@ type Model struct {
 // New creates a new powerline footer model.
 func New(_ lipgloss.Style, keys []key.Binding) Model {
 	return Model{
-		hints:   keys,
-		badge:   " ⚡ Agent Viz ",
-		badgeBg: colorMauve,
+		hints: keys,
+		badge: " ⚡ Agent Viz ",
 	}
 }
 
@@ -61,7 +68,8 @@ func (m *Model) SetHints(keys []key.Binding) {
 	m.hints = keys
 }
 
-// SetBadge sets the left-side badge text and color.
+// SetBadge sets the left-side badge text and color. A nil color uses the
+// theme's accent.
 func (m *Model) SetBadge(text string, bg color.Color) {
 	m.badge = text
 	m.badgeBg = bg
@@ -85,22 +93,35 @@ func (m *Model) SetWidth(width int) {
 
 // View renders the powerline-style footer.
 func (m Model) View() string {
+	pl := colors.Current().Powerline
+	badgeBg := m.badgeBg
+	if badgeBg == nil {
+		badgeBg = pl.Accent
+	}
+	badge, status := m.badge, m.status
+	if a11y.Current().ASCII() {
+		badge = asciiText(badge)
+		if status != "" {
+			status = asciiText(status)
+		}
+	}
+
 	// Left side: badge + optional status
 	leftSegs := []segment{
-		{text: m.badge, fg: colorBase, bg: m.badgeBg},
+		{text: badge, fg: pl.Base, bg: badgeBg},
 	}
-	if m.status != "" {
+	if status != "" {
 		leftSegs = append(leftSegs, segment{
-			text: m.status, fg: colorBase, bg: m.statusBg,
+			text: status, fg: pl.Base, bg: m.statusBg,
 		})
 	}
 
-	left := renderPowerlineLeft(leftSegs)
+	left := renderPowerlineLeft(leftSegs, pl.Base)
 	leftW := lipgloss.Width(left)
 
 	// Right side: key hints (truncated to fit available space)
-	rightSegs := m.buildHintSegments(leftW)
-	right := renderPowerlineRight(rightSegs)
+	rightSegs := m.buildHintSegments(leftW, pl)
+	right := renderPowerlineRight(rightSegs, pl.Base)
 
 	rightW := lipgloss.Width(right)
 	gap := m.width - leftW - rightW
@@ -109,19 +130,19 @@ func (m Model) View() string {
 	}
 
 	mid := lipgloss.NewStyle().
-		Background(colorBase).
+		Background(pl.Base).
 		Width(gap).
 		Render("")
 
 	return "\n" + lipgloss.JoinHorizontal(lipgloss.Top, left, mid, right)
 }
 
-func (m Model) buildHintSegments(leftWidth int) []segment {
+func (m Model) buildHintSegments(leftWidth int, pl colors.Powerline) []segment {
 	if len(m.hints) == 0 {
 		return nil
 	}
 
-	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(colorLavender)
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(pl.Key)
 
 	segs := make([]segment, 0, len(m.hints))
 	for i, h := range m.hints {
@@ -129,19 +150,19 @@ func (m Model) buildHintSegments(leftWidth int) []segment {
 		if help.Key == "" {
 			continue
 		}
-		text := fmt.Sprintf(" %s %s ", keyStyle.Render(help.Key), help.Desc)
+		text := fmt.Sprintf(" %s %s ", keyStyle.Render(a11y.Text(help.Key)), a11y.Text(help.Desc))
 
 		// Alternate between surface1 and surface2 for visual grouping
-		bg := colorSurface1
+		bg := pl.Surface1
 		if i >= len(m.hints)-2 {
-			bg = colorSurface2
+			bg = pl.Surface2
 		}
-		segs = append(segs, segment{text: text, fg: colorText, bg: bg})
+		segs = append(segs, segment{text: text, fg: pl.Text, bg: bg})
 	}
 
 	// Last hint gets the accent color
 	if len(segs) > 0 {
-		segs[len(segs)-1].bg = colorMauve
+		segs[len(segs)-1].bg = pl.Accent
 	}
 
 	// Truncate hints that don't fit — reserve space for the left badge + a small gap
@@ -162,10 +183,11 @@ func (m Model) buildHintSegments(leftWidth int) []segment {
 	return segs
 }
 
-func renderPowerlineLeft(segs []segment) string {
+func renderPowerlineLeft(segs []segment, base color.Color) string {
 	if len(segs) == 0 {
 		return ""
 	}
+	sep, _ := separators()
 	var b strings.Builder
 	for i, seg := range segs {
 		body := lipgloss.NewStyle().
@@ -174,33 +196,34 @@ func renderPowerlineLeft(segs []segment) string {
 			Render(seg.text)
 		b.WriteString(body)
 
-		nextBg := colorBase
+		nextBg := base
 		if i+1 < len(segs) {
 			nextBg = segs[i+1].bg
 		}
 		arrow := lipgloss.NewStyle().
 			Foreground(seg.bg).
 			Background(nextBg).
-			Render(sepRight)
+			Render(sep)
 		b.WriteString(arrow)
 	}
 	return b.String()
 }
 
-func renderPowerlineRight(segs []segment) string {
+func renderPowerlineRight(segs []segment, base color.Color) string {
 	if len(segs) == 0 {
 		return ""
 	}
+	_, sep := separators()
 	var b strings.Builder
 	for i, seg := range segs {
-		prevBg := colorBase
+		prevBg := base
 		if i > 0 {
 			prevBg = segs[i-1].bg
 		}
 		arrow := lipgloss.NewStyle().
 			Foreground(seg.bg).
 			Background(prevBg).
-			Render(sepLeft)
+			Render(sep)
 		b.WriteString(arrow)
 
 		body := lipgloss.NewStyle().
@@ -212,13 +235,13 @@ func renderPowerlineRight(segs []segment) string {
 	return b.String()
 }
 
-// Badge color helpers for view modes
-func BadgeBgMission() color.Color  { return colorMauve }
-func BadgeBgActive() color.Color   { return colorGreen }
-func BadgeBgList() color.Color     { return colorLavender }
-func BadgeBgDetail() color.Color   { return colorSurface2 }
-func BadgeBgLog() color.Color      { return colorSurface2 }
+// Badge color helpers for view modes, read from the active theme.
+func BadgeBgMission() color.Color  { return colors.Current().Powerline.BadgeMission }
+func BadgeBgActive() color.Color   { return colors.Current().Powerline.BadgeActive }
+func BadgeBgList() color.Color     { return colors.Current().Powerline.BadgeList }
+func BadgeBgDetail() color.Color   { return colors.Current().Powerline.BadgeDetail }
+func BadgeBgLog() color.Color      { return colors.Current().Powerline.BadgeLog }
 
-func StatusBgRunning() color.Color    { return colorTeal }
-func StatusBgFailed() color.Color     { return colorRed }
-func StatusBgNeedsInput() color.Color { return colorYellow }
+func StatusBgRunning() color.Color    { return colors.Current().Powerline.StatusRunning }
+func StatusBgFailed() color.Color     { return colors.Current().Powerline.StatusFailed }
+func StatusBgNeedsInput() color.Color { return colors.Current().Powerline.StatusNeedsInput }
@@ -6,6 +6,9 @@ import (
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 func TestNew(t *testing.T) {
@@ -134,3 +137,39 @@ func TestView_EmptyHints(t *testing.T) {
 		t.Error("expected view to start with newline even with empty hints")
 	}
 }
+
+func TestView_UsesActiveThemeColors(t *testing.T) {
+	defer colors.Set(colors.Default())
+
+	model := New(lipgloss.NewStyle(), []key.Binding{
+		key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+	})
+	model.SetWidth(80)
+
+	pl := colors.CatppuccinPowerline()
+	pl.Base = lipgloss.Color("#123456")
+	colors.Set(colors.Palette{Powerline: pl})
+
+	if out := model.View(); !strings.Contains(out, "48;2;18;52;86") {
+		t.Errorf("expected footer background from the active theme, got %q", out)
+	}
+}
+
+func TestView_PlainModeDropsPowerlineGlyphs(t *testing.T) {
+	a11y.Set(a11y.Mode{Plain: true})
+	defer a11y.Set(a11y.Mode{})
+
+	model := New(lipgloss.NewStyle(), []key.Binding{
+		key.NewBinding(key.WithKeys("q"), key.WithHelp("q", "quit")),
+	})
+	model.SetWidth(80)
+	model.SetBadge(" ⚡ Active ", nil)
+	view := ansi.Strip(model.View())
+
+	if strings.Contains(view, sepRight) || strings.Contains(view, sepLeft) {
+		t.Errorf("expected no powerline separators in plain mode, got %q", view)
+	}
+	if strings.Contains(view, "⚡") || !strings.Contains(view, " Active |") {
+		t.Errorf("expected an ASCII badge, got %q", view)
+	}
+}
@@ -2,26 +2,60 @@ package gitactivity
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
 )
 
-// Styles for rendering
-var (
-	titleStyle  = lipgloss.NewStyle().Bold(true).Foreground(compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("75")})
-	statsStyle  = lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("242"), Dark: lipgloss.Color("245")})
-	addStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))  // green
-	delStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // red
-	hunkStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("45")).Faint(true)
-	headerStyle = lipgloss.NewStyle().Bold(true)
-	sepStyle    = lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("238")})
-	emptyStyle  = lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("244"), Dark: lipgloss.Color("245")}).Italic(true)
+// Styles for rendering, built from the active theme
+var headerStyle = lipgloss.NewStyle().Bold(true)
+
+func titleStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Highlight)
+}
+
+func statsStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Muted)
+}
+
+func addStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Diff.Added)
+}
+
+func delStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Diff.Removed)
+}
+
+func hunkStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Diff.Hunk).Faint(true)
+}
+
+func sepStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Subtle)
+}
+
+func emptyStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(colors.Current().Muted).Italic(true)
+}
+
+// Section is one of the git activity view's tabs.
+type Section int
+
+const (
+	// SectionUncommitted shows staged, unstaged and untracked changes.
+	SectionUncommitted Section = iota
+	// SectionBranch shows the cumulative diff since the default branch.
+	SectionBranch
+	// SectionCommits lists the branch's commits and browses their diffs.
+	SectionCommits
+	sectionCount
 )
 
 // Model represents the git activity view component
@@ -33,6 +67,14 @@ type Model struct {
 	height   int
 	ready    bool
 	loading  bool
+
+	section       Section
+	sectionChosen bool // the user picked a section, so results don't move them
+	branchFiles   []diffview.FileDiff
+	cursor        int
+	commit        *data.GitCommit // commit whose diff is open, if any
+	commitFiles   []diffview.FileDiff
+	commitLoading bool
 }
 
 // New creates a new git activity model
@@ -46,6 +88,16 @@ func New(width, height int) Model {
 	}
 }
 
+// Reset forgets the section, cursor and open commit of a previous session.
+func (m *Model) Reset() {
+	m.section = SectionUncommitted
+	m.sectionChosen = false
+	m.cursor = 0
+	m.commit = nil
+	m.commitFiles = nil
+	m.commitLoading = false
+}
+
 // SetSize updates the component dimensions
 func (m *Model) SetSize(width, height int) {
 	m.width = width
@@ -62,8 +114,14 @@ func (m *Model) SetLoading(loading bool) {
 	m.loading = loading
 }
 
-// SetDiffResult updates the diff data and re-renders
+// SetDiffResult updates the diff data and re-renders. Until the user picks
+// a section, a session with committed work but a clean working tree opens
+// on the branch diff rather than an empty page.
 func (m *Model) SetDiffResult(result *data.GitDiffResult) {
+	var selected string
+	if c, ok := m.SelectedCommit(); ok {
+		selected = c.Hash
+	}
 	m.result = result
 	m.loading = false
 	if result != nil && result.Diff != "" {
@@ -71,10 +129,153 @@ func (m *Model) SetDiffResult(result *data.GitDiffResult) {
 	} else {
 		m.files = nil
 	}
+	m.branchFiles = nil
+	if b := m.branch(); b != nil {
+		m.branchFiles = diffview.ParseUnifiedDiff(b.Diff)
+	}
+	commits := m.commits()
+	m.cursor = min(m.cursor, max(len(commits)-1, 0))
+	for i, c := range commits {
+		if c.Hash == selected {
+			m.cursor = i
+		}
+	}
+	if !m.sectionChosen {
+		m.section = SectionUncommitted
+		if len(m.files) == 0 && len(commits) > 0 {
+			m.section = SectionBranch
+		}
+	}
 	m.ready = true
 	m.renderContent()
 }
 
+// Section returns the section being shown.
+func (m Model) Section() Section {
+	return m.section
+}
+
+// NextSection shows the next section, wrapping around.
+func (m *Model) NextSection() {
+	m.setSection((m.section + 1) % sectionCount)
+}
+
+// PrevSection shows the previous section, wrapping around.
+func (m *Model) PrevSection() {
+	m.setSection((m.section + sectionCount - 1) % sectionCount)
+}
+
+func (m *Model) setSection(s Section) {
+	m.section = s
+	m.sectionChosen = true
+	m.viewport.GotoTop()
+	m.renderContent()
+}
+
+// BrowsingCommits reports whether the commit list is shown, so the cursor
+// keys move between commits instead of scrolling.
+func (m Model) BrowsingCommits() bool {
+	return m.section == SectionCommits && m.commit == nil && len(m.commits()) > 0
+}
+
+// MoveCursor moves the commit list cursor by delta, keeping it in view.
+func (m *Model) MoveCursor(delta int) {
+	n := len(m.commits())
+	if n == 0 {
+		return
+	}
+	m.cursor = max(0, min(n-1, m.cursor+delta))
+	m.renderContent()
+	line := commitListOffset + m.cursor
+	if line < m.viewport.YOffset() {
+		m.viewport.SetYOffset(line)
+	} else if bottom := m.viewport.YOffset() + m.viewport.Height() - 1; line > bottom {
+		m.viewport.SetYOffset(line - m.viewport.Height() + 1)
+	}
+}
+
+// SelectedCommit returns the commit under the cursor.
+func (m Model) SelectedCommit() (data.GitCommit, bool) {
+	commits := m.commits()
+	if m.cursor < 0 || m.cursor >= len(commits) {
+		return data.GitCommit{}, false
+	}
+	return commits[m.cursor], true
+}
+
+// OpenCommit shows c's diff, which loads until SetCommitDiff delivers it.
+func (m *Model) OpenCommit(c data.GitCommit) {
+	m.commit = &c
+	m.commitFiles = nil
+	m.commitLoading = true
+	m.viewport.GotoTop()
+	m.renderContent()
+}
+
+// SetCommitDiff shows the diff of the open commit. Diffs of other commits,
+// which arrive after the user moved on, are ignored.
+func (m *Model) SetCommitDiff(hash, diff string) {
+	if m.commit == nil || m.commit.Hash != hash {
+		return
+	}
+	m.commitFiles = diffview.ParseUnifiedDiff(diff)
+	m.commitLoading = false
+	m.renderContent()
+}
+
+// CloseCommit returns from a commit's diff to the commit list. It reports
+// whether a commit was open.
+func (m *Model) CloseCommit() bool {
+	if m.commit == nil {
+		return false
+	}
+	m.commit = nil
+	m.commitFiles = nil
+	m.commitLoading = false
+	m.renderContent()
+	m.MoveCursor(0)
+	return true
+}
+
+// Files returns the changed files of the section being shown: the working
+// tree's, the branch's or the open commit's. The commit list itself has none.
+func (m Model) Files() []diffview.FileDiff {
+	switch m.section {
+	case SectionBranch:
+		return m.branchFiles
+	case SectionCommits:
+		if m.commit == nil || m.commitLoading {
+			return nil
+		}
+		return m.commitFiles
+	}
+	if m.result == nil {
+		return nil
+	}
+	files := slices.Clone(m.files)
+	for _, path := range m.result.Untracked {
+		// Untracked files too large or binary to diff have no patch
+		if !slices.ContainsFunc(files, func(f diffview.FileDiff) bool { return f.Path == path }) {
+			files = append(files, diffview.FileDiff{Path: path})
+		}
+	}
+	return files
+}
+
+func (m Model) branch() *data.GitBranchResult {
+	if m.result == nil {
+		return nil
+	}
+	return m.result.Branch
+}
+
+func (m Model) commits() []data.GitCommit {
+	if b := m.branch(); b != nil {
+		return b.Commits
+	}
+	return nil
+}
+
 // Update handles incoming messages
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -86,62 +287,195 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	if m.loading {
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-			statsStyle.Render("Loading git changes…"))
+			statsStyle().Render("Loading git changes" + a11y.Ellipsis()))
 	}
 	return m.viewport.View()
 }
 
+// commitListOffset is the number of lines above the first commit in the
+// commit list: title, tabs, stats and separator.
+const commitListOffset = 4
+
 // renderContent builds the viewport content from the current diff result
 func (m *Model) renderContent() {
-	if m.result == nil || (m.result.Diff == "" && m.result.StatLines == "") {
+	if m.result == nil || (m.result.Diff == "" && m.result.StatLines == "" && len(m.commits()) == 0) {
 		m.viewport.SetContent(lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-			emptyStyle.Render("No uncommitted changes")))
+			emptyStyle().Render("No uncommitted changes")))
 		return
 	}
 
 	var sb strings.Builder
-
-	// Header with stats
-	sb.WriteString(titleStyle.Render("  📂 Git Activity"))
+	sb.WriteString(titleStyle().Render(a11y.Text("  📂 Git Activity")))
+	sb.WriteString("\n")
+	sb.WriteString("  " + m.renderTabs())
 	sb.WriteString("\n")
-	sb.WriteString(statsStyle.Render(fmt.Sprintf("  %d file(s) changed, %s+%d%s %s−%d%s",
-		m.result.FileCount,
-		addStyle.Render(""), m.result.Additions, statsStyle.Render(""),
-		delStyle.Render(""), m.result.Deletions, statsStyle.Render(""))))
+
+	switch m.section {
+	case SectionBranch:
+		m.renderBranch(&sb)
+	case SectionCommits:
+		m.renderCommits(&sb)
+	default:
+		m.renderUncommitted(&sb)
+	}
+	m.viewport.SetContent(sb.String())
+}
+
+// renderTabs renders the section names with their sizes, highlighting the
+// section being shown.
+func (m Model) renderTabs() string {
+	branch := "Branch"
+	var branchFiles, commits int
+	if b := m.branch(); b != nil {
+		branch = "Branch vs " + b.Base
+		branchFiles, commits = b.FileCount, len(b.Commits)
+	}
+	tabs := []string{
+		fmt.Sprintf("Uncommitted (%d)", m.result.FileCount),
+		fmt.Sprintf("%s (%d)", branch, branchFiles),
+		fmt.Sprintf("Commits (%d)", commits),
+	}
+	for i, t := range tabs {
+		if Section(i) == m.section {
+			tabs[i] = titleStyle().Render(t)
+		} else {
+			tabs[i] = statsStyle().Render(t)
+		}
+	}
+	return strings.Join(tabs, sepStyle().Render(a11y.Glyph(" │ ", " | ")))
+}
+
+func (m Model) renderStats(sb *strings.Builder, files, adds, dels int) {
+	sb.WriteString(statsStyle().Render(fmt.Sprintf("  %d file(s) changed, %s+%d%s %s"+a11y.Glyph("−", "-")+"%d%s",
+		files,
+		addStyle().Render(""), adds, statsStyle().Render(""),
+		delStyle().Render(""), dels, statsStyle().Render(""))))
 	sb.WriteString("\n")
-	sb.WriteString(sepStyle.Render(strings.Repeat("─", m.width-2)))
+	sb.WriteString(sepStyle().Render(strings.Repeat(a11y.Glyph("─", "-"), max(m.width-2, 0))))
 	sb.WriteString("\n")
+}
+
+func (m Model) renderEmpty(sb *strings.Builder, text string) {
+	sb.WriteString("\n  " + emptyStyle().Render(text) + "\n")
+}
+
+func (m Model) renderUncommitted(sb *strings.Builder) {
+	if m.result.Diff == "" && m.result.StatLines == "" {
+		m.renderEmpty(sb, "No uncommitted changes")
+		return
+	}
+	m.renderStats(sb, m.result.FileCount, m.result.Additions, m.result.Deletions)
 
 	// Stat summary (file list with +/- bars)
-	if m.result.StatLines != "" {
+	if m.result.StatLines != "" || len(m.result.Untracked) > 0 {
 		for _, line := range strings.Split(m.result.StatLines, "\n") {
-			sb.WriteString("  " + line + "\n")
+			if line != "" {
+				sb.WriteString("  " + line + "\n")
+			}
+		}
+		for _, f := range m.result.Untracked {
+			sb.WriteString("  " + f + statsStyle().Render(" (untracked)") + "\n")
 		}
-		sb.WriteString(sepStyle.Render(strings.Repeat("─", m.width-2)))
+		sb.WriteString(sepStyle().Render(strings.Repeat(a11y.Glyph("─", "-"), max(m.width-2, 0))))
 		sb.WriteString("\n\n")
 	}
+	renderFiles(sb, m.files)
+}
 
-	// Full colored diff
-	for _, file := range m.files {
+func (m Model) renderBranch(sb *strings.Builder) {
+	b := m.branch()
+	switch {
+	case b == nil:
+		m.renderEmpty(sb, "No default branch to compare against")
+		return
+	case len(b.Commits) == 0:
+		m.renderEmpty(sb, "No commits since "+b.Base)
+		return
+	}
+	m.renderStats(sb, b.FileCount, b.Additions, b.Deletions)
+	sb.WriteString(statsStyle().Render(fmt.Sprintf("  %s since merge-base %s with %s, %d commit(s)",
+		b.Branch, shortHash(b.MergeBase), b.Base, len(b.Commits))))
+	sb.WriteString("\n\n")
+	renderFiles(sb, m.branchFiles)
+}
+
+func (m Model) renderCommits(sb *strings.Builder) {
+	commits := m.commits()
+	if m.commit != nil {
+		c := m.commit
+		var adds, dels int
+		for _, f := range m.commitFiles {
+			adds += f.Additions
+			dels += f.Deletions
+		}
+		m.renderStats(sb, len(m.commitFiles), adds, dels)
+		sb.WriteString(headerStyle.Render(fmt.Sprintf("  %s %s", c.Short, c.Subject)))
+		sb.WriteString("\n")
+		sb.WriteString(statsStyle().Render(fmt.Sprintf("  %s %s %s", c.Author, a11y.Glyph("·", "-"), c.When.Local().Format("2006-01-02 15:04"))))
+		sb.WriteString("\n\n")
+		if m.commitLoading {
+			sb.WriteString("  " + statsStyle().Render("Loading commit" + a11y.Ellipsis()) + "\n")
+			return
+		}
+		if len(m.commitFiles) == 0 {
+			m.renderEmpty(sb, "No file changes in this commit")
+			return
+		}
+		renderFiles(sb, m.commitFiles)
+		return
+	}
+	if len(commits) == 0 {
+		if b := m.branch(); b != nil {
+			m.renderEmpty(sb, "No commits since "+b.Base)
+		} else {
+			m.renderEmpty(sb, "No default branch to compare against")
+		}
+		return
+	}
+	b := m.branch()
+	sb.WriteString(statsStyle().Render(fmt.Sprintf("  %d commit(s) on %s since %s "+a11y.Glyph("—", "-")+" enter to view a commit",
+		len(commits), b.Branch, b.Base)))
+	sb.WriteString("\n")
+	sb.WriteString(sepStyle().Render(strings.Repeat(a11y.Glyph("─", "-"), max(m.width-2, 0))))
+	sb.WriteString("\n")
+	for i, c := range commits {
+		meta := statsStyle().Render(fmt.Sprintf("  %s %s %s", c.Author, a11y.Glyph("·", "-"), c.When.Local().Format("2006-01-02 15:04")))
+		line := fmt.Sprintf("%s %s", c.Short, c.Subject)
+		if i == m.cursor {
+			sb.WriteString(titleStyle().Render(a11y.Glyph("▸ ", "> ")+line) + meta + "\n")
+		} else {
+			sb.WriteString("  " + line + meta + "\n")
+		}
+	}
+}
+
+// renderFiles writes each file's header and colored patch.
+func renderFiles(sb *strings.Builder, files []diffview.FileDiff) {
+	for _, file := range files {
 		sb.WriteString(headerStyle.Render(fmt.Sprintf("  %s", file.Path)))
-		sb.WriteString(statsStyle.Render(fmt.Sprintf(" (+%d, -%d)", file.Additions, file.Deletions)))
+		sb.WriteString(statsStyle().Render(fmt.Sprintf(" (+%d, -%d)", file.Additions, file.Deletions)))
 		sb.WriteString("\n")
 
 		for _, line := range strings.Split(file.Patch, "\n") {
 			if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-				sb.WriteString("  " + addStyle.Render(line) + "\n")
+				sb.WriteString("  " + addStyle().Render(line) + "\n")
 			} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
-				sb.WriteString("  " + delStyle.Render(line) + "\n")
+				sb.WriteString("  " + delStyle().Render(line) + "\n")
 			} else if strings.HasPrefix(line, "@@") {
-				sb.WriteString("  " + hunkStyle.Render(line) + "\n")
+				sb.WriteString("  " + hunkStyle().Render(line) + "\n")
 			} else {
 				sb.WriteString("  " + line + "\n")
 			}
 		}
 		sb.WriteString("\n")
 	}
+}
 
-	m.viewport.SetContent(sb.String())
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
 }
 
 // ScrollUp scrolls up one line
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
 )
 
@@ -65,3 +66,114 @@ func TestSetLoading(t *testing.T) {
 		t.Fatal("expected loading to be true")
 	}
 }
+
+func branchResult() *data.GitDiffResult {
+	return &data.GitDiffResult{
+		Branch: &data.GitBranchResult{
+			Branch:    "copilot/fix",
+			Base:      "origin/main",
+			MergeBase: "0123456789abcdef",
+			Commits: []data.GitCommit{
+				{Hash: "bbbb", Short: "bbbb", Subject: "update readme", Author: "Agent"},
+				{Hash: "aaaa", Short: "aaaa", Subject: "add fix", Author: "Agent"},
+			},
+			Diff:      "diff --git a/fix.go b/fix.go\n--- /dev/null\n+++ b/fix.go\n@@ -0,0 +1 @@\n+package fix\n",
+			FileCount: 1,
+			Additions: 1,
+		},
+	}
+}
+
+func TestSetDiffResult_CommittedWorkOpensOnBranch(t *testing.T) {
+	m := New(80, 24)
+	m.SetDiffResult(branchResult())
+	if m.Section() != SectionBranch {
+		t.Fatalf("expected the branch section for a clean tree with commits, got %v", m.Section())
+	}
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "Branch vs origin/main") || !strings.Contains(view, "+package fix") {
+		t.Fatalf("expected the cumulative branch diff, got: %q", view)
+	}
+
+	m.PrevSection()
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "No uncommitted changes") {
+		t.Fatalf("expected the empty uncommitted section, got: %q", view)
+	}
+	m.SetDiffResult(branchResult())
+	if m.Section() != SectionUncommitted {
+		t.Fatal("expected a chosen section to survive a refresh")
+	}
+}
+
+func TestCommitBrowser(t *testing.T) {
+	m := New(80, 24)
+	m.SetDiffResult(branchResult())
+	m.NextSection()
+	if !m.BrowsingCommits() {
+		t.Fatal("expected the commit list")
+	}
+	m.MoveCursor(1)
+	c, ok := m.SelectedCommit()
+	if !ok || c.Hash != "aaaa" {
+		t.Fatalf("expected the second commit, got %+v", c)
+	}
+
+	m.OpenCommit(c)
+	if m.BrowsingCommits() || !strings.Contains(ansi.Strip(m.View()), "Loading commit") {
+		t.Fatal("expected the commit to be loading")
+	}
+	m.SetCommitDiff("bbbb", "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n hello\n+world\n")
+	if strings.Contains(ansi.Strip(m.View()), "+world") {
+		t.Fatal("expected a diff for another commit to be ignored")
+	}
+	m.SetCommitDiff("aaaa", "diff --git a/fix.go b/fix.go\n--- /dev/null\n+++ b/fix.go\n@@ -0,0 +1 @@\n+package fix\n")
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "aaaa add fix") || !strings.Contains(view, "+package fix") {
+		t.Fatalf("expected the commit diff, got: %q", view)
+	}
+
+	m.SetDiffResult(branchResult())
+	if !strings.Contains(ansi.Strip(m.View()), "+package fix") {
+		t.Fatal("expected a refresh to keep the open commit")
+	}
+	if !m.CloseCommit() || !m.BrowsingCommits() {
+		t.Fatal("expected to return to the commit list")
+	}
+	if c, _ := m.SelectedCommit(); c.Hash != "aaaa" {
+		t.Fatalf("expected the cursor to stay on the viewed commit, got %+v", c)
+	}
+	if m.CloseCommit() {
+		t.Fatal("expected no commit to close")
+	}
+}
+
+func TestSetDiffResult_UntrackedFilesListed(t *testing.T) {
+	m := New(80, 24)
+	m.SetDiffResult(&data.GitDiffResult{
+		Diff:      "diff --git a/notes.txt b/notes.txt\nnew file mode 100644\n--- /dev/null\n+++ b/notes.txt\n@@ -0,0 +1 @@\n+one\n",
+		FileCount: 1,
+		Additions: 1,
+		Untracked: []string{"notes.txt"},
+	})
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "notes.txt (untracked)") || !strings.Contains(view, "+one") {
+		t.Fatalf("expected the untracked file and its contents, got: %q", view)
+	}
+}
+
+func TestFiles_FollowSection(t *testing.T) {
+	m := New(80, 24)
+	result := branchResult()
+	result.Diff = "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -3 +3 @@\n-old\n+new\n"
+	result.Untracked = []string{"image.png"}
+	m.SetDiffResult(result)
+	if files := m.Files(); len(files) != 2 || files[0].Path != "main.go" || files[1].Path != "image.png" {
+		t.Fatalf("expected the working tree's files and the undiffed untracked one, got %+v", files)
+	}
+	m.NextSection()
+	if files := m.Files(); len(files) != 1 || files[0].Path != "fix.go" {
+		t.Fatalf("expected the branch's files, got %+v", files)
+	}
+	m.NextSection()
+	if files := m.Files(); files != nil {
+		t.Fatalf("expected no files in the commit list, got %+v", files)
+	}
+}
@@ -6,7 +6,8 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 // taglines displayed randomly on startup
@@ -78,6 +79,14 @@ func New(titleStyle, tabActive, tabInactive, tabCount lipgloss.Style, title stri
 	}
 }
 
+// SetStyles replaces the theme styles, e.g. after a theme switch.
+func (m *Model) SetStyles(titleStyle, tabActive, tabInactive, tabCount lipgloss.Style) {
+	m.titleStyle = titleStyle
+	m.tabActive = tabActive
+	m.tabInactive = tabInactive
+	m.tabCount = tabCount
+}
+
 // SetUpgradeVersion sets the latest available version for the upgrade nudge.
 func (m *Model) SetUpgradeVersion(v string) {
 	m.upgradeVersion = v
@@ -89,11 +98,24 @@ func (m *Model) SetSize(width, height int) {
 	m.height = height
 }
 
+// SetTagline replaces the randomly chosen tagline; "" hides it.
+func (m *Model) SetTagline(tagline string) {
+	m.tagline = tagline
+}
+
 // SetCounts updates the filter counts displayed in tab badges
 func (m *Model) SetCounts(counts FilterCounts) {
 	m.counts = counts
 }
 
+// rule returns the full-width line drawn under the tab bar.
+func (m Model) rule() string {
+	if a11y.Current().ASCII() {
+		return strings.Repeat("-", m.width)
+	}
+	return strings.Repeat("━", m.width)
+}
+
 // showBanner returns true when the ASCII banner should be displayed
 func (m Model) showBanner() bool {
 	return m.useAsciiHeader && m.height >= minHeightForBanner && m.width >= bannerWidth
@@ -132,8 +154,8 @@ func (m Model) View() string {
 	tabLine := tabBar
 
 	separator := lipgloss.NewStyle().
-		Foreground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("240")}).
-		Render(strings.Repeat("━", m.width))
+		Foreground(colors.Current().Subtle).
+		Render(m.rule())
 
 	if m.showBanner() {
 		bannerStyle := lipgloss.NewStyle().
@@ -145,7 +167,7 @@ func (m Model) View() string {
 		var infoParts []string
 		if m.tagline != "" {
 			tagStyle := lipgloss.NewStyle().
-				Foreground(compat.AdaptiveColor{Light: lipgloss.Color("245"), Dark: lipgloss.Color("250")}).
+				Foreground(colors.Current().Muted).
 				Italic(true)
 			infoParts = append(infoParts, tagStyle.Render(m.tagline))
 		}
@@ -168,8 +190,8 @@ func (m Model) View() string {
 // ViewBannerOnly renders just the banner and tagline without the tab bar.
 func (m Model) ViewBannerOnly() string {
 	separator := lipgloss.NewStyle().
-		Foreground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("240")}).
-		Render(strings.Repeat("━", m.width))
+		Foreground(colors.Current().Subtle).
+		Render(m.rule())
 
 	if m.showBanner() {
 		bannerStyle := lipgloss.NewStyle().
@@ -180,7 +202,7 @@ func (m Model) ViewBannerOnly() string {
 		var infoParts []string
 		if m.tagline != "" {
 			tagStyle := lipgloss.NewStyle().
-				Foreground(compat.AdaptiveColor{Light: lipgloss.Color("245"), Dark: lipgloss.Color("250")}).
+				Foreground(colors.Current().Muted).
 				Italic(true)
 			infoParts = append(infoParts, tagStyle.Render(m.tagline))
 		}
@@ -210,8 +232,8 @@ func (m Model) renderVersionBadge() string {
 	latest := strings.TrimPrefix(m.upgradeVersion, "v")
 	if latest != "" && latest != current {
 		upgradeStyle := lipgloss.NewStyle().
-			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("214"), Dark: lipgloss.Color("214")})
-		badge += "  " + upgradeStyle.Render("⬆ v"+latest+" available")
+			Foreground(colors.Current().Attention.Warning)
+		badge += "  " + upgradeStyle.Render(a11y.Glyph("⬆ ", "^ ")+"v"+latest+" available")
 	}
 	return badge
 }
@@ -4,19 +4,41 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
+// Entry is a single key/description line in the overlay.
+type Entry struct {
+	Key  string
+	Desc string
+}
+
+// Section is a titled group of entries.
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
 // Model represents the help overlay state.
 type Model struct {
-	visible bool
-	width   int
-	height  int
+	visible  bool
+	width    int
+	height   int
+	sections []Section
+	closeKey string
 }
 
 // New creates a new help overlay model.
 func New() Model {
-	return Model{}
+	return Model{closeKey: "?"}
+}
+
+// SetSections replaces the listed shortcuts. closeKey is the key shown in
+// the "press … to close" hint.
+func (m *Model) SetSections(sections []Section, closeKey string) {
+	m.sections = sections
+	m.closeKey = closeKey
 }
 
 // Toggle flips help overlay visibility.
@@ -41,77 +63,49 @@ func (m Model) View() string {
 		return ""
 	}
 
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(compat.AdaptiveColor{Light: lipgloss.Color("55"), Dark: lipgloss.Color("99")})
-	keyStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("28"), Dark: lipgloss.Color("42")}).Bold(true)
-	descStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("240"), Dark: lipgloss.Color("252")})
-	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(compat.AdaptiveColor{Light: lipgloss.Color("27"), Dark: lipgloss.Color("63")}).MarginBottom(1)
-	dimStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("244"), Dark: lipgloss.Color("241")}).Italic(true)
+	p := colors.Current()
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(p.Accent)
+	keyStyle := lipgloss.NewStyle().Foreground(p.Key).Bold(true)
+	descStyle := lipgloss.NewStyle().Foreground(p.Text)
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(p.Section).MarginBottom(1)
+	dimStyle := lipgloss.NewStyle().Foreground(p.Muted).Italic(true)
 
 	formatKey := func(k, desc string) string {
-		return keyStyle.Render(k) + "  " + descStyle.Render(desc)
+		return keyStyle.Render(a11y.Text(k)) + "  " + descStyle.Render(a11y.Text(desc))
+	}
+
+	renderSection := func(sec Section) string {
+		lines := []string{sectionStyle.Render(a11y.Text(sec.Title))}
+		for _, e := range sec.Entries {
+			lines = append(lines, formatKey(e.Key, e.Desc))
+		}
+		return strings.Join(lines, "\n")
 	}
 
-	// Navigation section
-	nav := sectionStyle.Render("Navigation") + "\n" +
-		formatKey("↑/↓ j/k", "navigate") + "\n" +
-		formatKey("enter", "details") + "\n" +
-		formatKey("esc", "back") + "\n" +
-		formatKey("tab", "cycle filter") + "\n" +
-		formatKey("a", "attention tab")
-
-	// Actions section
-	actions := sectionStyle.Render("Actions") + "\n" +
-		formatKey("o", "open PR") + "\n" +
-		formatKey("s", "resume session") + "\n" +
-		formatKey("x", "dismiss") + "\n" +
-		formatKey("X", "dismiss all done") + "\n" +
-		formatKey("r", "refresh") + "\n" +
-		formatKey("p", "toggle preview")
-
-	// Views section
-	views := sectionStyle.Render("Views") + "\n" +
-		formatKey("M", "mission control") + "\n" +
-		formatKey("A", "active sessions") + "\n" +
-		formatKey("l", "logs") + "\n" +
-		formatKey("c", "conversation") + "\n" +
-		formatKey("t", "tool timeline") + "\n" +
-		formatKey("d", "diff (PR)") + "\n" +
-		formatKey("p", "preview pane")
-
-	// Groups section
-	groups := sectionStyle.Render("Groups") + "\n" +
-		formatKey("g", "cycle grouping") + "\n" +
-		formatKey("⎵", "expand/collapse")
-
-	// Log View section
-	logView := sectionStyle.Render("Log View") + "\n" +
-		formatKey("d/u", "page down/up") + "\n" +
-		formatKey("g/G", "top/bottom") + "\n" +
-		formatKey("f", "toggle follow")
-
-	// Meta section
-	meta := sectionStyle.Render("Meta") + "\n" +
-		formatKey("!", "open session repo") + "\n" +
-		formatKey("@", "file tool issue")
-
-	// Layout: two columns for Navigation+Actions, Views+Groups
+	// Layout: sections in two columns, paired row by row
 	colWidth := 28
 	col := func(content string) string {
 		return lipgloss.NewStyle().Width(colWidth).Render(content)
 	}
 
-	topRow := lipgloss.JoinHorizontal(lipgloss.Top, col(nav), col(actions))
-	midRow := lipgloss.JoinHorizontal(lipgloss.Top, col(views), col(groups))
-	botRow := lipgloss.JoinHorizontal(lipgloss.Top, col(logView), col(meta))
+	var rows []string
+	for i := 0; i < len(m.sections); i += 2 {
+		left := col(renderSection(m.sections[i]))
+		right := ""
+		if i+1 < len(m.sections) {
+			right = col(renderSection(m.sections[i+1]))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, left, right))
+	}
 
-	body := strings.Join([]string{topRow, midRow, botRow}, "\n\n")
+	body := strings.Join(rows, "\n\n")
 
-	closeHint := dimStyle.Render("Press ? or esc to close")
+	closeHint := dimStyle.Render("Press " + m.closeKey + " or esc to close")
 	body += "\n\n" + lipgloss.NewStyle().Width(colWidth*2).Align(lipgloss.Center).Render(closeHint)
 
 	boxStyle := lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("238")}).
+		BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
+		BorderForeground(p.Subtle).
 		Padding(1, 3).
 		Width(colWidth*2 + 8)
 
@@ -1,6 +1,9 @@
 package help
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestToggle(t *testing.T) {
 	m := New()
@@ -38,3 +41,19 @@ func TestView_HiddenReturnsEmpty(t *testing.T) {
 		t.Fatalf("expected empty view when hidden, got %q", v)
 	}
 }
+
+func TestView_RendersSections(t *testing.T) {
+	m := New()
+	m.SetSize(120, 40)
+	m.SetSections([]Section{
+		{Title: "Actions", Entries: []Entry{{Key: "z", Desc: "dismiss"}}},
+		{Title: "Meta", Entries: []Entry{{Key: "F1", Desc: "help"}}},
+	}, "F1")
+	m.Toggle()
+	v := m.View()
+	for _, want := range []string{"Actions", "dismiss", "Meta", "Press F1 or esc to close"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("expected help view to contain %q", want)
+		}
+	}
+}
@@ -6,7 +6,12 @@ import (
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/x/ansi"
 	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/find"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/selection"
 )
 
 // maxLogBytes caps the raw log content retained in memory.
@@ -26,14 +31,22 @@ func truncateLog(content string) string {
 	return "... (truncated) ...\n" + content[cut:]
 }
 
+// filterContext is how many lines around each match filter mode keeps.
+const filterContext = 2
+
 // Model represents the log view component state
 type Model struct {
 	titleStyle     lipgloss.Style
 	viewport       viewport.Model
-	rawContent     string // Original unrendered content (may be truncated)
-	rawLen         int    // Length of original content before truncation
-	content        string // Rendered content currently displayed
-	lineCount      int    // Cache line count for performance
+	rawContent     string   // Original unrendered content (may be truncated)
+	rawLen         int      // Length of original content before truncation
+	content        string   // Rendered content currently displayed
+	lines          []string // lines shown: content, or its matches in filter mode
+	lineCount      int      // Cache line count for performance
+	showRaw        bool     // show rawContent rather than rendered markdown
+	selection      selection.Model
+	find           find.Model
+	searchOrigin   int // top line when the search started, where matches are sought from
 	ready          bool
 	followMode     bool // whether auto-scroll is active
 	liveSession    bool // whether the session is running (enables LIVE indicator)
@@ -77,12 +90,20 @@ func (m Model) View() string {
 		return m.titleStyle.Render("No logs available")
 	}
 
+	if m.find.Filtering() && m.lineCount == 0 {
+		return lipgloss.NewStyle().Faint(true).Render("No lines match " + m.find.Query())
+	}
+
 	if m.liveSession {
-		indicator := " PAUSED ⏸ "
-		style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11")) // yellow
+		paused, live := " PAUSED ⏸ ", " LIVE 🔴 "
+		if a11y.Current().ASCII() {
+			paused, live = " PAUSED ", " LIVE "
+		}
+		indicator := paused
+		style := lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Attention.Warning)
 		if m.followMode {
-			indicator = " LIVE 🔴 "
-			style = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9")) // red
+			indicator = live
+			style = lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Status.Failed)
 		}
 		return style.Render(indicator) + "\n" + m.viewport.View()
 	}
@@ -90,15 +111,26 @@ func (m Model) View() string {
 	return m.viewport.View()
 }
 
+// PageText returns the log lines shown on the current page as plain text.
+func (m Model) PageText() string {
+	lines := strings.Split(ansi.Strip(m.viewport.View()), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// SetTitleStyle replaces the theme title style, e.g. after a theme switch.
+func (m *Model) SetTitleStyle(style lipgloss.Style) {
+	m.titleStyle = style
+}
+
 // SetContent updates the log content
 func (m *Model) SetContent(content string) {
 	m.rawLen = len(content)
 	content = truncateLog(content)
 	m.rawContent = content
-	rendered := m.renderWithCache(content)
-	m.content = rendered
-	m.lineCount = len(strings.Split(rendered, "\n"))
-	m.viewport.SetContent(rendered)
+	m.setRendered(m.render())
 	m.ready = true
 }
 
@@ -107,13 +139,143 @@ func (m *Model) SetSize(width, height int) {
 	m.viewport.SetWidth(width)
 	m.viewport.SetHeight(height)
 	if m.rawContent != "" {
-		rendered := m.renderWithCache(m.rawContent)
-		m.content = rendered
-		m.lineCount = len(strings.Split(rendered, "\n"))
-		m.viewport.SetContent(rendered)
+		m.setRendered(m.render())
+	}
+}
+
+// render returns the content to display: the raw log, or the log rendered
+// as markdown. In plain and accessible modes the log's symbols are replaced
+// before it is wrapped, and the bullets glamour adds afterwards.
+func (m *Model) render() string {
+	content := a11y.Text(m.rawContent)
+	if m.showRaw {
+		return strings.TrimRight(content, "\n")
+	}
+	return a11y.Text(m.renderWithCache(content))
+}
+
+// setRendered displays rendered content.
+func (m *Model) setRendered(rendered string) {
+	m.content = rendered
+	m.rebuild()
+}
+
+// rebuild derives the lines shown from the content, collapsing them to the
+// search matches in filter mode, and finds the matches in them. Any
+// selection is kept within the lines.
+func (m *Model) rebuild() {
+	if m.content == "" {
+		m.lines = nil
+	} else {
+		m.lines = strings.Split(m.content, "\n")
+	}
+	if m.find.Filtering() {
+		m.lines = m.find.FilterLines(m.lines, filterContext)
+	}
+	m.find.Find(m.lines)
+	m.lineCount = len(m.lines)
+	m.selection.Clamp(m.lineCount)
+	m.refresh()
+}
+
+// refresh pushes the lines into the viewport, highlighting the search
+// matches and the selection.
+func (m *Model) refresh() {
+	lines := m.find.Highlight(m.lines)
+	if m.selection.Active() {
+		m.viewport.SetContent(m.selection.Highlight(lines, m.viewport.Width()))
+		return
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// ToggleRaw switches between the log rendered as markdown and the raw log.
+func (m *Model) ToggleRaw() {
+	m.showRaw = !m.showRaw
+	if m.rawContent != "" {
+		m.setRendered(m.render())
+	}
+}
+
+// Raw reports whether the raw log is shown.
+func (m Model) Raw() bool {
+	return m.showRaw
+}
+
+// StartSearch notes where a new search starts: matches are sought from
+// the top line shown.
+func (m *Model) StartSearch() {
+	m.searchOrigin = m.viewport.YOffset()
+}
+
+// Search highlights the matches of query, a regular expression matched
+// case-insensitively unless it has a capital letter, and scrolls to the
+// first one from where the search started. It stops following the log.
+func (m *Model) Search(query string) {
+	m.find.SetQuery(query)
+	if query != "" {
+		m.followMode = false
+	}
+	m.rebuild()
+	origin := m.searchOrigin
+	if m.find.Filtering() {
+		origin = 0
+	}
+	if match, ok := m.find.SeekFrom(origin); ok {
+		m.showLine(match.Line)
+	}
+}
+
+// NextMatch moves to the match delta matches away, wrapping around.
+func (m *Model) NextMatch(delta int) {
+	if match, ok := m.find.Next(delta); ok {
+		m.refresh()
+		m.showLine(match.Line)
+	}
+}
+
+// SetFilter turns filter mode, showing only the lines matching the search
+// with the lines around them, on or off.
+func (m *Model) SetFilter(on bool) {
+	m.find.SetFilter(on)
+	m.rebuild()
+	if match, ok := m.find.Current(); ok {
+		m.showLine(match.Line)
+	} else {
+		m.viewport.GotoTop()
 	}
 }
 
+// ClearSearch drops the search and filter mode.
+func (m *Model) ClearSearch() {
+	m.find.Clear()
+	m.rebuild()
+}
+
+// SearchState returns the search, for showing its query and match count.
+func (m Model) SearchState() find.Model {
+	return m.find
+}
+
+// showLine scrolls line into view when it isn't, putting it a third of the
+// way down.
+func (m *Model) showLine(line int) {
+	top, height := m.viewport.YOffset(), m.viewport.Height()
+	if line >= top && line < top+height {
+		return
+	}
+	m.viewport.SetYOffset(max(0, line-height/3))
+}
+
+// styleOption picks glamour's ASCII style in plain and accessible modes,
+// and otherwise matches the terminal background.
+func styleOption() glamour.TermRendererOption {
+	if a11y.Current().ASCII() {
+		return glamour.WithStandardStyle("ascii")
+	}
+	return glamour.WithAutoStyle()
+}
+
 // renderMarkdown renders content through glamour for rich markdown display.
 // Falls back to raw content if rendering fails.
 func renderMarkdown(content string, width int) string {
@@ -121,7 +283,7 @@ func renderMarkdown(content string, width int) string {
 		width = 80
 	}
 	renderer, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
+		styleOption(),
 		glamour.WithWordWrap(width),
 	)
 	if err != nil {
@@ -143,7 +305,7 @@ func (m *Model) renderWithCache(content string) string {
 	}
 	if m.cachedRenderer == nil || m.cachedWidth != width {
 		r, err := glamour.NewTermRenderer(
-			glamour.WithAutoStyle(),
+			styleOption(),
 			glamour.WithWordWrap(width),
 		)
 		if err != nil {
@@ -159,38 +321,66 @@ func (m *Model) renderWithCache(content string) string {
 	return strings.TrimRight(rendered, "\n")
 }
 
-// GotoTop scrolls to the top
+// GotoTop scrolls to the top, or extends the selection to the first line
 func (m *Model) GotoTop() {
+	if m.selection.Active() {
+		m.MoveSelection(-m.lineCount)
+		return
+	}
 	m.viewport.GotoTop()
 }
 
-// GotoBottom scrolls to the bottom
+// GotoBottom scrolls to the bottom, or extends the selection to the last line
 func (m *Model) GotoBottom() {
+	if m.selection.Active() {
+		m.MoveSelection(m.lineCount)
+		return
+	}
 	m.viewport.GotoBottom()
 }
 
 // PageDown scrolls down one page
 func (m *Model) PageDown() {
+	if m.selection.Active() {
+		m.MoveSelection(m.viewport.Height())
+		return
+	}
 	m.viewport.PageDown()
 }
 
 // PageUp scrolls up one page
 func (m *Model) PageUp() {
+	if m.selection.Active() {
+		m.MoveSelection(-m.viewport.Height())
+		return
+	}
 	m.viewport.PageUp()
 }
 
 // HalfPageDown scrolls down half a page
 func (m *Model) HalfPageDown() {
+	if m.selection.Active() {
+		m.MoveSelection(m.viewport.Height() / 2)
+		return
+	}
 	m.viewport.HalfPageDown()
 }
 
 // HalfPageUp scrolls up half a page
 func (m *Model) HalfPageUp() {
+	if m.selection.Active() {
+		m.MoveSelection(-m.viewport.Height() / 2)
+		return
+	}
 	m.viewport.HalfPageUp()
 }
 
 // LineDown scrolls down one line
 func (m *Model) LineDown() {
+	if m.selection.Active() {
+		m.MoveSelection(1)
+		return
+	}
 	if m.viewport.YOffset() < m.lineCount-m.viewport.Height() {
 		m.viewport.ScrollDown(1)
 	}
@@ -198,11 +388,59 @@ func (m *Model) LineDown() {
 
 // LineUp scrolls up one line
 func (m *Model) LineUp() {
+	if m.selection.Active() {
+		m.MoveSelection(-1)
+		return
+	}
 	if m.viewport.YOffset() > 0 {
 		m.viewport.ScrollUp(1)
 	}
 }
 
+// StartSelection starts a line-wise selection at the top line shown and
+// stops following the log, so new output doesn't scroll it away. While a
+// selection is active the scrolling methods extend it instead.
+func (m *Model) StartSelection() {
+	if m.content == "" {
+		return
+	}
+	m.followMode = false
+	m.selection.Start(m.viewport.YOffset())
+	m.refresh()
+}
+
+// ClearSelection ends the selection.
+func (m *Model) ClearSelection() {
+	if !m.selection.Active() {
+		return
+	}
+	m.selection.Stop()
+	m.refresh()
+}
+
+// Selecting reports whether a selection is active.
+func (m Model) Selecting() bool {
+	return m.selection.Active()
+}
+
+// SelectionLen returns the number of lines selected.
+func (m Model) SelectionLen() int {
+	return m.selection.Len()
+}
+
+// MoveSelection moves the selection's cursor by delta lines, scrolling to
+// keep it visible.
+func (m *Model) MoveSelection(delta int) {
+	m.selection.Move(delta, m.lineCount)
+	m.refresh()
+	m.viewport.SetYOffset(m.selection.ScrollOffset(m.viewport.YOffset(), m.viewport.Height()))
+}
+
+// SelectedText returns the selected lines as plain text.
+func (m Model) SelectedText() string {
+	return selection.PlainText(m.selection.Lines(m.lines))
+}
+
 // SetFollowMode toggles follow mode
 func (m *Model) SetFollowMode(on bool) {
 	m.followMode = on
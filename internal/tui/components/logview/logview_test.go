@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestRenderMarkdown_Heading(t *testing.T) {
@@ -223,3 +224,73 @@ func TestLineCount_AfterSetContent(t *testing.T) {
 		t.Errorf("expected lineCount >= 1 after SetContent, got %d", m.lineCount)
 	}
 }
+
+func TestPageText_PlainVisibleLines(t *testing.T) {
+	m := New(lipgloss.NewStyle(), 80, 3)
+	m.SetContent("first line\n\nsecond line\n\nthird line\n\nfourth line")
+	text := m.PageText()
+	if strings.Contains(text, "\x1b") || strings.Contains(text, "fourth line") || !strings.Contains(text, "first line") {
+		t.Errorf("expected the plain first page, got %q", text)
+	}
+}
+
+func TestSelection_ExtendsInsteadOfScrolling(t *testing.T) {
+	m := New(lipgloss.NewStyle(), 80, 3)
+	m.SetContent("first line\n\nsecond line\n\nthird line\n\nfourth line")
+	m.SetFollowMode(true)
+	m.StartSelection()
+	if !m.Selecting() || m.FollowMode() {
+		t.Fatal("expected a selection started and following stopped")
+	}
+	for range 3 {
+		m.LineDown()
+	}
+	if m.SelectionLen() != 4 {
+		t.Errorf("expected 4 lines selected, got %d", m.SelectionLen())
+	}
+	if m.viewport.YOffset() == 0 {
+		t.Error("expected the view scrolled to keep the cursor visible")
+	}
+	text := m.SelectedText()
+	if strings.Contains(text, "\x1b") || !strings.HasPrefix(text, "first line") || !strings.HasSuffix(text, "second line") {
+		t.Errorf("expected the plain selected lines, got %q", text)
+	}
+	m.ClearSelection()
+	if m.Selecting() || m.SelectedText() != "" {
+		t.Error("expected the selection cleared")
+	}
+}
+
+func TestSearch_FindsFiltersAndShowsRaw(t *testing.T) {
+	m := New(lipgloss.NewStyle(), 80, 3)
+	m.SetContent("# Build\n\nstep one\n\nstep two\n\n**failed** on step three\n\ndone")
+	m.SetFollowMode(true)
+	m.StartSearch()
+	m.Search("step")
+	state := m.SearchState()
+	if state.Count() != 3 || state.Counter() != "1/3" || m.FollowMode() {
+		t.Fatalf("expected 3 matches and following stopped, got %s", state.Counter())
+	}
+	m.NextMatch(2)
+	if !strings.Contains(ansi.Strip(m.View()), "three") {
+		t.Errorf("expected the view scrolled to the third match, got %q", m.View())
+	}
+
+	m.Search("failed")
+	m.SetFilter(true)
+	if m.lineCount >= 9 || !strings.Contains(ansi.Strip(m.View()), "failed") {
+		t.Errorf("expected the log collapsed around the match, got %d lines", m.lineCount)
+	}
+	m.ToggleRaw()
+	if !m.Raw() || !strings.Contains(ansi.Strip(m.View()), "**failed**") {
+		t.Errorf("expected the raw markdown searched and shown, got %q", m.View())
+	}
+	m.Search("nothing like this")
+	if !strings.Contains(m.View(), "No lines match") {
+		t.Errorf("expected an empty filter explained, got %q", m.View())
+	}
+	m.ClearSearch()
+	if m.SearchState().Active() || m.SearchState().FilterMode() || m.lineCount < 9 {
+		t.Error("expected the whole log back after clearing the search")
+	}
+}
@@ -2,13 +2,15 @@ package mission
 
 import (
 "fmt"
+"image/color"
 "sort"
 "strings"
 "time"
 
 "charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
 "github.com/maxbeizer/gh-agent-viz/internal/data"
+"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 "github.com/maxbeizer/gh-agent-viz/internal/tui/components/sparkline"
 )
 
@@ -56,6 +58,18 @@ PanelRepos
 PanelIdle
 )
 
+// PanelNames lists the dashboard panels that can be shown or hidden with
+// SetVisiblePanels.
+var PanelNames = []string{"attention", "active", "recent", "fleet", "activity", "repos"}
+
+// focusPanelNames maps focusable panels to their names in PanelNames.
+var focusPanelNames = map[PanelFocus]string{
+	PanelAttention: "attention",
+	PanelActive:    "active",
+	PanelRecent:    "recent",
+	PanelRepos:     "repos",
+}
+
 // Model represents the mission control summary dashboard.
 type Model struct {
 sessions   []data.Session
@@ -69,6 +83,7 @@ panelHeights  [5]int  // per-panel visible content height from last render
 // Panel Y ranges for mouse click detection (set during render)
 panelYRanges [3][2]int // [panel][start, end] row ranges
 tokenUsage map[string]*data.TokenUsage
+hiddenPanels map[string]bool // panel names hidden via SetVisiblePanels
 statusIcon func(string) string
 animStatusIcon func(string, int) string
 animFrame  int
@@ -98,6 +113,13 @@ height:         24,
 }
 }
 
+// SetStyles replaces the theme styles, e.g. after a theme switch.
+func (m *Model) SetStyles(titleStyle, cardStyle, cardSelStyle lipgloss.Style) {
+m.titleStyle = titleStyle
+m.cardStyle = cardStyle
+m.cardSelStyle = cardSelStyle
+}
+
 // SetSessions recomputes all dashboard data from sessions.
 func (m *Model) SetSessions(sessions []data.Session) {
 m.sessions = sessions
@@ -189,6 +211,10 @@ case status == "needs-input":
 reason = "✋ Input needed"
 case status == "failed":
 reason = "❌ Failed"
+case data.PRAttention(s) == "checks failing":
+reason = "🔴 Checks failing"
+case data.PRAttention(s) == "needs review":
+reason = "👀 Needs review"
 case level == data.AttentionWarning && status == "queued":
 reason = "🟡 Queued too long"
 case level == data.AttentionWarning:
@@ -247,7 +273,11 @@ if m.scrollOffsets[p] < 0 { m.scrollOffsets[p] = 0 }
 // CyclePanel moves focus to the next/previous panel.
 func (m *Model) CyclePanel(delta int) {
 // Only cycle through rendered panels: Attention, Active, Recent.
-panels := []PanelFocus{PanelAttention, PanelActive, PanelRecent}
+var panels []PanelFocus
+for _, p := range []PanelFocus{PanelAttention, PanelActive, PanelRecent} {
+if m.panelVisible(focusPanelNames[p]) { panels = append(panels, p) }
+}
+if len(panels) == 0 { return }
 current := 0
 for i, p := range panels {
 if p == m.focus { current = i; break }
@@ -258,6 +288,63 @@ m.clampCursor()
 m.ensureVisible()
 }
 
+// SetVisiblePanels restricts the dashboard to the named panels (see
+// PanelNames). An empty list shows every panel. Unknown names are ignored
+// and returned so callers can report them.
+func (m *Model) SetVisiblePanels(names []string) []string {
+	m.hiddenPanels = nil
+	if len(names) == 0 {
+		return nil
+	}
+	known := map[string]bool{}
+	for _, n := range PanelNames {
+		known[n] = true
+	}
+	shown := map[string]bool{}
+	var unknown []string
+	for _, n := range names {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if !known[n] {
+			unknown = append(unknown, n)
+			continue
+		}
+		shown[n] = true
+	}
+	if len(shown) == 0 {
+		return unknown
+	}
+	m.hiddenPanels = map[string]bool{}
+	for _, n := range PanelNames {
+		if !shown[n] {
+			m.hiddenPanels[n] = true
+		}
+	}
+	if !m.panelVisible(focusPanelNames[m.focus]) {
+		for _, p := range []PanelFocus{PanelAttention, PanelActive, PanelRecent, PanelRepos} {
+			if m.panelVisible(focusPanelNames[p]) {
+				m.focus = p
+				break
+			}
+		}
+	}
+	return unknown
+}
+
+// VisiblePanels returns the names of the panels currently shown.
+func (m *Model) VisiblePanels() []string {
+	var names []string
+	for _, n := range PanelNames {
+		if m.panelVisible(n) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+func (m *Model) panelVisible(name string) bool {
+	return !m.hiddenPanels[name]
+}
+
 // SetFocus sets the focused panel directly.
 func (m *Model) SetFocus(panel PanelFocus) {
 m.focus = panel
@@ -358,7 +445,12 @@ return m.repos[idx].Name
 // View renders the summary dashboard.
 func (m *Model) View() string {
 if len(m.sessions) == 0 {
-return m.titleStyle.Render("  No sessions found — run gh agent-viz --demo to explore")
+return m.titleStyle.Render(a11y.Text("  No sessions found — run gh agent-viz --demo to explore"))
+}
+
+// Accessible mode: one column, read top to bottom
+if a11y.Current().Accessible {
+return m.viewLinear()
 }
 
 // Narrow terminals: fall back to single-column layout
@@ -371,16 +463,16 @@ return m.viewMultiPane()
 
 // renderPanel returns a bordered panel with a title header line above the box.
 func renderPanel(title string, content string, width, _ int) string {
-borderColor := compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("240")}
-titleColor := compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("75")}
+borderColor := colors.Current().Subtle
+titleColor := colors.Current().Highlight
 
 titleRendered := lipgloss.NewStyle().
 Bold(true).
 Foreground(titleColor).
-Render(" " + title)
+Render(" " + a11y.Text(title))
 
 box := lipgloss.NewStyle().
-Border(lipgloss.RoundedBorder()).
+Border(a11y.Border(lipgloss.RoundedBorder())).
 BorderForeground(borderColor).
 Width(width - 2).
 Padding(0, 1).
@@ -447,7 +539,7 @@ func truncateWithIndicator(lines []string, maxLines, totalItems int) []string {
 	hidden := totalItems - (maxLines - 1) // -1 for the indicator line
 	if hidden < 1 { hidden = 1 }
 	indicator := lipgloss.NewStyle().Faint(true).Render(
-		fmt.Sprintf("  ▼ %d more", hidden))
+		fmt.Sprintf(a11y.Glyph("  ▼ %d more", "  %d more below"), hidden))
 	result := make([]string, maxLines)
 	copy(result, lines[:maxLines-1])
 	result[maxLines-1] = indicator
@@ -487,16 +579,21 @@ func windowLines(lines []string, scrollOffset, maxLines, totalItems, linesPerIte
 	if end > len(lines) { end = len(lines) }
 	visible := lines[lineOffset:end]
 
+	aboveFmt, belowFmt := "  ▲ %d above", "  ▼ %d more"
+	if a11y.Current().ASCII() {
+		aboveFmt, belowFmt = "  %d more above", "  %d more below"
+	}
+
 	var result []string
 	if hasAbove {
 		above := scrollOffset
-		result = append(result, dim.Render(fmt.Sprintf("  ▲ %d above", above)))
+		result = append(result, dim.Render(fmt.Sprintf(aboveFmt, above)))
 	}
 	result = append(result, visible...)
 	if hasBelow {
 		belowItems := totalItems - scrollOffset - (len(visible) / linesPerItem)
 		if belowItems < 1 { belowItems = 1 }
-		result = append(result, dim.Render(fmt.Sprintf("  ▼ %d more", belowItems)))
+		result = append(result, dim.Render(fmt.Sprintf(belowFmt, belowItems)))
 	}
 
 	return result
@@ -505,17 +602,28 @@ func windowLines(lines []string, scrollOffset, maxLines, totalItems, linesPerIte
 // viewMultiPane renders the 2-column dashboard with Attention as the primary left panel.
 func (m *Model) viewMultiPane() string {
 dim := lipgloss.NewStyle().Faint(true)
-sessionStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("236"), Dark: lipgloss.Color("252")})
+sessionStyle := lipgloss.NewStyle().Foreground(colors.Current().Text)
 cursorStyle := lipgloss.NewStyle().Bold(true)
 
 totalWidth := m.width - 2
 leftWidth := totalWidth * 60 / 100
 rightWidth := totalWidth - leftWidth
 
+showAttn := m.panelVisible("attention")
+showFleet := m.panelVisible("fleet")
+showActivity := m.panelVisible("activity")
+showActive := m.panelVisible("active")
+showRecent := m.panelVisible("recent")
+if !showAttn {
+leftWidth, rightWidth = 0, totalWidth
+} else if !showFleet && !showActivity && !showActive && !showRecent {
+leftWidth, rightWidth = totalWidth, 0
+}
+
 availHeight := m.height - 6
 if availHeight < 12 { availHeight = 12 }
 
-focusColor := compat.AdaptiveColor{Light: lipgloss.Color("30"), Dark: lipgloss.Color("73")}
+focusColor := colors.Current().Focus
 
 // ── BUILD ALL CONTENT LINES FIRST (no truncation yet) ──
 
@@ -533,18 +641,18 @@ title := s.Title
 repo := shortRepo(s.Repository)
 age := ""
 if !s.CreatedAt.IsZero() {
-age = formatDuration(time.Since(s.CreatedAt))
+age = formatDuration(data.Since(s.CreatedAt))
 }
 rightText := repo
 if age != "" { rightText = repo + "  " + age }
-maxT := rInnerW - len(rightText) - 8
+maxT := rInnerW - len(rightText) - 8 - (lipgloss.Width(icon) - 2)
 if maxT < 10 { maxT = 10 }
-if len(title) > maxT { title = title[:maxT-1] + "…" }
+title = a11y.Truncate(title, maxT)
 
 gutter := "  "
 titleRender := sessionStyle.Render(title)
 if m.focus == PanelActive && i == m.cursors[PanelActive] {
-gutter = "▎ "
+gutter = a11y.Glyph("▎ ", "| ")
 titleRender = cursorStyle.Render(title)
 }
 left := fmt.Sprintf("%s%s %s", gutter, icon, titleRender)
@@ -557,7 +665,7 @@ if len(activeLines) == 0 {
 activeLines = append(activeLines, dim.Render("  no active sessions"))
 }
 
-// Attention — split needs-input (with messages) from failed (collapsed)
+// Attention — split needs-input and PR items (with details) from failed (collapsed)
 var attnLines []string
 innerW := leftWidth - 6
 
@@ -572,34 +680,37 @@ inputItems = append(inputItems, item)
 }
 }
 
-// Needs-input items: 2 lines each (title + assistant message)
+// Needs-input and PR items: 2 lines each (title + assistant message or PR signal)
 for i, item := range inputItems {
 title := item.Session.Title
 maxT := innerW * 2 / 3
 if maxT < 10 { maxT = 10 }
-if len(title) > maxT { title = title[:maxT-1] + "…" }
+title = a11y.Truncate(title, maxT)
 repo := shortRepo(item.Session.Repository)
 ago := formatAge(item.Session.UpdatedAt)
 
 gutter := "  "
 titleRender := sessionStyle.Render(title)
 if m.focus == PanelAttention && i == m.cursors[PanelAttention] {
-gutter = "▎ "
+gutter = a11y.Glyph("▎ ", "| ")
 titleRender = cursorStyle.Render(title)
 }
 
-left := fmt.Sprintf("%s✋ %s", gutter, titleRender)
+icon, _, _ := strings.Cut(a11y.Text(item.Reason), " ")
+left := fmt.Sprintf("%s%s %s", gutter, icon, titleRender)
 right := dim.Render(fmt.Sprintf("%s  %s", repo, ago))
 pad := innerW - lipgloss.Width(left) - lipgloss.Width(right)
 if pad < 1 { pad = 1 }
 attnLines = append(attnLines, left + strings.Repeat(" ", pad) + right)
 
-if item.Session.LastAssistantMessage != "" {
+if pr := data.PRAttention(item.Session); pr != "" && item.Session.PR != nil {
+attnLines = append(attnLines, gutter + "  " + dim.Render(a11y.Text(fmt.Sprintf("PR #%d · %s", item.Session.PR.Number, pr))))
+} else if item.Session.LastAssistantMessage != "" {
 msgText := item.Session.LastAssistantMessage
 maxMsg := innerW - 6
 if maxMsg < 20 { maxMsg = 20 }
-if len(msgText) > maxMsg { msgText = msgText[:maxMsg-1] + "…" }
-attnLines = append(attnLines, gutter + "  " + dim.Render("💬 " + msgText))
+msgText = a11y.Truncate(msgText, maxMsg)
+attnLines = append(attnLines, gutter + "  " + dim.Render(a11y.Glyph("💬 ", "> ") + msgText))
 } else {
 attnLines = append(attnLines, gutter + "  " + dim.Render("waiting for your response"))
 }
@@ -610,43 +721,43 @@ if len(failedItems) > 0 {
 if len(inputItems) > 0 {
 attnLines = append(attnLines, "")
 }
-failedStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("160"), Dark: lipgloss.Color("203")})
-attnLines = append(attnLines, failedStyle.Render(fmt.Sprintf("  ❌ %d failed sessions", len(failedItems))))
+failedStyle := lipgloss.NewStyle().Foreground(colors.Current().Status.Failed)
+attnLines = append(attnLines, failedStyle.Render(a11y.Text(fmt.Sprintf("  ❌ %d failed sessions", len(failedItems)))))
 maxShow := 3
 if len(failedItems) < maxShow { maxShow = len(failedItems) }
 for j := 0; j < maxShow; j++ {
 title := failedItems[j].Session.Title
 maxT := innerW - 10
 if maxT < 10 { maxT = 10 }
-if len(title) > maxT { title = title[:maxT-1] + "…" }
+title = a11y.Truncate(title, maxT)
 ago := formatAge(failedItems[j].Session.UpdatedAt)
 attnLines = append(attnLines, dim.Render(fmt.Sprintf("     %s  %s", title, ago)))
 }
 if len(failedItems) > maxShow {
-attnLines = append(attnLines, dim.Render(fmt.Sprintf("     … and %d more", len(failedItems)-maxShow)))
+attnLines = append(attnLines, dim.Render(fmt.Sprintf("     %s and %d more", a11y.Ellipsis(), len(failedItems)-maxShow)))
 }
 }
 
 if len(attnLines) == 0 {
-attnLines = append(attnLines, dim.Render("  all clear ✨"))
+attnLines = append(attnLines, dim.Render(a11y.Text("  all clear ✨")))
 }
 
 // Recent completions
 recentDone := m.recentCompletions(8)
 var recentLines []string
 for i, s := range recentDone {
-icon := "✅"
-if strings.EqualFold(s.Status, "failed") { icon = "❌" }
+icon := a11y.Glyph("✅", "+")
+if strings.EqualFold(s.Status, "failed") { icon = a11y.Glyph("❌", "x") }
 title := s.Title
 maxT := rInnerW - 20
 if maxT < 10 { maxT = 10 }
-if len(title) > maxT { title = title[:maxT-1] + "…" }
+title = a11y.Truncate(title, maxT)
 ago := formatAge(s.UpdatedAt)
 pr := ""
 if s.PRNumber > 0 { pr = dim.Render(fmt.Sprintf(" PR #%d", s.PRNumber)) }
 gutter := "  "
 if m.focus == PanelRecent && i == m.cursors[PanelRecent] {
-gutter = "▎ "
+gutter = a11y.Glyph("▎ ", "| ")
 title = cursorStyle.Render(title)
 }
 recentLines = append(recentLines, fmt.Sprintf("%s%s %s%s  %s", gutter, icon, title, pr, dim.Render(ago)))
@@ -672,9 +783,10 @@ summaryParts = append(summaryParts, fmt.Sprintf("🪙 %s", data.FormatTokenCount
 }
 // Pulse animation indicator
 pulseFrames := []string{"◐", "◓", "◑", "◒"}
+if a11y.Current().ASCII() { pulseFrames = []string{"*"} }
 pulseChar := pulseFrames[m.animFrame % len(pulseFrames)]
-pulseStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("28"), Dark: lipgloss.Color("42")})
-fleetLines = append(fleetLines, strings.Join(summaryParts, "  "))
+pulseStyle := lipgloss.NewStyle().Foreground(colors.Current().Status.Running)
+fleetLines = append(fleetLines, a11y.Text(strings.Join(summaryParts, "  ")))
 barWidth := rightWidth - 6
 if barWidth > 50 { barWidth = 50 }
 if barWidth > 0 && m.stats.Total > 0 {
@@ -685,7 +797,7 @@ if todayDone > 0 || todayTokens > 0 {
 var todayParts []string
 if todayDone > 0 { todayParts = append(todayParts, fmt.Sprintf("✅ %d completed", todayDone)) }
 if todayTokens > 0 { todayParts = append(todayParts, fmt.Sprintf("🪙 %s", data.FormatTokenCount(todayTokens))) }
-fleetLines = append(fleetLines, dim.Render("today: " + strings.Join(todayParts, "  ")))
+fleetLines = append(fleetLines, dim.Render(a11y.Text("today: " + strings.Join(todayParts, "  "))))
 }
 
 // Cost estimate
@@ -703,17 +815,17 @@ hourlyFloats := make([]float64, 24)
 for i, v := range hourly {
 hourlyFloats[i] = float64(v)
 }
-heatmapStr := sparkline.RenderHeatmap(hourlyFloats, 24)
-heatLabel := lipgloss.NewStyle().Bold(true).Foreground(compat.AdaptiveColor{Light: lipgloss.Color("172"), Dark: lipgloss.Color("214")}).Render("🔥 24h")
-activityLines = append(activityLines, heatLabel + " " + heatmapStr + dim.Render("  0h─────────12h────────23h"))
+heatmapStr := sparkline.Colorize(sparkline.RenderHeatmap(hourlyFloats, 24), colors.Current().Sparkline)
+heatLabel := lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Attention.Warning).Render(a11y.Text("🔥 24h"))
+activityLines = append(activityLines, heatLabel + " " + heatmapStr + dim.Render(a11y.Text("  0h─────────12h────────23h")))
 
 // 7-day trend
 daily7 := data.DailySessionCounts(m.sessions, 7)
 daily7f := make([]float64, len(daily7))
 for i, v := range daily7 { daily7f[i] = float64(v) }
-trendStr := sparkline.Render(daily7f, 14)
+trendStr := sparkline.Colorize(sparkline.Render(daily7f, 14), colors.Current().Sparkline)
 arrow := sparkline.TrendArrow(daily7f)
-trendLabel := lipgloss.NewStyle().Bold(true).Foreground(compat.AdaptiveColor{Light: lipgloss.Color("30"), Dark: lipgloss.Color("75")}).Render("📊 7d")
+trendLabel := lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Highlight).Render(a11y.Text("📊 7d"))
 activityLines = append(activityLines, trendLabel + "  " + trendStr + " " + arrow)
 
 // Model distribution
@@ -721,12 +833,15 @@ if m.tokenUsage != nil {
 dist := data.ModelDistribution(m.tokenUsage)
 if len(dist) > 0 {
 activityLines = append(activityLines, "")
-modelLabel := lipgloss.NewStyle().Bold(true).Foreground(compat.AdaptiveColor{Light: lipgloss.Color("99"), Dark: lipgloss.Color("141")}).Render("🤖 Models")
+modelLabel := lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Accent).Render(a11y.Text("🤖 Models"))
 activityLines = append(activityLines, modelLabel)
 type mc struct { name string; count int }
 var models []mc
 for k, v := range dist { models = append(models, mc{k, v}) }
-sort.Slice(models, func(i, j int) bool { return models[i].count > models[j].count })
+sort.Slice(models, func(i, j int) bool {
+if models[i].count != models[j].count { return models[i].count > models[j].count }
+return models[i].name < models[j].name
+})
 for _, mdl := range models {
 activityLines = append(activityLines, dim.Render(fmt.Sprintf("  %s: %d sessions", mdl.name, mdl.count)))
 }
@@ -739,14 +854,19 @@ activityLines = append(activityLines, dim.Render(fmt.Sprintf("  %s: %d sessions"
 // Right column: Fleet (fixed), Activity (fixed), Active, Recent
 
 leftPanelCount := 1 // Attention
-rightPanelCount := 4 // Fleet, Activity, Active, Recent
+rightPanelCount := 0 // Fleet, Activity, Active, Recent (when visible)
+for _, shown := range []bool{showFleet, showActivity, showActive, showRecent} {
+if shown { rightPanelCount++ }
+}
 
 leftContentBudget := availHeight - (leftPanelCount * panelChrome)
 if leftContentBudget < 3 { leftContentBudget = 3 }
 
 // Right column: reserve fleet+activity lines first (fixed), budget the rest.
 fleetFixed := len(fleetLines)
+if !showFleet { fleetFixed = 0 }
 activityFixed := len(activityLines)
+if !showActivity { activityFixed = 0 }
 rightContentBudget := availHeight - (rightPanelCount * panelChrome) - fleetFixed - activityFixed
 if rightContentBudget < 4 { rightContentBudget = 4 }
 
@@ -756,8 +876,10 @@ m.ensureVisible()
 attnLines = windowLines(attnLines, m.scrollOffsets[PanelAttention], leftContentBudget, len(m.attention), 2)
 
 // Right: Active and Recent split remaining budget
-rightRequested := []int{len(activeLines), len(recentLines)}
-rightAlloc := allocateBudget(rightRequested, rightContentBudget, 1)
+activeRequested, recentRequested := len(activeLines), len(recentLines)
+if !showActive { activeRequested = 0 }
+if !showRecent { recentRequested = 0 }
+rightAlloc := allocateBudget([]int{activeRequested, recentRequested}, rightContentBudget, 1)
 m.panelHeights[PanelActive] = rightAlloc[0]
 m.panelHeights[PanelRecent] = rightAlloc[1]
 activeLines = windowLines(activeLines, m.scrollOffsets[PanelActive], rightAlloc[0], len(activeSessions), 1)
@@ -784,12 +906,21 @@ strings.Join(recentLines, "\n"), rightWidth, len(recentLines),
 m.focus == PanelRecent, focusColor)
 
 // ── ASSEMBLE COLUMNS ──
-leftCol := attnPanel
+var columns []string
+if showAttn {
+columns = append(columns, attnPanel)
+}
 
-rightPanels := []string{fleetPanel, activityPanel, activePanel, recentPanel}
-rightCol := lipgloss.JoinVertical(lipgloss.Left, rightPanels...)
+var rightPanels []string
+if showFleet { rightPanels = append(rightPanels, fleetPanel) }
+if showActivity { rightPanels = append(rightPanels, activityPanel) }
+if showActive { rightPanels = append(rightPanels, activePanel) }
+if showRecent { rightPanels = append(rightPanels, recentPanel) }
+if len(rightPanels) > 0 {
+columns = append(columns, lipgloss.JoinVertical(lipgloss.Left, rightPanels...))
+}
 
-result := lipgloss.JoinHorizontal(lipgloss.Top, leftCol, rightCol)
+result := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
 
 // Safety clamp: ensure we never exceed the available height.
 if lipgloss.Height(result) > availHeight {
@@ -817,10 +948,10 @@ func (m *Model) viewSingleColumn() string {
 w := m.width - 4
 if w < 40 { w = 40 }
 
-sessionStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("236"), Dark: lipgloss.Color("252")})
+sessionStyle := lipgloss.NewStyle().Foreground(colors.Current().Text)
 cursorStyle := lipgloss.NewStyle().Bold(true)
 dim := lipgloss.NewStyle().Faint(true)
-tabActive := lipgloss.NewStyle().Bold(true).Foreground(compat.AdaptiveColor{Light: lipgloss.Color("15"), Dark: lipgloss.Color("15")}).Background(compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("62")}).Padding(0, 1)
+tabActive := lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Inverse).Background(colors.Current().TabBg).Padding(0, 1)
 tabInactive := lipgloss.NewStyle().Faint(true).Padding(0, 1)
 
 availHeight := m.height - 6
@@ -844,10 +975,13 @@ tabs := []tabDef{
 
 var tabParts []string
 for _, tab := range tabs {
+	if !m.panelVisible(focusPanelNames[tab.panel]) {
+		continue
+	}
 	if tab.panel == m.focus {
-		tabParts = append(tabParts, tabActive.Render(tab.label))
+		tabParts = append(tabParts, tabActive.Render(a11y.Text(tab.label)))
 	} else {
-		tabParts = append(tabParts, tabInactive.Render(tab.label))
+		tabParts = append(tabParts, tabInactive.Render(a11y.Text(tab.label)))
 	}
 }
 tabBar := strings.Join(tabParts, " ")
@@ -860,9 +994,10 @@ if m.stats.Done > 0 { summaryParts = append(summaryParts, fmt.Sprintf("✅ %d do
 if m.stats.Failed > 0 { summaryParts = append(summaryParts, fmt.Sprintf("❌ %d fail", m.stats.Failed)) }
 // Pulse animation
 pulseFrames := []string{"◐", "◓", "◑", "◒"}
+if a11y.Current().ASCII() { pulseFrames = []string{"*"} }
 pulseChar := pulseFrames[m.animFrame % len(pulseFrames)]
-pulseStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("28"), Dark: lipgloss.Color("42")})
-fleetLine := "  " + pulseStyle.Render(pulseChar + " LIVE") + "  " + strings.Join(summaryParts, "  ")
+pulseStyle := lipgloss.NewStyle().Foreground(colors.Current().Status.Running)
+fleetLine := "  " + pulseStyle.Render(pulseChar + " LIVE") + "  " + a11y.Text(strings.Join(summaryParts, "  "))
 
 // Chrome: fleet line + tab bar + blank line = 3 lines.
 contentHeight := availHeight - 3
@@ -883,11 +1018,11 @@ case PanelActive:
 		title := s.Title
 		maxT := w - 10
 		if maxT < 10 { maxT = 10 }
-		if len(title) > maxT { title = title[:maxT-1] + "…" }
+		title = a11y.Truncate(title, maxT)
 		gutter := "  "
 		titleRender := sessionStyle.Render(title)
 		if i == m.cursors[PanelActive] {
-			gutter = "▎ "
+			gutter = a11y.Glyph("▎ ", "| ")
 			titleRender = cursorStyle.Render(title)
 		}
 		items = append(items, fmt.Sprintf("%s%s %s", gutter, icon, titleRender))
@@ -902,35 +1037,35 @@ case PanelAttention:
 		title := item.Session.Title
 		maxT := w / 2
 		if maxT < 10 { maxT = 10 }
-		if len(title) > maxT { title = title[:maxT-1] + "…" }
+		title = a11y.Truncate(title, maxT)
 		ago := formatAge(item.Session.UpdatedAt)
 		gutter := "  "
 		titleRender := sessionStyle.Render(title)
 		if i == m.cursors[PanelAttention] {
-			gutter = "▎ "
+			gutter = a11y.Glyph("▎ ", "| ")
 			titleRender = cursorStyle.Render(title)
 		}
 		items = append(items, fmt.Sprintf("%s%s %s  %s", gutter, item.Reason, titleRender, dim.Render(ago)))
 	}
 	if len(items) == 0 {
-		items = append(items, dim.Render("  all clear ✨"))
+		items = append(items, dim.Render(a11y.Text("  all clear ✨")))
 	}
 
 case PanelRecent:
 	totalCount = len(recentDone)
 	for i, s := range recentDone {
-		icon := "✅"
-		if strings.EqualFold(s.Status, "failed") { icon = "❌" }
+		icon := a11y.Glyph("✅", "+")
+		if strings.EqualFold(s.Status, "failed") { icon = a11y.Glyph("❌", "x") }
 		title := s.Title
 		maxT := w - 20
 		if maxT < 10 { maxT = 10 }
-		if len(title) > maxT { title = title[:maxT-1] + "…" }
+		title = a11y.Truncate(title, maxT)
 		ago := formatAge(s.UpdatedAt)
 		pr := ""
 		if s.PRNumber > 0 { pr = dim.Render(fmt.Sprintf(" PR #%d", s.PRNumber)) }
 		gutter := "  "
 		if i == m.cursors[PanelRecent] {
-			gutter = "▎ "
+			gutter = a11y.Glyph("▎ ", "| ")
 			title = cursorStyle.Render(title)
 		}
 		items = append(items, fmt.Sprintf("%s%s %s%s  %s", gutter, icon, title, pr, dim.Render(ago)))
@@ -963,10 +1098,137 @@ return strings.Join([]string{
 }, "\n")
 }
 
+// viewLinear renders the dashboard as a single column of plain headed
+// sections for accessible mode: fleet summary, attention, active, recent and
+// activity, in that order. Statuses are spelled out and the cursor is marked
+// with ">" so the screen reads naturally from top to bottom.
+func (m *Model) viewLinear() string {
+	w := m.width - 2
+	if w < 40 { w = 40 }
+
+	heading := lipgloss.NewStyle().Bold(true)
+	dim := lipgloss.NewStyle().Faint(true)
+
+	availHeight := m.height - 6
+	if availHeight < 12 { availHeight = 12 }
+
+	clip := func(s string, max int) string {
+		if max < 10 { max = 10 }
+		if len(s) > max { return s[:max-3] + "..." }
+		return s
+	}
+	gutter := func(panel PanelFocus, i int) string {
+		if m.focus == panel && i == m.cursors[panel] { return "> " }
+		return "  "
+	}
+
+	// Fleet summary comes first, as one line.
+	fleet := fmt.Sprintf("Fleet: %d sessions, %d active, %d idle, %d needs input, %d done, %d failed",
+		m.stats.Total, m.stats.Active, m.stats.Idle, m.stats.NeedsInput, m.stats.Done, m.stats.Failed)
+
+	type section struct {
+		panel PanelFocus
+		title string
+		lines []string
+		count int
+		perItem int
+	}
+	var sections []section
+
+	if m.panelVisible("attention") {
+		var lines []string
+		for i, item := range m.attention {
+			age := formatAge(item.Session.UpdatedAt)
+			lines = append(lines, fmt.Sprintf("%s%s: %s (%s, %s)", gutter(PanelAttention, i),
+				reasonText(item.Reason), clip(item.Session.Title, w/2), shortRepo(item.Session.Repository), age))
+			msg := "waiting for your response"
+			if pr := data.PRAttention(item.Session); pr != "" && item.Session.PR != nil {
+				msg = fmt.Sprintf("PR #%d: %s", item.Session.PR.Number, pr)
+			} else if item.Session.LastAssistantMessage != "" {
+				msg = "last message: " + clip(item.Session.LastAssistantMessage, w-20)
+			}
+			lines = append(lines, "    "+dim.Render(msg))
+		}
+		if len(lines) == 0 { lines = append(lines, dim.Render("  all clear")) }
+		sections = append(sections, section{PanelAttention, fmt.Sprintf("Attention (%d)", len(m.attention)), lines, len(m.attention), 2})
+	}
+
+	if m.panelVisible("active") {
+		active := m.activeSessions()
+		var lines []string
+		for i, s := range active {
+			lines = append(lines, fmt.Sprintf("%s%s %s (%s)", gutter(PanelActive, i),
+				m.statusIcon(s.Status), clip(s.Title, w-30), shortRepo(s.Repository)))
+		}
+		if len(lines) == 0 { lines = append(lines, dim.Render("  no active sessions")) }
+		sections = append(sections, section{PanelActive, fmt.Sprintf("Active (%d)", len(active)), lines, len(active), 1})
+	}
+
+	if m.panelVisible("recent") {
+		recent := m.recentCompletions(8)
+		var lines []string
+		for i, s := range recent {
+			line := fmt.Sprintf("%s%s %s", gutter(PanelRecent, i), m.statusIcon(s.Status), clip(s.Title, w-40))
+			if s.PRNumber > 0 { line += fmt.Sprintf(", PR #%d", s.PRNumber) }
+			lines = append(lines, line+" "+dim.Render("("+formatAge(s.UpdatedAt)+")"))
+		}
+		if len(lines) == 0 { lines = append(lines, dim.Render("  no completions yet")) }
+		sections = append(sections, section{PanelRecent, fmt.Sprintf("Recent (%d)", len(recent)), lines, len(recent), 1})
+	}
+
+	var activity []string
+	if m.panelVisible("activity") {
+		hourly := data.HourlyActivity(m.sessions)
+		hourlyFloats := make([]float64, len(hourly))
+		for i, v := range hourly { hourlyFloats[i] = float64(v) }
+		daily7 := data.DailySessionCounts(m.sessions, 7)
+		daily7f := make([]float64, len(daily7))
+		for i, v := range daily7 { daily7f[i] = float64(v) }
+		activity = []string{
+			heading.Render("Activity"),
+			"  last 24 hours: " + sparkline.RenderHeatmap(hourlyFloats, 24),
+			"  last 7 days:   " + sparkline.Render(daily7f, 14) + " " + sparkline.TrendArrow(daily7f),
+		}
+	}
+
+	// Each section costs a heading line plus a blank separator; share what
+	// is left between them by how much each has to show.
+	budget := availHeight - 2 - len(activity) - 2*len(sections)
+	if budget < len(sections) { budget = len(sections) }
+	requested := make([]int, len(sections))
+	for i, sec := range sections { requested[i] = len(sec.lines) }
+	alloc := allocateBudget(requested, budget, 1)
+
+	var out []string
+	if m.panelVisible("fleet") { out = append(out, fleet) }
+	for i, sec := range sections {
+		title := sec.title
+		if sec.panel == m.focus { title += " [focused]" }
+		m.panelHeights[sec.panel] = alloc[i]
+		if sec.panel == m.focus { m.ensureVisible() }
+		if len(out) > 0 { out = append(out, "") }
+		out = append(out, heading.Render(title))
+		out = append(out, windowLines(sec.lines, m.scrollOffsets[sec.panel], alloc[i], sec.count, sec.perItem)...)
+	}
+	if len(activity) > 0 {
+		if len(out) > 0 { out = append(out, "") }
+		out = append(out, activity...)
+	}
+	return strings.Join(out, "\n")
+}
+
+// reasonText strips the leading icon from an attention reason.
+func reasonText(reason string) string {
+	if _, text, ok := strings.Cut(reason, " "); ok {
+		return text
+	}
+	return reason
+}
+
 // renderPanelFocused renders a panel with a highlighted border when focused.
-func renderPanelFocused(title string, content string, width, _ int, focused bool, focusColor compat.AdaptiveColor) string {
-borderColor := compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("240")}
-titleColor := compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("75")}
+func renderPanelFocused(title string, content string, width, _ int, focused bool, focusColor color.Color) string {
+borderColor := colors.Current().Subtle
+titleColor := colors.Current().Highlight
 if focused {
 borderColor = focusColor
 titleColor = focusColor
@@ -975,10 +1237,10 @@ titleColor = focusColor
 titleRendered := lipgloss.NewStyle().
 Bold(true).
 Foreground(titleColor).
-Render(" " + title)
+Render(" " + a11y.Text(title))
 
 box := lipgloss.NewStyle().
-Border(lipgloss.RoundedBorder()).
+Border(a11y.Border(lipgloss.RoundedBorder())).
 BorderForeground(borderColor).
 Width(width - 2).
 Padding(0, 1).
@@ -993,16 +1255,17 @@ if total == 0 {
 return ""
 }
 // Compute segment widths
+p := colors.Current()
 segments := []struct {
 count int
 char  string
-color string
+color color.Color
 }{
-{m.stats.Active, "█", "42"},
-{m.stats.Idle, "▓", "243"},
-{m.stats.NeedsInput, "█", "214"},
-{m.stats.Done, "░", "72"},
-{m.stats.Failed, "█", "203"},
+{m.stats.Active, a11y.Glyph("█", "#"), p.Status.Running},
+{m.stats.Idle, a11y.Glyph("▓", "="), p.Status.Idle},
+{m.stats.NeedsInput, a11y.Glyph("█", "!"), p.Status.NeedsInput},
+{m.stats.Done, a11y.Glyph("░", "."), p.Status.Completed},
+{m.stats.Failed, a11y.Glyph("█", "x"), p.Status.Failed},
 }
 
 var parts []string
@@ -1015,7 +1278,7 @@ if segWidth < 1 {
 segWidth = 1
 }
 parts = append(parts, lipgloss.NewStyle().
-Foreground(lipgloss.Color(seg.color)).
+Foreground(seg.color).
 Render(strings.Repeat(seg.char, segWidth)))
 }
 return strings.Join(parts, "")
@@ -1024,7 +1287,7 @@ return strings.Join(parts, "")
 func (m *Model) renderRepoRow(r repoSummary, selected bool, width int) string {
 gutter := "  "
 if selected {
-gutter = "▎ "
+gutter = a11y.Glyph("▎ ", "| ")
 }
 
 // Build counts
@@ -1047,11 +1310,9 @@ counts = append(counts, fmt.Sprintf("❌ %d", r.Failed))
 
 name := r.Name
 maxName := width / 3
-if len(name) > maxName {
-name = name[:maxName-1] + "…"
-}
+name = a11y.Truncate(name, maxName)
 
-right := strings.Join(counts, "   ")
+right := a11y.Text(strings.Join(counts, "   "))
 pad := width - len(gutter) - len(name) - len(right) - 4
 if pad < 2 {
 pad = 2
@@ -1109,7 +1370,7 @@ return idle
 
 // todayStats returns completed count and tokens burned since midnight UTC.
 func (m *Model) todayStats() (int, int64) {
-now := time.Now().UTC()
+now := data.Now().UTC()
 todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 completed := 0
 tokens := int64(0)
@@ -1150,7 +1411,7 @@ func formatAge(t time.Time) string {
 if t.IsZero() {
 return ""
 }
-d := time.Since(t)
+d := data.Since(t)
 switch {
 case d < time.Minute:
 return "just now"
@@ -1180,6 +1441,7 @@ return fmt.Sprintf("%dd", int(d.Hours()/24))
 }
 
 // DeriveLastAction returns a brief description of what the session is currently doing.
+// It only formats fields already on the session, so it is safe to call while rendering.
 func DeriveLastAction(s data.Session) string {
 status := strings.ToLower(strings.TrimSpace(s.Status))
 switch status {
@@ -1194,7 +1456,7 @@ return fmt.Sprintf("📤 PR #%d ready for review", s.PRNumber)
 return "✅ Completed"
 case "needs-input":
 if s.Source == data.SourceLocalCopilot {
-if msg := data.FetchLastAssistantMessage(s.ID); msg != "" {
+if msg := s.LastAssistantMessage; msg != "" {
 truncated := msg
 if len(truncated) > 80 {
 truncated = truncated[:77] + "..."
@@ -1205,8 +1467,8 @@ return "❓ \"" + truncated + "\""
 return "✋ Waiting for input"
 case "running":
 if s.Source == data.SourceLocalCopilot {
-if action := data.FetchLastSessionAction(s); action != "" {
-return action
+if s.LastAction != "" {
+return s.LastAction
 }
 }
 return "● Working..."
@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
 )
 
 func plainIcon(status string) string {
@@ -222,6 +224,17 @@ func TestDeriveLastAction_NeedsInput(t *testing.T) {
 	}
 }
 
+func TestDeriveLastAction_UsesSessionActivity(t *testing.T) {
+	running := data.Session{Status: "running", Source: data.SourceLocalCopilot, LastAction: "🔧 bash"}
+	if action := DeriveLastAction(running); action != "🔧 bash" {
+		t.Fatalf("expected the last tool, got %q", action)
+	}
+	waiting := data.Session{Status: "needs-input", Source: data.SourceLocalCopilot, LastAssistantMessage: "Proceed?"}
+	if action := DeriveLastAction(waiting); action != `❓ "Proceed?"` {
+		t.Fatalf("expected the last message, got %q", action)
+	}
+}
+
 func TestFormatAge(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -301,6 +314,32 @@ func TestView_AttentionSectionWithNeedsInput(t *testing.T) {
 	}
 }
 
+func TestView_AttentionSectionShowsPRSignals(t *testing.T) {
+	m := newTestModel()
+	m.SetSize(140, 40)
+	now := time.Now()
+	m.SetSessions([]data.Session{
+		{ID: "1", Status: "running", Title: "Red build", Repository: "owner/repo", Source: data.SourceAgentTask, UpdatedAt: now,
+			PR: &data.PRStatus{Number: 12, State: "OPEN", Checks: "FAILURE"}},
+		{ID: "2", Status: "completed", Title: "Ready to review", Repository: "owner/repo", Source: data.SourceAgentTask, UpdatedAt: now,
+			PR: &data.PRStatus{Number: 13, State: "OPEN", Checks: "SUCCESS", ReviewDecision: "REVIEW_REQUIRED"}},
+		{ID: "3", Status: "completed", Title: "Approved work", Repository: "owner/repo", Source: data.SourceAgentTask, UpdatedAt: now,
+			PR: &data.PRStatus{Number: 14, State: "OPEN", ReviewDecision: "APPROVED"}},
+	})
+	if len(m.attention) != 2 || m.attention[0].Reason != "🔴 Checks failing" || m.attention[1].Reason != "👀 Needs review" {
+		t.Fatalf("expected PR signals in the attention panel, got %+v", m.attention)
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"🔴 Red build", "PR #12 · checks failing", "👀 Ready to review", "PR #13 · needs review"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the attention panel, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Approved work") && strings.Contains(view, "PR #14") {
+		t.Error("an approved PR should not ask for attention")
+	}
+}
+
 func TestSetSize(t *testing.T) {
 	m := newTestModel()
 	m.SetSize(120, 40)
@@ -518,3 +557,82 @@ func TestWindowLines_ScrolledDown(t *testing.T) {
 		t.Fatalf("expected below indicator, got %q", result[3])
 	}
 }
+
+func TestSetVisiblePanels(t *testing.T) {
+	m := newTestModel()
+	m.SetSize(140, 40)
+	m.SetSessions([]data.Session{
+		{ID: "1", Status: "running", Title: "Fix auth bug", Repository: "owner/repo", UpdatedAt: time.Now()},
+		{ID: "2", Status: "needs-input", Title: "Needs answer", Repository: "owner/repo", UpdatedAt: time.Now()},
+	})
+
+	unknown := m.SetVisiblePanels([]string{"active", "fleet", "bogus"})
+	if len(unknown) != 1 || unknown[0] != "bogus" {
+		t.Errorf("expected bogus to be reported as unknown, got %v", unknown)
+	}
+	if got := strings.Join(m.VisiblePanels(), ","); got != "active,fleet" {
+		t.Errorf("expected active,fleet visible, got %s", got)
+	}
+	if m.Focus() != PanelActive {
+		t.Errorf("expected focus to move off the hidden attention panel, got %v", m.Focus())
+	}
+
+	view := m.View()
+	if strings.Contains(view, "Attention") {
+		t.Error("hidden attention panel should not render")
+	}
+	if !strings.Contains(view, "Active") || !strings.Contains(view, "Fleet") {
+		t.Error("visible panels should render")
+	}
+
+	m.CyclePanel(1)
+	if m.Focus() != PanelActive {
+		t.Errorf("cycling should stay on the only visible focusable panel, got %v", m.Focus())
+	}
+
+	m.SetVisiblePanels(nil)
+	if len(m.VisiblePanels()) != len(PanelNames) {
+		t.Error("empty panel list should show every panel")
+	}
+}
+
+func TestViewLinear_AccessibleOrder(t *testing.T) {
+	a11y.Set(a11y.Mode{Accessible: true})
+	defer a11y.Set(a11y.Mode{})
+
+	m := New(lipgloss.NewStyle(), lipgloss.NewStyle(), lipgloss.NewStyle(),
+		func(status string) string { return "[" + a11y.StatusLabel(status) + "]" }, nil)
+	m.SetSize(140, 40)
+	now := time.Now()
+	m.SetSessions([]data.Session{
+		{ID: "1", Status: "running", Title: "Build feature", Repository: "owner/repo", UpdatedAt: now, CreatedAt: now},
+		{ID: "2", Status: "needs-input", Title: "Waiting task", Repository: "owner/repo", Source: data.SourceAgentTask, UpdatedAt: now},
+		{ID: "3", Status: "completed", Title: "Shipped fix", Repository: "owner/repo", UpdatedAt: now.Add(-time.Hour)},
+	})
+	view := ansi.Strip(m.View())
+
+	var last int
+	for _, heading := range []string{"Fleet:", "Attention (1)", "Active (2)", "Recent (1)", "Activity"} {
+		idx := strings.Index(view, heading)
+		if idx < last {
+			t.Fatalf("expected %q after the previous section, view:\n%s", heading, view)
+		}
+		last = idx
+	}
+	if !strings.Contains(view, "[running] Build feature") || !strings.Contains(view, "[done] Shipped fix") {
+		t.Errorf("expected statuses in words, view:\n%s", view)
+	}
+	if !strings.Contains(view, "Input needed: Waiting task") {
+		t.Errorf("expected attention reason without its icon, view:\n%s", view)
+	}
+	if strings.Contains(view, "╭") || strings.Contains(view, "▎") {
+		t.Errorf("expected no boxes or glyph gutters, view:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		for _, r := range line {
+			if r > 127 {
+				t.Fatalf("expected ASCII-only output, got %q", line)
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package picker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+)
+
+// Item is a single selectable entry.
+type Item struct {
+	Label  string // primary text
+	Detail string // secondary, dimmed text
+	Key    string // key binding shown right-aligned (optional)
+	Value  string // opaque identifier returned on selection
+}
+
+// Model is a centered overlay listing items to choose from. When filterable,
+// typed text fuzzy-filters the list; otherwise items are numbered and the
+// digits 1-9 select directly.
+type Model struct {
+	title      string
+	filterable bool
+	visible    bool
+	items      []Item
+	matches    []int // indices into items, in display order
+	query      string
+	cursor     int
+	width      int
+	height     int
+}
+
+// maxRows caps how many items are listed at once.
+const maxRows = 12
+
+// New creates a hidden picker.
+func New(title string, filterable bool) Model {
+	return Model{title: title, filterable: filterable, width: 80, height: 24}
+}
+
+// Open shows the picker with the given items and resets the query and cursor.
+func (m *Model) Open(items []Item) {
+	m.items = items
+	m.visible = true
+	m.query = ""
+	m.cursor = 0
+	m.refilter()
+}
+
+// SetTitle changes the title shown above the items.
+func (m *Model) SetTitle(title string) {
+	m.title = title
+}
+
+// Title returns the title shown above the items.
+func (m Model) Title() string {
+	return m.title
+}
+
+// Close hides the picker.
+func (m *Model) Close() {
+	m.visible = false
+}
+
+// Visible returns whether the picker is shown.
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Filterable reports whether typed text filters the list.
+func (m Model) Filterable() bool {
+	return m.filterable
+}
+
+// SetSize updates the available dimensions for the overlay.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Query returns the current filter text.
+func (m Model) Query() string {
+	return m.query
+}
+
+// AppendQuery adds typed text to the filter.
+func (m *Model) AppendQuery(text string) {
+	m.query += text
+	m.refilter()
+}
+
+// Backspace removes the last character of the filter.
+func (m *Model) Backspace() {
+	if m.query == "" {
+		return
+	}
+	_, size := utf8.DecodeLastRuneInString(m.query)
+	m.query = m.query[:len(m.query)-size]
+	m.refilter()
+}
+
+// MoveCursor moves the selection, wrapping at either end.
+func (m *Model) MoveCursor(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = (m.cursor + delta + len(m.matches)) % len(m.matches)
+}
+
+// Selected returns the highlighted item.
+func (m Model) Selected() (Item, bool) {
+	if len(m.matches) == 0 {
+		return Item{}, false
+	}
+	return m.items[m.matches[m.cursor]], true
+}
+
+// ItemAt returns the n-th (1-based) displayed item, used for number-key
+// selection.
+func (m Model) ItemAt(n int) (Item, bool) {
+	if n < 1 || n > len(m.matches) {
+		return Item{}, false
+	}
+	return m.items[m.matches[n-1]], true
+}
+
+// Keys are the bindings a picker is driven with.
+type Keys struct {
+	Close  []key.Binding // close without choosing
+	Down   key.Binding
+	Up     key.Binding
+	Choose key.Binding
+}
+
+// HandleKey moves the cursor or chooses an item for msg. The digits 1-9
+// choose by position in pickers that don't filter. The picker closes once
+// an item is chosen or a Close key is pressed; closed reports the latter.
+func (m *Model) HandleKey(msg tea.KeyPressMsg, keys Keys) (item Item, chosen, closed bool) {
+	switch k := msg.String(); {
+	case key.Matches(msg, keys.Close...):
+		m.Close()
+		return Item{}, false, true
+	case key.Matches(msg, keys.Down):
+		m.MoveCursor(1)
+	case key.Matches(msg, keys.Up):
+		m.MoveCursor(-1)
+	case key.Matches(msg, keys.Choose):
+		item, chosen = m.Selected()
+	case !m.filterable && len(k) == 1 && k[0] >= '1' && k[0] <= '9':
+		item, chosen = m.ItemAt(int(k[0] - '0'))
+	}
+	if chosen {
+		m.Close()
+	}
+	return item, chosen, false
+}
+
+func (m *Model) refilter() {
+	m.matches = m.matches[:0]
+	if !m.filterable || m.query == "" {
+		for i := range m.items {
+			m.matches = append(m.matches, i)
+		}
+	} else {
+		type scored struct {
+			idx   int
+			score int
+		}
+		var hits []scored
+		for i, item := range m.items {
+			score, ok := FuzzyScore(m.query, item.Label+" "+item.Detail)
+			if ok {
+				hits = append(hits, scored{i, score})
+			}
+		}
+		sort.SliceStable(hits, func(a, b int) bool { return hits[a].score > hits[b].score })
+		for _, h := range hits {
+			m.matches = append(m.matches, h.idx)
+		}
+	}
+	if m.cursor >= len(m.matches) {
+		m.cursor = 0
+	}
+}
+
+// FuzzyScore reports whether every rune of query appears in target in order
+// (case-insensitive) and scores the match: consecutive runs and matches at
+// word starts rank higher.
+func FuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+	if len(q) == 0 {
+		return 0, true
+	}
+	score := 0
+	qi := 0
+	prevMatch := -2
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score++
+		if ti == prevMatch+1 {
+			score += 3
+		}
+		if ti == 0 || t[ti-1] == ' ' || t[ti-1] == '-' || t[ti-1] == '/' {
+			score += 2
+		}
+		prevMatch = ti
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// View renders the overlay. Returns empty string when hidden.
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	p := colors.Current()
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(p.Accent)
+	keyStyle := lipgloss.NewStyle().Foreground(p.Key).Bold(true)
+	selStyle := lipgloss.NewStyle().Bold(true).Foreground(p.Highlight)
+	dimStyle := lipgloss.NewStyle().Foreground(p.Muted)
+
+	boxWidth := m.width * 2 / 3
+	if boxWidth > 80 {
+		boxWidth = 80
+	}
+	if boxWidth < 40 {
+		boxWidth = 40
+	}
+	inner := boxWidth - 8
+
+	var lines []string
+	if m.filterable {
+		lines = append(lines, selStyle.Render(a11y.Glyph("› ", "> ")+m.query+a11y.Glyph("▍", "_")), "")
+	}
+
+	start := 0
+	if m.cursor >= maxRows {
+		start = m.cursor - maxRows + 1
+	}
+	end := start + maxRows
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+	for row := start; row < end; row++ {
+		item := m.items[m.matches[row]]
+		prefix := "  "
+		if !m.filterable && row < 9 {
+			prefix = fmt.Sprintf("%d ", row+1)
+		}
+		label := a11y.Text(item.Label)
+		if row == m.cursor {
+			label = selStyle.Render(a11y.Glyph("▸ ", "> ") + label)
+		} else {
+			label = "  " + label
+		}
+		left := prefix + label
+		if item.Detail != "" {
+			left += "  " + dimStyle.Render(a11y.Text(item.Detail))
+		}
+		right := ""
+		if item.Key != "" {
+			right = keyStyle.Render(a11y.Text(item.Key))
+		}
+		pad := inner - lipgloss.Width(left) - lipgloss.Width(right)
+		if pad < 1 {
+			pad = 1
+		}
+		lines = append(lines, left+strings.Repeat(" ", pad)+right)
+	}
+	if len(m.matches) == 0 {
+		lines = append(lines, dimStyle.Render("  no matches"))
+	} else if end < len(m.matches) {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %s %d more", a11y.Ellipsis(), len(m.matches)-end)))
+	}
+
+	hint := "enter select • esc close"
+	if !m.filterable {
+		hint = "1-9/enter select • esc close"
+	}
+	lines = append(lines, "", dimStyle.Italic(true).Render(a11y.Text(hint)))
+
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
+		BorderForeground(p.Subtle).
+		Padding(1, 3).
+		Width(boxWidth)
+
+	title := titleStyle.Render(" " + a11y.Text(m.title) + " ")
+	box := boxStyle.Render(title + "\n\n" + strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
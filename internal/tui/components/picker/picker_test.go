@@ -0,0 +1,168 @@
+package picker
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+func testItems() []Item {
+	return []Item{
+		{Label: "My repos", Value: "mine"},
+		{Label: "Failures today", Value: "failures"},
+		{Label: "Expensive sessions", Detail: "cost:>5", Value: "expensive"},
+	}
+}
+
+func TestOpenAndClose(t *testing.T) {
+	m := New("Views", false)
+	if m.Visible() {
+		t.Fatal("expected picker to start hidden")
+	}
+	m.Open(testItems())
+	if !m.Visible() {
+		t.Fatal("expected picker to be visible after Open")
+	}
+	m.Close()
+	if m.Visible() {
+		t.Fatal("expected picker to be hidden after Close")
+	}
+	if m.View() != "" {
+		t.Fatal("expected empty view when hidden")
+	}
+}
+
+func TestMoveCursorWraps(t *testing.T) {
+	m := New("Views", false)
+	m.Open(testItems())
+	m.MoveCursor(-1)
+	item, ok := m.Selected()
+	if !ok || item.Value != "expensive" {
+		t.Fatalf("expected cursor to wrap to last item, got %+v", item)
+	}
+	m.MoveCursor(1)
+	item, _ = m.Selected()
+	if item.Value != "mine" {
+		t.Fatalf("expected cursor to wrap to first item, got %+v", item)
+	}
+}
+
+func TestItemAt(t *testing.T) {
+	m := New("Views", false)
+	m.Open(testItems())
+	item, ok := m.ItemAt(2)
+	if !ok || item.Value != "failures" {
+		t.Fatalf("ItemAt(2) = %+v, %v", item, ok)
+	}
+	if _, ok := m.ItemAt(4); ok {
+		t.Fatal("expected ItemAt out of range to fail")
+	}
+}
+
+func TestHandleKey(t *testing.T) {
+	keys := Keys{
+		Close:  []key.Binding{key.NewBinding(key.WithKeys("esc"))},
+		Down:   key.NewBinding(key.WithKeys("j")),
+		Up:     key.NewBinding(key.WithKeys("k")),
+		Choose: key.NewBinding(key.WithKeys("enter")),
+	}
+	press := func(m *Model, k string) (Item, bool, bool) {
+		msg := tea.KeyPressMsg{Code: []rune(k)[0], Text: k}
+		switch k {
+		case "esc":
+			msg = tea.KeyPressMsg{Code: tea.KeyEscape}
+		case "enter":
+			msg = tea.KeyPressMsg{Code: tea.KeyEnter}
+		}
+		return m.HandleKey(msg, keys)
+	}
+	m := New("Pick", false)
+	m.Open(testItems())
+
+	if _, chosen, closed := press(&m, "j"); chosen || closed || !m.Visible() {
+		t.Fatal("expected j only to move the cursor")
+	}
+	if item, chosen, _ := press(&m, "enter"); !chosen || item.Value != testItems()[1].Value || m.Visible() {
+		t.Errorf("expected enter to choose the highlighted item and close, got %+v", item)
+	}
+
+	m.Open(testItems())
+	if item, chosen, _ := press(&m, "3"); !chosen || item.Value != testItems()[2].Value {
+		t.Errorf("expected 3 to choose the third item, got %+v", item)
+	}
+	m.Open(testItems())
+	if _, chosen, _ := press(&m, "9"); chosen || !m.Visible() {
+		t.Error("expected a digit past the items to do nothing")
+	}
+	if _, chosen, closed := press(&m, "esc"); chosen || !closed || m.Visible() {
+		t.Error("expected esc to close without choosing")
+	}
+
+	f := New("Filter", true)
+	f.Open(testItems())
+	if _, chosen, _ := press(&f, "1"); chosen {
+		t.Error("expected digits not to choose in a filterable picker")
+	}
+}
+
+func TestFilterableQueryNarrowsItems(t *testing.T) {
+	m := New("Commands", true)
+	m.Open(testItems())
+	m.AppendQuery("fail")
+	item, ok := m.Selected()
+	if !ok || item.Value != "failures" {
+		t.Fatalf("expected fuzzy match on 'fail', got %+v", item)
+	}
+	m.AppendQuery("zzz")
+	if _, ok := m.Selected(); ok {
+		t.Fatal("expected no selection when nothing matches")
+	}
+	if !strings.Contains(m.View(), "no matches") {
+		t.Error("expected view to report no matches")
+	}
+	m.Backspace()
+	m.Backspace()
+	m.Backspace()
+	if _, ok := m.Selected(); !ok {
+		t.Fatal("expected matches after backspacing")
+	}
+}
+
+func TestNonFilterableIgnoresQuery(t *testing.T) {
+	m := New("Views", false)
+	m.Open(testItems())
+	m.AppendQuery("zzz")
+	if _, ok := m.Selected(); !ok {
+		t.Fatal("non-filterable picker should keep all items")
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := FuzzyScore("opr", "open PR"); !ok {
+		t.Error("expected subsequence to match")
+	}
+	if _, ok := FuzzyScore("xyz", "open PR"); ok {
+		t.Error("expected non-subsequence to fail")
+	}
+	prefix, _ := FuzzyScore("op", "open PR")
+	scattered, _ := FuzzyScore("op", "show preview")
+	if prefix <= scattered {
+		t.Errorf("expected word-start run to outscore scattered match: %d <= %d", prefix, scattered)
+	}
+}
+
+func TestViewShowsNumbersAndKeys(t *testing.T) {
+	m := New("Views", false)
+	m.SetSize(120, 40)
+	items := testItems()
+	items[0].Key = "1"
+	m.Open(items)
+	v := m.View()
+	for _, want := range []string{"Views", "My repos", "Failures today", "cost:>5"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("expected view to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package prompt
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+)
+
+// Model is a centered overlay asking for one line of text, such as a note
+// or a list of tags.
+type Model struct {
+	title   string
+	hint    string
+	value   string
+	visible bool
+	width   int
+	height  int
+}
+
+// New creates a hidden prompt.
+func New() Model {
+	return Model{width: 80, height: 24}
+}
+
+// Open shows the prompt with a title, a hint line and the initial text.
+func (m *Model) Open(title, hint, value string) {
+	m.title = title
+	m.hint = hint
+	m.value = value
+	m.visible = true
+}
+
+// Close hides the prompt.
+func (m *Model) Close() {
+	m.visible = false
+}
+
+// Visible returns whether the prompt is shown.
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// SetSize updates the available dimensions for the overlay.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Value returns the text entered so far.
+func (m Model) Value() string {
+	return m.value
+}
+
+// Insert appends typed text. Newlines are flattened to spaces because the
+// prompt holds a single line.
+func (m *Model) Insert(text string) {
+	m.value += strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(text)
+}
+
+// Backspace removes the last character.
+func (m *Model) Backspace() {
+	if m.value == "" {
+		return
+	}
+	_, size := utf8.DecodeLastRuneInString(m.value)
+	m.value = m.value[:len(m.value)-size]
+}
+
+// Clear empties the text.
+func (m *Model) Clear() {
+	m.value = ""
+}
+
+// View renders the overlay. Returns empty string when hidden.
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	p := colors.Current()
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(p.Accent)
+	inputStyle := lipgloss.NewStyle().Bold(true).Foreground(p.Highlight)
+	dimStyle := lipgloss.NewStyle().Foreground(p.Muted)
+
+	boxWidth := m.width * 2 / 3
+	if boxWidth > 80 {
+		boxWidth = 80
+	}
+	if boxWidth < 40 {
+		boxWidth = 40
+	}
+
+	lines := []string{inputStyle.Render(a11y.Glyph("› ", "> ") + m.value + a11y.Glyph("▍", "_"))}
+	if m.hint != "" {
+		lines = append(lines, "", dimStyle.Render(a11y.Text(m.hint)))
+	}
+	lines = append(lines, "", dimStyle.Italic(true).Render(a11y.Text("enter save • ctrl+u clear • esc cancel")))
+
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
+		BorderForeground(p.Subtle).
+		Padding(1, 3).
+		Width(boxWidth)
+
+	title := titleStyle.Render(" " + a11y.Text(m.title) + " ")
+	box := boxStyle.Render(title + "\n\n" + strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,54 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOpenAndClose(t *testing.T) {
+	m := New()
+	if m.Visible() || m.View() != "" {
+		t.Fatal("expected prompt to start hidden")
+	}
+	m.Open("Note", "", "draft")
+	if !m.Visible() || m.Value() != "draft" {
+		t.Fatalf("expected an open prompt holding the initial text, got %q", m.Value())
+	}
+	m.Close()
+	if m.Visible() {
+		t.Fatal("expected prompt to be hidden after Close")
+	}
+}
+
+func TestEditing(t *testing.T) {
+	m := New()
+	m.Open("Tags", "", "")
+	m.Insert("ops ")
+	m.Insert("naïve\npasted")
+	if m.Value() != "ops naïve pasted" {
+		t.Fatalf("expected newlines flattened, got %q", m.Value())
+	}
+	for range len("pasted") + 1 {
+		m.Backspace()
+	}
+	if m.Value() != "ops naïve" {
+		t.Fatalf("expected backspace to remove whole runes, got %q", m.Value())
+	}
+	m.Clear()
+	m.Backspace()
+	if m.Value() != "" {
+		t.Fatalf("expected empty value, got %q", m.Value())
+	}
+}
+
+func TestViewShowsTitleValueAndHint(t *testing.T) {
+	m := New()
+	m.SetSize(100, 30)
+	m.Open("Note for Fix login", "empty to remove", "blocked")
+	out := m.View()
+	for _, want := range []string{"Note for Fix login", "blocked", "empty to remove", "esc cancel"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view:\n%s", want, out)
+		}
+	}
+}
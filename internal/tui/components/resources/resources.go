@@ -0,0 +1,93 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/sparkline"
+)
+
+// sparkWidth is the widest a history sparkline is drawn.
+const sparkWidth = 20
+
+// Lines renders a session's resource panel within width columns: CPU and
+// memory totals with sparklines of their history, the open file and
+// process counts, then up to maxProcs processes of the tree. It returns
+// nil when nothing has been sampled.
+func Lines(r data.SessionResources, width, maxProcs int) []string {
+	latest, ok := r.Latest()
+	if !ok {
+		return nil
+	}
+	p := colors.Current()
+	label := lipgloss.NewStyle().Foreground(p.Muted)
+	dim := lipgloss.NewStyle().Foreground(p.Muted)
+
+	cpu := make([]float64, len(r.History))
+	rss := make([]float64, len(r.History))
+	files := make([]float64, len(r.History))
+	for i, s := range r.History {
+		cpu[i], rss[i], files[i] = s.CPUPercent, float64(s.RSS), float64(s.OpenFiles)
+	}
+	spark := func(values []float64) string {
+		w := min(len(values), sparkWidth, width-24)
+		if w < 2 {
+			return ""
+		}
+		return sparkline.Colorize(sparkline.Render(values, w), p.Sparkline)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s %-9s %s", label.Render("CPU  "), fmt.Sprintf("%.1f%%", latest.CPUPercent), spark(cpu)),
+		fmt.Sprintf("%s %-9s %s", label.Render("RSS  "), FormatBytes(latest.RSS), spark(rss)),
+		fmt.Sprintf("%s %-9d %s", label.Render("Files"), latest.OpenFiles, spark(files)),
+		label.Render(fmt.Sprintf("%d process(es)", latest.Processes)),
+	}
+
+	branch := "└ "
+	if a11y.Current().ASCII() {
+		branch = "`- "
+	}
+	for i, proc := range r.Processes {
+		if i == maxProcs {
+			lines = append(lines, dim.Render(fmt.Sprintf("  %s %d more", a11y.Ellipsis(), len(r.Processes)-maxProcs)))
+			break
+		}
+		prefix := "  "
+		if proc.Depth > 0 {
+			prefix += strings.Repeat("  ", proc.Depth-1) + branch
+		}
+		stats := fmt.Sprintf("%5.1f%% %8s %4d fd", proc.CPUPercent, FormatBytes(proc.RSS), proc.OpenFiles)
+		name := fmt.Sprintf("%s%d %s", prefix, proc.PID, proc.Command)
+		if room := width - lipgloss.Width(stats) - 1; room > 8 {
+			name = fitWidth(name, room)
+		}
+		lines = append(lines, name+" "+dim.Render(stats))
+	}
+	return lines
+}
+
+// FormatBytes renders a byte count in binary units, e.g. 212 MB.
+func FormatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%d MB", n/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%d KB", n/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// fitWidth truncates or pads s to exactly width display columns.
+func fitWidth(s string, width int) string {
+	if lipgloss.Width(s) > width {
+		return a11y.Truncate(s, width)
+	}
+	return s + strings.Repeat(" ", width-lipgloss.Width(s))
+}
@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func sampleResources() data.SessionResources {
+	now := time.Now()
+	return data.SessionResources{
+		Processes: []data.ProcessUsage{
+			{PID: 4242, Command: "node copilot", CPUPercent: 3.5, RSS: 210 << 20, OpenFiles: 40},
+			{PID: 5001, Depth: 1, Command: "go test ./...", CPUPercent: 95, RSS: 150 << 20, OpenFiles: 12},
+			{PID: 5002, Depth: 2, Command: "compile", RSS: 40 << 20, OpenFiles: 3},
+		},
+		History: []data.ResourceSample{
+			{At: now.Add(-4 * time.Second), CPUPercent: 10, RSS: 300 << 20, OpenFiles: 50, Processes: 2},
+			{At: now.Add(-2 * time.Second), CPUPercent: 60, RSS: 380 << 20, OpenFiles: 54, Processes: 3},
+			{At: now, CPUPercent: 98.5, RSS: 400 << 20, OpenFiles: 55, Processes: 3},
+		},
+	}
+}
+
+func TestLines(t *testing.T) {
+	out := ansi.Strip(strings.Join(Lines(sampleResources(), 80, 8), "\n"))
+	for _, want := range []string{"CPU   98.5%", "RSS   400 MB", "Files 55", "3 process(es)", "4242 node copilot", "└ 5001 go test ./...", "  └ 5002 compile", "95.0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	if !strings.ContainsAny(out, "▁▂▃▄▅▆▇█") {
+		t.Errorf("expected history sparklines in:\n%s", out)
+	}
+}
+
+func TestLines_CapsProcesses(t *testing.T) {
+	out := ansi.Strip(strings.Join(Lines(sampleResources(), 80, 1), "\n"))
+	if strings.Contains(out, "5001") || !strings.Contains(out, "… 2 more") {
+		t.Errorf("expected the tree cut after one process, got:\n%s", out)
+	}
+}
+
+func TestLines_NothingSampled(t *testing.T) {
+	if lines := Lines(data.SessionResources{}, 80, 8); lines != nil {
+		t.Errorf("expected no panel without samples, got %v", lines)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{512: "512 B", 2048: "2 KB", 210 << 20: "210 MB", 3 << 29: "1.5 GB"} {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
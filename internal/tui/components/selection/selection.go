@@ -0,0 +1,142 @@
+// Package selection tracks a line-wise visual selection over rendered
+// lines, like vim's V, for the read-only log and conversation views.
+package selection
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Model is a selection between an anchor line, where it started, and a
+// cursor line that moves. Both are indexes into the rendered lines.
+type Model struct {
+	active bool
+	anchor int
+	cursor int
+}
+
+// Start begins a selection of the single line at line.
+func (m *Model) Start(line int) {
+	m.active = true
+	m.anchor = line
+	m.cursor = line
+}
+
+// Stop ends the selection.
+func (m *Model) Stop() {
+	*m = Model{}
+}
+
+// Active reports whether a selection is in progress.
+func (m Model) Active() bool {
+	return m.active
+}
+
+// Cursor returns the line the selection was extended to.
+func (m Model) Cursor() int {
+	return m.cursor
+}
+
+// Move moves the cursor by delta, keeping it within count lines.
+func (m *Model) Move(delta, count int) {
+	m.cursor = clamp(m.cursor+delta, count)
+}
+
+// Clamp keeps the anchor and cursor within count lines, e.g. after the
+// content was re-rendered shorter.
+func (m *Model) Clamp(count int) {
+	m.anchor = clamp(m.anchor, count)
+	m.cursor = clamp(m.cursor, count)
+}
+
+// Range returns the selected lines as [start, end).
+func (m Model) Range() (start, end int) {
+	if !m.active {
+		return 0, 0
+	}
+	if m.anchor <= m.cursor {
+		return m.anchor, m.cursor + 1
+	}
+	return m.cursor, m.anchor + 1
+}
+
+// Len returns the number of lines selected.
+func (m Model) Len() int {
+	start, end := m.Range()
+	return end - start
+}
+
+// ScrollOffset returns the viewport offset, starting from yOffset, that
+// keeps the cursor visible in a viewport height lines tall.
+func (m Model) ScrollOffset(yOffset, height int) int {
+	switch {
+	case m.cursor < yOffset:
+		return m.cursor
+	case height > 0 && m.cursor >= yOffset+height:
+		return m.cursor - height + 1
+	}
+	return yOffset
+}
+
+var highlightStyle = lipgloss.NewStyle().Reverse(true)
+
+// Highlight returns lines joined for display, with the selected lines
+// shown in reverse video padded to width.
+func (m Model) Highlight(lines []string, width int) string {
+	start, end := m.Range()
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		if i < start || i >= end {
+			out[i] = l
+			continue
+		}
+		plain := ansi.Strip(l)
+		if pad := width - lipgloss.Width(plain); pad > 0 {
+			plain += strings.Repeat(" ", pad)
+		}
+		out[i] = highlightStyle.Render(plain)
+	}
+	return strings.Join(out, "\n")
+}
+
+// Lines returns the selected lines of lines.
+func (m Model) Lines(lines []string) []string {
+	start, end := m.Range()
+	end = min(end, len(lines))
+	if start >= end {
+		return nil
+	}
+	return lines[start:end]
+}
+
+// PlainText returns rendered lines as plain text: styling and trailing
+// spaces removed, and the indentation they all share, such as a markdown
+// margin, taken off.
+func PlainText(lines []string) string {
+	plain := make([]string, len(lines))
+	indent := -1
+	for i, l := range lines {
+		plain[i] = strings.TrimRight(ansi.Strip(l), " ")
+		if plain[i] == "" {
+			continue
+		}
+		if n := len(plain[i]) - len(strings.TrimLeft(plain[i], " ")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, l := range plain {
+		if len(l) >= indent && indent > 0 {
+			plain[i] = l[indent:]
+		}
+	}
+	return strings.Trim(strings.Join(plain, "\n"), "\n")
+}
+
+func clamp(line, count int) int {
+	if line >= count {
+		line = count - 1
+	}
+	return max(line, 0)
+}
@@ -0,0 +1,81 @@
+package selection
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRange_EitherDirection(t *testing.T) {
+	var m Model
+	if m.Active() || m.Len() != 0 {
+		t.Fatal("expected no selection before Start")
+	}
+	m.Start(5)
+	m.Move(2, 10)
+	if start, end := m.Range(); start != 5 || end != 8 {
+		t.Errorf("expected lines 5-7, got [%d, %d)", start, end)
+	}
+	m.Move(-4, 10)
+	if start, end := m.Range(); start != 3 || end != 6 {
+		t.Errorf("expected the anchor kept when moving above it, got [%d, %d)", start, end)
+	}
+	m.Move(100, 10)
+	if m.Cursor() != 9 {
+		t.Errorf("expected the cursor kept on the last line, got %d", m.Cursor())
+	}
+	m.Clamp(4)
+	if start, end := m.Range(); start != 3 || end != 4 {
+		t.Errorf("expected the selection clamped to shorter content, got [%d, %d)", start, end)
+	}
+	m.Stop()
+	if m.Active() {
+		t.Error("expected Stop to end the selection")
+	}
+}
+
+func TestScrollOffset_KeepsCursorVisible(t *testing.T) {
+	var m Model
+	m.Start(2)
+	if got := m.ScrollOffset(5, 10); got != 2 {
+		t.Errorf("expected scrolling up to the cursor, got %d", got)
+	}
+	m.Move(20, 100)
+	if got := m.ScrollOffset(0, 10); got != 13 {
+		t.Errorf("expected scrolling down to show the cursor last, got %d", got)
+	}
+	if got := m.ScrollOffset(15, 10); got != 15 {
+		t.Errorf("expected the offset kept while the cursor is visible, got %d", got)
+	}
+}
+
+func TestHighlight_OnlySelectedLines(t *testing.T) {
+	lines := []string{"one", "two", "three"}
+	var m Model
+	if got := m.Highlight(lines, 10); got != "one\ntwo\nthree" {
+		t.Errorf("expected lines unchanged without a selection, got %q", got)
+	}
+	m.Start(1)
+	out := strings.Split(m.Highlight(lines, 10), "\n")
+	if out[0] != "one" || out[2] != "three" {
+		t.Errorf("expected unselected lines unchanged, got %q", out)
+	}
+	if ansi.Strip(out[1]) != "two       " {
+		t.Errorf("expected the selected line padded to the width, got %q", ansi.Strip(out[1]))
+	}
+}
+
+func TestPlainText_RemovesSharedIndent(t *testing.T) {
+	lines := []string{"  \x1b[1mfunc main() {\x1b[0m   ", "", "      fmt.Println()", "  }"}
+	want := "func main() {\n\n    fmt.Println()\n}"
+	if got := PlainText(lines); got != want {
+		t.Errorf("PlainText() = %q, want %q", got, want)
+	}
+	var m Model
+	m.Start(2)
+	m.Move(5, len(lines))
+	if got := PlainText(m.Lines(lines)); got != "    fmt.Println()\n}" {
+		t.Errorf("expected the selected lines only, got %q", got)
+	}
+}
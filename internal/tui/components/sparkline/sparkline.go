@@ -1,8 +1,12 @@
 package sparkline
 
 import (
+	"image/color"
 	"math"
 	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
 )
 
 // SparkChars contains the 8 block characters used for sparklines.
@@ -11,8 +15,26 @@ const SparkChars = "▁▂▃▄▅▆▇█"
 // HeatChars contains 5 intensity levels for heatmaps.
 const HeatChars = " ░▒▓█"
 
+// ASCIISparkChars and ASCIIHeatChars replace the block characters in
+// plain and accessible modes.
+const (
+	ASCIISparkChars = "_.-~=+*#"
+	ASCIIHeatChars  = " .:*#"
+)
+
 var sparkRunes = []rune(SparkChars)
 var heatRunes = []rune(HeatChars)
+var asciiSparkRunes = []rune(ASCIISparkChars)
+var asciiHeatRunes = []rune(ASCIIHeatChars)
+
+// glyphs returns the sparkline and heatmap rune sets for the active
+// rendering mode.
+func glyphs() (spark, heat []rune) {
+	if a11y.Current().ASCII() {
+		return asciiSparkRunes, asciiHeatRunes
+	}
+	return sparkRunes, heatRunes
+}
 
 // Render renders a sparkline string of the given width.
 // If values is shorter than width, pad with the last value.
@@ -35,6 +57,7 @@ func Render(values []float64, width int) string {
 		}
 	}
 
+	sparkRunes, _ := glyphs()
 	var sb strings.Builder
 	numChars := len(sparkRunes)
 	for _, v := range data {
@@ -61,6 +84,7 @@ func RenderHeatmap(values []float64, width int) string {
 		}
 	}
 
+	_, heatRunes := glyphs()
 	var sb strings.Builder
 	for _, v := range data {
 		if v == 0 {
@@ -84,10 +108,54 @@ func RenderHeatmap(values []float64, width int) string {
 	return sb.String()
 }
 
-// TrendArrow returns "↑" if upward trend, "↓" if downward, "→" if flat.
+// Colorize colors each block of a rendered sparkline or heatmap by its
+// height, picking from a low-to-high gradient. Spaces and unknown runes are
+// left as-is; an empty gradient returns s unchanged.
+func Colorize(s string, gradient []color.Color) string {
+	if len(gradient) == 0 {
+		return s
+	}
+	sparkRunes, heatRunes := glyphs()
+	var sb strings.Builder
+	for _, r := range s {
+		level := -1.0
+		if i := runeIndex(sparkRunes, r); i >= 0 {
+			level = float64(i) / float64(len(sparkRunes)-1)
+		} else if i := runeIndex(heatRunes, r); i > 0 {
+			level = float64(i-1) / float64(len(heatRunes)-2)
+		}
+		if level < 0 {
+			sb.WriteRune(r)
+			continue
+		}
+		c := gradient[int(math.Round(level*float64(len(gradient)-1)))]
+		sb.WriteString(lipgloss.NewStyle().Foreground(c).Render(string(r)))
+	}
+	return sb.String()
+}
+
+func runeIndex(runes []rune, r rune) int {
+	for i, x := range runes {
+		if x == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// TrendArrow returns "↑" if upward trend, "↓" if downward, "→" if flat
+// ("^", "v" and "-" in plain and accessible modes).
 // Compares average of last 3 values to average of previous 3.
 // Uses a 10% threshold for flat.
 func TrendArrow(values []float64) string {
+	arrow := trendArrow(values)
+	if a11y.Current().ASCII() {
+		return map[string]string{"↑": "^", "↓": "v", "→": "-"}[arrow]
+	}
+	return arrow
+}
+
+func trendArrow(values []float64) string {
 	if len(values) < 6 {
 		return "→"
 	}
@@ -1,8 +1,13 @@
 package sparkline
 
 import (
+	"image/color"
 	"strings"
 	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
 )
 
 func TestRender(t *testing.T) {
@@ -163,3 +168,49 @@ func TestRenderHeatmap(t *testing.T) {
 		})
 	}
 }
+
+func TestColorize(t *testing.T) {
+	line := Render([]float64{0, 1, 2, 3}, 4)
+	gradient := []color.Color{lipgloss.Color("1"), lipgloss.Color("2"), lipgloss.Color("3")}
+
+	if got := Colorize(line, nil); got != line {
+		t.Errorf("Colorize(nil gradient) = %q, want %q", got, line)
+	}
+
+	got := Colorize(line, gradient)
+	if got == line {
+		t.Error("Colorize() should add color to sparkline blocks")
+	}
+	if ansi.Strip(got) != line {
+		t.Errorf("Colorize() changed the blocks: %q, want %q", ansi.Strip(got), line)
+	}
+
+	heat := RenderHeatmap([]float64{0, 1, 4}, 3)
+	colored := Colorize(heat, gradient)
+	if ansi.Strip(colored) != heat {
+		t.Errorf("Colorize(heatmap) = %q, want %q", ansi.Strip(colored), heat)
+	}
+	if !strings.HasPrefix(colored, " ") {
+		t.Error("Colorize() should leave heatmap gaps uncolored")
+	}
+}
+
+func TestASCIIMode(t *testing.T) {
+	a11y.Set(a11y.Mode{Plain: true})
+	defer a11y.Set(a11y.Mode{})
+
+	if got := Render([]float64{0, 7}, 2); got != "_#" {
+		t.Errorf("Render() = %q, want %q", got, "_#")
+	}
+	if got := RenderHeatmap([]float64{0, 1, 4}, 3); got != " .#" {
+		t.Errorf("RenderHeatmap() = %q, want %q", got, " .#")
+	}
+	if got := TrendArrow([]float64{1, 1, 1, 5, 5, 5}); got != "^" {
+		t.Errorf("TrendArrow() = %q, want %q", got, "^")
+	}
+	line := Render([]float64{0, 1, 2, 3}, 4)
+	gradient := []color.Color{lipgloss.Color("1"), lipgloss.Color("2")}
+	if got := Colorize(line, gradient); got == line || ansi.Strip(got) != line {
+		t.Errorf("Colorize() should color ASCII blocks, got %q", got)
+	}
+}
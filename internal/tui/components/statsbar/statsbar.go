@@ -5,8 +5,9 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 // Counts holds the stats to display.
@@ -45,11 +46,12 @@ func (m *Model) SetWidth(width int) {
 func (m Model) View() string {
 	var parts []string
 
-	activeStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("28"), Dark: lipgloss.Color("42")})
-	urgentStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("160"), Dark: lipgloss.Color("203")})
-	doneStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("30"), Dark: lipgloss.Color("72")})
-	tokenStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("244"), Dark: lipgloss.Color("245")})
-	dimStyle := lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("240")})
+	p := colors.Current()
+	activeStyle := lipgloss.NewStyle().Foreground(p.Status.Running)
+	urgentStyle := lipgloss.NewStyle().Foreground(p.Attention.Urgent)
+	doneStyle := lipgloss.NewStyle().Foreground(p.Status.Completed)
+	tokenStyle := lipgloss.NewStyle().Foreground(p.Muted)
+	dimStyle := lipgloss.NewStyle().Foreground(p.Subtle)
 
 	if m.counts.Active > 0 {
 		parts = append(parts, activeStyle.Render(fmt.Sprintf("● %d active", m.counts.Active)))
@@ -67,5 +69,5 @@ func (m Model) View() string {
 
 	bar := strings.Join(parts, dimStyle.Render("  │  "))
 
-	return dimStyle.Render("  ") + bar
+	return a11y.Text(dimStyle.Render("  ") + bar)
 }
@@ -7,6 +7,9 @@ import (
 
 	"charm.land/lipgloss/v2"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/resources"
 )
 
 // Model represents the task detail component state
@@ -18,6 +21,7 @@ type Model struct {
 	statusIcon  func(string) string
 	width       int
 	height      int
+	resources   map[string]data.SessionResources // by session ID
 }
 
 // New creates a new task detail model
@@ -29,6 +33,12 @@ func New(titleStyle, borderStyle lipgloss.Style, statusIconFunc func(string) str
 	}
 }
 
+// SetStyles replaces the theme styles, e.g. after a theme switch.
+func (m *Model) SetStyles(titleStyle, borderStyle lipgloss.Style) {
+	m.titleStyle = titleStyle
+	m.borderStyle = borderStyle
+}
+
 // View renders the session detail pane
 func (m Model) View() string {
 	if m.session == nil {
@@ -39,24 +49,20 @@ func (m Model) View() string {
 		m.titleStyle.Render(detailTitle(m.session.Title)),
 		"",
 		fmt.Sprintf("Status:     %s %s", m.statusIcon(m.session.Status), m.session.Status),
-		fmt.Sprintf("Source:     %s", m.session.Source),
+		fmt.Sprintf("Source:     %s", sourceText(*m.session)),
 		fmt.Sprintf("Repository: %s", detailValue(m.session.Repository, "not available")),
 		fmt.Sprintf("Branch:     %s", detailValue(m.session.Branch, "not available")),
 	}
 
 	// Add PR info when available (any source)
-	if m.session.PRNumber > 0 || m.session.PRURL != "" {
-		details = append(details,
-			fmt.Sprintf("PR:         #%d", m.session.PRNumber),
-			fmt.Sprintf("PR URL:     %s", m.session.PRURL),
-		)
-	}
+	details = append(details, prLines(m.session)...)
 
 	details = append(details,
 		fmt.Sprintf("Created:    %s", detailTimestamp(m.session.CreatedAt)),
 		fmt.Sprintf("Updated:    %s", detailTimestamp(m.session.UpdatedAt)),
 		fmt.Sprintf("Session ID: %s", m.session.ID),
 	)
+	details = append(details, annotationLines(m.session)...)
 
 	if reason := attentionReason(m.session); reason != "" {
 		details = append(details, "", reason)
@@ -66,6 +72,7 @@ func (m Model) View() string {
 		details = append(details, sectionDivider(m.width-4))
 		details = append(details, fmt.Sprintf("Timeline:   %s", tl))
 	}
+	details = append(details, eventLines(m.session)...)
 
 	// Show telemetry if available
 	if m.session.Telemetry != nil {
@@ -97,6 +104,15 @@ func (m Model) View() string {
 		}
 	}
 
+	// Show what the session's processes cost the machine
+	if res, ok := m.resources[m.session.ID]; ok {
+		if panel := resources.Lines(res, m.width-8, 8); len(panel) > 0 {
+			details = append(details, sectionDivider(m.width-4))
+			details = append(details, m.titleStyle.Render("Resources"))
+			details = append(details, panel...)
+		}
+	}
+
 	// Show dependency graph if relationships exist
 	graph := ParseSessionDeps(m.session, m.allSessions)
 	if rendered := RenderDepGraph(graph, m.width); rendered != "" {
@@ -104,7 +120,7 @@ func (m Model) View() string {
 		details = append(details, m.titleStyle.Render("Related Sessions"), rendered)
 	}
 
-	return m.borderStyle.Render(joinVertical(details))
+	return m.borderStyle.Render(a11y.Text(joinVertical(details)))
 }
 
 // SetTask updates the session being displayed
@@ -117,6 +133,11 @@ func (m *Model) Session() *data.Session {
 	return m.session
 }
 
+// SetResources updates the sampled process resources, keyed by session ID.
+func (m *Model) SetResources(resources map[string]data.SessionResources) {
+	m.resources = resources
+}
+
 // SetAllSessions updates the full session list for dependency graph rendering.
 func (m *Model) SetAllSessions(sessions []data.Session) {
 	m.allSessions = sessions
@@ -137,12 +158,12 @@ func (m Model) ViewSplit() string {
 	if m.session == nil {
 		content := m.titleStyle.Render("No session selected")
 		style := lipgloss.NewStyle().
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 			BorderLeft(true).
 			BorderTop(false).
 			BorderBottom(false).
 			BorderRight(false).
-			BorderForeground(lipgloss.Color("63")).
+			BorderForeground(colors.Current().Section).
 			PaddingLeft(1)
 		if m.width > 0 {
 			style = style.Width(m.width - 2)
@@ -157,23 +178,19 @@ func (m Model) ViewSplit() string {
 		m.titleStyle.Render(detailTitle(m.session.Title)),
 		"",
 		fmt.Sprintf("Status:     %s %s", m.statusIcon(m.session.Status), m.session.Status),
-		fmt.Sprintf("Source:     %s", m.session.Source),
+		fmt.Sprintf("Source:     %s", sourceText(*m.session)),
 		fmt.Sprintf("Repository: %s", detailValue(m.session.Repository, "n/a")),
 		fmt.Sprintf("Branch:     %s", detailValue(m.session.Branch, "n/a")),
 	}
 
-	if m.session.PRNumber > 0 || m.session.PRURL != "" {
-		details = append(details,
-			fmt.Sprintf("PR:         #%d", m.session.PRNumber),
-			fmt.Sprintf("PR URL:     %s", m.session.PRURL),
-		)
-	}
+	details = append(details, prLines(m.session)...)
 
 	details = append(details,
 		fmt.Sprintf("Created:    %s", detailTimestamp(m.session.CreatedAt)),
 		fmt.Sprintf("Updated:    %s", detailTimestamp(m.session.UpdatedAt)),
 		fmt.Sprintf("Session ID: %s", m.session.ID),
 	)
+	details = append(details, annotationLines(m.session)...)
 
 	if reason := attentionReason(m.session); reason != "" {
 		details = append(details, "", reason)
@@ -183,6 +200,7 @@ func (m Model) ViewSplit() string {
 		details = append(details, sectionDivider(m.width-4))
 		details = append(details, fmt.Sprintf("Timeline:   %s", tl))
 	}
+	details = append(details, eventLines(m.session)...)
 
 	if m.session.Telemetry != nil {
 		t := m.session.Telemetry
@@ -221,12 +239,12 @@ func (m Model) ViewSplit() string {
 	}
 
 	style := lipgloss.NewStyle().
-		BorderStyle(lipgloss.NormalBorder()).
+		BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 		BorderLeft(true).
 		BorderTop(false).
 		BorderBottom(false).
 		BorderRight(false).
-		BorderForeground(lipgloss.Color("63")).
+		BorderForeground(colors.Current().Section).
 		PaddingLeft(1)
 	if m.width > 0 {
 		style = style.Width(m.width - 2)
@@ -235,7 +253,7 @@ func (m Model) ViewSplit() string {
 		style = style.Height(m.height)
 	}
 
-	return style.Render(joinVertical(details))
+	return style.Render(a11y.Text(joinVertical(details)))
 }
 
 func joinVertical(lines []string) string {
@@ -259,6 +277,12 @@ func attentionReason(session *data.Session) string {
 		}
 		return "🔴 This session has failed. Press 'l' to check logs."
 	case data.AttentionWarning:
+		switch data.PRAttention(*session) {
+		case "checks failing":
+			return "🔴 Checks are failing on this session's PR."
+		case "needs review":
+			return "👀 This session's PR is waiting for your review."
+		}
 		status := strings.ToLower(strings.TrimSpace(session.Status))
 		if status == "queued" {
 			return "🟡 This session has been queued for a while — it may need investigation."
@@ -269,6 +293,67 @@ func attentionReason(session *data.Session) string {
 	}
 }
 
+// prLines shows the session's PR and, once fetched, its state, checks and
+// review decision.
+func prLines(session *data.Session) []string {
+	if session.PRNumber == 0 && session.PRURL == "" {
+		return nil
+	}
+	lines := []string{
+		fmt.Sprintf("PR:         #%d", session.PRNumber),
+		fmt.Sprintf("PR URL:     %s", session.PRURL),
+	}
+	if session.PR != nil {
+		lines = append(lines, fmt.Sprintf("PR status:  %s", session.PR.Summary()))
+	}
+	return lines
+}
+
+// annotationLines shows the user's pin, tags and note for a session.
+func annotationLines(session *data.Session) []string {
+	a := session.Annotation
+	if a == nil {
+		return nil
+	}
+	var lines []string
+	if a.Pinned {
+		lines = append(lines, "Pinned:     📌 yes")
+	}
+	if len(a.Tags) > 0 {
+		lines = append(lines, fmt.Sprintf("Tags:       #%s", strings.Join(a.Tags, " #")))
+	}
+	if a.Note != "" {
+		lines = append(lines, fmt.Sprintf("Note:       📝 %s", a.Note))
+	}
+	return lines
+}
+
+// eventLines lists the actions taken on a session from here, such as
+// stopping its process, below the timeline.
+func eventLines(session *data.Session) []string {
+	if session.Annotation == nil {
+		return nil
+	}
+	var lines []string
+	for i, e := range session.Annotation.Events {
+		label := "            "
+		if i == 0 {
+			label = "Actions:    "
+		}
+		lines = append(lines, fmt.Sprintf("%s%s  %s", label, e.At.Local().Format("2006-01-02 15:04"), e.Text))
+	}
+	return lines
+}
+
+// sourceText names the session's source and, for sessions read from an
+// extra session root, the root's label.
+func sourceText(session data.Session) string {
+	if session.Origin == "" {
+		return string(session.Source)
+	}
+	return fmt.Sprintf("%s (%s)", session.Source, session.Origin)
+}
+
 func detailValue(value string, fallback string) string {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
@@ -293,7 +378,7 @@ func sectionDivider(width int) string {
 	if width <= 0 {
 		width = 40
 	}
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render(strings.Repeat("─", width))
+	return lipgloss.NewStyle().Foreground(colors.Current().Subtle).Render(strings.Repeat("─", width))
 }
 
 func formatDuration(d time.Duration) string {
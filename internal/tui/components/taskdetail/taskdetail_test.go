@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
 )
 
@@ -57,6 +58,81 @@ func TestView_ShowsRecordedTimestamps(t *testing.T) {
 	}
 }
 
+func TestView_ShowsSessionRootLabel(t *testing.T) {
+	model := New(
+		lipgloss.NewStyle(),
+		lipgloss.NewStyle(),
+		func(string) string { return "•" },
+	)
+	model.SetTask(&data.Session{ID: "session-3", Source: data.SourceLocalCopilot, Origin: "devbox"})
+
+	if view := model.View(); !strings.Contains(view, "Source:     local-copilot (devbox)") {
+		t.Fatalf("expected the root label beside the source, got: %s", view)
+	}
+}
+
+func TestView_ShowsAnnotations(t *testing.T) {
+	model := New(
+		lipgloss.NewStyle(),
+		lipgloss.NewStyle(),
+		func(string) string { return "•" },
+	)
+	model.SetTask(&data.Session{ID: "session-4", Annotation: &data.Annotation{
+		Pinned: true, Note: "waiting on review", Tags: []string{"auth", "release"},
+	}})
+
+	view := model.View()
+	for _, want := range []string{"Pinned:     📌 yes", "Tags:       #auth #release", "Note:       📝 waiting on review"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the detail view, got: %s", want, view)
+		}
+	}
+}
+
+func TestView_ShowsRecordedActions(t *testing.T) {
+	model := New(
+		lipgloss.NewStyle(),
+		lipgloss.NewStyle(),
+		func(string) string { return "•" },
+	)
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+	model.SetTask(&data.Session{ID: "session-5", Annotation: &data.Annotation{Events: []data.AnnotationEvent{
+		{At: at, Text: "Stopped PID 4242 with SIGINT"},
+		{At: at.Add(time.Minute), Text: "Removed 1 stale lock file(s)"},
+	}}})
+
+	view := model.View()
+	for _, want := range []string{"Actions:    2026-03-01 09:30  Stopped PID 4242 with SIGINT", "2026-03-01 09:31  Removed 1 stale lock file(s)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the detail view, got: %s", want, view)
+		}
+	}
+}
+
+func TestView_ShowsResources(t *testing.T) {
+	model := New(
+		lipgloss.NewStyle(),
+		lipgloss.NewStyle(),
+		func(string) string { return "•" },
+	)
+	model.SetSize(100, 40)
+	model.SetTask(&data.Session{ID: "session-6", Source: data.SourceLocalCopilot})
+	if strings.Contains(model.View(), "Resources") {
+		t.Fatal("expected no resources panel before a sample")
+	}
+	model.SetResources(map[string]data.SessionResources{"session-6": {
+		Processes: []data.ProcessUsage{{PID: 4242, Command: "node copilot", RSS: 64 << 20}},
+		History:   []data.ResourceSample{{CPUPercent: 12, RSS: 64 << 20, OpenFiles: 9, Processes: 1}},
+	}})
+
+	view := ansi.Strip(model.View())
+	for _, want := range []string{"Resources", "CPU   12.0%", "RSS   64 MB", "4242 node copilot"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the detail view, got: %s", want, view)
+		}
+	}
+}
+
 func TestView_ShowsTelemetry(t *testing.T) {
 	model := New(
 		lipgloss.NewStyle(),
@@ -196,6 +272,30 @@ func TestAttentionReason_CompletedSession(t *testing.T) {
 	}
 }
 
+func TestAttentionReason_PRSignals(t *testing.T) {
+	s := &data.Session{Status: "completed", PR: &data.PRStatus{State: "OPEN", Checks: "ERROR"}}
+	if got := attentionReason(s); !strings.Contains(got, "Checks are failing") {
+		t.Fatalf("expected failing checks reason, got %q", got)
+	}
+	s.PR.Checks = "SUCCESS"
+	if got := attentionReason(s); !strings.Contains(got, "waiting for your review") {
+		t.Fatalf("expected needs review reason, got %q", got)
+	}
+}
+
+func TestView_ShowsPRStatus(t *testing.T) {
+	model := New(lipgloss.NewStyle(), lipgloss.NewStyle(), func(string) string { return "•" })
+	model.SetTask(&data.Session{
+		ID:       "session-1",
+		PRNumber: 12,
+		PRURL:    "https://github.com/org/repo/pull/12",
+		PR:       &data.PRStatus{Number: 12, State: "OPEN", Draft: true, Checks: "PENDING"},
+	})
+	if view := ansi.Strip(model.View()); !strings.Contains(view, "PR status:  draft · checks running") {
+		t.Fatalf("expected the PR status line, got: %s", view)
+	}
+}
+
 func TestDetailValue(t *testing.T) {
 	tests := []struct {
 		value    string
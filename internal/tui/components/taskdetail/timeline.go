@@ -16,7 +16,7 @@ const (
 )
 
 // nowFunc is overridable for testing.
-var nowFunc = time.Now
+var nowFunc = func() time.Time { return data.Now() }
 
 // RenderTimeline returns a Unicode timeline bar showing the session lifecycle.
 // The bar uses ░ (idle/pre-creation), ▒ (created but inactive), ▓ (active), █ (current activity).
@@ -9,13 +9,18 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 // groupByModes defines the cycle order for group-by modes.
 var groupByModes = []string{"", "repository", "status", "source"}
 
+// sortModes lists the supported sort orders. "updated" (most recent
+// activity first) is the default.
+var sortModes = []string{"updated", "created", "cost", "title", "status"}
+
 // autoGroupThreshold is the session count at which auto-grouping by repo kicks in.
 const autoGroupThreshold = 8
 
@@ -29,7 +34,7 @@ type Model struct {
 	sessions           []data.Session
 	duplicateCounts   map[int]int // newest session index → count of older duplicates
 	dismissedIDs      map[string]struct{}
-	dismissedStore    *data.DismissedStore
+	dismissedStore    *data.AnnotationStore
 	rowCursor         int
 	loading           bool
 	statusIcon        func(string) string
@@ -40,6 +45,7 @@ type Model struct {
 	height            int
 	splitMode          bool
 	groupBy            string // "", "repository", "status", "source"
+	sortBy             string // one of sortModes; "" means "updated"
 	userSetGroupBy     bool   // true once user manually toggles via 'g'
 	expandedGroup      int    // index of expanded group (-1 = none)
 }
@@ -49,11 +55,11 @@ func New(titleStyle, headerStyle, rowStyle, rowSelectedStyle lipgloss.Style, sta
 	return NewWithStore(titleStyle, headerStyle, rowStyle, rowSelectedStyle, lipgloss.NewStyle(), statusIconFunc, nil, nil)
 }
 
-// NewWithStore creates a new task list model backed by a persistent dismissed store.
-func NewWithStore(titleStyle, headerStyle, rowStyle, rowSelectedStyle, sectionHeaderStyle lipgloss.Style, statusIconFunc func(string) string, animStatusIconFunc func(string, int) string, store *data.DismissedStore) Model {
+// NewWithStore creates a new task list model that records dismissals in store.
+func NewWithStore(titleStyle, headerStyle, rowStyle, rowSelectedStyle, sectionHeaderStyle lipgloss.Style, statusIconFunc func(string) string, animStatusIconFunc func(string, int) string, store *data.AnnotationStore) Model {
 	dismissed := map[string]struct{}{}
 	if store != nil {
-		dismissed = store.IDs()
+		dismissed = store.DismissedIDs()
 	}
 	return Model{
 		titleStyle:         titleStyle,
@@ -98,7 +104,7 @@ func (m *Model) SetSplitMode(split bool) {
 // View renders the sessions as a focused single-column list
 func (m Model) View() string {
 	if m.loading {
-		return m.titleStyle.Render("🔄 Loading sessions...\n\nFetching your agent sessions, one moment.")
+		return m.titleStyle.Render(a11y.Text("🔄 Loading sessions...\n\nFetching your agent sessions, one moment."))
 	}
 
 	if len(m.sessions) == 0 {
@@ -112,12 +118,27 @@ func (m Model) View() string {
     │   or press 'r' to refresh.            │
     │                                       │
     ╰───────────────────────────────────────╯`
+		if a11y.Current().ASCII() {
+			emptyArt = asciiEmptyArt
+		}
 		return m.titleStyle.Render(emptyArt)
 	}
 
 	return m.renderFocusedList()
 }
 
+// asciiEmptyArt is the empty list's box drawn in ASCII.
+const asciiEmptyArt = `
+    +---------------------------------------+
+    |                                       |
+    |   All quiet on the agent front        |
+    |                                       |
+    |   No sessions found. Try              |
+    |   'gh agent-viz --demo' to explore,   |
+    |   or press 'r' to refresh.            |
+    |                                       |
+    +---------------------------------------+`
+
 func (m Model) renderFocusedList() string {
 	// Auto-group by repo when many sessions and user hasn't manually set grouping
 	effectiveGroupBy := m.groupBy
@@ -149,7 +170,7 @@ func (m Model) renderFocusedList() string {
 
 	if start > 0 {
 		rows = append(rows, m.tableRowStyle.Render(
-			fmt.Sprintf("  ↑ %d more above", start)))
+			fmt.Sprintf("  %s %d more above", a11y.Glyph("↑", "^"), start)))
 	}
 
 	for i := start; i < end; i++ {
@@ -160,7 +181,7 @@ func (m Model) renderFocusedList() string {
 
 	if end < len(m.sessions) {
 		rows = append(rows, m.tableRowStyle.Render(
-			fmt.Sprintf("  ↓ %d more below", len(m.sessions)-end)))
+			fmt.Sprintf("  %s %d more below", a11y.Glyph("↓", "v"), len(m.sessions)-end)))
 	}
 
 	return strings.Join(rows, "\n")
@@ -178,31 +199,37 @@ func (m Model) renderRow(sessionIdx int, session data.Session, selected bool, wi
 		icon = m.animStatusIcon(session.Status, m.animFrame)
 	}
 
-	badge := sessionBadge(session, m.duplicateCounts[sessionIdx])
+	badge := a11y.Text(sessionBadge(session, m.duplicateCounts[sessionIdx]))
 
 	// Gutter indicator: colored by status, brighter when selected
 	gutter := m.statusGutter(session.Status, selected)
 
+	// Word labels in accessible mode are wider than the usual icon
+	iconExtra := lipgloss.Width(icon) - 2
+	if iconExtra < 0 {
+		iconExtra = 0
+	}
+
 	if width < 40 {
-		titleMax := width - 8
+		titleMax := width - 8 - iconExtra
 		if titleMax < 3 {
 			titleMax = 3
 		}
-		title := truncate(sessionTitle(session), titleMax)
+		title := rowTitle(session, titleMax)
 		titleLine := fmt.Sprintf("%s%s %s", gutter, icon, title)
 		return style.Render(titleLine)
 	}
 
 	// Title line: left-aligned title, right-aligned badge
 	badgeLen := len(badge)
-	titleMax := width - 8 - badgeLen
+	titleMax := width - 8 - badgeLen - iconExtra
 	if badgeLen > 0 {
 		titleMax -= 2 // space before badge
 	}
 	if titleMax < 10 {
 		titleMax = 10
 	}
-	title := truncate(sessionTitle(session), titleMax)
+	title := rowTitle(session, titleMax)
 	leftPart := fmt.Sprintf("%s%s %s", gutter, icon, title)
 	if badge != "" {
 		pad := width - len(leftPart) - badgeLen
@@ -218,10 +245,26 @@ func (m Model) renderRow(sessionIdx int, session data.Session, selected bool, wi
 		repoMaxWidth = 10
 	}
 	repo := truncate(rowRepository(session), repoMaxWidth)
-	metaText := fmt.Sprintf("    %s  %s", repo, formatTime(session.UpdatedAt))
+	metaText := fmt.Sprintf("    %s%s  %s%s", rowOrigin(session), repo, formatTime(session.UpdatedAt), rowAnnotations(session))
+	dimStyle := lipgloss.NewStyle().Faint(true)
+
+	// A fetched PR status is shown in full, ahead of the duration
+	if session.PR != nil {
+		badge, badgeWidth := prBadge(session)
+		meta := dimStyle.Render(metaText) + "  " + badge
+		if dur := compactDuration(session); dur != "" {
+			durStr := a11y.Text("⏱ " + dur)
+			pad := width - len(metaText) - 2 - badgeWidth - len(durStr)
+			if pad < 1 {
+				pad = 1
+			}
+			meta += dimStyle.Render(strings.Repeat(" ", pad) + durStr)
+		}
+		return style.Render(leftPart + "\n" + meta)
+	}
 
 	if dur := compactDuration(session); dur != "" {
-		durStr := "⏱ " + dur
+		durStr := a11y.Text("⏱ " + dur)
 		pad := width - len(metaText) - len(durStr)
 		if pad < 1 {
 			pad = 1
@@ -229,13 +272,12 @@ func (m Model) renderRow(sessionIdx int, session data.Session, selected bool, wi
 		metaText += strings.Repeat(" ", pad) + durStr
 	}
 
-	dimStyle := lipgloss.NewStyle().Faint(true)
 	meta := dimStyle.Render(metaText)
 
 	// PR tag rendered separately so it's visible (not faint)
 	if hasPRBranch(session) {
 		prTag := lipgloss.NewStyle().
-			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("30"), Dark: lipgloss.Color("73")}).
+			Foreground(colors.Current().Focus).
 			Render(" PR")
 		meta = dimStyle.Render(metaText) + prTag
 	}
@@ -243,6 +285,15 @@ func (m Model) renderRow(sessionIdx int, session data.Session, selected bool, wi
 	return style.Render(leftPart + "\n" + meta)
 }
 
+// SetStyles replaces the theme styles, e.g. after a theme switch.
+func (m *Model) SetStyles(titleStyle, headerStyle, rowStyle, rowSelectedStyle, sectionHeaderStyle lipgloss.Style) {
+	m.titleStyle = titleStyle
+	m.tableHeaderStyle = headerStyle
+	m.tableRowStyle = rowStyle
+	m.tableRowSelected = rowSelectedStyle
+	m.sectionHeaderStyle = sectionHeaderStyle
+}
+
 // SetTasks updates sessions with sorting and de-emphasis
 func (m *Model) SetTasks(sessions []data.Session) {
 	m.loading = false
@@ -276,17 +327,9 @@ func (m *Model) SetTasks(sessions []data.Session) {
 		})
 	}
 
-	// Sort: most recent first
+	// Sort: most recent first unless another order was chosen
 	sort.SliceStable(ranked, func(i, j int) bool {
-		ti := ranked[i].session.UpdatedAt
-		tj := ranked[j].session.UpdatedAt
-		if !ti.IsZero() && !tj.IsZero() {
-			return ti.After(tj)
-		}
-		if !ti.IsZero() {
-			return true
-		}
-		return false
+		return sessionLess(ranked[i].session, ranked[j].session, m.sortBy)
 	})
 
 	m.sessions = make([]data.Session, 0, len(ranked))
@@ -397,7 +440,7 @@ func (m *Model) DismissSelected() {
 func (m *Model) DismissByID(id string) {
 	m.dismissedIDs[id] = struct{}{}
 	if m.dismissedStore != nil {
-		m.dismissedStore.Add(id)
+		m.dismissedStore.Dismiss(id)
 	}
 	// Remove from current sessions
 	newSessions := make([]data.Session, 0, len(m.sessions))
@@ -417,13 +460,14 @@ func (m *Model) DismissByID(id string) {
 }
 
 // DismissCompleted removes all completed sessions from view and returns the count dismissed.
+// Archived sessions are read-only and left in place.
 func (m *Model) DismissCompleted() int {
 	count := 0
 	for _, s := range m.sessions {
-		if strings.EqualFold(strings.TrimSpace(s.Status), "completed") && s.ID != "" {
+		if strings.EqualFold(strings.TrimSpace(s.Status), "completed") && s.ID != "" && !s.Archived {
 			m.dismissedIDs[s.ID] = struct{}{}
 			if m.dismissedStore != nil {
-				m.dismissedStore.Add(s.ID)
+				m.dismissedStore.Dismiss(s.ID)
 			}
 			count++
 		}
@@ -481,7 +525,7 @@ func formatTime(t time.Time) string {
 		return "not recorded"
 	}
 
-	now := time.Now()
+	now := data.Now()
 	diff := now.Sub(t)
 
 	if diff < time.Minute {
@@ -514,7 +558,7 @@ func sessionBadge(session data.Session, duplicateCount int) string {
 		return badge
 	}
 	if isActiveStatus(session.Status) && !session.UpdatedAt.IsZero() {
-		idle := time.Since(session.UpdatedAt)
+		idle := data.Since(session.UpdatedAt)
 		if idle >= data.AttentionStaleMax {
 			return "💤 idle " + formatIdleDuration(idle)
 		}
@@ -587,7 +631,7 @@ func isQuietDuplicateSession(session data.Session) bool {
 	if !isActiveStatus(session.Status) || session.UpdatedAt.IsZero() {
 		return false
 	}
-	return time.Since(session.UpdatedAt) >= data.AttentionStaleThreshold
+	return data.Since(session.UpdatedAt) >= data.AttentionStaleThreshold
 }
 
 func quietDuplicateKey(session data.Session) string {
@@ -634,6 +678,42 @@ func rowRepository(session data.Session) string {
 	return fmt.Sprintf("%s @ %s", repository, branch)
 }
 
+// rowOrigin labels sessions read from a session root other than ~/.copilot.
+func rowOrigin(session data.Session) string {
+	if session.Origin == "" {
+		return ""
+	}
+	return "[" + session.Origin + "] "
+}
+
+// rowTitle truncates the session title to maxLen, leaving room for the pin
+// marker of pinned sessions.
+func rowTitle(session data.Session, maxLen int) string {
+	if !session.Annotation.IsPinned() {
+		return truncate(sessionTitle(session), maxLen)
+	}
+	if maxLen > 6 {
+		maxLen -= 3
+	}
+	return a11y.Glyph("📌 ", "^ ") + truncate(sessionTitle(session), maxLen)
+}
+
+// rowAnnotations shows a session's tags and whether it has a note.
+func rowAnnotations(session data.Session) string {
+	a := session.Annotation
+	if a == nil {
+		return ""
+	}
+	out := ""
+	if len(a.Tags) > 0 {
+		out += "  #" + strings.Join(a.Tags, " #")
+	}
+	if a.Note != "" {
+		out += a11y.Glyph("  📝", "  (note)")
+	}
+	return out
+}
+
 // compactDuration returns a short duration string for the metadata line.
 // Returns empty string when telemetry is nil or duration is zero.
 func compactDuration(session data.Session) string {
@@ -665,6 +745,61 @@ func sessionHasLinkedPR(session data.Session) bool {
 	return session.PRNumber > 0 && strings.TrimSpace(session.Repository) != ""
 }
 
+// prBadge renders the state, checks and review of a session's PR, e.g.
+// "PR #12 ✗ checks · needs review", and returns it with its width.
+func prBadge(session data.Session) (string, int) {
+	pr := *session.PR
+	c := colors.Current()
+	type part struct {
+		text  string
+		color color.Color
+	}
+	parts := []part{{fmt.Sprintf("PR #%d", pr.Number), c.Focus}}
+	switch {
+	case pr.Merged():
+		parts = append(parts, part{"merged", c.Status.Completed})
+	case pr.Closed():
+		parts = append(parts, part{"closed", c.Muted})
+	default:
+		if pr.Draft {
+			parts = append(parts, part{"draft", c.Muted})
+		}
+		switch {
+		case pr.ChecksFailing():
+			parts = append(parts, part{"✗ checks", c.Status.Failed})
+		case pr.ChecksPending():
+			parts = append(parts, part{"● checks", c.Status.Running})
+		case pr.ChecksPassed():
+			parts = append(parts, part{"✓ checks", c.Status.Completed})
+		}
+		switch {
+		case pr.Approved():
+			parts = append(parts, part{"approved", c.Status.Completed})
+		case pr.ReviewDecision == "CHANGES_REQUESTED":
+			parts = append(parts, part{"changes requested", c.Status.Failed})
+		case data.PRAttention(session) == "needs review":
+			parts = append(parts, part{"needs review", c.Attention.Warning})
+		}
+		if pr.HasConflicts() {
+			parts = append(parts, part{"conflicts", c.Status.Failed})
+		}
+	}
+	var texts, rendered []string
+	for _, p := range parts {
+		text := a11y.Text(p.text)
+		texts = append(texts, text)
+		rendered = append(rendered, lipgloss.NewStyle().Foreground(p.color).Render(text))
+	}
+	plain, badge := texts[0], rendered[0]
+	if len(parts) > 1 {
+		sep := a11y.Glyph(" · ", " - ")
+		plain += " " + strings.Join(texts[1:], sep)
+		badge += " " + strings.Join(rendered[1:], lipgloss.NewStyle().Faint(true).Render(sep))
+	}
+	width := lipgloss.Width(plain)
+	return badge, width
+}
+
 // hasPRBranch returns true if the session is on a feature branch (not main/master)
 func hasPRBranch(session data.Session) bool {
 	if data.IsDefaultBranch(session.Branch) {
@@ -735,6 +870,82 @@ func (m *Model) CycleGroupBy() {
 	m.groupBy = groupByModes[0]
 }
 
+// SetGroupBy selects a group-by mode directly. It returns false and leaves
+// grouping unchanged when mode is not one of the supported modes.
+func (m *Model) SetGroupBy(mode string) bool {
+	for _, candidate := range groupByModes {
+		if candidate == mode {
+			m.groupBy = mode
+			m.userSetGroupBy = true
+			m.expandedGroup = -1
+			return true
+		}
+	}
+	return false
+}
+
+// SetSortBy selects the sort order applied on the next SetTasks. It returns
+// false and leaves the order unchanged when mode is not supported.
+func (m *Model) SetSortBy(mode string) bool {
+	if mode == "" {
+		mode = "updated"
+	}
+	for _, candidate := range sortModes {
+		if candidate == mode {
+			m.sortBy = mode
+			return true
+		}
+	}
+	return false
+}
+
+// SortBy returns the active sort order.
+func (m Model) SortBy() string {
+	if m.sortBy == "" {
+		return "updated"
+	}
+	return m.sortBy
+}
+
+// sessionLess orders sessions for the given sort mode. Pinned sessions come
+// first whatever the mode. Time and cost orders put the largest value first;
+// title and status sort alphabetically. Ties fall back to most recent
+// activity.
+func sessionLess(a, b data.Session, mode string) bool {
+	if pa, pb := a.Annotation.IsPinned(), b.Annotation.IsPinned(); pa != pb {
+		return pa
+	}
+	switch mode {
+	case "created":
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+	case "cost":
+		if ca, cb := data.SessionCost(a), data.SessionCost(b); ca != cb {
+			return ca > cb
+		}
+	case "title":
+		if ta, tb := strings.ToLower(sessionTitle(a)), strings.ToLower(sessionTitle(b)); ta != tb {
+			return ta < tb
+		}
+	case "status":
+		if sa, sb := strings.ToLower(a.Status), strings.ToLower(b.Status); sa != sb {
+			return sa < sb
+		}
+	}
+	ti, tj := a.UpdatedAt, b.UpdatedAt
+	if !ti.IsZero() && !tj.IsZero() {
+		return ti.After(tj)
+	}
+	return !ti.IsZero()
+}
+
+// GroupBy returns the active group-by mode: "" when ungrouped, otherwise
+// "repository", "status" or "source", including automatic grouping.
+func (m Model) GroupBy() string {
+	return m.effectiveGroupBy()
+}
+
 // GroupByLabel returns a human-readable label for the current group mode.
 func (m Model) GroupByLabel() string {
 	switch m.groupBy {
@@ -838,6 +1049,9 @@ func sessionGroupKey(session data.Session, mode string) string {
 		if src == "" {
 			return "(unknown)"
 		}
+		if session.Origin != "" {
+			return src + " (" + session.Origin + ")"
+		}
 		return src
 	default:
 		return ""
@@ -905,9 +1119,9 @@ func (m Model) renderGroupedListWith(groupBy string) string {
 					break
 				}
 			}
-			indicator := "▸"
+			indicator := a11y.Glyph("▸", ">")
 			if cursorInGroup {
-				indicator = "▎▸"
+				indicator = a11y.Glyph("▎▸", "|>")
 			}
 			headerLine := fmt.Sprintf("  %s %s (%d)", indicator, g.label, len(g.sessions))
 			rows = append(rows, m.sectionHeaderStyle.Render(headerLine))
@@ -915,7 +1129,7 @@ func (m Model) renderGroupedListWith(groupBy string) string {
 		}
 
 		// Expanded group
-		headerLine := fmt.Sprintf("  ▾ %s (%d)", g.label, len(g.sessions))
+		headerLine := fmt.Sprintf("  %s %s (%d)", a11y.Glyph("▾", "v"), g.label, len(g.sessions))
 		rows = append(rows, m.sectionHeaderStyle.Render(headerLine))
 
 		// Paginate within group
@@ -937,7 +1151,7 @@ func (m Model) renderGroupedListWith(groupBy string) string {
 			start, end := visibleRange(len(g.sessions), cursorPos, pageSize)
 			if start > 0 {
 				rows = append(rows, m.tableRowStyle.Render(
-					fmt.Sprintf("    ↑ %d more", start)))
+					fmt.Sprintf("    %s %d more", a11y.Glyph("↑", "^"), start)))
 			}
 			for si := start; si < end; si++ {
 				idx := g.sessions[si]
@@ -947,36 +1161,24 @@ func (m Model) renderGroupedListWith(groupBy string) string {
 			}
 			if end < len(g.sessions) {
 				rows = append(rows, m.tableRowStyle.Render(
-					fmt.Sprintf("    ↓ %d more", len(g.sessions)-end)))
+					fmt.Sprintf("    %s %d more", a11y.Glyph("↓", "v"), len(g.sessions)-end)))
 			}
 		}
 	}
 	return strings.Join(rows, "\n")
 }
 
-// statusColor returns a lipgloss.Color for the given session status.
+// statusColor returns the active theme's color for the given session status.
 func (m Model) statusColor(status string) color.Color {
-	switch strings.ToLower(strings.TrimSpace(status)) {
-	case "running":
-		return lipgloss.Color("42")
-	case "queued":
-		return lipgloss.Color("222")
-	case "needs-input":
-		return lipgloss.Color("214")
-	case "completed":
-		return lipgloss.Color("72")
-	case "failed":
-		return lipgloss.Color("203")
-	default:
-		return lipgloss.Color("245")
-	}
+	return colors.Current().StatusColor(status)
 }
 
 // statusGutter renders a colored gutter bar based on session status.
 func (m Model) statusGutter(status string, selected bool) string {
 	color := m.statusColor(status)
+	bar := a11y.Glyph("▎", "|")
 	if !selected {
-		return lipgloss.NewStyle().Foreground(color).Faint(true).Render("▎") + " "
+		return lipgloss.NewStyle().Foreground(color).Faint(true).Render(bar) + " "
 	}
-	return lipgloss.NewStyle().Foreground(color).Render("▎") + " "
+	return lipgloss.NewStyle().Foreground(color).Render(bar) + " "
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
 )
 
@@ -420,6 +421,34 @@ func TestDismissSelected_PersistsAcrossRefresh(t *testing.T) {
 	}
 }
 
+func TestSetTasks_PinnedSessionsFirst(t *testing.T) {
+	model := newModel()
+	now := time.Now()
+	model.SetTasks([]data.Session{
+		{ID: "new", Status: "running", Title: "Newest", UpdatedAt: now},
+		{ID: "pinned", Status: "completed", Title: "Pinned", UpdatedAt: now.Add(-48 * time.Hour),
+			Annotation: &data.Annotation{Pinned: true}},
+	})
+	if model.sessions[0].ID != "pinned" {
+		t.Fatalf("expected the pinned session first, got %s", model.sessions[0].ID)
+	}
+}
+
+func TestView_ShowsAnnotationBadges(t *testing.T) {
+	model := newModel()
+	model.SetSize(120, 30)
+	model.SetTasks([]data.Session{{
+		ID: "1", Status: "completed", Title: "Fix login", Repository: "org/api", UpdatedAt: time.Now(),
+		Annotation: &data.Annotation{Pinned: true, Note: "check SSO", Tags: []string{"auth", "release"}},
+	}})
+	view := model.View()
+	for _, want := range []string{"📌 Fix login", "#auth #release", "📝"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the row, got:\n%s", want, view)
+		}
+	}
+}
+
 func TestFormatIdleDuration(t *testing.T) {
 	tests := []struct {
 		d    time.Duration
@@ -527,6 +556,40 @@ func TestView_MetaLineShowsDuration(t *testing.T) {
 	}
 }
 
+func TestView_MetaLineShowsPRStatus(t *testing.T) {
+	model := newModel()
+	model.SetSize(140, 30)
+	model.SetTasks([]data.Session{
+		{
+			ID: "1", Status: "completed", Title: "Failing PR", Repository: "owner/repo", PRNumber: 12,
+			UpdatedAt: time.Now(), Telemetry: &data.SessionTelemetry{Duration: 12 * time.Minute},
+			PR:        &data.PRStatus{Number: 12, State: "OPEN", Checks: "FAILURE", Mergeable: "CONFLICTING"},
+		},
+		{
+			ID: "2", Status: "completed", Title: "Unreviewed PR", Repository: "owner/repo", PRNumber: 13,
+			UpdatedAt: time.Now().Add(-time.Minute),
+			PR:        &data.PRStatus{Number: 13, State: "OPEN", Checks: "SUCCESS", ReviewDecision: "REVIEW_REQUIRED"},
+		},
+		{
+			ID: "3", Status: "completed", Title: "Merged PR", Repository: "owner/repo", PRNumber: 14,
+			UpdatedAt: time.Now().Add(-2 * time.Minute),
+			PR:        &data.PRStatus{Number: 14, State: "MERGED", Checks: "FAILURE"},
+		},
+	})
+
+	view := ansi.Strip(model.View())
+	for _, want := range []string{"PR #12 ✗ checks · conflicts", "PR #13 ✓ checks · needs review", "PR #14 merged"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the list, got: %s", want, view)
+		}
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "PR #12") && (!strings.HasSuffix(strings.TrimSpace(line), "⏱ 12m") || lipgloss.Width(line) > 140) {
+			t.Errorf("expected the duration right-aligned after the PR badge, got %q", line)
+		}
+	}
+}
+
 func TestNewestDuplicateShowsCountIndicator(t *testing.T) {
 	model := newModel()
 	model.SetSize(160, 32)
@@ -583,6 +646,19 @@ func TestNonDuplicateSessionsUnaffected(t *testing.T) {
 	}
 }
 
+func TestSessionGroupKey_SourceIncludesRootLabel(t *testing.T) {
+	s := data.Session{Source: data.SourceLocalCopilot, Origin: "devbox"}
+	if got := sessionGroupKey(s, "source"); got != "local-copilot (devbox)" {
+		t.Errorf("sessionGroupKey = %q", got)
+	}
+	if got := rowOrigin(s); got != "[devbox] " {
+		t.Errorf("rowOrigin = %q", got)
+	}
+	if got := rowOrigin(data.Session{}); got != "" {
+		t.Errorf("expected no label for the default root, got %q", got)
+	}
+}
+
 func TestCycleGroupBy(t *testing.T) {
 	model := newModel()
 	if model.GroupByLabel() != "" {
@@ -869,3 +945,61 @@ func TestToggleGroupExpand(t *testing.T) {
 		t.Fatalf("expected repo-a collapsed after second toggle, got: %s", view)
 	}
 }
+
+func TestSetGroupBy(t *testing.T) {
+	model := newModel()
+	if !model.SetGroupBy("status") {
+		t.Fatal("expected status to be a valid group-by mode")
+	}
+	if model.GroupByLabel() != "status" {
+		t.Errorf("expected status grouping, got %q", model.GroupByLabel())
+	}
+	if model.SetGroupBy("owner") {
+		t.Error("expected unknown group-by mode to be rejected")
+	}
+	if model.GroupByLabel() != "status" {
+		t.Error("rejected mode should leave grouping unchanged")
+	}
+	if !model.SetGroupBy("") || model.IsGrouped() {
+		t.Error("expected empty mode to disable grouping, including auto-grouping")
+	}
+}
+
+func TestSetSortBy(t *testing.T) {
+	now := time.Now()
+	sessions := []data.Session{
+		{ID: "cheap", Title: "Beta", UpdatedAt: now, CreatedAt: now.Add(-3 * time.Hour),
+			Telemetry: &data.SessionTelemetry{Model: "claude-haiku", InputTokens: 1000}},
+		{ID: "pricey", Title: "Alpha", UpdatedAt: now.Add(-time.Hour), CreatedAt: now.Add(-2 * time.Hour),
+			Telemetry: &data.SessionTelemetry{Model: "claude-opus", InputTokens: 1_000_000}},
+		{ID: "new", Title: "Gamma", UpdatedAt: now.Add(-2 * time.Hour), CreatedAt: now.Add(-time.Hour)},
+	}
+
+	cases := []struct {
+		mode  string
+		first string
+	}{
+		{"updated", "cheap"},
+		{"created", "new"},
+		{"cost", "pricey"},
+		{"title", "pricey"},
+	}
+	for _, tc := range cases {
+		model := newModel()
+		if !model.SetSortBy(tc.mode) {
+			t.Fatalf("expected %q to be a valid sort mode", tc.mode)
+		}
+		model.SetTasks(sessions)
+		if got := model.sessions[0].ID; got != tc.first {
+			t.Errorf("sort %q: expected %s first, got %s", tc.mode, tc.first, got)
+		}
+	}
+
+	model := newModel()
+	if model.SetSortBy("random") {
+		t.Error("expected unknown sort mode to be rejected")
+	}
+	if model.SortBy() != "updated" {
+		t.Errorf("expected default sort to be updated, got %q", model.SortBy())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
 )
 
 const (
@@ -43,9 +44,6 @@ func New() Model {
 // Push adds a new toast to the stack. If the stack is full, the oldest
 // toast is evicted to make room.
 func (m *Model) Push(icon, title, message string) {
-	if len(title) > maxTitleLen {
-		title = title[:maxTitleLen-1] + "…"
-	}
 	t := Toast{
 		Icon:    icon,
 		Title:   title,
@@ -86,10 +84,12 @@ func (m Model) View() string {
 
 	var lines []string
 	for _, t := range m.toasts {
-		line := fmt.Sprintf("%s %s %s",
-			t.Icon,
-			titleStyle.Render(t.Title),
-			msgStyle.Render(t.Message))
+		line := fmt.Sprintf("%s %s",
+			titleStyle.Render(a11y.Truncate(a11y.Text(t.Title), maxTitleLen)),
+			msgStyle.Render(a11y.Text(t.Message)))
+		if icon := a11y.Text(t.Icon); icon != "" {
+			line = icon + " " + line
+		}
 		lines = append(lines, style.Render(line))
 	}
 
@@ -8,6 +8,8 @@ import (
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 // ToolEvent represents a single tool execution in the timeline
@@ -65,11 +67,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	if !m.ready || len(m.events) == 0 {
 		boxStyle := lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("238")).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
+			BorderForeground(colors.Current().Subtle).
 			Padding(1, 2).
 			Width(m.width - 4)
-		title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).Render("Tool Timeline")
+		title := lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Accent).Render("Tool Timeline")
 		return boxStyle.Render(title + "\n\nNo tool executions recorded for this session.")
 	}
 
@@ -93,9 +95,9 @@ func (m *Model) renderTimeline() string {
 		return ""
 	}
 
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99"))
-	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	iconStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("75"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Current().Accent)
+	dimStyle := lipgloss.NewStyle().Foreground(colors.Current().Muted)
+	iconStyle := lipgloss.NewStyle().Foreground(colors.Current().Highlight)
 
 	var lines []string
 	var prevTime string
@@ -117,11 +119,11 @@ func (m *Model) renderTimeline() string {
 		lines = append(lines, line)
 	}
 
-	content := strings.Join(lines, "\n")
+	content := a11y.Text(strings.Join(lines, "\n"))
 
 	boxStyle := lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("238")).
+		BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
+		BorderForeground(colors.Current().Subtle).
 		Padding(1, 2).
 		Width(m.width - 4)
 
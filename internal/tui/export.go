@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/conversation"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// exportedMsg signals that a selection or conversation was written to a file.
+type exportedMsg struct {
+	what string // e.g. "conversation"
+	path string
+}
+
+// Formats the export picker offers for a whole conversation.
+const (
+	exportMarkdown = "markdown"
+	exportHTML     = "html"
+)
+
+// writeExport writes content to a new file in dir, named for the session,
+// the time and what is saved; a second save within the same second gets a
+// numbered name rather than overwriting the first.
+func writeExport(dir, sessionID, what, ext, content string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("no export directory: set exportDir in the config")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	id := strings.ReplaceAll(sessionID, string(filepath.Separator), "-")
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	if id == "" {
+		id = "session"
+	}
+	base := fmt.Sprintf("%s-%s-%s", id, time.Now().Format("20060102-150405"), strings.ReplaceAll(what, " ", "-"))
+	for n := 1; ; n++ {
+		name := base + "." + ext
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d.%s", base, n, ext)
+		}
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.WriteString(content); err != nil {
+			f.Close()
+			return "", err
+		}
+		return path, f.Close()
+	}
+}
+
+// logSelecting reports whether the log or conversation view shown has a
+// selection.
+func (m Model) logSelecting() bool {
+	if m.showConversation {
+		return m.conversationView.Selecting()
+	}
+	return m.logView.Selecting()
+}
+
+// toggleLogSelection starts a selection in the log or conversation view
+// shown, or ends the one in progress.
+func (m *Model) toggleLogSelection() {
+	switch {
+	case m.logSelecting():
+		m.clearLogSelection()
+	case m.showConversation:
+		m.conversationView.StartSelection()
+	default:
+		m.logView.StartSelection()
+	}
+}
+
+// clearLogSelection ends the selection in the log or conversation view.
+func (m *Model) clearLogSelection() {
+	if m.showConversation {
+		m.conversationView.ClearSelection()
+	} else {
+		m.logView.ClearSelection()
+	}
+}
+
+// logSelection returns the selected text of the log or conversation view,
+// and what to call it in confirmations.
+func (m Model) logSelection() (what, text string) {
+	if m.showConversation {
+		return "conversation selection", m.conversationView.SelectedText()
+	}
+	return "log selection", m.logView.SelectedText()
+}
+
+// saveLogSelection writes the selection to a text file in the export
+// directory and ends it.
+func (m *Model) saveLogSelection() tea.Cmd {
+	what, text := m.logSelection()
+	m.clearLogSelection()
+	if text == "" {
+		return nil
+	}
+	dir := m.ctx.Config.ExportDirPath()
+	sessionID := m.logSessionID
+	return func() tea.Msg {
+		path, err := writeExport(dir, sessionID, what, "txt", text+"\n")
+		if err != nil {
+			return errMsg{fmt.Errorf("saving the selection failed: %w", err)}
+		}
+		return exportedMsg{what: what, path: path}
+	}
+}
+
+// openExportPicker offers the formats a local session's conversation can
+// be exported in.
+func (m *Model) openExportPicker(s *data.Session) {
+	if s == nil {
+		return
+	}
+	if !hasLocalLog(s) {
+		m.toast.Push("ℹ️", "Export", fmt.Sprintf("conversation export is only available for local Copilot sessions — press %s to select log lines to save", firstKey(m.keys.VisualSelect)))
+		return
+	}
+	m.promptSessionID = s.ID
+	m.openChooser("Export Conversation", []picker.Item{
+		{Label: "Markdown", Detail: ".md — renders on GitHub and in editors", Value: exportMarkdown},
+		{Label: "HTML", Detail: ".html — a standalone page for the browser", Value: exportHTML},
+	}, chooser{
+		choose: func(m *Model, item picker.Item) tea.Cmd {
+			return m.exportConversation(m.findSession(m.promptSessionID), item.Value)
+		},
+	})
+}
+
+// exportConversation writes a local session's whole conversation to the
+// export directory as Markdown or HTML, keeping who said what, when, and
+// the tools each turn used.
+func (m Model) exportConversation(s *data.Session, format string) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	dir := m.ctx.Config.ExportDirPath()
+	id, title := s.ID, s.Title
+	if title == "" {
+		title = "Session " + id
+	}
+	return func() tea.Msg {
+		if m.replay != nil {
+			return errMsg{replayUnavailable("conversation")}
+		}
+		events, err := data.FetchSessionEvents(id)
+		if err != nil {
+			return errMsg{err}
+		}
+		messages := conversationMessages(events)
+		if len(messages) == 0 {
+			return errMsg{fmt.Errorf("no conversation in this session yet")}
+		}
+		content, ext := conversation.ExportMarkdown(title, messages), "md"
+		if format == exportHTML {
+			content, ext = conversation.ExportHTML(title, messages), "html"
+		}
+		path, err := writeExport(dir, id, "conversation", ext, content)
+		if err != nil {
+			return errMsg{fmt.Errorf("exporting the conversation failed: %w", err)}
+		}
+		return exportedMsg{what: "conversation", path: path}
+	}
+}
+
+// handleSelectionKeys handles the select and save keys in the log view,
+// and esc while a selection is active. It reports whether msg was one of
+// those keys.
+func (m *Model) handleSelectionKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.VisualSelect):
+		m.toggleLogSelection()
+	case key.Matches(msg, m.keys.SaveSelection):
+		if m.logSelecting() {
+			return m.saveLogSelection(), true
+		}
+		m.openExportPicker(m.findSession(m.logSessionID))
+	case key.Matches(msg, m.keys.NavigateBack) && m.logSelecting():
+		m.clearLogSelection()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// logTestModel shows the log of a local session that has a conversation on
+// disk, with exports going to a temporary directory.
+func logTestModel(t *testing.T) Model {
+	t.Helper()
+	m := annotationTestModel(t)
+	m.ctx.Config.ExportDir = t.TempDir()
+	dir := filepath.Join(os.Getenv("HOME"), ".copilot", "session-state", "local-1")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	events := `{"type":"user.message","timestamp":"2026-01-15T09:15:00Z","data":{"content":"Fix the login bug"}}
+{"type":"tool.execution_start","timestamp":"2026-01-15T09:15:30Z","data":{"toolName":"grep"}}
+{"type":"assistant.message","timestamp":"2026-01-15T09:16:00Z","data":{"content":"The token expired."}}
+`
+	if err := os.WriteFile(filepath.Join(dir, "events.jsonl"), []byte(events), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.mergeSessions([]data.Session{
+		{ID: "local-1", Title: "Fix login", Status: "completed", Source: data.SourceLocalCopilot, HasLog: true, UpdatedAt: time.Now().Add(time.Hour)},
+	})
+	m.taskList.MoveCursor(-len(m.allSessions))
+	for i := 0; i < len(m.allSessions) && m.taskList.SelectedTask().ID != "local-1"; i++ {
+		m.taskList.MoveCursor(1)
+	}
+	m.viewMode = ViewModeLog
+	m.logSessionID = "local-1"
+	m.logView.SetSize(80, 4)
+	m.logView.SetContent("first line\n\nsecond line\n\nthird line")
+	return m
+}
+
+func TestLogSelectionKeys(t *testing.T) {
+	stubClipboardTools(t)
+	m := logTestModel(t)
+	press := func(k string) tea.Cmd {
+		t.Helper()
+		var msg tea.KeyPressMsg
+		if k == "esc" {
+			msg = tea.KeyPressMsg{Code: tea.KeyEscape}
+		} else {
+			msg = tea.KeyPressMsg{Code: []rune(k)[0], Text: k}
+		}
+		next, cmd := m.handleKeyPress(msg)
+		m = next.(Model)
+		return cmd
+	}
+
+	press("V")
+	press("j")
+	press("j")
+	if !m.logSelecting() || m.logView.SelectionLen() != 3 {
+		t.Fatalf("expected 3 lines selected, got %d", m.logView.SelectionLen())
+	}
+	press("esc")
+	if m.logSelecting() || m.viewMode != ViewModeLog {
+		t.Fatal("expected esc to cancel the selection and stay in the log")
+	}
+
+	// The rendered log starts with a blank line.
+	press("v")
+	press("j")
+	press("j")
+	press("j")
+	cmd := press("y")
+	if cmd == nil || m.logSelecting() {
+		t.Fatal("expected the selection copied and ended")
+	}
+	batch := cmd().(tea.BatchMsg)
+	if msg := batch[1]().(clipboardCopiedMsg); msg.what != "log selection" || msg.value != "first line\n\nsecond line" {
+		t.Errorf("unexpected copy %+v", msg)
+	}
+
+	press("v")
+	press("j")
+	cmd = press("w")
+	if cmd == nil || m.logSelecting() {
+		t.Fatal("expected the selection saved and ended")
+	}
+	saved, ok := cmd().(exportedMsg)
+	if !ok {
+		t.Fatalf("expected the selection saved, got %+v", saved)
+	}
+	if body, _ := os.ReadFile(saved.path); string(body) != "first line\n" {
+		t.Errorf("unexpected saved selection %q", body)
+	}
+	if !strings.HasPrefix(filepath.Base(saved.path), "local-1-") || !strings.HasSuffix(saved.path, "-log-selection.txt") {
+		t.Errorf("unexpected file name %s", saved.path)
+	}
+}
+
+func TestExportConversation(t *testing.T) {
+	m := logTestModel(t)
+	next, _ := m.handleKeyPress(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	m = next.(Model)
+	if !m.pickerOpen("Export Conversation") {
+		t.Fatal("expected the export formats offered without a selection")
+	}
+	next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: '1', Text: "1"})
+	m = next.(Model)
+	if cmd == nil || m.pickerOpen("Export Conversation") {
+		t.Fatal("expected the Markdown export started")
+	}
+	msg, ok := cmd().(exportedMsg)
+	if !ok || !strings.HasSuffix(msg.path, "-conversation.md") {
+		t.Fatalf("expected a Markdown file written, got %+v", msg)
+	}
+	body, _ := os.ReadFile(msg.path)
+	if !strings.HasPrefix(string(body), "# Fix login\n") || !strings.Contains(string(body), "The token expired.\n\n> Tools: 🔍 grep") {
+		t.Errorf("unexpected export:\n%s", body)
+	}
+	next, _ = m.Update(msg)
+	m = next.(Model)
+	if view := ansi.Strip(m.toast.View()); !strings.Contains(view, "Saved conversation") {
+		t.Errorf("expected the export confirmed, got %q", view)
+	}
+
+	if html, ok := m.exportConversation(m.findSession("local-1"), exportHTML)().(exportedMsg); !ok || !strings.HasSuffix(html.path, ".html") {
+		t.Errorf("expected an HTML file written, got %+v", html)
+	}
+	m.openExportPicker(m.findSession("a"))
+	if m.pickerOpen("Export Conversation") || !strings.Contains(ansi.Strip(m.toast.View()), "only available for local Copilot sessions") {
+		t.Error("expected a session without a local log refused")
+	}
+}
+
+func TestLogSelection_UsesSessionLogsWereOpenedFor(t *testing.T) {
+	m := logTestModel(t)
+	// The active view and the palette open logs without moving the list.
+	m.taskList.MoveCursor(len(m.allSessions))
+	if m.taskList.SelectedTask().ID == "local-1" {
+		t.Fatal("expected another session selected in the list")
+	}
+	m.openLogs(m.findSession("local-1"))
+	press := func(k string) tea.Cmd {
+		t.Helper()
+		next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: []rune(k)[0], Text: k})
+		m = next.(Model)
+		return cmd
+	}
+
+	press("v")
+	press("j")
+	saved, ok := press("w")().(exportedMsg)
+	if !ok || !strings.HasPrefix(filepath.Base(saved.path), "local-1-") {
+		t.Errorf("expected the selection saved for the session shown, got %+v", saved)
+	}
+	press("w")
+	if !m.pickerOpen("Export Conversation") || m.promptSessionID != "local-1" {
+		t.Errorf("expected the shown session's conversation offered for export, got %q", m.promptSessionID)
+	}
+}
+
+func TestWriteExport_SameSecondKeepsBoth(t *testing.T) {
+	dir := t.TempDir()
+	first, err := writeExport(dir, "abcdef123456", "selection", "txt", "one")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := writeExport(dir, "abcdef123456", "selection", "txt", "two")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first == second {
+		t.Fatalf("expected a second name, got %s twice", first)
+	}
+	for path, want := range map[string]string{first: "one", second: "two"} {
+		if got, _ := os.ReadFile(path); string(got) != want {
+			t.Errorf("expected %s to hold %q, got %q", path, want, got)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package tui
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/toast"
+)
+
+// Run `go test ./internal/tui -run TestGolden -update` to rewrite the
+// golden files after an intended layout change, then review the diff.
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// goldenSizes are the terminal sizes every view is rendered at.
+var goldenSizes = []struct{ width, height int }{
+	{80, 24},
+	{120, 40},
+	{160, 50},
+}
+
+// goldenModes lists every ViewMode with the name used in golden file names.
+var goldenModes = []ViewMode{
+	ViewModeMission,
+	ViewModeList,
+	ViewModeActive,
+	ViewModeDetail,
+	ViewModeLog,
+	ViewModeToolTimeline,
+	ViewModeDiff,
+	ViewModeGitActivity,
+}
+
+// replayFixture loads testdata/snapshots/<name>.json into a model that has
+// finished its initial load, with every source of randomness and wall-clock
+// time pinned so renders are reproducible.
+func replayFixture(t *testing.T, name string, width, height int) Model {
+	t.Helper()
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("HOME", t.TempDir())
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() {
+		time.Local = local
+		data.Now = time.Now
+		colors.Set(colors.Default())
+		a11y.Set(a11y.Mode{})
+	})
+
+	snap, err := data.ReadSnapshot(filepath.Join("testdata", "snapshots", name+".json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	m, err := NewReplayModel(snap, "", "test")
+	if err != nil {
+		t.Fatalf("replay fixture: %v", err)
+	}
+	m.header.SetTagline("")
+	m.toast = toast.New()
+
+	for _, msg := range []tea.Msg{
+		tea.WindowSizeMsg{Width: width, Height: height},
+		resizeDebouncedMsg{},
+		m.fetchLocalSessions(),
+		m.fetchAgentTasks(),
+		m.fetchTokenUsage(),
+	} {
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+// renderGolden renders mode from the named fixture at the given size, with
+// styling stripped so golden files hold only layout and text.
+func renderGolden(t *testing.T, fixture string, mode ViewMode, width, height int) string {
+	t.Helper()
+	m := replayFixture(t, fixture, width, height)
+	m.viewMode = mode
+	if mode == ViewModeDetail {
+		m.taskDetail.SetTask(m.taskList.SelectedTask())
+	}
+	m = loadGoldenContent(t, m, fixture, mode)
+	return ansi.Strip(m.View().Content)
+}
+
+// loadGoldenContent hands the views that load content on demand what they
+// would fetch for the selected session, from testdata/snapshots/<fixture>/:
+// its log, tool events, PR diff and working-tree diff. Replays can't fetch
+// these, so without them the goldens would hold only placeholders.
+func loadGoldenContent(t *testing.T, m Model, fixture string, mode ViewMode) Model {
+	t.Helper()
+	read := func(name string) string {
+		t.Helper()
+		raw, err := os.ReadFile(filepath.Join("testdata", "snapshots", fixture, name))
+		if err != nil {
+			t.Fatalf("read fixture content: %v", err)
+		}
+		return string(raw)
+	}
+	s := m.taskList.SelectedTask()
+	var msg tea.Msg
+	switch mode {
+	case ViewModeLog:
+		msg = taskLogLoadedMsg{log: read("log.md")}
+	case ViewModeToolTimeline:
+		dir := filepath.Join(os.Getenv("HOME"), ".copilot", "session-state", s.ID)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "events.jsonl"), []byte(read("events.jsonl")), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		events, err := data.FetchSessionEvents(s.ID)
+		if err != nil {
+			t.Fatalf("read fixture events: %v", err)
+		}
+		msg = toolTimelineLoadedMsg{events: toolEvents(events)}
+	case ViewModeDiff:
+		msg = diffLoadedMsg{files: diffview.ParseUnifiedDiff(read("pr.diff")), repo: s.Repository, prNumber: 42}
+	case ViewModeGitActivity:
+		result := &data.GitDiffResult{Diff: read("worktree.diff")}
+		for _, f := range diffview.ParseUnifiedDiff(result.Diff) {
+			result.FileCount++
+			result.Additions += f.Additions
+			result.Deletions += f.Deletions
+		}
+		msg = gitDiffLoadedMsg{result: result}
+	default:
+		return m
+	}
+	next, _ := m.Update(msg)
+	return next.(Model)
+}
+
+// assertGolden compares got with testdata/golden/<name>.golden, or rewrites
+// the file when -update is set.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s does not match the rendered view (run with -update if the change is intended)\n%s",
+			path, lineDiff(string(want), got))
+	}
+}
+
+// lineDiff reports the first differing line between want and got.
+func lineDiff(want, got string) string {
+	wl, gl := strings.Split(want, "\n"), strings.Split(got, "\n")
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %q\n  got:  %q", i+1, w, g)
+		}
+	}
+	return "files differ"
+}
+
+func TestGolden(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "snapshots", "*.json"))
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("no fixture snapshots found: %v", err)
+	}
+	for _, path := range fixtures {
+		fixture := strings.TrimSuffix(filepath.Base(path), ".json")
+		for _, mode := range goldenModes {
+			for _, size := range goldenSizes {
+				name := fmt.Sprintf("%s-%s-%dx%d", fixture, Model{viewMode: mode}.viewModeName(), size.width, size.height)
+				t.Run(name, func(t *testing.T) {
+					assertGolden(t, name, renderGolden(t, fixture, mode, size.width, size.height))
+				})
+			}
+		}
+	}
+}
+
+// TestGolden_PlainIsASCII renders every view in --plain mode, with toasts
+// and the search bar up, and fails on any glyph outside ASCII.
+func TestGolden_PlainIsASCII(t *testing.T) {
+	SetRenderFlags(true, false)
+	t.Cleanup(func() { SetRenderFlags(false, false) })
+	for _, mode := range goldenModes {
+		for _, size := range goldenSizes {
+			name := fmt.Sprintf("%s-%dx%d", Model{viewMode: mode}.viewModeName(), size.width, size.height)
+			t.Run(name, func(t *testing.T) {
+				m := replayFixture(t, "fleet", size.width, size.height)
+				m.viewMode = mode
+				if mode == ViewModeDetail {
+					m.taskDetail.SetTask(m.taskList.SelectedTask())
+				}
+				m = loadGoldenContent(t, m, "fleet", mode)
+				m.toast.Push("⚠️", "Stop process", "no PR — session is on main")
+				m.toast.Push("✅", "Review", "approved · 2 line comment(s)")
+				m.searchActive, m.searchQuery = true, "login"
+				for lineNo, line := range strings.Split(ansi.Strip(m.View().Content), "\n") {
+					for _, r := range line {
+						if r > 0x7e {
+							t.Errorf("line %d has %q: %s", lineNo+1, r, line)
+							break
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestReplay_UsesSnapshotInsteadOfLiveData(t *testing.T) {
+	m := replayFixture(t, "fleet", 120, 40)
+
+	if len(m.allSessions) != 8 {
+		t.Fatalf("expected the 8 fixture sessions, got %d", len(m.allSessions))
+	}
+	if m.viewMode != ViewModeMission {
+		t.Errorf("expected the captured dashboard view, got %s", m.viewModeName())
+	}
+	if got := data.Now().UTC().Format(time.RFC3339); got != "2026-05-01T15:04:05Z" {
+		t.Errorf("expected the clock pinned to the capture time, got %s", got)
+	}
+	if msg := m.fetchToolTimeline("c0ffee01-local-running")(); msg == nil {
+		t.Error("expected replay to report tool events as unavailable")
+	} else if e, ok := msg.(errMsg); !ok || !strings.Contains(e.err.Error(), "replaying") {
+		t.Errorf("expected a replay error, got %#v", msg)
+	}
+
+	// Re-capturing the replay reproduces the same state.
+	state := m.snapshotState()
+	if len(state.Sessions) != 8 || state.TokenUsage["c0ffee01-local-running"] == nil {
+		t.Errorf("expected sessions and token usage in the re-captured state, got %+v", state)
+	}
+	var input string
+	for _, r := range state.Sessions {
+		if r.ID == "c0ffee02-local-input" {
+			input = r.LastAssistantMessage
+		}
+	}
+	if input == "" {
+		t.Error("expected the last assistant message to be recorded")
+	}
+}
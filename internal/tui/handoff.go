@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// editorCommand returns $VISUAL or $EDITOR split into words, e.g.
+// ["code", "-w"], falling back to vi.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// shellCommand returns the user's $SHELL, falling back to /bin/sh.
+func shellCommand() []string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return []string{shell}
+	}
+	return []string{"/bin/sh"}
+}
+
+// openDirCommand builds the command that hands the terminal to an editor
+// opened on dir, or a shell started in it, like resumeSession hands it to
+// Copilot CLI. A non-empty setup command runs in dir first, in the same
+// terminal so its output is visible; the editor or shell opens even when it
+// fails.
+func openDirCommand(dir string, editor bool, setup string) *exec.Cmd {
+	target := shellCommand()
+	if editor {
+		target = append(editorCommand(), ".")
+	}
+	var c *exec.Cmd
+	if setup == "" {
+		c = exec.Command(target[0], target[1:]...)
+	} else {
+		script := setup + ` || echo "gh-agent-viz: setup command failed" >&2; exec "$@"`
+		c = exec.Command("sh", append([]string{"-c", script, "sh"}, target...)...)
+	}
+	c.Dir = dir
+	return c
+}
+
+// editorAtLine returns the editor command opening file at line, in the
+// form the editor understands: --goto file:line for VS Code and its forks,
+// file:line for editors that parse it, +line file otherwise (vi, nano,
+// emacs and most terminal editors).
+func editorAtLine(file string, line int) []string {
+	editor := editorCommand()
+	if line <= 0 {
+		return append(editor, file)
+	}
+	at := file + ":" + strconv.Itoa(line)
+	switch filepath.Base(editor[0]) {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return append(editor, "--goto", at)
+	case "subl", "zed", "hx", "helix":
+		return append(editor, at)
+	}
+	return append(editor, "+"+strconv.Itoa(line), file)
+}
+
+// openFileCommand builds the command that hands the terminal to the editor
+// opened on file, relative to dir, at line.
+func openFileCommand(dir, file string, line int) *exec.Cmd {
+	target := editorAtLine(file, line)
+	c := exec.Command(target[0], target[1:]...)
+	c.Dir = dir
+	return c
+}
+
+// handOff runs c with the terminal handed over, restoring the TUI when it
+// exits and reporting a failure as what exiting with an error.
+func handOff(what string, c *exec.Cmd) tea.Cmd {
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return errMsg{fmt.Errorf("%s exited with error: %w", what, err)}
+		}
+		return nil
+	})
+}
@@ -94,14 +94,17 @@ func (m *Model) updateFooterHints() {
 		m.footer.SetBadge(" 📋 List ", footer.BadgeBgList())
 		m.footer.ClearStatus()
 		hints := []key.Binding{
-			key.NewBinding(key.WithKeys("↑/↓"), key.WithHelp("↑/↓", "navigate")),
+			pairHint(m.keys.MoveDown, m.keys.MoveUp, "navigate"),
 			m.keys.SelectTask,
 			m.keys.ToggleFilter,
 			m.keys.SearchFilter,
+			m.keys.CommandPalette,
 			m.keys.ToggleMission,
-			m.keys.ShowHelp,
-			m.keys.ExitApp,
 		}
+		if len(m.ctx.Config.Views) > 0 {
+			hints = append(hints, m.keys.SwitchView)
+		}
+		hints = append(hints, m.keys.ShowHelp, m.keys.ExitApp)
 		m.footer.SetHints(hints)
 	case ViewModeDetail:
 		m.footer.SetBadge(" 🔍 Detail ", footer.BadgeBgDetail())
@@ -112,12 +115,12 @@ func (m *Model) updateFooterHints() {
 		}
 		session := m.taskList.SelectedTask()
 		if session != nil && session.Source == data.SourceLocalCopilot && session.HasLog {
-			hints = append(hints, key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tools")))
+			hints = append(hints, m.keys.ShowToolTimeline)
 		}
 		if canShowDiff(session) {
 			hints = append(hints, m.keys.ShowDiff)
 		}
-		if session != nil && session.Source == data.SourceLocalCopilot && session.WorkDir != "" {
+		if hasWorkDir(session) {
 			hints = append(hints, m.keys.ShowGitActivity)
 		}
 		hints = append(hints, m.keys.DismissSession, m.keys.ShowHelp, m.keys.ExitApp)
@@ -125,14 +128,34 @@ func (m *Model) updateFooterHints() {
 	case ViewModeLog:
 		m.footer.SetBadge(" 📜 Logs ", footer.BadgeBgLog())
 		m.footer.ClearStatus()
+		if m.logSelecting() {
+			lines := m.logView.SelectionLen()
+			if m.showConversation {
+				lines = m.conversationView.SelectionLen()
+			}
+			m.footer.SetStatus(fmt.Sprintf(" VISUAL %d ", lines), footer.StatusBgNeedsInput())
+			m.footer.SetHints([]key.Binding{
+				key.NewBinding(key.WithKeys(firstKey(m.keys.NavigateBack)), key.WithHelp(firstKey(m.keys.NavigateBack), "cancel")),
+				pairHint(m.keys.MoveDown, m.keys.MoveUp, "extend"),
+				m.keys.Copy,
+				m.keys.SaveSelection,
+				m.keys.ShowHelp,
+			})
+			break
+		}
 		logHints := []key.Binding{
 			m.keys.NavigateBack,
-			key.NewBinding(key.WithKeys("↑/↓"), key.WithHelp("↑/↓", "scroll")),
+			pairHint(m.keys.MoveDown, m.keys.MoveUp, "scroll"),
 			m.keys.ToggleFollow,
+			m.keys.SearchFilter,
+			m.keys.VisualSelect,
+		}
+		if m.logSearchState().Active() {
+			logHints = append(logHints, pairHint(m.keys.NextMatch, m.keys.PrevMatch, "match"), m.keys.FilterMatches)
 		}
 		session := m.taskList.SelectedTask()
 		if session != nil && session.Source == data.SourceLocalCopilot {
-			logHints = append(logHints, key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "convo")))
+			logHints = append(logHints, m.keys.ShowConversation)
 		}
 		logHints = append(logHints, m.keys.ShowHelp, m.keys.ExitApp)
 		m.footer.SetHints(logHints)
@@ -141,7 +164,7 @@ func (m *Model) updateFooterHints() {
 		m.footer.ClearStatus()
 		timelineHints := []key.Binding{
 			m.keys.NavigateBack,
-			key.NewBinding(key.WithKeys("↑/↓"), key.WithHelp("↑/↓", "scroll")),
+			pairHint(m.keys.MoveDown, m.keys.MoveUp, "scroll"),
 			m.keys.ShowHelp,
 			m.keys.ExitApp,
 		}
@@ -151,18 +174,28 @@ func (m *Model) updateFooterHints() {
 		m.footer.ClearStatus()
 		missionHints := []key.Binding{
 			key.NewBinding(key.WithKeys("1-5"), key.WithHelp("1-5", "panel")),
-			key.NewBinding(key.WithKeys("j/k"), key.WithHelp("j/k", "navigate")),
+			pairHint(m.keys.MoveDown, m.keys.MoveUp, "navigate"),
 			m.keys.SelectTask,
-			m.keys.ShowHelp,
-			m.keys.ExitApp,
+			m.keys.CommandPalette,
 		}
+		if len(m.ctx.Config.Views) > 0 {
+			missionHints = append(missionHints, m.keys.SwitchView)
+		}
+		missionHints = append(missionHints, m.keys.ShowHelp, m.keys.ExitApp)
 		m.footer.SetHints(missionHints)
 	case ViewModeDiff:
 		m.footer.SetBadge(" 📝 Diff ", footer.BadgeBgDetail())
 		m.footer.ClearStatus()
 		diffHints := []key.Binding{
 			m.keys.NavigateBack,
-			key.NewBinding(key.WithKeys("↑/↓"), key.WithHelp("↑/↓", "scroll")),
+			pairHint(m.keys.MoveDown, m.keys.MoveUp, "line"),
+			pairHint(m.keys.NextHunk, m.keys.PrevHunk, "hunk"),
+			pairHint(m.keys.NextFile, m.keys.PrevFile, "file"),
+			m.keys.ToggleSplit,
+			m.keys.FoldFile,
+			m.keys.CommentLine,
+			m.keys.SubmitReview,
+			key.NewBinding(key.WithKeys(firstKey(m.keys.OpenEditor)), key.WithHelp(firstKey(m.keys.OpenEditor), "open file")),
 			m.keys.ShowHelp,
 			m.keys.ExitApp,
 		}
@@ -170,10 +203,16 @@ func (m *Model) updateFooterHints() {
 	case ViewModeGitActivity:
 		m.footer.SetBadge(" 🌿 Git ", footer.BadgeBgDetail())
 		m.footer.ClearStatus()
+		scroll := pairHint(m.keys.MoveDown, m.keys.MoveUp, "scroll")
+		if m.gitActivity.BrowsingCommits() {
+			scroll = pairHint(m.keys.MoveDown, m.keys.MoveUp, "commits")
+		}
 		gitHints := []key.Binding{
 			m.keys.NavigateBack,
-			key.NewBinding(key.WithKeys("↑/↓"), key.WithHelp("↑/↓", "scroll")),
+			scroll,
 			m.keys.RefreshData,
+			key.NewBinding(key.WithKeys(firstKey(m.keys.NextPanel)), key.WithHelp(firstKey(m.keys.NextPanel), "section")),
+			key.NewBinding(key.WithKeys(firstKey(m.keys.OpenEditor)), key.WithHelp(firstKey(m.keys.OpenEditor), "open file")),
 			m.keys.ShowHelp,
 			m.keys.ExitApp,
 		}
@@ -196,11 +235,11 @@ func (m *Model) updateFooterHints() {
 		}
 		activeHints := []key.Binding{
 			m.keys.NavigateBack,
-			key.NewBinding(key.WithKeys("j/k"), key.WithHelp("j/k", "navigate")),
+			pairHint(m.keys.MoveDown, m.keys.MoveUp, "navigate"),
 			m.keys.SelectTask,
 			m.keys.OpenInBrowser,
 			m.keys.ShowLogs,
-			key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy ID")),
+			m.keys.CopyID,
 			m.keys.DismissSession,
 			m.keys.RefreshData,
 			m.keys.ShowHelp,
@@ -227,20 +266,72 @@ func isSessionRunning(session *data.Session) bool {
 	return session != nil && data.StatusIsActive(session.Status)
 }
 
-// visibleSessions returns allSessions minus dismissed ones. Used to push
-// fresh data to components when the user switches views.
+// visibleSessions returns allSessions minus dismissed and snoozed ones,
+// annotated and narrowed by the active search filter. Used to push fresh
+// data to components when the user switches views.
 func (m Model) visibleSessions() []data.Session {
-	dismissedIDs := map[string]struct{}{}
-	if m.dismissedStore != nil {
-		dismissedIDs = m.dismissedStore.IDs()
+	return m.searchFilter.Filter(m.annotateSessions(m.allSessions))
+}
+
+// setSearchQuery updates the search text, recompiles the filter expression
+// and refreshes autocomplete candidates. On a parse error the previous
+// filter stays in effect so the list doesn't flicker while typing.
+func (m *Model) setSearchQuery(q string) {
+	m.searchQuery = q
+	m.searchCompletionIdx = -1
+	m.searchCompletions = data.CompleteQuery(q, m.allSessions, m.ctx.Config.Filters)
+	if strings.TrimSpace(q) == "" {
+		m.searchFilter = nil
+		m.searchErr = nil
+		return
+	}
+	query, err := data.ParseQuery(q, m.ctx.Config.Filters)
+	if err != nil {
+		m.searchErr = err
+		return
+	}
+	m.searchFilter = query
+	m.searchErr = nil
+}
+
+// cycleSearchCompletion replaces the search text with the next autocomplete
+// candidate. Repeated presses cycle through the candidates computed before
+// the first press; a lone candidate is accepted outright so the next press
+// offers the following level (e.g. values after a field name).
+func (m *Model) cycleSearchCompletion() {
+	if len(m.searchCompletions) == 0 {
+		return
 	}
-	visible := make([]data.Session, 0, len(m.allSessions))
-	for _, s := range m.allSessions {
-		if _, dismissed := dismissedIDs[s.ID]; !dismissed {
-			visible = append(visible, s)
+	completions := m.searchCompletions
+	idx := (m.searchCompletionIdx + 1) % len(completions)
+	m.setSearchQuery(completions[idx])
+	if len(completions) == 1 {
+		return
+	}
+	m.searchCompletions = completions
+	m.searchCompletionIdx = idx
+}
+
+// searchCompletionHint renders a compact list of autocomplete candidates,
+// highlighting the one currently applied.
+func searchCompletionHint(completions []string, idx int) string {
+	const maxShown = 6
+	parts := make([]string, 0, maxShown+1)
+	for i, c := range completions {
+		if i == maxShown {
+			parts = append(parts, fmt.Sprintf("+%d more", len(completions)-maxShown))
+			break
+		}
+		label := c
+		if j := strings.LastIndexAny(c, " ("); j >= 0 {
+			label = c[j+1:]
 		}
+		if i == idx {
+			label = "[" + label + "]"
+		}
+		parts = append(parts, label)
 	}
-	return visible
+	return strings.Join(parts, "  ")
 }
 
 func (m Model) refreshCmd() tea.Cmd {
@@ -299,6 +390,9 @@ func (m *Model) mergeSessions(newSessions []data.Session) {
 		}
 	}
 
+	m.attachActivity(m.allSessions)
+	m.attachPRStatus(m.allSessions)
+
 	// Cap session count to prevent unbounded memory growth
 	if len(m.allSessions) > maxSessions {
 		sort.SliceStable(m.allSessions, func(i, j int) bool {
@@ -310,30 +404,19 @@ func (m *Model) mergeSessions(newSessions []data.Session) {
 	// Filter dismissed — but auto-undismiss sessions whose status changed
 	// to urgent since they were last seen (e.g. was "running", now "failed").
 	// Sessions explicitly dismissed while already urgent stay dismissed.
-	dismissedIDs := map[string]struct{}{}
-	if m.dismissedStore != nil {
-		dismissedIDs = m.dismissedStore.IDs()
-	}
-	visible := make([]data.Session, 0, len(m.allSessions))
-	for _, s := range m.allSessions {
-		if _, dismissed := dismissedIDs[s.ID]; dismissed {
-			if data.SessionAttentionLevel(s) >= data.AttentionWarning {
+	if m.annotations != nil {
+		dismissedIDs := m.annotations.DismissedIDs()
+		for _, s := range m.allSessions {
+			if _, dismissed := dismissedIDs[s.ID]; dismissed && data.SessionAttentionLevel(s) >= data.AttentionWarning {
 				prevStatus, seen := m.prevSessions[s.ID]
-				statusChanged := seen && !strings.EqualFold(prevStatus, s.Status)
-				if statusChanged {
-					if m.dismissedStore != nil {
-						m.dismissedStore.Remove(s.ID)
-					}
-					visible = append(visible, s)
-					continue
+				if seen && !strings.EqualFold(prevStatus, s.Status) {
+					m.annotations.Undismiss(s.ID)
 				}
 			}
-			continue
 		}
-		visible = append(visible, s)
 	}
 
-	m.recomputeAndDisplay(visible)
+	m.recomputeAndDisplay(m.annotateSessions(m.allSessions))
 }
 
 // enrichTokenUsage applies token usage data to accumulated sessions and re-displays.
@@ -353,17 +436,7 @@ func (m *Model) enrichTokenUsage(usage map[string]*data.TokenUsage) {
 	}
 
 	// Re-display with enriched data
-	dismissedIDs := map[string]struct{}{}
-	if m.dismissedStore != nil {
-		dismissedIDs = m.dismissedStore.IDs()
-	}
-	visible := make([]data.Session, 0, len(m.allSessions))
-	for _, s := range m.allSessions {
-		if _, dismissed := dismissedIDs[s.ID]; !dismissed {
-			visible = append(visible, s)
-		}
-	}
-	m.recomputeAndDisplay(visible)
+	m.recomputeAndDisplay(m.annotateSessions(m.allSessions))
 }
 
 // recomputeAndDisplay recomputes filter counts from visible sessions,
@@ -371,6 +444,8 @@ func (m *Model) enrichTokenUsage(usage map[string]*data.TokenUsage) {
 // and updates all display components. Skips component updates when the
 // session data has not changed (fingerprint match).
 func (m *Model) recomputeAndDisplay(visible []data.Session) {
+	visible = m.searchFilter.Filter(visible)
+
 	// Fast-path: skip when data and active filter haven't changed
 	fp := sessionFingerprint(visible) + "|" + m.ctx.StatusFilter + "|" + m.searchQuery
 	unchanged := m.lastFingerprint == fp && m.initialLoadDone
@@ -432,7 +507,7 @@ func (m *Model) recomputeAndDisplay(visible []data.Session) {
 	})
 
 	// On first render, pick the best default tab
-	if !m.initialLoadDone {
+	if !m.initialLoadDone && !m.statusPinned {
 		m.ctx.StatusFilter = smartDefaultFilter(counts)
 	}
 
@@ -457,21 +532,6 @@ func (m *Model) recomputeAndDisplay(visible []data.Session) {
 		}
 	}
 
-	// Apply search filter
-	if m.searchQuery != "" {
-		q := strings.ToLower(m.searchQuery)
-		searchFiltered := make([]data.Session, 0, len(filtered))
-		for _, session := range filtered {
-			if strings.Contains(strings.ToLower(session.Title), q) ||
-				strings.Contains(strings.ToLower(session.Repository), q) ||
-				strings.Contains(strings.ToLower(session.Branch), q) ||
-				strings.Contains(strings.ToLower(session.Status), q) {
-				searchFiltered = append(searchFiltered, session)
-			}
-		}
-		filtered = searchFiltered
-	}
-
 	// Update display components — only push data to the active view to avoid
 	// wasted work on invisible components. They'll receive fresh data when
 	// the user switches to them.
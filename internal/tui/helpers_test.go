@@ -2,9 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
 )
 
@@ -110,14 +112,14 @@ func TestMergeSessions_BelowCapUnchanged(t *testing.T) {
 }
 
 func TestMergeSessions_AutoUndismissOnStatusChange(t *testing.T) {
-	store := data.NewDismissedStoreFromPath(t.TempDir() + "/dismissed.json")
-	store.Add("transitioning-session")
-	store.Add("already-failed-session")
-	store.Add("normal-session")
+	store := data.NewAnnotationStoreFromPath(t.TempDir() + "/annotations.json")
+	store.Dismiss("transitioning-session")
+	store.Dismiss("already-failed-session")
+	store.Dismiss("normal-session")
 
 	m := &Model{
 		ctx:            NewProgramContext(),
-		dismissedStore: store,
+		annotations:    store,
 		prevSessions: map[string]string{
 			"transitioning-session": "running",      // was running, will become failed → should undismiss
 			"already-failed-session": "failed",       // was already failed when dismissed → stays dismissed
@@ -133,7 +135,7 @@ func TestMergeSessions_AutoUndismissOnStatusChange(t *testing.T) {
 	}
 	m.mergeSessions(sessions)
 
-	ids := store.IDs()
+	ids := store.DismissedIDs()
 	// Session that transitioned to failed should be un-dismissed
 	if _, ok := ids["transitioning-session"]; ok {
 		t.Error("expected transitioning-session to be auto-undismissed (status changed running→failed)")
@@ -155,3 +157,103 @@ func TestMergeSessions_AutoUndismissOnStatusChange(t *testing.T) {
 		t.Errorf("expected 1 attention count, got %d", m.ctx.Counts.Attention)
 	}
 }
+
+func TestSetSearchQuery_StructuredFilterNarrowsVisibleSessions(t *testing.T) {
+	m := &Model{ctx: NewProgramContext()}
+	m.allSessions = []data.Session{
+		{ID: "a", Status: "failed", Repository: "org/api"},
+		{ID: "b", Status: "running", Repository: "org/api"},
+		{ID: "c", Status: "failed", Repository: "org/web"},
+	}
+
+	m.setSearchQuery("repo:org/api status:failed")
+	if m.searchErr != nil {
+		t.Fatalf("unexpected parse error: %v", m.searchErr)
+	}
+	visible := m.visibleSessions()
+	if len(visible) != 1 || visible[0].ID != "a" {
+		t.Fatalf("expected only session a, got %+v", visible)
+	}
+
+	// An incomplete expression keeps the previous filter in effect.
+	m.setSearchQuery("repo:org/api status:failed age:<")
+	if m.searchErr == nil {
+		t.Fatal("expected parse error for incomplete age comparison")
+	}
+	if got := len(m.visibleSessions()); got != 1 {
+		t.Errorf("expected previous filter to stay active, got %d sessions", got)
+	}
+
+	m.setSearchQuery("")
+	if got := len(m.visibleSessions()); got != 3 {
+		t.Errorf("expected all sessions after clearing search, got %d", got)
+	}
+}
+
+func TestTasksLoaded_SearchNarrowsCountsButTracksEverySession(t *testing.T) {
+	m := annotationTestModel(t)
+	m.setSearchQuery("repo:org/api")
+	now := time.Now()
+	sessions := []data.Session{
+		{ID: "a", Title: "Fix login", Status: "running", Repository: "org/api", UpdatedAt: now},
+		{ID: "c", Title: "Web build", Status: "failed", Repository: "org/web", UpdatedAt: now},
+	}
+	next, _ := m.Update(tasksLoadedMsg{sessions: sessions})
+	m = next.(Model)
+	if m.ctx.Counts.All != 1 || m.ctx.Counts.Failed != 0 {
+		t.Errorf("expected counts for the searched sessions only, got %+v", m.ctx.Counts)
+	}
+	if _, ok := m.prevSessions["c"]; !ok {
+		t.Fatal("expected a session hidden by the search still tracked")
+	}
+
+	sessions[1].Status = "completed"
+	next, _ = m.Update(tasksLoadedMsg{sessions: sessions})
+	m = next.(Model)
+	if view := ansi.Strip(m.toast.View()); !strings.Contains(view, "failed → completed") {
+		t.Errorf("expected a hidden session's status change toasted, got %q", view)
+	}
+}
+
+func TestSetSearchQuery_SavedFilterFromConfig(t *testing.T) {
+	m := &Model{ctx: NewProgramContext()}
+	m.ctx.Config.Filters = map[string]string{"web": "repo:org/web"}
+	m.allSessions = []data.Session{
+		{ID: "a", Repository: "org/api"},
+		{ID: "b", Repository: "org/web"},
+	}
+
+	m.setSearchQuery("@web")
+	visible := m.visibleSessions()
+	if len(visible) != 1 || visible[0].ID != "b" {
+		t.Fatalf("expected saved filter to match session b, got %+v", visible)
+	}
+}
+
+func TestCycleSearchCompletion(t *testing.T) {
+	m := &Model{ctx: NewProgramContext()}
+	m.allSessions = []data.Session{
+		{ID: "a", Repository: "org/api"},
+		{ID: "b", Repository: "org/web"},
+	}
+
+	m.setSearchQuery("rep")
+	m.cycleSearchCompletion()
+	if m.searchQuery != "repo:" {
+		t.Fatalf("expected field completion to repo:, got %q", m.searchQuery)
+	}
+
+	m.cycleSearchCompletion()
+	if m.searchQuery != "repo:org/api" {
+		t.Fatalf("expected first value completion, got %q", m.searchQuery)
+	}
+	m.cycleSearchCompletion()
+	if m.searchQuery != "repo:org/web" {
+		t.Fatalf("expected tab to cycle to next value, got %q", m.searchQuery)
+	}
+}
+
+// pickerOpen reports whether the chooser is open with the given title.
+func (m Model) pickerOpen(title string) bool {
+	return m.chooser.Visible() && m.chooser.Title() == title
+}
@@ -1,9 +1,9 @@
 package tui
 
 import (
-	"fmt"
-	"time"
+	"unicode/utf8"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/mission"
@@ -16,12 +16,33 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
-		if msg.String() == "?" || msg.Code == tea.KeyEscape {
+		if key.Matches(msg, m.keys.ShowHelp) || msg.Code == tea.KeyEscape {
 			m.help.Toggle()
 		}
 		return m, nil
 	}
 
+	if m.palette.Visible() {
+		return m.handlePaletteKeys(msg)
+	}
+
+	if m.chooser.Visible() {
+		return m.handleChooserKeys(msg)
+	}
+
+	if m.annotationPrompt.Visible() {
+		return m.handleAnnotationPromptKeys(msg)
+	}
+
+	if m.reviewPrompt.Visible() {
+		return m.handleReviewPromptKeys(msg)
+	}
+
+	// Log search bar: capture the query, searching as it is typed
+	if m.logSearchActive {
+		return m.handleLogSearchBarKeys(msg)
+	}
+
 	// Search mode: capture text input for filtering
 	if m.searchActive {
 		// ctrl+c always quits, even in search mode
@@ -31,22 +52,27 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		switch msg.Code {
 		case tea.KeyEscape:
 			m.searchActive = false
-			m.searchQuery = ""
+			m.setSearchQuery("")
 			m.recomputeAndDisplay(m.visibleSessions())
 			return m, nil
 		case tea.KeyEnter:
 			m.searchActive = false
 			// Keep the filter active, just stop capturing input
 			return m, nil
+		case tea.KeyTab:
+			m.cycleSearchCompletion()
+			m.recomputeAndDisplay(m.visibleSessions())
+			return m, nil
 		case tea.KeyBackspace:
 			if len(m.searchQuery) > 0 {
-				m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
+				_, size := utf8.DecodeLastRuneInString(m.searchQuery)
+				m.setSearchQuery(m.searchQuery[:len(m.searchQuery)-size])
 				m.recomputeAndDisplay(m.visibleSessions())
 			}
 			return m, nil
 		default:
 			if len(msg.Text) > 0 {
-				m.searchQuery += msg.Text
+				m.setSearchQuery(m.searchQuery + msg.Text)
 				m.recomputeAndDisplay(m.visibleSessions())
 				return m, nil
 			}
@@ -54,38 +80,84 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// ? toggles help overlay in any mode
-	if msg.String() == "?" {
+	// Help toggles the overlay in any mode
+	if key.Matches(msg, m.keys.ShowHelp) {
 		m.help.Toggle()
 		return m, nil
 	}
 
-	// Global quit key
-	if msg.String() == "q" || msg.String() == "ctrl+c" {
+	// Global quit key; ctrl+c quits even if remapped away
+	if key.Matches(msg, m.keys.ExitApp) || msg.String() == "ctrl+c" {
 		return m, tea.Quit
 	}
 
+	// Command palette (any view)
+	if key.Matches(msg, m.keys.CommandPalette) {
+		m.openPalette()
+		return m, nil
+	}
+
 	// Debug snapshot (any view)
-	if msg.String() == "S" {
-		ts := time.Now().UTC().Format("2006-01-02T150405Z")
-		path := fmt.Sprintf("/tmp/gh-agent-viz-snapshot-%s.json", ts)
-		origPath := m.snapshotPath
-		m.snapshotPath = path
-		m.writeSnapshot()
-		m.snapshotPath = origPath
-		m.toast.Push("📸", "Snapshot", path)
+	if key.Matches(msg, m.keys.Snapshot) {
+		m.saveSnapshot()
 		return m, nil
 	}
 
-	// / activates search in navigable views
-	if msg.String() == "/" {
+	// Theme picker (any view)
+	if key.Matches(msg, m.keys.SwitchTheme) {
+		m.openThemePicker()
+		return m, nil
+	}
+
+	// Open the saved-view picker in navigable views
+	if key.Matches(msg, m.keys.SwitchView) {
+		if m.viewMode == ViewModeList || m.viewMode == ViewModeMission || m.viewMode == ViewModeActive {
+			m.openViewPicker()
+			return m, nil
+		}
+	}
+
+	// Activate search in navigable views
+	if key.Matches(msg, m.keys.SearchFilter) {
 		if m.viewMode == ViewModeList || m.viewMode == ViewModeMission || m.viewMode == ViewModeActive {
+			// Keep any existing query so structured filters can be refined
+			// rather than retyped.
 			m.searchActive = true
-			m.searchQuery = ""
+			m.setSearchQuery(m.searchQuery)
 			return m, nil
 		}
 	}
 
+	// Snooze, pin, note and tags act on the selected session
+	if cmd, handled := m.handleAnnotationKeys(msg); handled {
+		return m, cmd
+	}
+
+	// Stopping a hung process asks for confirmation first
+	if cmd, handled := m.handleProcessKeys(msg); handled {
+		return m, cmd
+	}
+
+	// Merging, readying or closing a PR asks for confirmation first
+	if m.handlePRKeys(msg) {
+		return m, nil
+	}
+
+	// Checking out the session's branch hands the terminal over
+	if cmd, handled := m.handleWorktreeKeys(msg); handled {
+		return m, cmd
+	}
+
+	// So does opening the editor or a shell in the session's checkout
+	if cmd, handled := m.handleWorkDirKeys(msg); handled {
+		return m, cmd
+	}
+
+	// Copying picks what to copy, or takes the view's selection
+	if cmd, handled := m.handleCopyKeys(msg); handled {
+		return m, cmd
+	}
+
 	switch m.viewMode {
 	case ViewModeList:
 		return m.handleListKeys(msg)
@@ -110,238 +182,174 @@ func (m Model) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 // handleListKeys handles keys in list view mode
 func (m Model) handleListKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
-	case "j", "down":
+	switch {
+	case key.Matches(msg, m.keys.NavigateBack):
+		m.showMission()
+	case key.Matches(msg, m.keys.MoveDown):
 		m.taskList.MoveCursor(1)
-	case "k", "up":
+	case key.Matches(msg, m.keys.MoveUp):
 		m.taskList.MoveCursor(-1)
-	case "enter":
+	case key.Matches(msg, m.keys.SelectTask):
 		// If cursor is on a collapsed group header, expand it instead of opening detail
 		if m.taskList.IsCursorOnCollapsedGroup() {
 			m.taskList.ToggleGroupExpand()
 			return m, nil
 		}
-		session := m.taskList.SelectedTask()
-		if session != nil {
-			if session.Source == data.SourceLocalCopilot {
-				m.ctx.Error = nil
-				m.viewMode = ViewModeDetail
-				m.taskDetail.SetTask(session)
-				return m, nil
-			}
-			m.viewMode = ViewModeDetail
-			return m, m.fetchTaskDetail(session.ID, session.Repository)
-		}
-	case "l":
-		session := m.taskList.SelectedTask()
-		if session != nil {
-			m.viewMode = ViewModeLog
-			if isSessionRunning(session) {
-				m.logView.SetLive(true)
-				m.logView.SetFollowMode(true)
-				return m, tea.Batch(m.fetchTaskLog(session.ID, session.Repository), m.logPollTick())
-			}
-			return m, m.fetchTaskLog(session.ID, session.Repository)
-		}
-	case "c":
-		session := m.taskList.SelectedTask()
-		if session != nil && session.Source == data.SourceLocalCopilot && session.HasLog {
-			m.viewMode = ViewModeLog
-			m.showConversation = true
-			return m, m.fetchConversation(session.ID)
-		} else if session != nil {
-			m.toast.Push("ℹ️", "Conversation", "only available for local Copilot sessions")
-		}
-	case "o":
+		return m, m.openDetail(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.ShowLogs):
+		return m, m.openLogs(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.ShowConversation):
+		return m, m.openConversation(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.OpenInBrowser):
 		session := m.taskList.SelectedTask()
 		if session != nil {
 			return m, m.openTaskPR(session)
 		}
-	case "s":
+	case key.Matches(msg, m.keys.ResumeSession):
 		session := m.taskList.SelectedTask()
 		if session != nil {
 			return m, m.resumeSession(session)
 		}
-	case "x":
-		m.taskList.DismissSelected()
-		m.lastFingerprint = ""
-		m.lastSplitTaskID = ""
-		m.recomputeAndDisplay(m.visibleSessions())
-	case "X":
-		count := m.taskList.DismissCompleted()
-		if count > 0 {
-			m.lastFingerprint = ""
-			m.recomputeAndDisplay(m.visibleSessions())
-			m.toast.Push("🧹", "Dismissed", fmt.Sprintf("%d completed session(s) cleared", count))
-		} else {
-			m.toast.Push("ℹ️", "Nothing to dismiss", "no completed sessions found")
-		}
-	case "r":
+	case key.Matches(msg, m.keys.DismissSession):
+		m.dismissSession(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.MassDismiss):
+		m.dismissCompleted()
+	case key.Matches(msg, m.keys.RefreshData):
 		return m, m.fetchTasks
-	case "p":
+	case key.Matches(msg, m.keys.TogglePreview):
 		m.showPreview = !m.showPreview
 		m.updateSplitLayout()
 		return m, nil
-	case "a":
+	case key.Matches(msg, m.keys.FocusAttention):
 		m.ctx.StatusFilter = "attention"
 		m.showPreview = false
 		m.taskList.SetLoading(true)
 		return m, m.fetchTasks
-	case "tab":
+	case key.Matches(msg, m.keys.ToggleFilter):
 		m.cycleFilter(1)
 		m.taskList.SetLoading(true)
 		return m, m.fetchTasks
-	case "shift+tab", "backtab":
+	case key.Matches(msg, m.keys.ToggleFilterBack):
 		m.cycleFilter(-1)
 		m.taskList.SetLoading(true)
 		return m, m.fetchTasks
-	case "g":
+	case key.Matches(msg, m.keys.GroupBy):
 		m.taskList.CycleGroupBy()
 		return m, nil
-	case "space":
+	case key.Matches(msg, m.keys.ExpandGroup):
 		if m.taskList.IsGrouped() {
 			m.taskList.ToggleGroupExpand()
 			return m, nil
 		}
-	case "M":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+	case key.Matches(msg, m.keys.ToggleMission):
+		m.showMission()
 		return m, nil
-	case "A":
-		m.viewMode = ViewModeActive
-		m.activeView.SetSessions(m.visibleSessions())
-		m.activeView.SetSize(m.ctx.Width, m.ctx.Height-6)
+	case key.Matches(msg, m.keys.ToggleActive):
+		m.showActive()
 		return m, nil
-	case "!":
-		return m, m.openSessionRepo()
-	case "@":
+	case key.Matches(msg, m.keys.OpenRepo):
+		return m, m.openSessionRepo(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.FileIssue):
 		return m, m.openFileIssue()
-	case "d":
-		session := m.taskList.SelectedTask()
-		if session != nil && canShowDiff(session) {
-			m.diffView.SetLoading()
-			m.viewMode = ViewModeDiff
-			return m, m.fetchPRDiff(session)
-		} else if session != nil {
-			m.toast.Push("⚠️", session.Title, "no PR — session is on "+session.Branch)
-		}
-	case "t":
-		session := m.taskList.SelectedTask()
-		if session != nil && session.Source == data.SourceLocalCopilot && session.HasLog {
-			m.viewMode = ViewModeToolTimeline
-			m.toolTimeline.SetSize(m.ctx.Width-4, m.ctx.Height-8)
-			return m, m.fetchToolTimeline(session.ID)
-		} else if session != nil {
-			m.toast.Push("ℹ️", "Tool Timeline", "only available for local Copilot sessions")
-		}
-	case "G":
-		session := m.taskList.SelectedTask()
-		if session != nil && session.Source == data.SourceLocalCopilot && session.WorkDir != "" {
-			m.gitActivity.SetLoading(true)
-			m.gitActivity.SetSize(m.ctx.Width-4, m.ctx.Height-8)
-			m.viewMode = ViewModeGitActivity
-			return m, tea.Batch(m.fetchGitDiff(session.WorkDir), m.gitDiffPollTick())
-		} else if session != nil {
-			m.toast.Push("ℹ️", "Git Activity", "only available for local sessions with a working directory")
-		}
+	case key.Matches(msg, m.keys.ShowDiff):
+		return m, m.openDiff(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.ShowToolTimeline):
+		return m, m.openToolTimeline(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.ShowGitActivity):
+		return m, m.openGitActivity(m.taskList.SelectedTask())
+	case isDigitKey(msg):
+		m.switchToViewIndex(int(msg.String()[0] - '0'))
 	}
 	return m, nil
 }
 
+// isDigitKey reports whether msg is one of the number keys 1-9, which
+// select saved views by position.
+func isDigitKey(msg tea.KeyPressMsg) bool {
+	k := msg.String()
+	return len(k) == 1 && k[0] >= '1' && k[0] <= '9'
+}
+
 // handleDetailKeys handles keys in detail view mode
 func (m Model) handleDetailKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
-	case "l":
-		session := m.taskList.SelectedTask()
-		if session != nil {
-			m.viewMode = ViewModeLog
-			if isSessionRunning(session) {
-				m.logView.SetLive(true)
-				m.logView.SetFollowMode(true)
-				return m, tea.Batch(m.fetchTaskLog(session.ID, session.Repository), m.logPollTick())
-			}
-			return m, m.fetchTaskLog(session.ID, session.Repository)
-		}
-	case "c":
-		session := m.taskList.SelectedTask()
-		if session != nil && session.Source == data.SourceLocalCopilot && session.HasLog {
-			m.viewMode = ViewModeLog
-			m.showConversation = true
-			return m, m.fetchConversation(session.ID)
-		} else if session != nil {
-			m.toast.Push("ℹ️", "Conversation", "only available for local Copilot sessions")
-		}
-	case "o":
+	switch {
+	case key.Matches(msg, m.keys.NavigateBack):
+		m.showMission()
+	case key.Matches(msg, m.keys.ShowLogs):
+		return m, m.openLogs(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.ShowConversation):
+		return m, m.openConversation(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.OpenInBrowser):
 		session := m.taskList.SelectedTask()
 		if session != nil {
 			return m, m.openTaskPR(session)
 		}
-	case "s":
+	case key.Matches(msg, m.keys.ResumeSession):
 		session := m.taskList.SelectedTask()
 		if session != nil {
 			return m, m.resumeSession(session)
 		}
-	case "t":
-		session := m.taskList.SelectedTask()
-		if session != nil && session.Source == data.SourceLocalCopilot && session.HasLog {
-			m.viewMode = ViewModeToolTimeline
-			m.toolTimeline.SetSize(m.ctx.Width-4, m.ctx.Height-8)
-			return m, m.fetchToolTimeline(session.ID)
-		} else if session != nil {
-			m.toast.Push("ℹ️", "Tool Timeline", "only available for local Copilot sessions")
-		}
-	case "d":
-		session := m.taskList.SelectedTask()
-		if session != nil && canShowDiff(session) {
-			m.diffView.SetLoading()
-			m.viewMode = ViewModeDiff
-			return m, m.fetchPRDiff(session)
-		}
-	case "G":
-		session := m.taskList.SelectedTask()
-		if session != nil && session.Source == data.SourceLocalCopilot && session.WorkDir != "" {
-			m.gitActivity.SetLoading(true)
-			m.gitActivity.SetSize(m.ctx.Width-4, m.ctx.Height-8)
-			m.viewMode = ViewModeGitActivity
-			return m, tea.Batch(m.fetchGitDiff(session.WorkDir), m.gitDiffPollTick())
-		} else if session != nil {
-			m.toast.Push("ℹ️", "Git Activity", "only available for local sessions with a working directory")
-		}
-	case "x":
-		session := m.taskDetail.Session()
-		if session != nil && session.ID != "" && m.dismissedStore != nil {
-			m.dismissedStore.Add(session.ID)
-			m.taskList.DismissByID(session.ID)
-		}
-		m.lastFingerprint = "" // force recompute
-		m.lastSplitTaskID = ""
-		m.viewMode = ViewModeMission
-		m.recomputeAndDisplay(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+	case key.Matches(msg, m.keys.ShowToolTimeline):
+		return m, m.openToolTimeline(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.ShowDiff):
+		return m, m.openDiff(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.ShowGitActivity):
+		return m, m.openGitActivity(m.taskList.SelectedTask())
+	case key.Matches(msg, m.keys.DismissSession):
+		m.dismissSession(m.taskDetail.Session())
+		m.showMission()
 	}
 	return m, nil
 }
 
 // handleDiffKeys handles keys in diff view mode
 func (m Model) handleDiffKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+	if cmd, ok := m.handleReviewKeys(msg); ok {
+		return m, cmd
+	}
+	switch {
+	case key.Matches(msg, m.keys.NavigateBack):
+		m.showMission()
+		return m, nil
+	case key.Matches(msg, m.keys.MoveDown):
+		m.diffView.MoveCursor(1)
+		return m, nil
+	case key.Matches(msg, m.keys.MoveUp):
+		m.diffView.MoveCursor(-1)
+		return m, nil
+	case key.Matches(msg, m.keys.PageDown):
+		m.diffView.MoveCursor(m.diffView.PageSize())
+		return m, nil
+	case key.Matches(msg, m.keys.PageUp):
+		m.diffView.MoveCursor(-m.diffView.PageSize())
+		return m, nil
+	case key.Matches(msg, m.keys.GotoTop):
+		m.diffView.GotoTop()
+		return m, nil
+	case key.Matches(msg, m.keys.GotoBottom):
+		m.diffView.GotoBottom()
+		return m, nil
+	case key.Matches(msg, m.keys.NextHunk):
+		m.diffView.NextHunk()
+		return m, nil
+	case key.Matches(msg, m.keys.PrevHunk):
+		m.diffView.PrevHunk()
+		return m, nil
+	case key.Matches(msg, m.keys.NextFile):
+		m.diffView.NextFile()
+		return m, nil
+	case key.Matches(msg, m.keys.PrevFile):
+		m.diffView.PrevFile()
+		return m, nil
+	case key.Matches(msg, m.keys.ToggleSplit):
+		m.diffView.ToggleSplit()
+		return m, nil
+	case key.Matches(msg, m.keys.FoldFile):
+		m.diffView.ToggleFold()
 		return m, nil
 	}
 
-	// Delegate to viewport for scrolling
+	// Delegate to viewport for mouse scrolling
 	var cmd tea.Cmd
 	m.diffView, cmd = m.diffView.Update(msg)
 	return m, cmd
@@ -349,20 +357,44 @@ func (m Model) handleDiffKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 // handleGitActivityKeys handles keys in git activity view mode
 func (m Model) handleGitActivityKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+	switch {
+	case key.Matches(msg, m.keys.NavigateBack):
+		if m.gitActivity.CloseCommit() {
+			return m, nil
+		}
+		m.showMission()
 		return m, nil
-	case "r":
+	case key.Matches(msg, m.keys.RefreshData):
 		// Manual refresh
-		session := m.taskList.SelectedTask()
-		if session != nil && session.WorkDir != "" {
+		if m.gitWorkDir != "" {
 			m.gitActivity.SetLoading(true)
-			return m, m.fetchGitDiff(session.WorkDir)
+			return m, m.fetchGitDiff(m.gitWorkDir)
 		}
 		return m, nil
+	case key.Matches(msg, m.keys.NextPanel):
+		m.gitActivity.NextSection()
+		return m, nil
+	case key.Matches(msg, m.keys.PrevPanel):
+		m.gitActivity.PrevSection()
+		return m, nil
+	}
+
+	// The commit list takes the cursor keys; enter opens a commit's diff
+	if m.gitActivity.BrowsingCommits() {
+		switch {
+		case key.Matches(msg, m.keys.MoveDown):
+			m.gitActivity.MoveCursor(1)
+			return m, nil
+		case key.Matches(msg, m.keys.MoveUp):
+			m.gitActivity.MoveCursor(-1)
+			return m, nil
+		case key.Matches(msg, m.keys.SelectTask):
+			if c, ok := m.gitActivity.SelectedCommit(); ok && m.gitWorkDir != "" {
+				m.gitActivity.OpenCommit(c)
+				return m, m.fetchCommitDiff(m.gitWorkDir, c.Hash)
+			}
+			return m, nil
+		}
 	}
 
 	// Delegate to viewport for scrolling
@@ -372,16 +404,20 @@ func (m Model) handleGitActivityKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleLogKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+	if cmd, handled := m.handleSelectionKeys(msg); handled {
+		return m, cmd
+	}
+	if m.handleLogSearchKeys(msg) {
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, m.keys.NavigateBack):
+		m.showMission()
 		m.logView.SetLive(false)
 		m.logView.SetFollowMode(false)
 		m.showConversation = false
-	case "c":
-		session := m.taskList.SelectedTask()
+	case key.Matches(msg, m.keys.ShowConversation):
+		session := m.findSession(m.logSessionID)
 		if session != nil && session.Source == data.SourceLocalCopilot {
 			if m.showConversation {
 				m.showConversation = false
@@ -389,57 +425,58 @@ func (m Model) handleLogKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 				return m, m.fetchConversation(session.ID)
 			}
 		}
-	case "j", "down":
+	case key.Matches(msg, m.keys.MoveDown):
 		if m.showConversation {
 			m.conversationView.LineDown()
 		} else {
 			m.logView.SetFollowMode(false)
 			m.logView.LineDown()
 		}
-	case "k", "up":
+	case key.Matches(msg, m.keys.MoveUp):
 		if m.showConversation {
 			m.conversationView.LineUp()
 		} else {
 			m.logView.SetFollowMode(false)
 			m.logView.LineUp()
 		}
-	case "d":
+	case key.Matches(msg, m.keys.PageDown):
 		if m.showConversation {
 			m.conversationView.HalfPageDown()
 		} else {
 			m.logView.SetFollowMode(false)
 			m.logView.HalfPageDown()
 		}
-	case "u":
+	case key.Matches(msg, m.keys.PageUp):
 		if m.showConversation {
 			m.conversationView.HalfPageUp()
 		} else {
 			m.logView.SetFollowMode(false)
 			m.logView.HalfPageUp()
 		}
-	case "g":
+	case key.Matches(msg, m.keys.GotoTop):
 		if m.showConversation {
 			m.conversationView.GotoTop()
 		} else {
 			m.logView.SetFollowMode(false)
 			m.logView.GotoTop()
 		}
-	case "G":
+	case key.Matches(msg, m.keys.GotoBottom):
 		if m.showConversation {
 			m.conversationView.GotoBottom()
 		} else {
-			m.logView.SetFollowMode(true)
+			m.logView.SetFollowMode(!m.logView.Selecting())
 			m.logView.GotoBottom()
 		}
-	case "f":
+	case key.Matches(msg, m.keys.ToggleFollow):
 		if !m.showConversation {
 			m.logView.SetFollowMode(!m.logView.FollowMode())
 			if m.logView.FollowMode() {
+				m.logView.ClearSelection()
 				m.logView.GotoBottom()
 			}
 		}
-	case "s":
-		session := m.taskList.SelectedTask()
+	case key.Matches(msg, m.keys.ResumeSession):
+		session := m.findSession(m.logSessionID)
 		if session != nil {
 			return m, m.resumeSession(session)
 		}
@@ -449,16 +486,16 @@ func (m Model) handleLogKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 // handleToolTimelineKeys handles keys in tool timeline view mode
 func (m Model) handleToolTimelineKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
+	switch {
+	case key.Matches(msg, m.keys.NavigateBack):
 		m.viewMode = ViewModeDetail
-	case "j", "down":
+	case key.Matches(msg, m.keys.MoveDown):
 		m.toolTimeline.LineDown()
-	case "k", "up":
+	case key.Matches(msg, m.keys.MoveUp):
 		m.toolTimeline.LineUp()
-	case "d":
+	case key.Matches(msg, m.keys.PageDown):
 		m.toolTimeline.HalfPageDown()
-	case "u":
+	case key.Matches(msg, m.keys.PageUp):
 		m.toolTimeline.HalfPageUp()
 	}
 	return m, nil
@@ -466,40 +503,30 @@ func (m Model) handleToolTimelineKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd)
 
 // handleMissionKeys handles keys in mission control view mode
 func (m Model) handleMissionKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "j", "down":
+	switch {
+	case key.Matches(msg, m.keys.MoveDown):
 		m.mission.MoveCursor(1)
-	case "k", "up":
+	case key.Matches(msg, m.keys.MoveUp):
 		m.mission.MoveCursor(-1)
-	case "tab":
+	case key.Matches(msg, m.keys.NextPanel):
 		m.mission.CyclePanel(1)
-	case "shift+tab", "backtab":
+	case key.Matches(msg, m.keys.PrevPanel):
 		m.mission.CyclePanel(-1)
-	case "1":
+	case msg.String() == "1":
 		m.mission.SetFocus(mission.PanelActive)
-	case "2":
+	case msg.String() == "2":
 		m.mission.SetFocus(mission.PanelRecent)
-	case "3":
+	case msg.String() == "3":
 		m.mission.SetFocus(mission.PanelAttention)
-	case "4":
+	case msg.String() == "4":
 		m.mission.SetFocus(mission.PanelRepos)
-	case "5":
+	case msg.String() == "5":
 		m.mission.SetFocus(mission.PanelIdle)
-	case "enter":
+	case key.Matches(msg, m.keys.SelectTask):
 		// Drill into selected item based on focused panel
 		switch m.mission.Focus() {
 		case mission.PanelActive, mission.PanelAttention, mission.PanelRecent, mission.PanelIdle:
-			session := m.mission.SelectedSession()
-			if session != nil {
-				if session.Source == data.SourceLocalCopilot {
-					m.ctx.Error = nil
-					m.viewMode = ViewModeDetail
-					m.taskDetail.SetTask(session)
-					return m, nil
-				}
-				m.viewMode = ViewModeDetail
-				return m, m.fetchTaskDetail(session.ID, session.Repository)
-			}
+			return m, m.openDetail(m.mission.SelectedSession())
 		case mission.PanelRepos:
 			// Filter list view to show only this repo's sessions
 			repo := m.mission.SelectedRepo()
@@ -516,11 +543,9 @@ func (m Model) handleMissionKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 				m.taskList.SetTasks(filtered)
 			}
 		}
-	case "A":
-		m.viewMode = ViewModeActive
-		m.activeView.SetSessions(m.visibleSessions())
-		m.activeView.SetSize(m.ctx.Width, m.ctx.Height-6)
-	case "r":
+	case key.Matches(msg, m.keys.ToggleActive):
+		m.showActive()
+	case key.Matches(msg, m.keys.RefreshData):
 		return m, m.fetchTasks
 	}
 	return m, nil
@@ -528,58 +553,37 @@ func (m Model) handleMissionKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 // handleActiveKeys handles keys in active sessions view mode
 func (m Model) handleActiveKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "A":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
-	case "j", "down":
+	switch {
+	case key.Matches(msg, m.keys.NavigateBack, m.keys.ToggleActive):
+		m.showMission()
+	case key.Matches(msg, m.keys.MoveDown):
 		m.activeView.MoveCursor(1)
-	case "k", "up":
+	case key.Matches(msg, m.keys.MoveUp):
 		m.activeView.MoveCursor(-1)
-	case "enter":
-		session := m.activeView.SelectedSession()
-		if session != nil {
-			if session.Source == data.SourceLocalCopilot {
-				m.ctx.Error = nil
-				m.viewMode = ViewModeDetail
-				m.taskDetail.SetTask(session)
-				return m, nil
-			}
-			m.viewMode = ViewModeDetail
-			return m, m.fetchTaskDetail(session.ID, session.Repository)
-		}
-	case "o":
+	case key.Matches(msg, m.keys.SelectTask):
+		return m, m.openDetail(m.activeView.SelectedSession())
+	case key.Matches(msg, m.keys.OpenInBrowser):
 		session := m.activeView.SelectedSession()
 		if session != nil {
 			return m, m.openTaskPR(session)
 		}
-	case "l":
-		session := m.activeView.SelectedSession()
-		if session != nil {
-			m.viewMode = ViewModeLog
-			if isSessionRunning(session) {
-				m.logView.SetLive(true)
-				m.logView.SetFollowMode(true)
-				return m, tea.Batch(m.fetchTaskLog(session.ID, session.Repository), m.logPollTick())
-			}
-			return m, m.fetchTaskLog(session.ID, session.Repository)
-		}
-	case "c":
+	case key.Matches(msg, m.keys.ShowLogs):
+		return m, m.openLogs(m.activeView.SelectedSession())
+	case key.Matches(msg, m.keys.CopyID):
 		session := m.activeView.SelectedSession()
 		if session != nil {
-			return m, m.copyToClipboard(session.ID)
+			return m, m.copyToClipboard(copyID, session.ID)
 		}
-	case "x":
+	case key.Matches(msg, m.keys.DismissSession):
 		m.activeView.DismissSelected()
 		m.lastFingerprint = ""
 		m.recomputeAndDisplay(m.visibleSessions())
-	case "r":
+	case key.Matches(msg, m.keys.RefreshData):
 		return m, m.fetchTasks
-	case "M":
-		m.viewMode = ViewModeMission
-		m.mission.SetSessions(m.visibleSessions())
-		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+	case key.Matches(msg, m.keys.ToggleMission):
+		m.showMission()
+	case isDigitKey(msg):
+		m.switchToViewIndex(int(msg.String()[0] - '0'))
 	}
 	return m, nil
 }
@@ -1,34 +1,82 @@
 package tui
 
-import "charm.land/bubbles/v2/key"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/help"
+)
 
 // Keybindings holds all key bindings for the application
 type Keybindings struct {
-	MoveUp         key.Binding
-	MoveDown       key.Binding
-	SelectTask     key.Binding
-	ShowLogs       key.Binding
-	OpenInBrowser  key.Binding
-	ResumeSession  key.Binding
-	DismissSession key.Binding
-	RefreshData    key.Binding
-	FocusAttention key.Binding
-	ExitApp        key.Binding
-	ToggleFilter   key.Binding
-	NavigateBack   key.Binding
-	TogglePreview  key.Binding
-	GroupBy        key.Binding
-	ExpandGroup    key.Binding
-	ToggleFollow   key.Binding
-	ToggleMission  key.Binding
-	ShowDiff       key.Binding
-	ShowHelp       key.Binding
-	OpenRepo       key.Binding
-	FileIssue      key.Binding
-	MassDismiss    key.Binding
-	SearchFilter   key.Binding
-	ShowGitActivity key.Binding
-	ToggleActive    key.Binding
+	MoveUp           key.Binding
+	MoveDown         key.Binding
+	SelectTask       key.Binding
+	ShowLogs         key.Binding
+	ShowConversation key.Binding
+	ShowToolTimeline key.Binding
+	OpenInBrowser    key.Binding
+	ResumeSession    key.Binding
+	DismissSession   key.Binding
+	CopyID           key.Binding
+	RefreshData      key.Binding
+	FocusAttention   key.Binding
+	ExitApp          key.Binding
+	ToggleFilter     key.Binding
+	ToggleFilterBack key.Binding
+	NextPanel        key.Binding
+	PrevPanel        key.Binding
+	NavigateBack     key.Binding
+	TogglePreview    key.Binding
+	GroupBy          key.Binding
+	ExpandGroup      key.Binding
+	ToggleFollow     key.Binding
+	PageDown         key.Binding
+	PageUp           key.Binding
+	GotoTop          key.Binding
+	GotoBottom       key.Binding
+	ToggleMission    key.Binding
+	ShowDiff         key.Binding
+	ShowHelp         key.Binding
+	OpenRepo         key.Binding
+	FileIssue        key.Binding
+	MassDismiss      key.Binding
+	SearchFilter     key.Binding
+	ShowGitActivity  key.Binding
+	ToggleActive     key.Binding
+	SwitchView       key.Binding
+	Snapshot         key.Binding
+	CommandPalette   key.Binding
+	SwitchTheme      key.Binding
+	SnoozeSession    key.Binding
+	PinSession       key.Binding
+	EditNote         key.Binding
+	EditTags         key.Binding
+	StopProcess      key.Binding
+	PRActions        key.Binding
+	CheckoutWorktree key.Binding
+	OpenEditor       key.Binding
+	OpenShell        key.Binding
+	Copy             key.Binding
+	VisualSelect     key.Binding
+	SaveSelection    key.Binding
+	NextMatch        key.Binding
+	PrevMatch        key.Binding
+	FilterMatches    key.Binding
+	ToggleRaw        key.Binding
+	NextHunk         key.Binding
+	PrevHunk         key.Binding
+	NextFile         key.Binding
+	PrevFile         key.Binding
+	ToggleSplit      key.Binding
+	FoldFile         key.Binding
+	CommentLine      key.Binding
+	DiscardComment   key.Binding
+	SubmitReview     key.Binding
+	AskCopilot       key.Binding
 }
 
 // NewKeybindings creates the default key bindings for the TUI
@@ -50,6 +98,14 @@ func NewKeybindings() Keybindings {
 			key.WithKeys("l"),
 			key.WithHelp("l", "logs"),
 		),
+		ShowConversation: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "convo"),
+		),
+		ShowToolTimeline: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "tools"),
+		),
 		OpenInBrowser: key.NewBinding(
 			key.WithKeys("o"),
 			key.WithHelp("o", "open PR"),
@@ -62,6 +118,10 @@ func NewKeybindings() Keybindings {
 			key.WithKeys("x"),
 			key.WithHelp("x", "dismiss"),
 		),
+		CopyID: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy ID"),
+		),
 		RefreshData: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -75,9 +135,21 @@ func NewKeybindings() Keybindings {
 			key.WithHelp("q", "exit"),
 		),
 		ToggleFilter: key.NewBinding(
-			key.WithKeys("tab", "shift+tab"),
+			key.WithKeys("tab"),
 			key.WithHelp("tab", "filter"),
 		),
+		ToggleFilterBack: key.NewBinding(
+			key.WithKeys("shift+tab", "backtab"),
+			key.WithHelp("shift+tab", "prev filter"),
+		),
+		NextPanel: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next panel"),
+		),
+		PrevPanel: key.NewBinding(
+			key.WithKeys("shift+tab", "backtab"),
+			key.WithHelp("shift+tab", "prev panel"),
+		),
 		NavigateBack: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "back"),
@@ -91,13 +163,29 @@ func NewKeybindings() Keybindings {
 			key.WithHelp("g", "group"),
 		),
 		ExpandGroup: key.NewBinding(
-			key.WithKeys(" "),
-			key.WithHelp("space", "expand/collapse"),
+			key.WithKeys("space"),
+			key.WithHelp("⎵", "expand/collapse"),
 		),
 		ToggleFollow: key.NewBinding(
 			key.WithKeys("f"),
 			key.WithHelp("f", "follow"),
 		),
+		PageDown: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "page down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "page up"),
+		),
+		GotoTop: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "top"),
+		),
+		GotoBottom: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("G", "bottom"),
+		),
 		ToggleMission: key.NewBinding(
 			key.WithKeys("M"),
 			key.WithHelp("M", "mission"),
@@ -134,5 +222,497 @@ func NewKeybindings() Keybindings {
 			key.WithKeys("A"),
 			key.WithHelp("A", "active view"),
 		),
+		SwitchView: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "views"),
+		),
+		Snapshot: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "snapshot"),
+		),
+		CommandPalette: key.NewBinding(
+			key.WithKeys("ctrl+p", ":"),
+			key.WithHelp("ctrl+p/:", "commands"),
+		),
+		SwitchTheme: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "themes"),
+		),
+		SnoozeSession: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "snooze"),
+		),
+		PinSession: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "pin"),
+		),
+		EditNote: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "note"),
+		),
+		EditTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "tags"),
+		),
+		StopProcess: key.NewBinding(
+			key.WithKeys("ctrl+k"),
+			key.WithHelp("ctrl+k", "stop process"),
+		),
+		PRActions: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "merge/close PR"),
+		),
+		CheckoutWorktree: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "worktree"),
+		),
+		OpenEditor: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "editor"),
+		),
+		OpenShell: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "shell"),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy"),
+		),
+		VisualSelect: key.NewBinding(
+			key.WithKeys("v", "V"),
+			key.WithHelp("v", "select"),
+		),
+		SaveSelection: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "save"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "prev match"),
+		),
+		FilterMatches: key.NewBinding(
+			key.WithKeys("&"),
+			key.WithHelp("&", "filter"),
+		),
+		ToggleRaw: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "raw"),
+		),
+		NextHunk: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next hunk"),
+		),
+		PrevHunk: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "prev hunk"),
+		),
+		NextFile: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next file"),
+		),
+		PrevFile: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev file"),
+		),
+		ToggleSplit: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "split"),
+		),
+		FoldFile: key.NewBinding(
+			key.WithKeys("space"),
+			key.WithHelp("⎵", "fold"),
+		),
+		CommentLine: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "comment"),
+		),
+		DiscardComment: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "discard comment"),
+		),
+		SubmitReview: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "review"),
+		),
+		AskCopilot: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "ask copilot"),
+		),
+	}
+}
+
+// keyAction describes a remappable binding: the name used in the keys:
+// config section and the view modes whose handlers respond to it. Two
+// actions may share a key only if they are never handled in the same mode.
+type keyAction struct {
+	name    string
+	binding func(*Keybindings) *key.Binding
+	modes   []ViewMode
+}
+
+var (
+	allModes = []ViewMode{
+		ViewModeList, ViewModeDetail, ViewModeLog, ViewModeToolTimeline,
+		ViewModeMission, ViewModeDiff, ViewModeGitActivity, ViewModeActive,
+	}
+	// navModes are the session-browsing screens where search and saved
+	// views are available.
+	navModes = []ViewMode{ViewModeList, ViewModeMission, ViewModeActive}
+	// browseModes are the screens with a list browsed with up, down and
+	// select: the session views and git activity's commits.
+	browseModes = []ViewMode{ViewModeList, ViewModeMission, ViewModeActive, ViewModeGitActivity}
+	// checkoutModes are the screens where the editor and shell keys open a
+	// session's checkout: the session views, the diff and git activity.
+	checkoutModes = []ViewMode{
+		ViewModeList, ViewModeDetail, ViewModeMission, ViewModeActive, ViewModeDiff, ViewModeGitActivity,
+	}
+	// worktreeModes are the screens where the worktree key checks out a
+	// session's branch: the session views and the diff of its PR.
+	worktreeModes = []ViewMode{ViewModeList, ViewModeDetail, ViewModeMission, ViewModeActive, ViewModeDiff}
+)
+
+// keyActions lists every remappable action. Number keys (panel focus and
+// saved-view shortcuts) are positional and not remappable.
+var keyActions = []keyAction{
+	{"help", func(k *Keybindings) *key.Binding { return &k.ShowHelp }, allModes},
+	{"quit", func(k *Keybindings) *key.Binding { return &k.ExitApp }, allModes},
+	{"snapshot", func(k *Keybindings) *key.Binding { return &k.Snapshot }, allModes},
+	{"palette", func(k *Keybindings) *key.Binding { return &k.CommandPalette }, allModes},
+	{"theme", func(k *Keybindings) *key.Binding { return &k.SwitchTheme }, allModes},
+	{"search", func(k *Keybindings) *key.Binding { return &k.SearchFilter },
+		[]ViewMode{ViewModeList, ViewModeMission, ViewModeActive, ViewModeLog}},
+	{"views", func(k *Keybindings) *key.Binding { return &k.SwitchView }, navModes},
+	{"back", func(k *Keybindings) *key.Binding { return &k.NavigateBack }, allModes},
+	{"up", func(k *Keybindings) *key.Binding { return &k.MoveUp },
+		append([]ViewMode{ViewModeLog, ViewModeToolTimeline, ViewModeDiff}, browseModes...)},
+	{"down", func(k *Keybindings) *key.Binding { return &k.MoveDown },
+		append([]ViewMode{ViewModeLog, ViewModeToolTimeline, ViewModeDiff}, browseModes...)},
+	{"select", func(k *Keybindings) *key.Binding { return &k.SelectTask }, browseModes},
+	{"logs", func(k *Keybindings) *key.Binding { return &k.ShowLogs },
+		[]ViewMode{ViewModeList, ViewModeDetail, ViewModeActive}},
+	{"conversation", func(k *Keybindings) *key.Binding { return &k.ShowConversation },
+		[]ViewMode{ViewModeList, ViewModeDetail, ViewModeLog}},
+	{"tools", func(k *Keybindings) *key.Binding { return &k.ShowToolTimeline },
+		[]ViewMode{ViewModeList, ViewModeDetail}},
+	{"openPR", func(k *Keybindings) *key.Binding { return &k.OpenInBrowser },
+		[]ViewMode{ViewModeList, ViewModeDetail, ViewModeActive}},
+	{"resume", func(k *Keybindings) *key.Binding { return &k.ResumeSession },
+		[]ViewMode{ViewModeList, ViewModeDetail, ViewModeLog}},
+	{"dismiss", func(k *Keybindings) *key.Binding { return &k.DismissSession },
+		[]ViewMode{ViewModeList, ViewModeDetail, ViewModeActive}},
+	{"dismissDone", func(k *Keybindings) *key.Binding { return &k.MassDismiss }, []ViewMode{ViewModeList}},
+	{"snooze", func(k *Keybindings) *key.Binding { return &k.SnoozeSession }, sessionModes},
+	{"pin", func(k *Keybindings) *key.Binding { return &k.PinSession }, sessionModes},
+	{"note", func(k *Keybindings) *key.Binding { return &k.EditNote }, sessionModes},
+	{"tags", func(k *Keybindings) *key.Binding { return &k.EditTags }, sessionModes},
+	{"stopProcess", func(k *Keybindings) *key.Binding { return &k.StopProcess }, sessionModes},
+	{"prActions", func(k *Keybindings) *key.Binding { return &k.PRActions }, sessionModes},
+	{"worktree", func(k *Keybindings) *key.Binding { return &k.CheckoutWorktree }, worktreeModes},
+	{"editor", func(k *Keybindings) *key.Binding { return &k.OpenEditor }, checkoutModes},
+	{"shell", func(k *Keybindings) *key.Binding { return &k.OpenShell }, checkoutModes},
+	{"copy", func(k *Keybindings) *key.Binding { return &k.Copy },
+		[]ViewMode{ViewModeList, ViewModeDetail, ViewModeMission, ViewModeActive, ViewModeLog, ViewModeDiff}},
+	{"visual", func(k *Keybindings) *key.Binding { return &k.VisualSelect }, []ViewMode{ViewModeLog}},
+	{"save", func(k *Keybindings) *key.Binding { return &k.SaveSelection }, []ViewMode{ViewModeLog}},
+	{"nextMatch", func(k *Keybindings) *key.Binding { return &k.NextMatch }, []ViewMode{ViewModeLog}},
+	{"prevMatch", func(k *Keybindings) *key.Binding { return &k.PrevMatch }, []ViewMode{ViewModeLog}},
+	{"filterLines", func(k *Keybindings) *key.Binding { return &k.FilterMatches }, []ViewMode{ViewModeLog}},
+	{"raw", func(k *Keybindings) *key.Binding { return &k.ToggleRaw }, []ViewMode{ViewModeLog}},
+	{"copyID", func(k *Keybindings) *key.Binding { return &k.CopyID }, []ViewMode{ViewModeActive}},
+	{"refresh", func(k *Keybindings) *key.Binding { return &k.RefreshData },
+		[]ViewMode{ViewModeList, ViewModeMission, ViewModeActive, ViewModeGitActivity}},
+	{"preview", func(k *Keybindings) *key.Binding { return &k.TogglePreview }, []ViewMode{ViewModeList}},
+	{"attention", func(k *Keybindings) *key.Binding { return &k.FocusAttention }, []ViewMode{ViewModeList}},
+	{"nextFilter", func(k *Keybindings) *key.Binding { return &k.ToggleFilter }, []ViewMode{ViewModeList}},
+	{"prevFilter", func(k *Keybindings) *key.Binding { return &k.ToggleFilterBack }, []ViewMode{ViewModeList}},
+	{"nextPanel", func(k *Keybindings) *key.Binding { return &k.NextPanel },
+		[]ViewMode{ViewModeMission, ViewModeGitActivity}},
+	{"prevPanel", func(k *Keybindings) *key.Binding { return &k.PrevPanel },
+		[]ViewMode{ViewModeMission, ViewModeGitActivity}},
+	{"groupBy", func(k *Keybindings) *key.Binding { return &k.GroupBy }, []ViewMode{ViewModeList}},
+	{"expandGroup", func(k *Keybindings) *key.Binding { return &k.ExpandGroup }, []ViewMode{ViewModeList}},
+	{"mission", func(k *Keybindings) *key.Binding { return &k.ToggleMission },
+		[]ViewMode{ViewModeList, ViewModeActive}},
+	{"active", func(k *Keybindings) *key.Binding { return &k.ToggleActive }, navModes},
+	{"diff", func(k *Keybindings) *key.Binding { return &k.ShowDiff },
+		[]ViewMode{ViewModeList, ViewModeDetail}},
+	{"gitActivity", func(k *Keybindings) *key.Binding { return &k.ShowGitActivity },
+		[]ViewMode{ViewModeList, ViewModeDetail}},
+	{"openRepo", func(k *Keybindings) *key.Binding { return &k.OpenRepo }, []ViewMode{ViewModeList}},
+	{"fileIssue", func(k *Keybindings) *key.Binding { return &k.FileIssue }, []ViewMode{ViewModeList}},
+	{"follow", func(k *Keybindings) *key.Binding { return &k.ToggleFollow }, []ViewMode{ViewModeLog}},
+	{"pageDown", func(k *Keybindings) *key.Binding { return &k.PageDown },
+		[]ViewMode{ViewModeLog, ViewModeToolTimeline, ViewModeDiff}},
+	{"pageUp", func(k *Keybindings) *key.Binding { return &k.PageUp },
+		[]ViewMode{ViewModeLog, ViewModeToolTimeline, ViewModeDiff}},
+	{"top", func(k *Keybindings) *key.Binding { return &k.GotoTop }, []ViewMode{ViewModeLog, ViewModeDiff}},
+	{"bottom", func(k *Keybindings) *key.Binding { return &k.GotoBottom }, []ViewMode{ViewModeLog, ViewModeDiff}},
+	{"nextHunk", func(k *Keybindings) *key.Binding { return &k.NextHunk }, []ViewMode{ViewModeDiff}},
+	{"prevHunk", func(k *Keybindings) *key.Binding { return &k.PrevHunk }, []ViewMode{ViewModeDiff}},
+	{"nextFile", func(k *Keybindings) *key.Binding { return &k.NextFile }, []ViewMode{ViewModeDiff}},
+	{"prevFile", func(k *Keybindings) *key.Binding { return &k.PrevFile }, []ViewMode{ViewModeDiff}},
+	{"toggleSplit", func(k *Keybindings) *key.Binding { return &k.ToggleSplit }, []ViewMode{ViewModeDiff}},
+	{"foldFile", func(k *Keybindings) *key.Binding { return &k.FoldFile }, []ViewMode{ViewModeDiff}},
+	{"commentLine", func(k *Keybindings) *key.Binding { return &k.CommentLine }, []ViewMode{ViewModeDiff}},
+	{"discardComment", func(k *Keybindings) *key.Binding { return &k.DiscardComment }, []ViewMode{ViewModeDiff}},
+	{"submitReview", func(k *Keybindings) *key.Binding { return &k.SubmitReview }, []ViewMode{ViewModeDiff}},
+	{"askCopilot", func(k *Keybindings) *key.Binding { return &k.AskCopilot }, []ViewMode{ViewModeDiff}},
+}
+
+// findKeyAction returns the action with the given config name.
+func findKeyAction(name string) (keyAction, bool) {
+	for _, a := range keyActions {
+		if strings.EqualFold(a.name, name) {
+			return a, true
+		}
+	}
+	return keyAction{}, false
+}
+
+// ApplyKeyOverrides returns kb with the config overrides applied. Unknown
+// action names are skipped, and overrides that would make two actions share
+// a key in the same view mode are reverted to their defaults. Each skipped
+// or reverted override is described in the returned problems.
+func ApplyKeyOverrides(kb Keybindings, overrides map[string]config.KeyList) (Keybindings, []string) {
+	var problems []string
+	defaults := kb
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	overridden := map[string]bool{}
+	for _, name := range names {
+		action, ok := findKeyAction(name)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown action %q", name))
+			continue
+		}
+		keys := normalizeKeys(overrides[name])
+		b := action.binding(&kb)
+		*b = key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyHelpLabel(keys), b.Help().Desc))
+		if len(keys) == 0 {
+			b.SetEnabled(false)
+		}
+		overridden[action.name] = true
+	}
+
+	// Reverting an override can expose a conflict with another override,
+	// so repeat until the set is clean.
+	for {
+		conflicts := keyConflicts(&kb)
+		reverted := false
+		for _, c := range conflicts {
+			for _, name := range []string{c.a, c.b} {
+				if !overridden[name] {
+					continue
+				}
+				action, _ := findKeyAction(name)
+				*action.binding(&kb) = *action.binding(&defaults)
+				delete(overridden, name)
+				reverted = true
+				problems = append(problems, fmt.Sprintf("%s: %q conflicts with %s", name, c.key, otherAction(c, name)))
+			}
+		}
+		if !reverted {
+			break
+		}
+	}
+	return kb, problems
+}
+
+// keyConflict records two actions bound to the same key in the same mode.
+type keyConflict struct {
+	a, b string
+	key  string
+}
+
+func otherAction(c keyConflict, name string) string {
+	if c.a == name {
+		return c.b
+	}
+	return c.a
+}
+
+// keyConflicts returns every pair of actions that share a key in at least
+// one view mode.
+func keyConflicts(kb *Keybindings) []keyConflict {
+	var conflicts []keyConflict
+	for i, a := range keyActions {
+		for _, b := range keyActions[i+1:] {
+			if !modesOverlap(a.modes, b.modes) {
+				continue
+			}
+			ba, bb := a.binding(kb), b.binding(kb)
+			if !ba.Enabled() || !bb.Enabled() {
+				continue
+			}
+			for _, k := range ba.Keys() {
+				if containsKey(bb.Keys(), k) {
+					conflicts = append(conflicts, keyConflict{a: a.name, b: b.name, key: k})
+					break
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+func modesOverlap(a, b []ViewMode) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsKey(keys []string, k string) bool {
+	for _, candidate := range keys {
+		if candidate == k {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeKeys trims key names and maps a literal space to "space", the
+// name bubbletea reports for the space bar.
+func normalizeKeys(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k == " " {
+			out = append(out, "space")
+			continue
+		}
+		if k = strings.TrimSpace(k); k != "" {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// keyHelpLabel renders keys compactly for help text, e.g. "k/↑".
+func keyHelpLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case "up":
+			k = "↑"
+		case "down":
+			k = "↓"
+		case "space":
+			k = "⎵"
+		}
+		labels[i] = k
+	}
+	return strings.Join(labels, "/")
+}
+
+// firstKey returns the primary key label of a binding, used where hints
+// pair two bindings (e.g. "j/k navigate").
+func firstKey(b key.Binding) string {
+	if keys := b.Keys(); len(keys) > 0 {
+		return keyHelpLabel(keys[:1])
+	}
+	return ""
+}
+
+// pairHint builds a footer hint that combines two bindings under one label.
+func pairHint(a, b key.Binding, desc string) key.Binding {
+	label := firstKey(a) + "/" + firstKey(b)
+	return key.NewBinding(key.WithKeys(label), key.WithHelp(label, desc))
+}
+
+// helpSections builds the help overlay contents from the current bindings,
+// so remapped keys are shown as configured. Disabled bindings are omitted.
+func helpSections(k Keybindings) []help.Section {
+	entry := func(b key.Binding, desc string) []help.Entry {
+		if !b.Enabled() {
+			return nil
+		}
+		return []help.Entry{{Key: b.Help().Key, Desc: desc}}
+	}
+	section := func(title string, entries ...[]help.Entry) help.Section {
+		sec := help.Section{Title: title}
+		for _, e := range entries {
+			sec.Entries = append(sec.Entries, e...)
+		}
+		return sec
+	}
+	return []help.Section{
+		section("Navigation",
+			[]help.Entry{{Key: k.MoveUp.Help().Key + " " + k.MoveDown.Help().Key, Desc: "navigate"}},
+			entry(k.SelectTask, "details"),
+			entry(k.NavigateBack, "back"),
+			entry(k.ToggleFilter, "cycle filter"),
+			entry(k.FocusAttention, "attention tab"),
+			entry(k.SearchFilter, "search"),
+			entry(k.CommandPalette, "command palette")),
+		section("Actions",
+			entry(k.OpenInBrowser, "open PR"),
+			entry(k.ResumeSession, "resume session"),
+			entry(k.DismissSession, "dismiss"),
+			entry(k.MassDismiss, "dismiss all done"),
+			entry(k.SnoozeSession, "snooze"),
+			entry(k.PinSession, "pin/unpin"),
+			entry(k.EditNote, "edit note"),
+			entry(k.EditTags, "edit tags"),
+			entry(k.StopProcess, "stop hung process"),
+			entry(k.PRActions, "merge, mark ready or close PR"),
+			entry(k.CheckoutWorktree, "check out branch in a worktree"),
+			entry(k.OpenEditor, "open working dir in editor"),
+			entry(k.OpenShell, "open shell in working dir"),
+			entry(k.Copy, "copy ID, branch, PR URL…"),
+			entry(k.RefreshData, "refresh"),
+			entry(k.TogglePreview, "toggle preview")),
+		section("Views",
+			entry(k.ToggleMission, "mission control"),
+			entry(k.ToggleActive, "active sessions"),
+			entry(k.ShowLogs, "logs"),
+			entry(k.ShowConversation, "conversation"),
+			entry(k.ShowToolTimeline, "tool timeline"),
+			entry(k.ShowDiff, "diff (PR)"),
+			entry(k.ShowGitActivity, "git changes"),
+			entry(k.SwitchView, "saved views")),
+		section("Groups",
+			entry(k.GroupBy, "cycle grouping"),
+			entry(k.ExpandGroup, "expand/collapse")),
+		section("Log View",
+			[]help.Entry{{Key: k.PageDown.Help().Key + "/" + k.PageUp.Help().Key, Desc: "page down/up"}},
+			[]help.Entry{{Key: k.GotoTop.Help().Key + "/" + k.GotoBottom.Help().Key, Desc: "top/bottom"}},
+			entry(k.ToggleFollow, "toggle follow"),
+			entry(k.SearchFilter, "search (regex)"),
+			[]help.Entry{{Key: k.NextMatch.Help().Key + "/" + k.PrevMatch.Help().Key, Desc: "next/prev match"}},
+			entry(k.FilterMatches, "only matching lines"),
+			entry(k.ToggleRaw, "raw/rendered log"),
+			entry(k.VisualSelect, "select lines"),
+			entry(k.Copy, "copy selection or page"),
+			entry(k.SaveSelection, "save selection / export conversation")),
+		section("Diff View",
+			[]help.Entry{{Key: k.NextHunk.Help().Key + "/" + k.PrevHunk.Help().Key, Desc: "next/prev hunk"}},
+			[]help.Entry{{Key: k.NextFile.Help().Key + "/" + k.PrevFile.Help().Key, Desc: "next/prev file"}},
+			entry(k.ToggleSplit, "unified/split"),
+			entry(k.FoldFile, "collapse/expand file"),
+			entry(k.CommentLine, "comment on line"),
+			entry(k.DiscardComment, "discard line's comments"),
+			entry(k.SubmitReview, "submit PR review"),
+			entry(k.AskCopilot, "ask Copilot to iterate"),
+			entry(k.CheckoutWorktree, "check out branch in a worktree"),
+			entry(k.OpenEditor, "open file at line"),
+			entry(k.Copy, "copy hunk")),
+		section("Meta",
+			entry(k.OpenRepo, "open session repo"),
+			entry(k.FileIssue, "file tool issue"),
+			entry(k.SwitchTheme, "switch theme"),
+			entry(k.Snapshot, "snapshot")),
 	}
 }
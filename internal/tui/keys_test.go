@@ -1,6 +1,14 @@
 package tui
 
-import "testing"
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+)
 
 func TestKeybindings_OpenRepoExists(t *testing.T) {
 	kb := NewKeybindings()
@@ -23,3 +31,112 @@ func TestKeybindings_FileIssueExists(t *testing.T) {
 		t.Fatalf("expected FileIssue key to be '@', got %q", keys[0])
 	}
 }
+
+func TestKeybindings_DefaultsHaveNoConflicts(t *testing.T) {
+	kb := NewKeybindings()
+	if conflicts := keyConflicts(&kb); len(conflicts) > 0 {
+		t.Fatalf("default bindings conflict: %+v", conflicts)
+	}
+}
+
+func TestApplyKeyOverrides_Remaps(t *testing.T) {
+	kb, problems := ApplyKeyOverrides(NewKeybindings(), map[string]config.KeyList{
+		"dismiss":   {"b"},
+		"fileIssue": {},
+	})
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	if keys := kb.DismissSession.Keys(); len(keys) != 1 || keys[0] != "b" {
+		t.Errorf("expected dismiss remapped to b, got %v", keys)
+	}
+	if kb.DismissSession.Help().Key != "b" || kb.DismissSession.Help().Desc != "dismiss" {
+		t.Errorf("expected help to show the new key and keep its description, got %+v", kb.DismissSession.Help())
+	}
+	if kb.FileIssue.Enabled() {
+		t.Error("expected an empty key list to unbind the action")
+	}
+}
+
+func TestApplyKeyOverrides_RejectsConflicts(t *testing.T) {
+	kb, problems := ApplyKeyOverrides(NewKeybindings(), map[string]config.KeyList{
+		"dismiss": {"o"}, // collides with openPR in the list view
+		"follow":  {"d"}, // collides with pageDown in the log view
+		"top":     {"x"}, // fine: dismiss is never handled in the log view
+		"bogus":   {"b"},
+	})
+	if len(problems) != 3 {
+		t.Fatalf("expected 3 problems, got %v", problems)
+	}
+	if kb.DismissSession.Keys()[0] != "x" {
+		t.Errorf("expected conflicting dismiss override to revert, got %v", kb.DismissSession.Keys())
+	}
+	if kb.ToggleFollow.Keys()[0] != "f" {
+		t.Errorf("expected conflicting follow override to revert, got %v", kb.ToggleFollow.Keys())
+	}
+	if kb.GotoTop.Keys()[0] != "x" {
+		t.Errorf("expected non-conflicting override to apply, got %v", kb.GotoTop.Keys())
+	}
+}
+
+func TestKeyActions_CoverGitActivityBrowsing(t *testing.T) {
+	for _, a := range keyActions {
+		switch a.name {
+		case "up", "down", "select":
+			if !slices.Contains(a.modes, ViewModeGitActivity) {
+				t.Errorf("%s browses git activity's commits but is not checked for conflicts there", a.name)
+			}
+		}
+	}
+}
+
+func TestApplyKeyOverrides_AllowsSwaps(t *testing.T) {
+	kb, problems := ApplyKeyOverrides(NewKeybindings(), map[string]config.KeyList{
+		"dismiss":     {"X"},
+		"dismissDone": {"x"},
+	})
+	if len(problems) != 0 {
+		t.Fatalf("swapping two keys should not conflict: %v", problems)
+	}
+	if kb.DismissSession.Keys()[0] != "X" || kb.MassDismiss.Keys()[0] != "x" {
+		t.Error("expected keys to be swapped")
+	}
+}
+
+func TestRemappedKeyDrivesHandlersAndHints(t *testing.T) {
+	m := NewModel("", false, false, "", "dev")
+	m.keys, _ = ApplyKeyOverrides(NewKeybindings(), map[string]config.KeyList{
+		"mission": {"m"},
+		"help":    {"f1"},
+	})
+	m.help.SetSections(helpSections(m.keys), firstKey(m.keys.ShowHelp))
+	m.viewMode = ViewModeList
+
+	result, _ := m.handleKeyPress(tea.KeyPressMsg{Code: 'M', Text: "M"})
+	if result.(Model).viewMode != ViewModeList {
+		t.Fatal("old key should no longer switch to mission control")
+	}
+	result, _ = m.handleKeyPress(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	m = result.(Model)
+	if m.viewMode != ViewModeMission {
+		t.Fatal("remapped key should switch to mission control")
+	}
+
+	m.viewMode = ViewModeList
+	m.footer.SetWidth(200)
+	m.updateFooterHints()
+	footer := ansi.Strip(m.footer.View())
+	if !strings.Contains(footer, "m mission") || strings.Contains(footer, "M mission") {
+		t.Errorf("expected footer to show remapped mission key, got %s", footer)
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyF1})
+	m = result.(Model)
+	if !m.help.Visible() {
+		t.Fatal("remapped help key should open the overlay")
+	}
+	m.help.SetSize(120, 50)
+	if v := m.help.View(); !strings.Contains(v, "Press f1 or esc") {
+		t.Error("expected help overlay close hint to show the remapped key")
+	}
+}
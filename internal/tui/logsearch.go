@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/find"
+)
+
+// searchableView is the log or conversation view, which search alike.
+type searchableView interface {
+	StartSearch()
+	Search(query string)
+	NextMatch(delta int)
+	SetFilter(on bool)
+	ClearSearch()
+	SearchState() find.Model
+}
+
+// logSearchView returns the log or conversation view shown.
+func (m *Model) logSearchView() searchableView {
+	if m.showConversation {
+		return &m.conversationView
+	}
+	return &m.logView
+}
+
+// logSearchState returns the search of the log or conversation view shown.
+func (m Model) logSearchState() find.Model {
+	if m.showConversation {
+		return m.conversationView.SearchState()
+	}
+	return m.logView.SearchState()
+}
+
+// openLogSearch opens the search bar over the log or conversation view,
+// starting from the current query so it can be refined.
+func (m *Model) openLogSearch() {
+	view := m.logSearchView()
+	view.StartSearch()
+	m.logSearchInput = view.SearchState().Query()
+	m.logSearchActive = true
+}
+
+// toggleLogFilter turns filter mode on or off, asking for a query when
+// there is none to filter by.
+func (m *Model) toggleLogFilter() {
+	view := m.logSearchView()
+	state := view.SearchState()
+	view.SetFilter(!state.FilterMode())
+	if !state.Active() {
+		m.openLogSearch()
+	}
+}
+
+// handleLogSearchBarKeys handles keys while the search bar is open: the
+// view is searched as the query is typed, enter keeps the search and esc
+// drops it.
+func (m Model) handleLogSearchBarKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	view := m.logSearchView()
+	switch {
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	case msg.Code == tea.KeyEscape:
+		m.logSearchActive = false
+		m.logSearchInput = ""
+		view.ClearSearch()
+	case msg.Code == tea.KeyEnter:
+		m.logSearchActive = false
+		if m.logSearchInput == "" {
+			view.ClearSearch()
+		}
+	case msg.Code == tea.KeyTab:
+		view.SetFilter(!view.SearchState().FilterMode())
+	case msg.Code == tea.KeyBackspace:
+		if m.logSearchInput != "" {
+			_, size := utf8.DecodeLastRuneInString(m.logSearchInput)
+			m.logSearchInput = m.logSearchInput[:len(m.logSearchInput)-size]
+			view.Search(m.logSearchInput)
+		}
+	case len(msg.Text) > 0:
+		m.logSearchInput += msg.Text
+		view.Search(m.logSearchInput)
+	}
+	return m, nil
+}
+
+// handleLogSearchKeys handles the search, match, filter and raw keys in
+// the log view, and esc while a search is shown. It reports whether msg
+// was one of those keys.
+func (m *Model) handleLogSearchKeys(msg tea.KeyPressMsg) bool {
+	view := m.logSearchView()
+	state := view.SearchState()
+	switch {
+	case key.Matches(msg, m.keys.SearchFilter):
+		m.openLogSearch()
+	case key.Matches(msg, m.keys.NextMatch) && state.Active():
+		view.NextMatch(1)
+	case key.Matches(msg, m.keys.PrevMatch) && state.Active():
+		view.NextMatch(-1)
+	case key.Matches(msg, m.keys.FilterMatches):
+		m.toggleLogFilter()
+	case key.Matches(msg, m.keys.ToggleRaw) && !m.showConversation:
+		m.logView.ToggleRaw()
+	case key.Matches(msg, m.keys.NavigateBack) && (state.Active() || state.FilterMode()):
+		view.ClearSearch()
+	default:
+		return false
+	}
+	return true
+}
+
+// logSearchBar renders the log search: the query, the match counter and
+// the modes in effect.
+func (m Model) logSearchBar() string {
+	state := m.logSearchState()
+	if !m.logSearchActive && !state.Active() && !state.FilterMode() {
+		return ""
+	}
+	query := state.Query()
+	if m.logSearchActive {
+		query = m.logSearchInput + a11y.Glyph("▍", "_") // cursor
+	}
+	bar := lipgloss.NewStyle().Foreground(colors.Current().Highlight).Bold(true).
+		Render(fmt.Sprintf("  %s/%s", a11y.Glyph("🔎 ", ""), query))
+	var notes []string
+	if state.Active() {
+		notes = append(notes, state.Counter())
+	}
+	if state.FilterMode() {
+		notes = append(notes, "filter")
+	}
+	if m.logSearchActive {
+		notes = append(notes, "tab: filter")
+	}
+	for _, n := range notes {
+		bar += lipgloss.NewStyle().Faint(true).Render(a11y.Glyph("  · ", "  - ") + n)
+	}
+	if literal, reason := state.Literal(); literal {
+		bar += "  " + lipgloss.NewStyle().Foreground(colors.Current().Attention.Warning).
+			Render(a11y.Glyph("⚠ ", "! ")+"not a regex ("+reason+"), matched as text")
+	}
+	return bar + "\n"
+}
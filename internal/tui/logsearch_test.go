@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLogSearchKeys(t *testing.T) {
+	m := logTestModel(t)
+	press := func(keys ...string) {
+		t.Helper()
+		for _, k := range keys {
+			var msg tea.KeyPressMsg
+			switch k {
+			case "esc":
+				msg = tea.KeyPressMsg{Code: tea.KeyEscape}
+			case "enter":
+				msg = tea.KeyPressMsg{Code: tea.KeyEnter}
+			case "backspace":
+				msg = tea.KeyPressMsg{Code: tea.KeyBackspace}
+			default:
+				msg = tea.KeyPressMsg{Code: []rune(k)[0], Text: k}
+			}
+			next, cmd := m.handleKeyPress(msg)
+			m = next.(Model)
+			if cmd != nil {
+				t.Fatalf("unexpected command after %q", k)
+			}
+		}
+	}
+
+	press("/", "q", "backspace", "l", "i", "n", "e")
+	if !m.logSearchActive || m.logView.SearchState().Counter() != "1/3" {
+		t.Fatalf("expected the log searched as the query is typed, got %s", m.logView.SearchState().Counter())
+	}
+	if bar := ansi.Strip(m.logSearchBar()); !strings.Contains(bar, "/line▍") || !strings.Contains(bar, "1/3") {
+		t.Errorf("expected the query and counter in the search bar, got %q", bar)
+	}
+	press("enter", "n", "n", "n", "N")
+	if m.logSearchActive || m.logView.SearchState().Counter() != "3/3" {
+		t.Errorf("expected n and N to step through the matches, got %s", m.logView.SearchState().Counter())
+	}
+
+	press("&")
+	if !m.logView.SearchState().Filtering() || !strings.Contains(ansi.Strip(m.logSearchBar()), "filter") {
+		t.Error("expected filter mode shown")
+	}
+	press("esc")
+	if m.logView.SearchState().Active() || m.viewMode != ViewModeLog {
+		t.Fatal("expected esc to clear the search and stay in the log")
+	}
+	if m.logSearchBar() != "" {
+		t.Error("expected the search bar gone")
+	}
+
+	press("/", "(", "enter")
+	if literal, _ := m.logView.SearchState().Literal(); !literal || !strings.Contains(ansi.Strip(m.logSearchBar()), "matched as text") {
+		t.Errorf("expected an invalid regex matched as text, got %q", ansi.Strip(m.logSearchBar()))
+	}
+	press("esc", "esc")
+	if m.viewMode == ViewModeLog {
+		t.Error("expected esc without a search to leave the log")
+	}
+}
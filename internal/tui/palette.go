@@ -0,0 +1,440 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// paletteCommand is an entry in the command palette.
+type paletteCommand struct {
+	id    string
+	title string
+	// action names the keyAction whose binding is shown beside the command
+	// when it applies in the current mode; empty for commands without a key.
+	action string
+	// keyRuns reports whether the action's key runs this command rather
+	// than a sibling it picks between by config; nil means it does.
+	keyRuns func(m *Model) bool
+	// modes limits where the command is offered; nil means every mode.
+	modes []ViewMode
+	// available reports whether the command applies to the current state
+	// and selected session (which may be nil); nil means always.
+	available func(m *Model, s *data.Session) bool
+	run       func(m *Model, s *data.Session) tea.Cmd
+}
+
+// needsSession wraps a session predicate so it also requires a selection.
+func needsSession(pred func(*data.Session) bool) func(*Model, *data.Session) bool {
+	return func(_ *Model, s *data.Session) bool {
+		return s != nil && (pred == nil || pred(s))
+	}
+}
+
+var sessionModes = []ViewMode{ViewModeList, ViewModeDetail, ViewModeMission, ViewModeActive}
+
+// paletteCommands lists every command the palette can offer.
+var paletteCommands = []paletteCommand{
+	// Session actions
+	{id: "session.detail", title: "Open session details", action: "select", modes: navModes,
+		available: needsSession(nil),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openDetail(s) }},
+	{id: "session.logs", title: "Show logs", action: "logs", modes: sessionModes,
+		available: needsSession(nil),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openLogs(s) }},
+	{id: "session.conversation", title: "Show conversation", action: "conversation", modes: sessionModes,
+		available: needsSession(hasLocalLog),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openConversation(s) }},
+	{id: "session.tools", title: "Show tool timeline", action: "tools", modes: sessionModes,
+		available: needsSession(hasLocalLog),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openToolTimeline(s) }},
+	{id: "session.diff", title: "Show PR diff", action: "diff", modes: sessionModes,
+		available: needsSession(canShowDiff),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openDiff(s) }},
+	{id: "session.git", title: "Show git activity", action: "gitActivity", modes: sessionModes,
+		available: needsSession(hasWorkDir),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openGitActivity(s) }},
+	{id: "session.openPR", title: "Open PR in browser", action: "openPR",
+		available: needsSession(nil),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openTaskPR(s) }},
+	{id: "session.openRepo", title: "Open repository in browser", action: "openRepo",
+		available: needsSession(func(s *data.Session) bool { return s.Repository != "" }),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openSessionRepo(s) }},
+	{id: "session.resume", title: "Resume session", action: "resume",
+		available: needsSession(func(s *data.Session) bool { return s.Source == data.SourceLocalCopilot && isSessionRunning(s) }),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.resumeSession(s) }},
+	{id: "session.dismiss", title: "Dismiss session", action: "dismiss", modes: sessionModes,
+		available: needsSession(nil),
+		run: func(m *Model, s *data.Session) tea.Cmd {
+			m.dismissSession(s)
+			return nil
+		}},
+	{id: "session.snooze", title: "Snooze session", action: "snooze", modes: sessionModes,
+		available: needsSession(nil),
+		run: func(m *Model, s *data.Session) tea.Cmd {
+			m.openSnoozePicker(s)
+			return nil
+		}},
+	{id: "session.pin", title: "Pin or unpin session", action: "pin", modes: sessionModes,
+		available: needsSession(nil),
+		run: func(m *Model, s *data.Session) tea.Cmd {
+			m.togglePin(s)
+			return nil
+		}},
+	{id: "session.note", title: "Edit session note", action: "note", modes: sessionModes,
+		available: needsSession(nil),
+		run: func(m *Model, s *data.Session) tea.Cmd {
+			m.openNotePrompt(s)
+			return nil
+		}},
+	{id: "session.tags", title: "Edit session tags", action: "tags", modes: sessionModes,
+		available: needsSession(nil),
+		run: func(m *Model, s *data.Session) tea.Cmd {
+			m.openTagsPrompt(s)
+			return nil
+		}},
+	{id: "session.stopProcess", title: "Stop session process", action: "stopProcess", modes: sessionModes,
+		available: canManageProcess,
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.inspectSessionProcess(s) }},
+	{id: "session.prActions", title: "Merge, mark ready or close PR", action: "prActions", modes: sessionModes,
+		available: canManagePR,
+		run: func(m *Model, s *data.Session) tea.Cmd {
+			m.openPRActions(s)
+			return nil
+		}},
+	{id: "session.worktreeShell", title: "Check out branch in a worktree (shell)", action: "worktree", modes: sessionModes,
+		keyRuns:   func(m *Model) bool { return !m.ctx.Config.WorktreeOpensEditor() },
+		available: canCheckoutBranch,
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.checkoutWorktree(s, false) }},
+	{id: "session.worktreeEditor", title: "Check out branch in a worktree (editor)", action: "worktree", modes: sessionModes,
+		keyRuns:   func(m *Model) bool { return m.ctx.Config.WorktreeOpensEditor() },
+		available: canCheckoutBranch,
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.checkoutWorktree(s, true) }},
+	{id: "session.editor", title: "Open working directory in editor", action: "editor", modes: sessionModes,
+		available: canOpenWorkDir,
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openWorkDir(s, true) }},
+	{id: "session.shell", title: "Open shell in working directory", action: "shell", modes: sessionModes,
+		available: canOpenWorkDir,
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.openWorkDir(s, false) }},
+	{id: "session.copyID", title: "Copy session ID", action: "copyID",
+		available: needsSession(nil),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.copySession(s, copyID) }},
+	{id: "session.copyBranch", title: "Copy branch name",
+		available: needsSession(func(s *data.Session) bool { return s.Branch != "" }),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.copySession(s, copyBranch) }},
+	{id: "session.copyRepo", title: "Copy repository name",
+		available: needsSession(func(s *data.Session) bool { return s.Repository != "" }),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.copySession(s, copyRepository) }},
+	{id: "session.copyPRURL", title: "Copy PR URL",
+		available: needsSession(func(s *data.Session) bool { return sessionPRURL(s) != "" }),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.copySession(s, copyPRURL) }},
+	{id: "session.copyResume", title: "Copy resume command",
+		available: needsSession(func(s *data.Session) bool { return resumeCommand(s) != "" }),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.copySession(s, copyResume) }},
+	{id: "session.copyLastMessage", title: "Copy last assistant message",
+		available: needsSession(hasLocalLog),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.copySession(s, copyLastMessage) }},
+	{id: "session.exportMarkdown", title: "Export conversation as Markdown",
+		available: needsSession(hasLocalLog),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.exportConversation(s, exportMarkdown) }},
+	{id: "session.exportHTML", title: "Export conversation as HTML",
+		available: needsSession(hasLocalLog),
+		run:       func(m *Model, s *data.Session) tea.Cmd { return m.exportConversation(s, exportHTML) }},
+	{id: "copy.selection", title: "Copy selection, page or hunk", action: "copy",
+		modes: []ViewMode{ViewModeLog, ViewModeDiff},
+		run:   func(m *Model, _ *data.Session) tea.Cmd { return m.copyViewSelection() }},
+	{id: "log.select", title: "Select lines", action: "visual", modes: []ViewMode{ViewModeLog},
+		run: func(m *Model, _ *data.Session) tea.Cmd { m.toggleLogSelection(); return nil }},
+	{id: "log.saveSelection", title: "Save selected lines to a file", action: "save", modes: []ViewMode{ViewModeLog},
+		available: func(m *Model, _ *data.Session) bool { return m.logSelecting() },
+		run:       func(m *Model, _ *data.Session) tea.Cmd { return m.saveLogSelection() }},
+	{id: "log.search", title: "Search the log", action: "search", modes: []ViewMode{ViewModeLog},
+		run: func(m *Model, _ *data.Session) tea.Cmd { m.openLogSearch(); return nil }},
+	{id: "log.filter", title: "Show only matching lines", action: "filterLines", modes: []ViewMode{ViewModeLog},
+		run: func(m *Model, _ *data.Session) tea.Cmd { m.toggleLogFilter(); return nil }},
+	{id: "log.raw", title: "Toggle raw and rendered log", action: "raw", modes: []ViewMode{ViewModeLog},
+		available: func(m *Model, _ *data.Session) bool { return !m.showConversation },
+		run:       func(m *Model, _ *data.Session) tea.Cmd { m.logView.ToggleRaw(); return nil }},
+
+	// PR review, in the diff view
+	{id: "review.comment", title: "Comment on the selected line", action: "commentLine", modes: []ViewMode{ViewModeDiff},
+		available: func(m *Model, _ *data.Session) bool { return m.canReview() },
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.openCommentPrompt()
+			return nil
+		}},
+	{id: "review.submit", title: "Submit PR review", action: "submitReview", modes: []ViewMode{ViewModeDiff},
+		available: func(m *Model, _ *data.Session) bool { return m.canReview() },
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.openReviewPicker()
+			return nil
+		}},
+	{id: "review.copilot", title: "Ask Copilot to iterate on the PR", action: "askCopilot", modes: []ViewMode{ViewModeDiff},
+		available: func(m *Model, _ *data.Session) bool { return m.canReview() },
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.openCopilotPrompt()
+			return nil
+		}},
+
+	// Navigation
+	{id: "go.mission", title: "Go to mission control", action: "mission",
+		modes: []ViewMode{ViewModeList, ViewModeDetail, ViewModeLog, ViewModeToolTimeline, ViewModeDiff, ViewModeGitActivity, ViewModeActive},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.showMission()
+			return nil
+		}},
+	{id: "go.list", title: "Go to session table",
+		modes: []ViewMode{ViewModeDetail, ViewModeLog, ViewModeToolTimeline, ViewModeMission, ViewModeDiff, ViewModeGitActivity, ViewModeActive},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.showList()
+			return nil
+		}},
+	{id: "go.active", title: "Go to active sessions", action: "active",
+		modes: []ViewMode{ViewModeList, ViewModeDetail, ViewModeLog, ViewModeToolTimeline, ViewModeMission, ViewModeDiff, ViewModeGitActivity},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.showActive()
+			return nil
+		}},
+	{id: "views", title: "Switch saved view", action: "views", modes: navModes,
+		available: func(m *Model, _ *data.Session) bool { return len(m.ctx.Config.Views) > 0 },
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.openViewPicker()
+			return nil
+		}},
+
+	// Search and filtering
+	{id: "search", title: "Search sessions", action: "search", modes: navModes,
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.searchActive = true
+			m.setSearchQuery(m.searchQuery)
+			return nil
+		}},
+	{id: "search.clear", title: "Clear search filter", modes: navModes,
+		available: func(m *Model, _ *data.Session) bool { return m.searchQuery != "" },
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.setSearchQuery("")
+			m.recomputeAndDisplay(m.visibleSessions())
+			return nil
+		}},
+	statusCommand("all", "Show all sessions"),
+	statusCommand("active", "Show active sessions"),
+	statusCommand("attention", "Show sessions needing attention"),
+	statusCommand("completed", "Show completed sessions"),
+	statusCommand("failed", "Show failed sessions"),
+	groupCommand("", "Ungroup sessions"),
+	groupCommand("repository", "Group by repository"),
+	groupCommand("status", "Group by status"),
+	groupCommand("source", "Group by source"),
+	sortCommand("updated", "Sort by last update"),
+	sortCommand("created", "Sort by creation time"),
+	sortCommand("cost", "Sort by cost"),
+	sortCommand("title", "Sort by title"),
+	sortCommand("status", "Sort by status"),
+
+	// General
+	{id: "refresh", title: "Refresh sessions", action: "refresh",
+		run: func(m *Model, _ *data.Session) tea.Cmd { return m.fetchTasks }},
+	{id: "archive", title: "Archive old finished sessions",
+		available: canArchive,
+		run:       func(m *Model, _ *data.Session) tea.Cmd { return m.archiveSessions }},
+	{id: "archived", title: "Show or hide archived sessions",
+		available: canArchive,
+		run:       func(m *Model, _ *data.Session) tea.Cmd { return m.toggleArchived() }},
+	{id: "unsnoozeAll", title: "Unsnooze all sessions",
+		available: func(m *Model, _ *data.Session) bool { return m.hasSnoozed() },
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.unsnoozeAll()
+			return nil
+		}},
+	{id: "dismissDone", title: "Dismiss all completed sessions", action: "dismissDone", modes: []ViewMode{ViewModeList},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.dismissCompleted()
+			return nil
+		}},
+	{id: "preview", title: "Toggle preview pane", action: "preview", modes: []ViewMode{ViewModeList},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.showPreview = !m.showPreview
+			m.updateSplitLayout()
+			return nil
+		}},
+	{id: "follow", title: "Toggle log follow mode", action: "follow", modes: []ViewMode{ViewModeLog},
+		available: func(m *Model, _ *data.Session) bool { return !m.showConversation },
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.logView.SetFollowMode(!m.logView.FollowMode())
+			if m.logView.FollowMode() {
+				m.logView.GotoBottom()
+			}
+			return nil
+		}},
+	{id: "theme", title: "Switch theme", action: "theme",
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.openThemePicker()
+			return nil
+		}},
+	{id: "snapshot", title: "Save debug snapshot", action: "snapshot",
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.saveSnapshot()
+			return nil
+		}},
+	{id: "fileIssue", title: "File a tool issue", action: "fileIssue",
+		run: func(m *Model, _ *data.Session) tea.Cmd { return m.openFileIssue() }},
+	{id: "help", title: "Show keyboard shortcuts", action: "help",
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.help.Toggle()
+			return nil
+		}},
+	{id: "quit", title: "Quit", action: "quit",
+		run: func(*Model, *data.Session) tea.Cmd { return tea.Quit }},
+}
+
+// statusCommand switches the table to a status tab.
+func statusCommand(status, title string) paletteCommand {
+	return paletteCommand{
+		id: "status." + status, title: title, modes: navModes,
+		available: func(m *Model, _ *data.Session) bool {
+			return m.viewMode != ViewModeList || m.ctx.StatusFilter != status
+		},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.ctx.StatusFilter = status
+			m.showPreview = false
+			m.updateSplitLayout()
+			m.showList()
+			return nil
+		},
+	}
+}
+
+// groupCommand sets the table grouping.
+func groupCommand(mode, title string) paletteCommand {
+	return paletteCommand{
+		id: "group." + mode, title: title, modes: []ViewMode{ViewModeList},
+		available: func(m *Model, _ *data.Session) bool {
+			return m.taskList.GroupBy() != mode
+		},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.taskList.SetGroupBy(mode)
+			return nil
+		},
+	}
+}
+
+// sortCommand sets the table sort order.
+func sortCommand(mode, title string) paletteCommand {
+	return paletteCommand{
+		id: "sort." + mode, title: title, modes: []ViewMode{ViewModeList},
+		available: func(m *Model, _ *data.Session) bool {
+			return m.taskList.SortBy() != mode
+		},
+		run: func(m *Model, _ *data.Session) tea.Cmd {
+			m.taskList.SetSortBy(mode)
+			m.lastFingerprint = ""
+			m.recomputeAndDisplay(m.visibleSessions())
+			return nil
+		},
+	}
+}
+
+// paletteKey returns the key shown for a command, or "" when its action has
+// no binding in the current mode or its key runs a sibling command.
+func (m Model) paletteKey(c paletteCommand) string {
+	if c.action == "" || (c.keyRuns != nil && !c.keyRuns(&m)) {
+		return ""
+	}
+	action, ok := findKeyAction(c.action)
+	if !ok || !containsMode(action.modes, m.viewMode) {
+		return ""
+	}
+	b := action.binding(&m.keys)
+	if !b.Enabled() {
+		return ""
+	}
+	return b.Help().Key
+}
+
+func containsMode(modes []ViewMode, mode ViewMode) bool {
+	for _, candidate := range modes {
+		if candidate == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// availableCommands returns the commands that apply to the current view
+// mode and selected session.
+func (m *Model) availableCommands() []paletteCommand {
+	s := m.selectedSession()
+	var out []paletteCommand
+	for _, c := range paletteCommands {
+		if c.modes != nil && !containsMode(c.modes, m.viewMode) {
+			continue
+		}
+		if c.available != nil && !c.available(m, s) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// openPalette shows the command palette for the current context.
+func (m *Model) openPalette() {
+	commands := m.availableCommands()
+	items := make([]picker.Item, len(commands))
+	for i, c := range commands {
+		items[i] = picker.Item{Label: c.title, Key: m.paletteKey(c), Value: c.id}
+	}
+	title := "Commands"
+	if s := m.selectedSession(); s != nil && s.Title != "" {
+		label := []rune(s.Title)
+		if len(label) > 40 {
+			label = append(label[:39], '…')
+		}
+		title = fmt.Sprintf("Commands · %s", string(label))
+	}
+	m.palette = picker.New(title, true)
+	m.palette.SetSize(m.ctx.Width, m.ctx.Height)
+	m.palette.Open(items)
+}
+
+// runPaletteCommand executes the command with the given id against the
+// current selection.
+func (m *Model) runPaletteCommand(id string) tea.Cmd {
+	for _, c := range m.availableCommands() {
+		if c.id == id {
+			return c.run(m, m.selectedSession())
+		}
+	}
+	return nil
+}
+
+// handlePaletteKeys handles keys while the command palette is open. Typed
+// text filters the list, so only non-printing keys navigate.
+func (m Model) handlePaletteKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.palette.Close()
+	case "down", "ctrl+n":
+		m.palette.MoveCursor(1)
+	case "up", "ctrl+p":
+		m.palette.MoveCursor(-1)
+	case "enter":
+		item, ok := m.palette.Selected()
+		m.palette.Close()
+		if ok {
+			cmd := m.runPaletteCommand(item.Value)
+			return m, cmd
+		}
+	case "backspace":
+		m.palette.Backspace()
+	default:
+		if msg.Text != "" {
+			m.palette.AppendQuery(msg.Text)
+		}
+	}
+	return m, nil
+}
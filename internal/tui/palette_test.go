@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func newPaletteTestModel(sessions ...data.Session) Model {
+	m := NewModel("", false, false, "", "dev")
+	m.ctx.Width, m.ctx.Height = 120, 40
+	m.ctx.StatusFilter = "all"
+	m.initialLoadDone = true
+	m.allSessions = sessions
+	m.viewMode = ViewModeList
+	m.recomputeAndDisplay(m.visibleSessions())
+	return m
+}
+
+func findCommand(m *Model, id string) (paletteCommand, bool) {
+	for _, c := range m.availableCommands() {
+		if c.id == id {
+			return c, true
+		}
+	}
+	return paletteCommand{}, false
+}
+
+func TestPalette_CommandsFollowSessionCapabilities(t *testing.T) {
+	local := data.Session{ID: "l1", Title: "Local", Source: data.SourceLocalCopilot, HasLog: true, Status: "running", Branch: "fix"}
+	m := newPaletteTestModel(local)
+
+	c, ok := findCommand(&m, "session.conversation")
+	if !ok {
+		t.Fatal("expected conversation command for a local session with a log")
+	}
+	if key := m.paletteKey(c); key != "c" {
+		t.Errorf("expected conversation to show key c, got %q", key)
+	}
+	if _, ok := findCommand(&m, "session.copyBranch"); !ok {
+		t.Error("expected keyless copy-branch command to be offered")
+	}
+
+	remote := data.Session{ID: "r1", Title: "Remote", Source: data.SourceAgentTask, Status: "completed"}
+	m = newPaletteTestModel(remote)
+	if _, ok := findCommand(&m, "session.conversation"); ok {
+		t.Error("conversation should not be offered for agent tasks")
+	}
+	if _, ok := findCommand(&m, "session.copyBranch"); ok {
+		t.Error("copy branch should not be offered without a branch")
+	}
+}
+
+func TestPalette_KeyShownOnlyWhereBound(t *testing.T) {
+	m := newPaletteTestModel(data.Session{ID: "a", Title: "A", Status: "running", Source: data.SourceLocalCopilot})
+	m.showMission()
+	c, ok := findCommand(&m, "session.logs")
+	if !ok {
+		t.Fatal("expected logs command on the dashboard")
+	}
+	if key := m.paletteKey(c); key != "" {
+		t.Errorf("logs has no key on the dashboard, got %q", key)
+	}
+	if _, ok := findCommand(&m, "go.mission"); ok {
+		t.Error("should not offer switching to the current view")
+	}
+}
+
+func TestPalette_WorktreeKeyShownOnEntryItRuns(t *testing.T) {
+	m := newPaletteTestModel(data.Session{ID: "t", Title: "T", Status: "completed", Source: data.SourceAgentTask, Repository: "org/repo", Branch: "copilot/fix"})
+	keys := func() (shell, editor string) {
+		s, _ := findCommand(&m, "session.worktreeShell")
+		e, _ := findCommand(&m, "session.worktreeEditor")
+		return m.paletteKey(s), m.paletteKey(e)
+	}
+
+	if shell, editor := keys(); shell != "w" || editor != "" {
+		t.Errorf("expected w beside the shell checkout by default, got shell=%q editor=%q", shell, editor)
+	}
+	m.ctx.Config.Worktrees.Open = "editor"
+	if shell, editor := keys(); shell != "" || editor != "w" {
+		t.Errorf("expected w beside the editor checkout when it opens the editor, got shell=%q editor=%q", shell, editor)
+	}
+}
+
+func TestPalette_FilterAndRun(t *testing.T) {
+	m := newPaletteTestModel(data.Session{ID: "a", Title: "A", Status: "running"})
+
+	result, _ := m.handleKeyPress(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	m = result.(Model)
+	if !m.palette.Visible() {
+		t.Fatal("expected ctrl+p to open the palette")
+	}
+
+	for _, r := range "sort cost" {
+		result, _ = m.handleKeyPress(tea.KeyPressMsg{Code: r, Text: string(r)})
+		m = result.(Model)
+	}
+	if !m.palette.Visible() {
+		t.Fatal("typing should filter, not trigger other bindings")
+	}
+	result, _ = m.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = result.(Model)
+	if m.palette.Visible() {
+		t.Error("expected palette to close after running a command")
+	}
+	if m.taskList.SortBy() != "cost" {
+		t.Errorf("expected sort command to run, got sort %q", m.taskList.SortBy())
+	}
+}
+
+func TestPalette_GroupCommandsHideTheCurrentGrouping(t *testing.T) {
+	m := newPaletteTestModel(data.Session{ID: "a", Title: "A", Status: "running", Repository: "org/repo"})
+	if _, ok := findCommand(&m, "group."); ok {
+		t.Error("should not offer ungrouping an ungrouped list")
+	}
+	c, ok := findCommand(&m, "group.repository")
+	if !ok {
+		t.Fatal("expected group by repository to be offered")
+	}
+	c.run(&m, nil)
+	if _, ok := findCommand(&m, "group.repository"); ok {
+		t.Error("should not offer the grouping already applied")
+	}
+	if _, ok := findCommand(&m, "group."); !ok {
+		t.Error("expected ungrouping to be offered")
+	}
+}
+
+func TestPalette_ColonOpensAndEscCloses(t *testing.T) {
+	m := newPaletteTestModel()
+	result, _ := m.handleKeyPress(tea.KeyPressMsg{Code: ':', Text: ":"})
+	m = result.(Model)
+	if !m.palette.Visible() {
+		t.Fatal("expected : to open the palette")
+	}
+	result, _ = m.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = result.(Model)
+	if m.palette.Visible() {
+		t.Error("expected esc to close the palette")
+	}
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// prActionDoneMsg reports the outcome of a PR action.
+type prActionDoneMsg struct {
+	sessionID string
+	prNumber  int
+	action    string
+	deleted   bool // the head branch was deleted after merging
+	err       error
+}
+
+// PR actions offered by the PR picker, in the order they are listed.
+const (
+	prActionReady  = "ready"
+	prActionSquash = string(data.MergeSquash)
+	prActionMerge  = string(data.MergeCommit)
+	prActionRebase = string(data.MergeRebase)
+	prActionClose  = "close"
+)
+
+// prActionLabels name each action in the picker and its confirmation.
+var prActionLabels = map[string]string{
+	prActionReady:  "Mark ready for review",
+	prActionSquash: "Squash and merge",
+	prActionMerge:  "Create a merge commit",
+	prActionRebase: "Rebase and merge",
+	prActionClose:  "Close without merging",
+}
+
+// canManagePR reports whether a session's PR can be merged, marked ready or
+// closed from here: a Copilot agent task with a known PR, outside demo data
+// and replays.
+func canManagePR(m *Model, s *data.Session) bool {
+	return s != nil && !m.demo && m.replay == nil &&
+		s.Source == data.SourceAgentTask && s.PRNumber > 0 && s.Repository != ""
+}
+
+// openPRActions offers the actions that apply to the session's PR.
+func (m *Model) openPRActions(s *data.Session) {
+	if !canManagePR(m, s) {
+		if s != nil {
+			m.toast.Push("ℹ️", "Pull request", "only available for Copilot agent tasks with a PR")
+		}
+		return
+	}
+	if s.PR != nil && !s.PR.Open() {
+		m.toast.Push("ℹ️", "Pull request", fmt.Sprintf("PR #%d is already %s", s.PRNumber, s.PR.Summary()))
+		return
+	}
+	status := "status not fetched yet"
+	if s.PR != nil {
+		status = s.PR.Summary()
+	}
+	actions := []string{prActionSquash, prActionMerge, prActionRebase, prActionClose}
+	switch {
+	case s.PR == nil:
+		actions = append([]string{prActionReady}, actions...)
+	case s.PR.Draft:
+		// GitHub refuses to merge a draft; it has to be marked ready first.
+		actions = []string{prActionReady, prActionClose}
+	}
+	var items []picker.Item
+	for _, action := range actions {
+		items = append(items, picker.Item{Label: prActionLabels[action], Detail: status, Value: action})
+	}
+	items = append(items, picker.Item{Label: "Cancel", Value: "cancel"})
+	m.promptSessionID = s.ID
+	m.prAction = ""
+	m.openChooser("Pull Request", items, chooser{choose: (*Model).pickPRAction})
+}
+
+// confirmPRAction asks to confirm the chosen action and, for merges,
+// whether to delete the head branch.
+func (m *Model) confirmPRAction(action string) {
+	s := m.findSession(m.promptSessionID)
+	if s == nil {
+		return
+	}
+	target := fmt.Sprintf("PR #%d in %s", s.PRNumber, s.Repository)
+	var items []picker.Item
+	switch action {
+	case prActionReady, prActionClose:
+		items = append(items, picker.Item{Label: fmt.Sprintf("%s: PR #%d", prActionLabels[action], s.PRNumber), Detail: target, Value: "confirm"})
+	default:
+		branch := detailOr(s.Branch, "the head branch")
+		items = append(items,
+			picker.Item{Label: prActionLabels[action] + ", then delete the branch", Detail: target + " · deletes " + branch, Value: "delete"},
+			picker.Item{Label: prActionLabels[action] + ", keep the branch", Detail: target, Value: "keep"})
+	}
+	items = append(items, picker.Item{Label: "Cancel", Value: "cancel"})
+	m.prAction = action
+	m.openChooser("Pull Request", items, chooser{choose: (*Model).pickPRAction})
+}
+
+func detailOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// pickPRAction confirms the action chosen from the PR chooser, or runs it
+// once confirmed.
+func (m *Model) pickPRAction(choice picker.Item) tea.Cmd {
+	switch {
+	case choice.Value == "cancel":
+		return nil
+	case m.prAction == "":
+		m.confirmPRAction(choice.Value)
+		return nil
+	}
+	return m.runPRAction(m.prAction, choice.Value == "delete")
+}
+
+// runPRAction carries out a confirmed action on the session's PR in the
+// background.
+func (m *Model) runPRAction(action string, deleteBranch bool) tea.Cmd {
+	s := m.findSession(m.promptSessionID)
+	if s == nil {
+		return nil
+	}
+	id, repo, pr := s.ID, s.Repository, s.PRNumber
+	m.toast.Push("⏳", "Pull request", fmt.Sprintf("%s: PR #%d", prActionLabels[action], pr))
+	return func() tea.Msg {
+		var err error
+		switch action {
+		case prActionReady:
+			err = data.MarkPRReady(repo, pr)
+		case prActionClose:
+			err = data.ClosePR(repo, pr)
+		default:
+			err = data.MergePR(repo, pr, data.MergeMethod(action), deleteBranch)
+		}
+		return prActionDoneMsg{sessionID: id, prNumber: pr, action: action, deleted: deleteBranch, err: err}
+	}
+}
+
+// handlePRActionDone reports a PR action, records it in the session's
+// timeline and reloads sessions so the PR's new state shows right away.
+func (m *Model) handlePRActionDone(msg prActionDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		m.toast.Push("⚠️", "Pull request", msg.err.Error())
+		return nil
+	}
+	var event string
+	switch msg.action {
+	case prActionReady:
+		event = fmt.Sprintf("Marked PR #%d ready for review", msg.prNumber)
+	case prActionClose:
+		event = fmt.Sprintf("Closed PR #%d", msg.prNumber)
+	default:
+		event = fmt.Sprintf("Merged PR #%d (%s)", msg.prNumber, msg.action)
+		if msg.deleted {
+			event += ", deleted its branch"
+		}
+	}
+	m.toast.Push("✅", "Pull request", event)
+	m.recordSessionEvent(msg.sessionID, event)
+	return m.fetchTasks
+}
+
+// handlePRKeys handles the PR actions key in the session views. It reports
+// whether msg was that key.
+func (m *Model) handlePRKeys(msg tea.KeyPressMsg) bool {
+	if !slices.Contains(sessionModes, m.viewMode) || !key.Matches(msg, m.keys.PRActions) {
+		return false
+	}
+	m.openPRActions(m.selectedSession())
+	return true
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// prActionsTestModel adds an agent task with an open PR.
+func prActionsTestModel(t *testing.T) Model {
+	t.Helper()
+	m := annotationTestModel(t)
+	m.mergeSessions([]data.Session{{
+		ID: "task", Title: "Add retries", Status: "completed", Source: data.SourceAgentTask,
+		Repository: "org/repo", Branch: "copilot/retries", PRNumber: 5, UpdatedAt: time.Now(),
+		PR: &data.PRStatus{Number: 5, State: "OPEN", Checks: "SUCCESS", ReviewDecision: "APPROVED"},
+	}})
+	return m
+}
+
+func TestPRActions_MergeAfterConfirming(t *testing.T) {
+	m := prActionsTestModel(t)
+	m.openPRActions(m.findSession("task"))
+	if !m.pickerOpen("Pull Request") {
+		t.Fatal("expected the PR actions to be offered")
+	}
+	if item, _ := m.chooser.ItemAt(1); item.Value != prActionSquash {
+		t.Fatalf("a ready PR should not offer marking it ready, got %+v first", item)
+	}
+
+	m = pressKeys(t, m, "enter")
+	if !m.pickerOpen("Pull Request") || m.prAction != prActionSquash {
+		t.Fatal("expected squash and merge to ask for confirmation")
+	}
+	if item, _ := m.chooser.ItemAt(1); !strings.Contains(item.Detail, "copilot/retries") {
+		t.Errorf("expected the confirmation to name the branch it deletes, got %+v", item)
+	}
+	m = pressKeys(t, m, "down")
+	next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = next.(Model)
+	if cmd == nil || m.pickerOpen("Pull Request") {
+		t.Fatal("expected the confirmed merge to run")
+	}
+
+	next, _ = m.Update(prActionDoneMsg{sessionID: "task", prNumber: 5, action: prActionSquash, err: errors.New("failed to merge PR: not mergeable")})
+	m = next.(Model)
+	if !strings.Contains(ansi.Strip(m.toast.View()), "not mergeable") {
+		t.Error("expected the failure reported")
+	}
+	next, cmd = m.Update(prActionDoneMsg{sessionID: "task", prNumber: 5, action: prActionSquash})
+	m = next.(Model)
+	if cmd == nil || !strings.Contains(ansi.Strip(m.toast.View()), "Merged PR #5 (squash)") {
+		t.Error("expected a confirmation toast and a refresh")
+	}
+	if events := m.annotations.Get("task").Events; len(events) != 1 || events[0].Text != "Merged PR #5 (squash)" {
+		t.Errorf("expected the merge in the session's timeline, got %+v", events)
+	}
+}
+
+func TestPRActions_CancelAndUnavailable(t *testing.T) {
+	m := prActionsTestModel(t)
+	m.openPRActions(m.findSession("task"))
+	m = pressKeys(t, m, "5")
+	if m.pickerOpen("Pull Request") {
+		t.Error("expected cancel to close the picker")
+	}
+
+	m.openPRActions(m.findSession("a"))
+	if m.pickerOpen("Pull Request") || !strings.Contains(ansi.Strip(m.toast.View()), "only available for Copilot agent tasks") {
+		t.Error("expected local sessions to be refused")
+	}
+
+	s := m.findSession("task")
+	s.PR.Draft = true
+	m.openPRActions(s)
+	var offered []string
+	for i := 1; ; i++ {
+		item, ok := m.chooser.ItemAt(i)
+		if !ok {
+			break
+		}
+		offered = append(offered, item.Value)
+	}
+	if strings.Join(offered, " ") != "ready close cancel" {
+		t.Errorf("expected a draft to offer only marking it ready or closing it, got %v", offered)
+	}
+	m.chooser.Close()
+
+	s.PR.Draft = false
+	s.PR.State = "MERGED"
+	m.openPRActions(s)
+	if m.pickerOpen("Pull Request") || !strings.Contains(ansi.Strip(m.toast.View()), "already merged") {
+		t.Error("expected a merged PR to be refused")
+	}
+}
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/resources"
+)
+
+// processStopTimeout is how long a session process gets to exit after
+// SIGINT, and again after SIGTERM.
+const processStopTimeout = 5 * time.Second
+
+// processLocksMsg carries the lock files of the session whose process is
+// about to be stopped.
+type processLocksMsg struct {
+	sessionID string
+	locks     data.SessionLocks
+	err       error
+}
+
+// processStoppedMsg reports the outcome of stopping a session process.
+type processStoppedMsg struct {
+	sessionID string
+	result    data.StopResult
+	err       error
+}
+
+// staleLocksRemovedMsg reports a stale lock file cleanup.
+type staleLocksRemovedMsg struct {
+	sessionID string
+	removed   int
+	err       error
+}
+
+// canManageProcess reports whether a session's process can be stopped from
+// here: a live local session read from ~/.copilot on this machine, outside
+// demo data and replays. Sessions from other roots were synced from
+// elsewhere, so their lock PIDs aren't this machine's.
+func canManageProcess(m *Model, s *data.Session) bool {
+	return s != nil && !m.demo && m.replay == nil &&
+		s.Source == data.SourceLocalCopilot && s.Host == "" && s.Origin == "" && !s.Archived
+}
+
+// inspectSessionProcess reads the session's lock files so the stop
+// confirmation can describe the processes holding them.
+func (m *Model) inspectSessionProcess(s *data.Session) tea.Cmd {
+	if !canManageProcess(m, s) {
+		if s != nil {
+			m.toast.Push("ℹ️", "Stop process", "only available for local Copilot sessions on this machine")
+		}
+		return nil
+	}
+	id := s.ID
+	return func() tea.Msg {
+		locks, err := data.InspectSessionLocks(id)
+		return processLocksMsg{sessionID: id, locks: locks, err: err}
+	}
+}
+
+// handleProcessLocks asks to confirm stopping the session's process, or
+// removing its stale lock files, once they have been read.
+func (m *Model) handleProcessLocks(msg processLocksMsg) {
+	if msg.err != nil {
+		m.toast.Push("⚠️", "Stop process", msg.err.Error())
+		return
+	}
+	if len(msg.locks.Live) == 0 && len(msg.locks.Stale) == 0 {
+		m.toast.Push("ℹ️", "Stop process", "no process holds this session's lock")
+		return
+	}
+	var items []picker.Item
+	for _, p := range msg.locks.Live {
+		items = append(items, picker.Item{
+			Label:  fmt.Sprintf("Stop PID %d (SIGINT, then SIGTERM)", p.PID),
+			Detail: processDetail(p),
+			Value:  strconv.Itoa(p.PID),
+		})
+	}
+	if n := len(msg.locks.Stale); n > 0 {
+		items = append(items, picker.Item{
+			Label:  fmt.Sprintf("Remove %d stale lock file(s)", n),
+			Detail: "left behind by processes that have exited",
+			Value:  "stale",
+		})
+	}
+	items = append(items, picker.Item{Label: "Cancel", Value: "cancel"})
+	m.promptSessionID = msg.sessionID
+	m.openChooser("Stop Session Process", items, chooser{choose: (*Model).runProcessChoice})
+}
+
+// processDetail summarizes a process for the stop confirmation.
+func processDetail(p data.SessionProcess) string {
+	var parts []string
+	if p.Command != "" {
+		parts = append(parts, truncateRunes(p.Command, 60))
+	}
+	if p.Uptime > 0 {
+		parts = append(parts, "up "+formatUptime(p.Uptime))
+		parts = append(parts, fmt.Sprintf("CPU %.1f%%", p.CPUPercent()))
+	}
+	if p.RSS > 0 {
+		parts = append(parts, "RSS "+resources.FormatBytes(p.RSS))
+	}
+	if len(parts) == 0 {
+		return "process details unavailable"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatUptime renders a duration as its two largest units, e.g. 2h05m.
+func formatUptime(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd%02dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%ds", int(d.Seconds()))
+}
+
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+// runProcessChoice carries out the confirmed choice in the background;
+// stopping a process can take up to twice processStopTimeout.
+func (m *Model) runProcessChoice(choice picker.Item) tea.Cmd {
+	id := m.promptSessionID
+	switch choice.Value {
+	case "cancel":
+		return nil
+	case "stale":
+		return func() tea.Msg {
+			n, err := data.CleanStaleLocks(id)
+			return staleLocksRemovedMsg{sessionID: id, removed: n, err: err}
+		}
+	}
+	pid, err := strconv.Atoi(choice.Value)
+	if err != nil {
+		return nil
+	}
+	m.toast.Push("⏳", "Stop process", fmt.Sprintf("sending SIGINT to PID %d", pid))
+	return func() tea.Msg {
+		result, err := data.StopSessionProcess(id, pid, processStopTimeout)
+		return processStoppedMsg{sessionID: id, result: result, err: err}
+	}
+}
+
+// handleProcessStopped reports a stop, records it in the session's
+// timeline and reloads sessions so the new status shows.
+func (m *Model) handleProcessStopped(msg processStoppedMsg) tea.Cmd {
+	var event string
+	switch {
+	case msg.err != nil && msg.result.PID == 0:
+		m.toast.Push("⚠️", "Stop process", msg.err.Error())
+		return nil
+	case msg.result.Signal == "":
+		event = fmt.Sprintf("Failed to stop PID %d: %v", msg.result.PID, msg.err)
+		m.toast.Push("⚠️", "Stop process", msg.err.Error())
+	default:
+		event = fmt.Sprintf("Stopped PID %d with %s", msg.result.PID, msg.result.Signal)
+		if msg.result.LocksRemoved > 0 {
+			event += fmt.Sprintf(", removed %d stale lock file(s)", msg.result.LocksRemoved)
+		}
+		m.toast.Push("🛑", "Stop process", event)
+	}
+	m.recordSessionEvent(msg.sessionID, event)
+	return m.fetchTasks
+}
+
+// handleStaleLocksRemoved reports a stale lock cleanup and records it in the
+// session's timeline.
+func (m *Model) handleStaleLocksRemoved(msg staleLocksRemovedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.toast.Push("⚠️", "Stale locks", msg.err.Error())
+		return nil
+	}
+	event := fmt.Sprintf("Removed %d stale lock file(s)", msg.removed)
+	m.toast.Push("🧹", "Stale locks", event)
+	m.recordSessionEvent(msg.sessionID, event)
+	return m.fetchTasks
+}
+
+// recordSessionEvent adds an action to a session's timeline.
+func (m *Model) recordSessionEvent(id, text string) {
+	if m.annotations == nil || id == "" {
+		return
+	}
+	m.annotations.RecordEvent(id, text)
+	m.refreshAnnotations()
+}
+
+// handleProcessKeys handles the stop-process key in the session views. It
+// reports whether msg was that key.
+func (m *Model) handleProcessKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if !slices.Contains(sessionModes, m.viewMode) || !key.Matches(msg, m.keys.StopProcess) {
+		return nil, false
+	}
+	return m.inspectSessionProcess(m.selectedSession()), true
+}
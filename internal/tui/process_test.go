@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func TestStopProcessConfirmsAndRecords(t *testing.T) {
+	m := annotationTestModel(t)
+	m.allSessions[0].Source = data.SourceLocalCopilot
+
+	m.handleProcessLocks(processLocksMsg{sessionID: "a", locks: data.SessionLocks{
+		Live:  []data.SessionProcess{{PID: 4242, Command: "node copilot", Uptime: 2 * time.Hour, CPUTime: 36 * time.Second, RSS: 200 << 20}},
+		Stale: []string{"/tmp/inuse.1111.lock"},
+	}})
+	if !m.pickerOpen("Stop Session Process") {
+		t.Fatal("expected the stop confirmation to open")
+	}
+	view := ansi.Strip(m.chooser.View())
+	for _, want := range []string{"Stop PID 4242", "node copilot · up 2h00m · CPU 0.5%", "Remove 1 stale lock file(s)", "Cancel"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the confirmation, got:\n%s", want, view)
+		}
+	}
+
+	m = pressKeys(t, m, "3") // cancel
+	if m.pickerOpen("Stop Session Process") {
+		t.Error("expected cancel to close the confirmation")
+	}
+
+	m.handleProcessStopped(processStoppedMsg{sessionID: "a", result: data.StopResult{PID: 4242, Signal: "SIGINT", LocksRemoved: 2}})
+	m.handleProcessStopped(processStoppedMsg{sessionID: "a", result: data.StopResult{PID: 4343}, err: errors.New("still running")})
+	events := m.annotations.Get("a").Events
+	if len(events) != 2 ||
+		events[0].Text != "Stopped PID 4242 with SIGINT, removed 2 stale lock file(s)" ||
+		!strings.HasPrefix(events[1].Text, "Failed to stop PID 4343") {
+		t.Errorf("expected both outcomes in the session's timeline, got %+v", events)
+	}
+}
+
+func TestStopProcessUnavailableForRemoteSessions(t *testing.T) {
+	m := annotationTestModel(t)
+	remote := &data.Session{ID: "r", Source: data.SourceLocalCopilot, Host: "devbox"}
+	if canManageProcess(&m, remote) {
+		t.Error("expected sessions on other hosts to be refused")
+	}
+	if cmd := m.inspectSessionProcess(remote); cmd != nil {
+		t.Error("expected no process lookup for a remote session")
+	}
+	if canManageProcess(&m, &data.Session{ID: "s", Source: data.SourceLocalCopilot, Origin: "devbox"}) {
+		t.Error("expected sessions from other Copilot roots to be refused")
+	}
+	if !canManageProcess(&m, &data.Session{ID: "l", Source: data.SourceLocalCopilot}) {
+		t.Error("expected local sessions to be manageable")
+	}
+}
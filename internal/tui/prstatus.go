@@ -0,0 +1,59 @@
+package tui
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// prStatusLoadedMsg carries the PR status of sessions with a PR.
+type prStatusLoadedMsg struct {
+	statuses map[string]data.PRStatus
+	err      error
+}
+
+// loadPRStatus fetches checks, reviews and state of the sessions' PRs in
+// the background. Statuses are cached by the data layer, so calling it on
+// every refresh only asks GitHub about PRs that may have changed.
+func (m Model) loadPRStatus() tea.Cmd {
+	if m.demo || m.replay != nil || len(m.allSessions) == 0 {
+		return nil
+	}
+	sessions := append([]data.Session(nil), m.allSessions...)
+	return func() tea.Msg {
+		statuses, err := data.FetchPRStatuses(sessions)
+		return prStatusLoadedMsg{statuses: statuses, err: err}
+	}
+}
+
+// applyPRStatus stores fetched PR statuses and redraws with them. A failed
+// fetch keeps the statuses already shown.
+func (m *Model) applyPRStatus(msg prStatusLoadedMsg) {
+	if msg.err != nil && m.ctx.Debug {
+		m.toast.Push("⚠️", "PR status", msg.err.Error())
+	}
+	if len(msg.statuses) == 0 {
+		return
+	}
+	m.prStatus = msg.statuses
+	m.attachPRStatus(m.allSessions)
+	m.lastFingerprint = ""
+	m.lastSplitTaskID = ""
+	m.recomputeAndDisplay(m.annotateSessions(m.allSessions))
+}
+
+// attachPRStatus copies the last fetched PR statuses onto sessions, so
+// reloaded sessions keep them until the next fetch. Sessions found by
+// branch also learn their PR's number and URL.
+func (m Model) attachPRStatus(sessions []data.Session) {
+	for i := range sessions {
+		pr, ok := m.prStatus[sessions[i].ID]
+		if !ok {
+			continue
+		}
+		sessions[i].PR = &pr
+		if sessions[i].PRNumber == 0 {
+			sessions[i].PRNumber = pr.Number
+			sessions[i].PRURL = pr.URL
+		}
+	}
+}
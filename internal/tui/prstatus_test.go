@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func TestPRStatusLoadedFlagsAttention(t *testing.T) {
+	m := annotationTestModel(t)
+	if m.loadPRStatus() == nil {
+		t.Fatal("expected PR statuses to be fetched for live data")
+	}
+
+	statuses := map[string]data.PRStatus{"b": {Number: 7, URL: "https://github.com/org/repo/pull/7", State: "OPEN", Checks: "FAILURE"}}
+	next, _ := m.Update(prStatusLoadedMsg{statuses: statuses})
+	m = next.(Model)
+	b := m.allSessions[1]
+	if b.PR == nil || b.PRNumber != 7 || b.PRURL == "" {
+		t.Fatalf("expected the PR status and number on the session, got %+v", b)
+	}
+	if m.ctx.Counts.Warning != 1 {
+		t.Errorf("expected the failing PR counted as a warning, got %+v", m.ctx.Counts)
+	}
+
+	// A data refresh replaces the session but keeps its last fetched status,
+	// and a failed fetch does not drop it either.
+	m.mergeSessions([]data.Session{{ID: "b", Title: "Old docs", Status: "completed"}})
+	next, _ = m.Update(prStatusLoadedMsg{})
+	m = next.(Model)
+	if m.allSessions[1].PR == nil {
+		t.Errorf("expected the PR status to survive a reload, got %+v", m.allSessions[1])
+	}
+
+	m.demo = true
+	if m.loadPRStatus() != nil {
+		t.Error("expected no fetches for demo data")
+	}
+}
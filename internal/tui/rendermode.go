@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+)
+
+// renderFlags holds the --plain and --accessible command-line flags.
+var renderFlags a11y.Mode
+
+// SetRenderFlags records the --plain and --accessible flags. Call it before
+// NewModel; the flags add to whatever the config file enables.
+func SetRenderFlags(plain, accessible bool) {
+	renderFlags = a11y.Mode{Plain: plain, Accessible: accessible}
+}
+
+// applyRenderMode installs the rendering mode chosen by flags, config and
+// NO_COLOR. Accessible mode turns off animations and the banner, which
+// screen readers would otherwise re-read on every frame.
+func applyRenderMode(cfg *config.Config) {
+	mode := a11y.Mode{
+		Plain:      renderFlags.Plain || cfg.PlainEnabled(),
+		Accessible: renderFlags.Accessible || cfg.Accessible,
+	}
+	a11y.Set(mode)
+	if mode.ASCII() {
+		off := false
+		cfg.AsciiHeader = &off
+	}
+	if mode.Accessible {
+		off := false
+		cfg.Animations = &off
+	}
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+)
+
+func TestApplyRenderMode(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Cleanup(func() {
+		SetRenderFlags(false, false)
+		a11y.Set(a11y.Mode{})
+	})
+
+	cfg := config.DefaultConfig()
+	applyRenderMode(cfg)
+	if a11y.Current() != (a11y.Mode{}) {
+		t.Errorf("expected the default mode, got %+v", a11y.Current())
+	}
+	if !cfg.AnimationsEnabled() || !cfg.AsciiHeaderEnabled() {
+		t.Error("the default mode should keep animations and the banner")
+	}
+
+	SetRenderFlags(false, true)
+	applyRenderMode(cfg)
+	if !a11y.Current().Accessible {
+		t.Error("expected --accessible to enable accessible mode")
+	}
+	if cfg.AnimationsEnabled() || cfg.AsciiHeaderEnabled() {
+		t.Error("accessible mode should turn off animations and the banner")
+	}
+
+	SetRenderFlags(false, false)
+	t.Setenv("NO_COLOR", "1")
+	cfg = config.DefaultConfig()
+	applyRenderMode(cfg)
+	if mode := a11y.Current(); !mode.Plain || mode.Accessible {
+		t.Errorf("expected NO_COLOR to enable plain mode only, got %+v", mode)
+	}
+	if !cfg.AnimationsEnabled() {
+		t.Error("plain mode should keep animations")
+	}
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"gopkg.in/yaml.v3"
+)
+
+// NewReplayModel creates a TUI model that renders the sessions, token usage
+// and config recorded in snap instead of fetching live data. The clock is
+// pinned to the moment of capture so ages and trends look as they did then.
+func NewReplayModel(snap *data.Snapshot, snapshotPath string, version string) (Model, error) {
+	if snap == nil || snap.State == nil {
+		return Model{}, fmt.Errorf("snapshot has no session state to replay")
+	}
+	cfg, err := config.Parse([]byte(snap.State.Config))
+	if err != nil {
+		return Model{}, fmt.Errorf("snapshot config: %w", err)
+	}
+	if at := snap.CapturedAt(); !at.IsZero() {
+		data.Now = func() time.Time { return at }
+	}
+
+	m := newModel(cfg, nil, snap, snap.State.Repo, false, false, snapshotPath, version)
+	if isValidFilter(snap.State.StatusFilter) {
+		m.ctx.StatusFilter = snap.State.StatusFilter
+		m.statusPinned = true
+	}
+	if mode, ok := viewModeFromConfig(snap.ViewMode); ok && snap.ViewMode != "" {
+		m.viewMode = mode
+	}
+	m.toast.Push("⏪", "Replay", "Snapshot from "+snap.Timestamp)
+	return m, nil
+}
+
+// replaySessions returns the sessions recorded in the replayed snapshot.
+func (m Model) replaySessions() []data.Session {
+	return m.replay.State.SessionList()
+}
+
+// replayAnnotations returns the annotations recorded in a snapshot. Snapshots
+// from before annotations existed record only dismissed IDs; those are dated
+// by the capture time.
+func replayAnnotations(snap *data.Snapshot) map[string]*data.Annotation {
+	if snap.State.Annotations != nil {
+		return snap.State.Annotations
+	}
+	at := snap.CapturedAt()
+	if at.IsZero() {
+		at = time.Now()
+	}
+	out := make(map[string]*data.Annotation, len(snap.State.Dismissed))
+	for _, id := range snap.State.Dismissed {
+		out[id] = &data.Annotation{DismissedAt: at}
+	}
+	return out
+}
+
+// replayUnavailable reports data a snapshot does not record, such as logs.
+func replayUnavailable(what string) error {
+	return fmt.Errorf("%s not available when replaying a snapshot", what)
+}
+
+// snapshotState records everything needed to replay the current state.
+func (m Model) snapshotState() *data.SnapshotState {
+	records := make([]data.SessionRecord, len(m.allSessions))
+	for i, s := range m.allSessions {
+		records[i] = data.NewSessionRecord(s)
+	}
+	var dismissed []string
+	var annotations map[string]*data.Annotation
+	if m.annotations != nil {
+		for id := range m.annotations.DismissedIDs() {
+			dismissed = append(dismissed, id)
+		}
+		sort.Strings(dismissed)
+		if all := m.annotations.All(); len(all) > 0 {
+			annotations = all
+		}
+	}
+	cfg, _ := yaml.Marshal(m.ctx.Config)
+	return &data.SnapshotState{
+		Sessions:     records,
+		TokenUsage:   m.tokenUsageMap,
+		Dismissed:    dismissed,
+		Annotations:  annotations,
+		StatusFilter: m.ctx.StatusFilter,
+		Repo:         m.repo,
+		Config:       string(cfg),
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// resourceSampleInterval is how often live session processes are sampled
+// while the active view or a session's detail is shown.
+const resourceSampleInterval = 2 * time.Second
+
+// resourceTickMsg triggers the next resource sample.
+type resourceTickMsg struct{}
+
+// resourcesSampledMsg carries the process resources of live local sessions.
+type resourcesSampledMsg struct {
+	resources map[string]data.SessionResources
+}
+
+// canSampleResources reports whether process resources can be read: only
+// for real sessions on this machine, not demo data or replays.
+func (m Model) canSampleResources() bool {
+	return m.resourceMonitor != nil && !m.demo && m.replay == nil
+}
+
+func (m Model) resourceTick() tea.Cmd {
+	return tea.Tick(resourceSampleInterval, func(time.Time) tea.Msg {
+		return resourceTickMsg{}
+	})
+}
+
+// handleResourceTick samples resources when a view that shows them is open
+// and schedules the next tick.
+func (m Model) handleResourceTick() tea.Cmd {
+	if m.viewMode != ViewModeActive && m.viewMode != ViewModeDetail {
+		return m.resourceTick()
+	}
+	ids := m.resourceSessionIDs()
+	if len(ids) == 0 {
+		return m.resourceTick()
+	}
+	monitor := m.resourceMonitor
+	return tea.Batch(m.resourceTick(), func() tea.Msg {
+		return resourcesSampledMsg{resources: monitor.Sample(ids)}
+	})
+}
+
+// resourceSessionIDs lists the live sessions whose processes are sampled:
+// those in ~/.copilot on this machine, as for stopping a process, since
+// the lock PIDs of sessions from other roots belong to other machines.
+func (m Model) resourceSessionIDs() []string {
+	var ids []string
+	for i := range m.allSessions {
+		s := &m.allSessions[i]
+		if canManageProcess(&m, s) && (data.StatusIsActive(s.Status) || strings.EqualFold(s.Status, "needs-input")) {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
+
+// applyResources hands sampled resources to the views that show them.
+func (m *Model) applyResources(resources map[string]data.SessionResources) {
+	m.activeView.SetResources(resources)
+	m.taskDetail.SetResources(resources)
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func TestResourcesSampledReachViews(t *testing.T) {
+	m := annotationTestModel(t)
+	if !m.canSampleResources() {
+		t.Fatal("expected resources to be sampled for live data")
+	}
+	m.demo = true
+	if m.canSampleResources() {
+		t.Error("expected no sampling for demo data")
+	}
+
+	res := map[string]data.SessionResources{"a": {History: []data.ResourceSample{{CPUPercent: 5, Processes: 1}}}}
+	next, _ := m.Update(resourcesSampledMsg{resources: res})
+	m = next.(Model)
+	m.viewMode = ViewModeDetail
+	m.taskDetail.SetTask(&m.allSessions[0])
+	m.taskDetail.SetSize(100, 40)
+	if view := m.taskDetail.View(); !strings.Contains(view, "Resources") {
+		t.Errorf("expected the sampled resources in the detail view, got %s", view)
+	}
+}
+
+func TestResourcesSampledOnlyForDefaultRoot(t *testing.T) {
+	m := annotationTestModel(t)
+	m.allSessions = []data.Session{
+		{ID: "here", Status: "running", Source: data.SourceLocalCopilot},
+		{ID: "synced", Status: "running", Source: data.SourceLocalCopilot, Origin: "devbox"},
+		{ID: "remote", Status: "running", Source: data.SourceLocalCopilot, Host: "devbox"},
+		{ID: "done", Status: "completed", Source: data.SourceLocalCopilot},
+	}
+	if ids := m.resourceSessionIDs(); len(ids) != 1 || ids[0] != "here" {
+		t.Errorf("expected only the live session in ~/.copilot sampled, got %v", ids)
+	}
+}
@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// reviewSubmittedMsg reports the outcome of submitting a PR review.
+type reviewSubmittedMsg struct {
+	event    data.ReviewEvent
+	comments int
+	err      error
+}
+
+// copilotRequestedMsg reports the outcome of asking Copilot to iterate.
+type copilotRequestedMsg struct {
+	prNumber int
+	err      error
+}
+
+// Fields the review prompt can ask for.
+const (
+	reviewFieldComment = "comment"
+	reviewFieldBody    = "body"
+	reviewFieldCopilot = "copilot"
+)
+
+// canReview reports whether the diff view shows a PR that can be reviewed
+// from here; demo diffs and replays cannot.
+func (m *Model) canReview() bool {
+	return m.viewMode == ViewModeDiff && m.diffPR > 0 && m.diffRepo != "" && !m.demo && m.replay == nil
+}
+
+// handleReviewKeys handles the review keys in the diff view. It reports
+// whether msg was one of them.
+func (m *Model) handleReviewKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, m.keys.CommentLine):
+		m.openCommentPrompt()
+	case key.Matches(msg, m.keys.DiscardComment):
+		if n := m.diffView.RemoveComments(); n > 0 {
+			m.toast.Push("🗑", "Review", fmt.Sprintf("discarded %d pending comment(s)", n))
+		}
+	case key.Matches(msg, m.keys.SubmitReview):
+		m.openReviewPicker()
+	case key.Matches(msg, m.keys.AskCopilot):
+		m.openCopilotPrompt()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// reviewUnavailable explains why the review actions cannot be used.
+func (m *Model) reviewUnavailable() bool {
+	if m.canReview() {
+		return false
+	}
+	m.toast.Push("ℹ️", "Review", "only available for a loaded PR diff")
+	return true
+}
+
+// openCommentPrompt asks for a comment on the line under the cursor.
+func (m *Model) openCommentPrompt() {
+	if m.reviewUnavailable() {
+		return
+	}
+	path, line, ok := m.diffView.SelectedLine()
+	if !ok || !diffview.Commentable(line) {
+		m.toast.Push("ℹ️", "Review", "move the cursor to a code line to comment on it")
+		return
+	}
+	m.openReviewPrompt(reviewFieldComment, fmt.Sprintf("Comment on %s:%d", path, line.Number()),
+		fmt.Sprintf("Kept as pending until you submit a review with %s.", firstKey(m.keys.SubmitReview)))
+}
+
+// openReviewPicker offers the review verdicts.
+func (m *Model) openReviewPicker() {
+	if m.reviewUnavailable() {
+		return
+	}
+	pending := "no line comments"
+	if n := len(m.diffView.Comments()); n > 0 {
+		pending = fmt.Sprintf("with %d line comment(s)", n)
+	}
+	m.openChooser("Submit Review", []picker.Item{
+		{Label: "Comment", Detail: pending, Value: string(data.ReviewComment)},
+		{Label: "Approve", Detail: pending, Value: string(data.ReviewApprove)},
+		{Label: "Request changes", Detail: pending, Value: string(data.ReviewRequestChanges)},
+		{Label: "Cancel", Value: "cancel"},
+	}, chooser{choose: (*Model).pickReviewVerdict})
+}
+
+// openCopilotPrompt asks for instructions to send Copilot.
+func (m *Model) openCopilotPrompt() {
+	if m.reviewUnavailable() {
+		return
+	}
+	m.openReviewPrompt(reviewFieldCopilot, fmt.Sprintf("Ask Copilot to iterate on PR #%d", m.diffPR),
+		"Posted as an @copilot comment. Leave empty to ask it to address the review feedback.")
+}
+
+func (m *Model) openReviewPrompt(field, title, hint string) {
+	m.reviewField = field
+	m.reviewPrompt.SetSize(m.ctx.Width, m.ctx.Height)
+	m.reviewPrompt.Open(title, hint, "")
+}
+
+// pickReviewVerdict asks for the body of a review with the chosen verdict.
+func (m *Model) pickReviewVerdict(choice picker.Item) tea.Cmd {
+	if choice.Value == "cancel" {
+		return nil
+	}
+	m.reviewEvent = data.ReviewEvent(choice.Value)
+	hint := "Optional."
+	switch {
+	case m.reviewEvent == data.ReviewRequestChanges:
+		hint = "Required: explain what needs to change."
+	case m.reviewEvent == data.ReviewComment && len(m.diffView.Comments()) == 0:
+		hint = "Required without line comments."
+	}
+	m.openReviewPrompt(reviewFieldBody, choice.Label+" review of PR #"+fmt.Sprint(m.diffPR), hint)
+	return nil
+}
+
+// handleReviewPromptKeys captures text for a line comment, a review body
+// or a request to Copilot.
+func (m Model) handleReviewPromptKeys(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	case msg.Code == tea.KeyEscape:
+		m.reviewPrompt.Close()
+	case msg.Code == tea.KeyEnter:
+		m.reviewPrompt.Close()
+		return m, m.saveReviewPrompt()
+	case msg.Code == tea.KeyBackspace:
+		m.reviewPrompt.Backspace()
+	case msg.String() == "ctrl+u":
+		m.reviewPrompt.Clear()
+	case msg.Text != "":
+		m.reviewPrompt.Insert(msg.Text)
+	}
+	return m, nil
+}
+
+// saveReviewPrompt acts on what was typed into the review prompt.
+func (m *Model) saveReviewPrompt() tea.Cmd {
+	value := m.reviewPrompt.Value()
+	switch m.reviewField {
+	case reviewFieldComment:
+		m.diffView.AddComment(value)
+	case reviewFieldBody:
+		return m.submitReview(m.reviewEvent, value)
+	case reviewFieldCopilot:
+		return m.requestCopilot(value)
+	}
+	return nil
+}
+
+// submitReview posts the review with the pending line comments.
+func (m *Model) submitReview(event data.ReviewEvent, body string) tea.Cmd {
+	repo, pr := m.diffRepo, m.diffPR
+	var comments []data.LineComment
+	for _, c := range m.diffView.Comments() {
+		comments = append(comments, data.LineComment{Path: c.Path, Line: c.Line.Number(), Side: c.Line.Side(), Body: c.Body})
+	}
+	m.toast.Push("⏳", "Review", fmt.Sprintf("submitting to PR #%d", pr))
+	return func() tea.Msg {
+		err := data.SubmitPRReview(repo, pr, event, body, comments)
+		return reviewSubmittedMsg{event: event, comments: len(comments), err: err}
+	}
+}
+
+// handleReviewSubmitted reports a submitted review and drops the comments
+// it carried; they are kept for another try when it failed.
+func (m *Model) handleReviewSubmitted(msg reviewSubmittedMsg) {
+	if msg.err != nil {
+		m.toast.Push("⚠️", "Review", msg.err.Error())
+		return
+	}
+	verdict := map[data.ReviewEvent]string{
+		data.ReviewApprove:        "Approved",
+		data.ReviewRequestChanges: "Requested changes",
+		data.ReviewComment:        "Commented",
+	}[msg.event]
+	m.diffView.ClearComments()
+	m.toast.Push("✅", "Review", fmt.Sprintf("%s with %d line comment(s)", verdict, msg.comments))
+}
+
+// requestCopilot mentions Copilot on the PR with the instructions.
+func (m *Model) requestCopilot(instructions string) tea.Cmd {
+	repo, pr := m.diffRepo, m.diffPR
+	return func() tea.Msg {
+		return copilotRequestedMsg{prNumber: pr, err: data.RequestCopilotIteration(repo, pr, instructions)}
+	}
+}
+
+func (m *Model) handleCopilotRequested(msg copilotRequestedMsg) {
+	if msg.err != nil {
+		m.toast.Push("⚠️", "Copilot", msg.err.Error())
+		return
+	}
+	m.toast.Push("🤖", "Copilot", fmt.Sprintf("asked to iterate on PR #%d", msg.prNumber))
+}
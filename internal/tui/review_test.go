@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
+)
+
+const reviewTestDiff = "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1,2 +1,2 @@\n package a\n-var x = 1\n+var x = 2\n"
+
+// reviewTestModel shows a loaded PR diff with the cursor on its hunk header.
+func reviewTestModel(t *testing.T) Model {
+	t.Helper()
+	m := annotationTestModel(t)
+	m.viewMode = ViewModeDiff
+	next, _ := m.Update(diffLoadedMsg{files: diffview.ParseUnifiedDiff(reviewTestDiff), repo: "org/repo", prNumber: 9})
+	return next.(Model)
+}
+
+func TestReview_CommentThenSubmit(t *testing.T) {
+	m := reviewTestModel(t)
+
+	// Two lines down from the hunk header is the removed line
+	m = pressKeys(t, m, "j", "j", "c")
+	if !m.reviewPrompt.Visible() {
+		t.Fatal("expected c to ask for a line comment")
+	}
+	m = pressKeys(t, m, "w", "h", "y", "?", "enter")
+	comments := m.diffView.Comments()
+	if len(comments) != 1 || comments[0].Body != "why?" || comments[0].Line.Side() != "LEFT" || comments[0].Line.Number() != 2 {
+		t.Fatalf("expected a pending comment on the removed line, got %+v", comments)
+	}
+
+	m = pressKeys(t, m, "R")
+	if !m.pickerOpen("Submit Review") {
+		t.Fatal("expected R to offer review verdicts")
+	}
+	m = pressKeys(t, m, "down", "down", "enter")
+	if m.reviewEvent != data.ReviewRequestChanges || !m.reviewPrompt.Visible() {
+		t.Fatalf("expected a body prompt for requesting changes, got event %q", m.reviewEvent)
+	}
+	m = pressKeys(t, m, "f", "i", "x")
+	next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = next.(Model)
+	if cmd == nil {
+		t.Fatal("expected enter to submit the review")
+	}
+
+	// A failed submission keeps the comments for another try
+	next, _ = m.Update(reviewSubmittedMsg{event: data.ReviewRequestChanges, comments: 1, err: errors.New("HTTP 422")})
+	m = next.(Model)
+	if len(m.diffView.Comments()) != 1 || !strings.Contains(ansi.Strip(m.toast.View()), "HTTP 422") {
+		t.Fatal("expected the error reported and the comments kept")
+	}
+	next, _ = m.Update(reviewSubmittedMsg{event: data.ReviewRequestChanges, comments: 1})
+	m = next.(Model)
+	if len(m.diffView.Comments()) != 0 || !strings.Contains(ansi.Strip(m.toast.View()), "Requested changes") {
+		t.Fatal("expected a submitted review to clear the pending comments")
+	}
+}
+
+func TestReview_AskCopilot(t *testing.T) {
+	m := reviewTestModel(t)
+	m = pressKeys(t, m, "@")
+	if !m.reviewPrompt.Visible() || m.reviewField != reviewFieldCopilot {
+		t.Fatal("expected @ to ask for instructions for Copilot")
+	}
+	next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to post the request")
+	}
+	next, _ = next.(Model).Update(copilotRequestedMsg{prNumber: 9})
+	if !strings.Contains(ansi.Strip(next.(Model).toast.View()), "asked to iterate on PR #9") {
+		t.Errorf("expected a confirmation toast, got %q", ansi.Strip(next.(Model).toast.View()))
+	}
+}
+
+func TestReview_UnavailableWithoutPR(t *testing.T) {
+	m := annotationTestModel(t)
+	m.viewMode = ViewModeDiff
+	m.diffView.SetDiffs(diffview.ParseUnifiedDiff(reviewTestDiff))
+	m = pressKeys(t, m, "R")
+	if m.pickerOpen("Submit Review") || !strings.Contains(ansi.Strip(m.toast.View()), "only available for a loaded PR diff") {
+		t.Error("expected review actions to be refused without a PR")
+	}
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// activityRefreshInterval is how often the log tails of live sessions are
+// re-read while the active view shows them.
+const activityRefreshInterval = 2 * time.Second
+
+// activityTickMsg triggers the next read of live sessions' activity.
+type activityTickMsg struct{}
+
+// activityLoadedMsg carries the recent activity of live local sessions.
+type activityLoadedMsg struct {
+	activity map[string]data.SessionActivity
+}
+
+// canLoadActivity reports whether session event files can be read: only
+// for real sessions, not demo data or replays.
+func (m Model) canLoadActivity() bool {
+	return !m.demo && m.replay == nil
+}
+
+func (m Model) activityTick() tea.Cmd {
+	return tea.Tick(activityRefreshInterval, func(time.Time) tea.Msg {
+		return activityTickMsg{}
+	})
+}
+
+// loadActivity reads the last action, last message and log tail of live
+// local sessions in the background, so views never touch disk while
+// rendering.
+func (m Model) loadActivity() tea.Cmd {
+	if !m.canLoadActivity() {
+		return nil
+	}
+	var live []data.Session
+	for _, s := range m.allSessions {
+		if data.StatusIsActive(s.Status) || strings.EqualFold(s.Status, "needs-input") {
+			live = append(live, s)
+		}
+	}
+	if len(live) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		activity := make(map[string]data.SessionActivity, len(live))
+		for _, s := range live {
+			if a, ok := data.ReadSessionActivity(s); ok {
+				activity[s.ID] = a
+			}
+		}
+		return activityLoadedMsg{activity: activity}
+	}
+}
+
+// handleActivityTick re-reads activity while the active view, which shows
+// log tails, is open and schedules the next tick.
+func (m Model) handleActivityTick() tea.Cmd {
+	if m.viewMode != ViewModeActive {
+		return m.activityTick()
+	}
+	return tea.Batch(m.activityTick(), m.loadActivity())
+}
+
+// applyActivity stores freshly read activity and redraws with it.
+func (m *Model) applyActivity(activity map[string]data.SessionActivity) {
+	m.activity = activity
+	m.attachActivity(m.allSessions)
+	m.lastFingerprint = ""
+	m.lastSplitTaskID = ""
+	m.recomputeAndDisplay(m.annotateSessions(m.allSessions))
+}
+
+// attachActivity copies the last read activity onto sessions, so reloaded
+// sessions keep it until the next read.
+func (m Model) attachActivity(sessions []data.Session) {
+	for i := range sessions {
+		if a, ok := m.activity[sessions[i].ID]; ok {
+			a.ApplyTo(&sessions[i])
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func TestActivityLoadedReachesActiveView(t *testing.T) {
+	m := annotationTestModel(t)
+	if !m.canLoadActivity() {
+		t.Fatal("expected activity to be read for live data")
+	}
+	if m.loadActivity() == nil {
+		t.Fatal("expected a read for the running session")
+	}
+
+	activity := map[string]data.SessionActivity{"a": {LastAction: "🔧 bash", LogTail: []string{"🔧 bash"}}}
+	next, _ := m.Update(activityLoadedMsg{activity: activity})
+	m = next.(Model)
+	if m.allSessions[0].LastAction != "🔧 bash" {
+		t.Fatalf("expected the activity on the session, got %+v", m.allSessions[0])
+	}
+
+	// A data refresh replaces the session but keeps its last read activity.
+	m.mergeSessions([]data.Session{{ID: "a", Title: "Fix login", Status: "running", Source: data.SourceLocalCopilot}})
+	if len(m.allSessions[0].LogTail) != 1 {
+		t.Errorf("expected the log tail to survive a reload, got %+v", m.allSessions[0])
+	}
+
+	m.showActive()
+	m.activeView.SetSize(140, 40)
+	if view := m.activeView.View(); !strings.Contains(view, "🔧 bash") {
+		t.Errorf("expected the last action in the active view, got %s", view)
+	}
+
+	m.demo = true
+	if m.canLoadActivity() || m.loadActivity() != nil {
+		t.Error("expected no reads for demo data")
+	}
+}
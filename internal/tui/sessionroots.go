@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// ApplySessionRoots points the local session and token usage readers at the
+// roots from GH_AGENT_VIZ_COPILOT_ROOTS or the copilotRoots config, falling
+// back to ~/.copilot when neither sets any. The agent helper uses it too, so
+// a remote host serves the roots its own config names.
+func ApplySessionRoots(cfg *config.Config) {
+	var roots []data.SessionRoot
+	for _, r := range cfg.ResolvedCopilotRoots() {
+		roots = append(roots, data.SessionRoot{
+			Label:           r.Label,
+			SessionStateDir: r.SessionState,
+			LogDir:          r.Logs,
+		})
+	}
+	data.SetSessionRoots(roots)
+}
+
+// applyRemoteHosts registers the machines from the remotes config, whose
+// sessions are read over ssh through the agent helper.
+func applyRemoteHosts(cfg *config.Config) {
+	var hosts []data.RemoteHost
+	for _, r := range cfg.Remotes {
+		command := r.Command
+		if len(command) == 0 {
+			if r.Host == "" {
+				continue
+			}
+			command = data.SSHAgentCommand(r.Host)
+		}
+		hosts = append(hosts, data.RemoteHost{Name: r.DisplayName(), Command: command})
+	}
+	data.SetRemoteHosts(hosts)
+}
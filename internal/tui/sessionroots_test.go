@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func TestApplySessionRoots(t *testing.T) {
+	t.Setenv(config.CopilotRootsEnv, "")
+	t.Cleanup(func() { data.SetSessionRoots(nil) })
+
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.CopilotRoots = []config.CopilotRoot{{Path: filepath.Join(dir, "devbox", ".copilot")}}
+	ApplySessionRoots(cfg)
+
+	roots := data.SessionRoots()
+	if len(roots) != 1 {
+		t.Fatalf("expected 1 root, got %d", len(roots))
+	}
+	want := data.CopilotRoot("devbox", filepath.Join(dir, "devbox", ".copilot"))
+	if roots[0] != want {
+		t.Errorf("root = %+v, want %+v", roots[0], want)
+	}
+
+	ApplySessionRoots(config.DefaultConfig())
+	if roots := data.SessionRoots(); len(roots) != 1 || roots[0].Label != "" {
+		t.Errorf("expected the unlabeled default root without config, got %+v", roots)
+	}
+}
+
+func TestApplyRemoteHosts(t *testing.T) {
+	t.Cleanup(func() { data.SetRemoteHosts(nil) })
+
+	cfg := config.DefaultConfig()
+	cfg.Remotes = []config.Remote{
+		{Host: "devbox"},
+		{Name: "container", Command: []string{"docker", "exec", "-i", "agents", "gh", "agent-viz", "agent"}},
+		{Name: "incomplete"},
+	}
+	applyRemoteHosts(cfg)
+
+	hosts := data.RemoteHosts()
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 hosts (one without host or command skipped), got %+v", hosts)
+	}
+	if hosts[0].Name != "devbox" || strings.Join(hosts[0].Command, " ") != "ssh -o BatchMode=yes devbox gh agent-viz agent" {
+		t.Errorf("unexpected ssh host %+v", hosts[0])
+	}
+	if hosts[1].Name != "container" || hosts[1].Command[0] != "docker" {
+		t.Errorf("unexpected custom host %+v", hosts[1])
+	}
+}
+
+func TestUpdate_RemoteSessionsLoaded(t *testing.T) {
+	m := NewModel("", false, true, "", "test")
+	m.allSessions = []data.Session{{ID: "local-1", Status: "running", Source: data.SourceLocalCopilot}}
+	m.tokenUsageMap = map[string]*data.TokenUsage{"local-1": {InputTokens: 10}}
+
+	next, _ := m.Update(remoteSessionsLoadedMsg{
+		sessions: []data.Session{{ID: "remote-1", Status: "running", Source: data.SourceLocalCopilot, Host: "devbox", Origin: "devbox"}},
+		usage:    map[string]*data.TokenUsage{"remote-1": {InputTokens: 20}},
+		err:      errors.New("gpu: Permission denied (publickey)"),
+	})
+	m = next.(Model)
+
+	if len(m.allSessions) != 2 {
+		t.Fatalf("expected the remote session merged, got %d sessions", len(m.allSessions))
+	}
+	if m.tokenUsageMap["local-1"] == nil || m.tokenUsageMap["remote-1"] == nil {
+		t.Errorf("expected local and remote token usage, got %v", m.tokenUsageMap)
+	}
+	if !strings.Contains(ansi.Strip(m.toast.View()), "Remote hosts") {
+		t.Error("expected a toast for the unreachable host")
+	}
+	if hasWorkDir(&data.Session{Source: data.SourceLocalCopilot, WorkDir: "/src", Host: "devbox"}) {
+		t.Error("expected git activity to be unavailable for remote sessions")
+	}
+	if resumeSessionErr(&data.Session{ID: "x", Status: "running", Source: data.SourceLocalCopilot, Host: "devbox"}) == nil {
+		t.Error("expected resume to be refused for remote sessions")
+	}
+}
@@ -1,9 +1,13 @@
 package tui
 
 import (
+	"image/color"
+
 	"charm.land/lipgloss/v2"
 	"charm.land/lipgloss/v2/compat"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 )
 
 // Theme contains all Lip Gloss styles for the UI
@@ -28,6 +32,9 @@ type Theme struct {
 	RowGutter     lipgloss.Style
 	RowGutterSel  lipgloss.Style
 	SectionHeader lipgloss.Style
+	// Palette colors everything not covered by the styles above: status
+	// and attention colors, the footer powerline, diffs and sparklines.
+	Palette colors.Palette
 }
 
 // ThemeName returns the name of the active theme.
@@ -40,6 +47,19 @@ func NewTheme() *Theme {
 	return newCatppuccinMochaTheme()
 }
 
+// builtinThemeNames lists the themes available without a theme file.
+var builtinThemeNames = []string{"catppuccin-mocha", "dracula", "tokyo-night", "solarized-light", "default"}
+
+// isBuiltinTheme reports whether name is a built-in theme.
+func isBuiltinTheme(name string) bool {
+	for _, n := range builtinThemeNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 // NewThemeFromConfig returns the theme matching themeName, or catppuccin-mocha
 // as the default when the name is empty or unrecognised.
 func NewThemeFromConfig(themeName string) *Theme {
@@ -70,7 +90,7 @@ func newDefaultTheme() *Theme {
 		TableHeader: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("99")).
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 			BorderBottom(true),
 		TableRow: lipgloss.NewStyle().
 			Padding(0, 1),
@@ -79,7 +99,7 @@ func newDefaultTheme() *Theme {
 			Foreground(lipgloss.Color("15")).
 			Bold(true),
 		Border: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("238")),
 		Title: lipgloss.NewStyle().
 			Bold(true).
@@ -99,7 +119,7 @@ func newDefaultTheme() *Theme {
 		TabCount: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("241")),
 		FocusBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("63")),
 		RowGutter: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("238")),
@@ -110,6 +130,7 @@ func newDefaultTheme() *Theme {
 			Foreground(lipgloss.Color("63")).
 			Bold(true).
 			Padding(0, 1),
+		Palette: colors.Default(),
 	}
 }
 
@@ -127,7 +148,7 @@ func newAdaptiveDefaultTheme() *Theme {
 		TableHeader: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("75")}).
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 			BorderBottom(true).
 			BorderForeground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("238")}),
 		TableRow: lipgloss.NewStyle().
@@ -138,7 +159,7 @@ func newAdaptiveDefaultTheme() *Theme {
 			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("0"), Dark: lipgloss.Color("255")}).
 			Bold(true),
 		Border: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("240")}),
 		Title: lipgloss.NewStyle().
 			Bold(true).
@@ -158,7 +179,7 @@ func newAdaptiveDefaultTheme() *Theme {
 		TabCount: lipgloss.NewStyle().
 			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("244"), Dark: lipgloss.Color("245")}),
 		FocusBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(compat.AdaptiveColor{Light: lipgloss.Color("30"), Dark: lipgloss.Color("73")}),
 		RowGutter: lipgloss.NewStyle().
 			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("249"), Dark: lipgloss.Color("239")}),
@@ -169,6 +190,7 @@ func newAdaptiveDefaultTheme() *Theme {
 			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("30"), Dark: lipgloss.Color("73")}).
 			Bold(true).
 			Padding(0, 1),
+		Palette: colors.Default(),
 	}
 }
 
@@ -183,7 +205,7 @@ func newCatppuccinMochaTheme() *Theme {
 		TableHeader: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#cba6f7")).
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 			BorderBottom(true),
 		TableRow: lipgloss.NewStyle().
 			Padding(0, 1).
@@ -193,7 +215,7 @@ func newCatppuccinMochaTheme() *Theme {
 			Foreground(lipgloss.Color("#cdd6f4")).
 			Bold(true),
 		Border: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#313244")),
 		Title: lipgloss.NewStyle().
 			Bold(true).
@@ -213,7 +235,7 @@ func newCatppuccinMochaTheme() *Theme {
 		TabCount: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#a6adc8")),
 		FocusBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#89b4fa")),
 		RowGutter: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#313244")),
@@ -224,6 +246,7 @@ func newCatppuccinMochaTheme() *Theme {
 			Foreground(lipgloss.Color("#89b4fa")).
 			Bold(true).
 			Padding(0, 1),
+		Palette: colors.Default(),
 	}
 }
 
@@ -238,7 +261,7 @@ func newDraculaTheme() *Theme {
 		TableHeader: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#bd93f9")).
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 			BorderBottom(true),
 		TableRow: lipgloss.NewStyle().
 			Padding(0, 1).
@@ -248,7 +271,7 @@ func newDraculaTheme() *Theme {
 			Foreground(lipgloss.Color("#f8f8f2")).
 			Bold(true),
 		Border: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#44475a")),
 		Title: lipgloss.NewStyle().
 			Bold(true).
@@ -268,7 +291,7 @@ func newDraculaTheme() *Theme {
 		TabCount: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#6272a4")),
 		FocusBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#8be9fd")),
 		RowGutter: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#44475a")),
@@ -279,6 +302,7 @@ func newDraculaTheme() *Theme {
 			Foreground(lipgloss.Color("#8be9fd")).
 			Bold(true).
 			Padding(0, 1),
+		Palette: draculaPalette(),
 	}
 }
 
@@ -293,7 +317,7 @@ func newTokyoNightTheme() *Theme {
 		TableHeader: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#bb9af7")).
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 			BorderBottom(true),
 		TableRow: lipgloss.NewStyle().
 			Padding(0, 1).
@@ -303,7 +327,7 @@ func newTokyoNightTheme() *Theme {
 			Foreground(lipgloss.Color("#c0caf5")).
 			Bold(true),
 		Border: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#33467c")),
 		Title: lipgloss.NewStyle().
 			Bold(true).
@@ -323,7 +347,7 @@ func newTokyoNightTheme() *Theme {
 		TabCount: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#565f89")),
 		FocusBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#7aa2f7")),
 		RowGutter: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#33467c")),
@@ -334,6 +358,7 @@ func newTokyoNightTheme() *Theme {
 			Foreground(lipgloss.Color("#7aa2f7")).
 			Bold(true).
 			Padding(0, 1),
+		Palette: tokyoNightPalette(),
 	}
 }
 
@@ -349,7 +374,7 @@ func newSolarizedLightTheme() *Theme {
 		TableHeader: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("#268bd2")).
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(a11y.Border(lipgloss.NormalBorder())).
 			BorderBottom(true).
 			BorderForeground(lipgloss.Color("#eee8d5")),
 		TableRow: lipgloss.NewStyle().
@@ -360,7 +385,7 @@ func newSolarizedLightTheme() *Theme {
 			Foreground(lipgloss.Color("#073642")).
 			Bold(true),
 		Border: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#eee8d5")),
 		Title: lipgloss.NewStyle().
 			Bold(true).
@@ -380,7 +405,7 @@ func newSolarizedLightTheme() *Theme {
 		TabCount: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#93a1a1")),
 		FocusBorder: lipgloss.NewStyle().
-			BorderStyle(lipgloss.RoundedBorder()).
+			BorderStyle(a11y.Border(lipgloss.RoundedBorder())).
 			BorderForeground(lipgloss.Color("#2aa198")),
 		RowGutter: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#eee8d5")),
@@ -391,16 +416,143 @@ func newSolarizedLightTheme() *Theme {
 			Foreground(lipgloss.Color("#2aa198")).
 			Bold(true).
 			Padding(0, 1),
+		Palette: solarizedLightPalette(),
 	}
 }
 
+func hexColor(c string) color.Color { return lipgloss.Color(c) }
+
+// draculaPalette returns the Dracula colors for everything outside Theme's styles.
+func draculaPalette() colors.Palette {
+	return colors.Merge(colors.Default(), colors.Palette{
+		Text:      hexColor("#f8f8f2"),
+		Muted:     hexColor("#6272a4"),
+		Subtle:    hexColor("#44475a"),
+		Accent:    hexColor("#bd93f9"),
+		Highlight: hexColor("#8be9fd"),
+		Section:   hexColor("#bd93f9"),
+		Focus:     hexColor("#8be9fd"),
+		Key:       hexColor("#50fa7b"),
+		Inverse:   hexColor("#282a36"),
+		TabBg:     hexColor("#bd93f9"),
+		Diff: colors.DiffColors{
+			Added: hexColor("#50fa7b"), Removed: hexColor("#ff5555"), Hunk: hexColor("#8be9fd"),
+			Keyword: hexColor("#ff79c6"), String: hexColor("#f1fa8c"), Comment: hexColor("#6272a4"), Number: hexColor("#bd93f9"),
+			AddedWord: hexColor("#2e4a3a"), RemovedWord: hexColor("#5a2a33"),
+		},
+		Status: colors.StatusColors{
+			Running: hexColor("#50fa7b"), Queued: hexColor("#f1fa8c"), NeedsInput: hexColor("#ffb86c"),
+			Completed: hexColor("#8be9fd"), Failed: hexColor("#ff5555"), Idle: hexColor("#6272a4"), Unknown: hexColor("#6272a4"),
+		},
+		Attention: colors.AttentionColors{Urgent: hexColor("#ff5555"), Warning: hexColor("#ffb86c"), Info: hexColor("#50fa7b"), None: hexColor("#6272a4")},
+		Powerline: colors.Powerline{
+			Base: hexColor("#282a36"), Surface0: hexColor("#343746"), Surface1: hexColor("#44475a"), Surface2: hexColor("#6272a4"),
+			Overlay: hexColor("#6272a4"), Text: hexColor("#f8f8f2"), Subtext: hexColor("#bfbfbf"), Key: hexColor("#8be9fd"), Accent: hexColor("#bd93f9"),
+			BadgeMission: hexColor("#bd93f9"), BadgeActive: hexColor("#50fa7b"), BadgeList: hexColor("#8be9fd"),
+			BadgeDetail: hexColor("#6272a4"), BadgeLog: hexColor("#6272a4"),
+			StatusRunning: hexColor("#8be9fd"), StatusFailed: hexColor("#ff5555"), StatusNeedsInput: hexColor("#f1fa8c"),
+		},
+		Sparkline: []color.Color{hexColor("#6272a4"), hexColor("#8be9fd"), hexColor("#50fa7b"), hexColor("#f1fa8c"), hexColor("#ffb86c")},
+	})
+}
+
+// tokyoNightPalette returns the Tokyo Night colors for everything outside Theme's styles.
+func tokyoNightPalette() colors.Palette {
+	return colors.Merge(colors.Default(), colors.Palette{
+		Text:      hexColor("#c0caf5"),
+		Muted:     hexColor("#565f89"),
+		Subtle:    hexColor("#3b4261"),
+		Accent:    hexColor("#bb9af7"),
+		Highlight: hexColor("#7aa2f7"),
+		Section:   hexColor("#7aa2f7"),
+		Focus:     hexColor("#7dcfff"),
+		Key:       hexColor("#9ece6a"),
+		Inverse:   hexColor("#1a1b26"),
+		TabBg:     hexColor("#bb9af7"),
+		Diff: colors.DiffColors{
+			Added: hexColor("#9ece6a"), Removed: hexColor("#f7768e"), Hunk: hexColor("#7dcfff"),
+			Keyword: hexColor("#bb9af7"), String: hexColor("#e0af68"), Comment: hexColor("#565f89"), Number: hexColor("#ff9e64"),
+			AddedWord: hexColor("#2d3f2b"), RemovedWord: hexColor("#4a2631"),
+		},
+		Status: colors.StatusColors{
+			Running: hexColor("#9ece6a"), Queued: hexColor("#e0af68"), NeedsInput: hexColor("#ff9e64"),
+			Completed: hexColor("#7dcfff"), Failed: hexColor("#f7768e"), Idle: hexColor("#565f89"), Unknown: hexColor("#565f89"),
+		},
+		Attention: colors.AttentionColors{Urgent: hexColor("#f7768e"), Warning: hexColor("#ff9e64"), Info: hexColor("#73daca"), None: hexColor("#565f89")},
+		Powerline: colors.Powerline{
+			Base: hexColor("#1a1b26"), Surface0: hexColor("#24283b"), Surface1: hexColor("#292e42"), Surface2: hexColor("#414868"),
+			Overlay: hexColor("#565f89"), Text: hexColor("#c0caf5"), Subtext: hexColor("#a9b1d6"), Key: hexColor("#7aa2f7"), Accent: hexColor("#bb9af7"),
+			BadgeMission: hexColor("#bb9af7"), BadgeActive: hexColor("#9ece6a"), BadgeList: hexColor("#7aa2f7"),
+			BadgeDetail: hexColor("#414868"), BadgeLog: hexColor("#414868"),
+			StatusRunning: hexColor("#73daca"), StatusFailed: hexColor("#f7768e"), StatusNeedsInput: hexColor("#e0af68"),
+		},
+		Sparkline: []color.Color{hexColor("#414868"), hexColor("#7aa2f7"), hexColor("#7dcfff"), hexColor("#e0af68"), hexColor("#ff9e64")},
+	})
+}
+
+// solarizedLightPalette returns the Solarized Light colors for everything
+// outside Theme's styles.
+func solarizedLightPalette() colors.Palette {
+	return colors.Merge(colors.Default(), colors.Palette{
+		Text:      hexColor("#586e75"),
+		Muted:     hexColor("#93a1a1"),
+		Subtle:    hexColor("#eee8d5"),
+		Accent:    hexColor("#6c71c4"),
+		Highlight: hexColor("#268bd2"),
+		Section:   hexColor("#2aa198"),
+		Focus:     hexColor("#2aa198"),
+		Key:       hexColor("#859900"),
+		Inverse:   hexColor("#fdf6e3"),
+		TabBg:     hexColor("#268bd2"),
+		Diff: colors.DiffColors{
+			Added: hexColor("#859900"), Removed: hexColor("#dc322f"), Hunk: hexColor("#2aa198"),
+			Keyword: hexColor("#6c71c4"), String: hexColor("#2aa198"), Comment: hexColor("#93a1a1"), Number: hexColor("#d33682"),
+			AddedWord: hexColor("#e6eccf"), RemovedWord: hexColor("#f6d5cf"),
+		},
+		Status: colors.StatusColors{
+			Running: hexColor("#859900"), Queued: hexColor("#b58900"), NeedsInput: hexColor("#cb4b16"),
+			Completed: hexColor("#2aa198"), Failed: hexColor("#dc322f"), Idle: hexColor("#93a1a1"), Unknown: hexColor("#93a1a1"),
+		},
+		Attention: colors.AttentionColors{Urgent: hexColor("#dc322f"), Warning: hexColor("#cb4b16"), Info: hexColor("#2aa198"), None: hexColor("#93a1a1")},
+		Powerline: colors.Powerline{
+			Base: hexColor("#eee8d5"), Surface0: hexColor("#fdf6e3"), Surface1: hexColor("#93a1a1"), Surface2: hexColor("#839496"),
+			Overlay: hexColor("#93a1a1"), Text: hexColor("#fdf6e3"), Subtext: hexColor("#657b83"), Key: hexColor("#073642"), Accent: hexColor("#6c71c4"),
+			BadgeMission: hexColor("#6c71c4"), BadgeActive: hexColor("#859900"), BadgeList: hexColor("#268bd2"),
+			BadgeDetail: hexColor("#839496"), BadgeLog: hexColor("#839496"),
+			StatusRunning: hexColor("#2aa198"), StatusFailed: hexColor("#dc322f"), StatusNeedsInput: hexColor("#b58900"),
+		},
+		Sparkline: []color.Color{hexColor("#93a1a1"), hexColor("#2aa198"), hexColor("#859900"), hexColor("#b58900"), hexColor("#cb4b16")},
+	})
+}
+
+// asciiStatusIcons are the plain-mode stand-ins for the status emoji.
+var asciiStatusIcons = map[string]string{
+	"running":     "*",
+	"queued":      "o",
+	"needs-input": "!",
+	"completed":   "+",
+	"failed":      "x",
+}
+
 // StatusIcon returns the appropriate icon for a given status, with color.
+// Plain mode uses ASCII glyphs; accessible mode spells the status out.
 func StatusIcon(status string) string {
+	mode := a11y.Current()
+	if mode.Accessible {
+		return "[" + a11y.StatusLabel(status) + "]"
+	}
+	if mode.Plain {
+		icon, ok := asciiStatusIcons[status]
+		if !ok {
+			icon = "?"
+		}
+		return lipgloss.NewStyle().Foreground(colors.Current().StatusColor(status)).Render(icon)
+	}
 	switch status {
 	case "running":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render("●")
+		return lipgloss.NewStyle().Foreground(colors.Current().Status.Running).Render("●")
 	case "queued":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("222")).Render("○")
+		return lipgloss.NewStyle().Foreground(colors.Current().Status.Queued).Render("○")
 	case "needs-input":
 		return "✋"
 	case "completed":
@@ -412,16 +564,17 @@ func StatusIcon(status string) string {
 	}
 }
 
-// Running sessions: steady dot, gentle color breathing between two close greens
-var runningColors = []string{"42", "42", "42", "36", "36", "42"}
+// Running sessions: steady dot, gentle breathing by dimming the running color
+var runningFaint = []bool{false, false, false, true, true, false}
 
 // AnimatedStatusIcon returns a subtly animated icon for running sessions.
 // Queued and other statuses use their static icon — only "in progress"
-// sessions get the gentle color pulse.
+// sessions get the gentle color pulse, and only outside plain and
+// accessible modes.
 func AnimatedStatusIcon(status string, frame int) string {
-	if status == "running" {
-		color := runningColors[frame%len(runningColors)]
-		return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("●")
+	if status == "running" && !a11y.Current().ASCII() {
+		style := lipgloss.NewStyle().Foreground(colors.Current().Status.Running)
+		return style.Faint(runningFaint[frame%len(runningFaint)]).Render("●")
 	}
 	return StatusIcon(status)
 }
@@ -440,16 +593,17 @@ func AttentionLevelIcon(level data.AttentionLevel) string {
 	}
 }
 
-// AttentionLevelColor returns an ANSI color string for a graduated attention level.
-func AttentionLevelColor(level data.AttentionLevel) string {
+// AttentionLevelColor returns the active theme's color for a graduated attention level.
+func AttentionLevelColor(level data.AttentionLevel) color.Color {
+	p := colors.Current()
 	switch level {
 	case data.AttentionUrgent:
-		return "203" // red
+		return p.Attention.Urgent
 	case data.AttentionWarning:
-		return "214" // amber/orange
+		return p.Attention.Warning
 	case data.AttentionInfo:
-		return "72" // teal/green
+		return p.Attention.Info
 	default:
-		return "245" // gray
+		return p.Attention.None
 	}
 }
@@ -3,6 +3,9 @@ package tui
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
 )
 
 func TestStatusIcon_Running(t *testing.T) {
@@ -67,6 +70,37 @@ func TestStatusIcon_InvalidStatus(t *testing.T) {
 	}
 }
 
+func TestStatusIcon_PlainUsesASCII(t *testing.T) {
+	a11y.Set(a11y.Mode{Plain: true})
+	defer a11y.Set(a11y.Mode{})
+
+	tests := map[string]string{
+		"running":     "*",
+		"queued":      "o",
+		"needs-input": "!",
+		"completed":   "+",
+		"failed":      "x",
+		"bogus":       "?",
+	}
+	for status, want := range tests {
+		if got := ansi.Strip(StatusIcon(status)); got != want {
+			t.Errorf("StatusIcon(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestStatusIcon_AccessibleUsesWords(t *testing.T) {
+	a11y.Set(a11y.Mode{Accessible: true})
+	defer a11y.Set(a11y.Mode{})
+
+	if got := StatusIcon("needs-input"); got != "[needs input]" {
+		t.Errorf("expected [needs input], got %q", got)
+	}
+	if got := AnimatedStatusIcon("running", 3); got != "[running]" {
+		t.Errorf("expected a static [running] label, got %q", got)
+	}
+}
+
 func TestNewTheme(t *testing.T) {
 	theme := NewTheme()
 	if theme == nil {
@@ -0,0 +1,301 @@
+package tui
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/compat"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"gopkg.in/yaml.v3"
+)
+
+// themeFile is the on-disk form of a custom theme. Every section is
+// optional: anything left out is inherited from the theme named by Extends
+// (catppuccin-mocha by default).
+type themeFile struct {
+	Name      string               `yaml:"name"`
+	Extends   string               `yaml:"extends"`
+	Styles    map[string]styleSpec `yaml:"styles"`
+	Colors    map[string]colorSpec `yaml:"colors"`
+	Diff      map[string]colorSpec `yaml:"diff"`
+	Status    map[string]colorSpec `yaml:"status"`
+	Attention map[string]colorSpec `yaml:"attention"`
+	Powerline map[string]colorSpec `yaml:"powerline"`
+	Sparkline []colorSpec          `yaml:"sparkline"`
+}
+
+// styleSpec overrides parts of one Theme style.
+type styleSpec struct {
+	Fg        *colorSpec `yaml:"fg"`
+	Bg        *colorSpec `yaml:"bg"`
+	Border    *colorSpec `yaml:"border"`
+	Bold      *bool      `yaml:"bold"`
+	Italic    *bool      `yaml:"italic"`
+	Underline *bool      `yaml:"underline"`
+	Faint     *bool      `yaml:"faint"`
+}
+
+// colorSpec is a color written as "#rrggbb", "#rgb", an ANSI number
+// ("42"), or a {light, dark} pair that adapts to the terminal background.
+type colorSpec struct {
+	color color.Color
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// parseColor validates a single color value.
+func parseColor(s string) (color.Color, error) {
+	s = strings.TrimSpace(s)
+	if hexColorPattern.MatchString(s) {
+		return lipgloss.Color(s), nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return nil, fmt.Errorf("invalid color %q (want #rrggbb, #rgb or 0-255)", s)
+}
+
+// UnmarshalYAML accepts a scalar color or a {light, dark} mapping.
+func (c *colorSpec) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		col, err := parseColor(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		c.color = col
+		return nil
+	}
+	var pair struct {
+		Light string `yaml:"light"`
+		Dark  string `yaml:"dark"`
+	}
+	if err := node.Decode(&pair); err != nil {
+		return err
+	}
+	light, err := parseColor(pair.Light)
+	if err != nil {
+		return fmt.Errorf("line %d: light: %w", node.Line, err)
+	}
+	dark, err := parseColor(pair.Dark)
+	if err != nil {
+		return fmt.Errorf("line %d: dark: %w", node.Line, err)
+	}
+	c.color = compat.AdaptiveColor{Light: light, Dark: dark}
+	return nil
+}
+
+// themeStyleFields maps style names in a theme file to Theme fields.
+func themeStyleFields(t *Theme) map[string]*lipgloss.Style {
+	return map[string]*lipgloss.Style{
+		"statusRunning":    &t.StatusRunning,
+		"statusQueued":     &t.StatusQueued,
+		"statusCompleted":  &t.StatusCompleted,
+		"statusFailed":     &t.StatusFailed,
+		"tableHeader":      &t.TableHeader,
+		"tableRow":         &t.TableRow,
+		"tableRowSelected": &t.TableRowSelected,
+		"border":           &t.Border,
+		"title":            &t.Title,
+		"footer":           &t.Footer,
+		"tabActive":        &t.TabActive,
+		"tabInactive":      &t.TabInactive,
+		"tabCount":         &t.TabCount,
+		"focusBorder":      &t.FocusBorder,
+		"rowGutter":        &t.RowGutter,
+		"rowGutterSel":     &t.RowGutterSel,
+		"sectionHeader":    &t.SectionHeader,
+	}
+}
+
+// paletteSections maps each color section of a theme file to the palette
+// fields it may set.
+func paletteSections(p *colors.Palette) map[string]map[string]*color.Color {
+	pl := &p.Powerline
+	return map[string]map[string]*color.Color{
+		"colors": {
+			"text": &p.Text, "muted": &p.Muted, "subtle": &p.Subtle, "accent": &p.Accent,
+			"highlight": &p.Highlight, "section": &p.Section, "focus": &p.Focus, "key": &p.Key,
+			"inverse": &p.Inverse, "tabBg": &p.TabBg,
+		},
+		"diff": {
+			"added": &p.Diff.Added, "removed": &p.Diff.Removed, "hunk": &p.Diff.Hunk,
+			"keyword": &p.Diff.Keyword, "string": &p.Diff.String, "comment": &p.Diff.Comment, "number": &p.Diff.Number,
+			"addedWord": &p.Diff.AddedWord, "removedWord": &p.Diff.RemovedWord,
+		},
+		"status": {
+			"running": &p.Status.Running, "queued": &p.Status.Queued, "needsInput": &p.Status.NeedsInput,
+			"completed": &p.Status.Completed, "failed": &p.Status.Failed, "idle": &p.Status.Idle,
+			"unknown": &p.Status.Unknown,
+		},
+		"attention": {
+			"urgent": &p.Attention.Urgent, "warning": &p.Attention.Warning,
+			"info": &p.Attention.Info, "none": &p.Attention.None,
+		},
+		"powerline": {
+			"base": &pl.Base, "surface0": &pl.Surface0, "surface1": &pl.Surface1, "surface2": &pl.Surface2,
+			"overlay": &pl.Overlay, "text": &pl.Text, "subtext": &pl.Subtext, "key": &pl.Key, "accent": &pl.Accent,
+			"badgeMission": &pl.BadgeMission, "badgeActive": &pl.BadgeActive, "badgeList": &pl.BadgeList,
+			"badgeDetail": &pl.BadgeDetail, "badgeLog": &pl.BadgeLog,
+			"statusRunning": &pl.StatusRunning, "statusFailed": &pl.StatusFailed,
+			"statusNeedsInput": &pl.StatusNeedsInput,
+		},
+	}
+}
+
+// apply layers the file's overrides onto a copy of base.
+func (f themeFile) apply(base *Theme) (*Theme, error) {
+	t := *base
+	t.name = f.Name
+
+	fields := themeStyleFields(&t)
+	for _, name := range sortedKeys(f.Styles) {
+		dst, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown style %q", name)
+		}
+		*dst = f.Styles[name].apply(*dst)
+	}
+
+	sections := paletteSections(&t.Palette)
+	for _, sec := range []struct {
+		name   string
+		values map[string]colorSpec
+	}{
+		{"colors", f.Colors}, {"diff", f.Diff}, {"status", f.Status},
+		{"attention", f.Attention}, {"powerline", f.Powerline},
+	} {
+		for _, name := range sortedKeys(sec.values) {
+			dst, ok := sections[sec.name][name]
+			if !ok {
+				return nil, fmt.Errorf("unknown %s color %q", sec.name, name)
+			}
+			*dst = sec.values[name].color
+		}
+	}
+
+	if len(f.Sparkline) > 0 {
+		t.Palette.Sparkline = make([]color.Color, len(f.Sparkline))
+		for i, c := range f.Sparkline {
+			t.Palette.Sparkline[i] = c.color
+		}
+	}
+	return &t, nil
+}
+
+// apply returns style with the spec's overrides.
+func (s styleSpec) apply(style lipgloss.Style) lipgloss.Style {
+	if s.Fg != nil {
+		style = style.Foreground(s.Fg.color)
+	}
+	if s.Bg != nil {
+		style = style.Background(s.Bg.color)
+	}
+	if s.Border != nil {
+		style = style.BorderForeground(s.Border.color)
+	}
+	if s.Bold != nil {
+		style = style.Bold(*s.Bold)
+	}
+	if s.Italic != nil {
+		style = style.Italic(*s.Italic)
+	}
+	if s.Underline != nil {
+		style = style.Underline(*s.Underline)
+	}
+	if s.Faint != nil {
+		style = style.Faint(*s.Faint)
+	}
+	return style
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LoadThemes reads every .yml, .yaml and .json theme file in dir. A file's
+// theme is named by its name field, or by its file name when that is empty.
+// Files that fail to parse are skipped and reported as errors; a missing
+// directory is not an error.
+func LoadThemes(dir string) (map[string]*Theme, []error) {
+	themes := map[string]*Theme{}
+	if dir == "" {
+		return themes, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return themes, nil
+		}
+		return themes, []error{err}
+	}
+
+	var errs []error
+	files := map[string]themeFile{}
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || (ext != ".yml" && ext != ".yaml" && ext != ".json") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var f themeFile
+		if err := yaml.Unmarshal(raw, &f); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name(), err))
+			continue
+		}
+		if f.Name == "" {
+			f.Name = strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+		}
+		files[f.Name] = f
+	}
+
+	// Resolve extends chains, which may point at built-ins or other files.
+	var resolve func(name string, seen map[string]bool) (*Theme, error)
+	resolve = func(name string, seen map[string]bool) (*Theme, error) {
+		if t, ok := themes[name]; ok {
+			return t, nil
+		}
+		f, ok := files[name]
+		if !ok {
+			if name == "" || isBuiltinTheme(name) {
+				return NewThemeFromConfig(name), nil
+			}
+			return nil, fmt.Errorf("unknown theme %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("theme %q extends itself", name)
+		}
+		seen[name] = true
+		base, err := resolve(f.Extends, seen)
+		if err != nil {
+			return nil, err
+		}
+		t, err := f.apply(base)
+		if err != nil {
+			return nil, err
+		}
+		themes[name] = t
+		return t, nil
+	}
+	for _, name := range sortedKeys(files) {
+		if _, err := resolve(name, map[string]bool{}); err != nil {
+			errs = append(errs, fmt.Errorf("theme %s: %w", name, err))
+		}
+	}
+	return themes, errs
+}
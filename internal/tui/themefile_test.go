@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/compat"
+)
+
+func writeThemeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write theme file: %v", err)
+	}
+}
+
+func TestLoadThemes_AppliesOverridesOnTopOfBase(t *testing.T) {
+	dir := t.TempDir()
+	writeThemeFile(t, dir, "midnight.yml", `
+extends: dracula
+styles:
+  title:
+    fg: "#ffffff"
+    bold: false
+status:
+  failed: "#ff0000"
+  running: {light: "28", dark: "#00ff00"}
+powerline:
+  base: "#000000"
+  badgeList: "33"
+sparkline: ["#111111", "#999999"]
+`)
+
+	themes, errs := LoadThemes(dir)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	th, ok := themes["midnight"]
+	if !ok {
+		t.Fatalf("expected theme named after its file, got %v", themes)
+	}
+	if th.ThemeName() != "midnight" {
+		t.Errorf("expected name midnight, got %q", th.ThemeName())
+	}
+	if got := th.Title.GetForeground(); got != lipgloss.Color("#ffffff") {
+		t.Errorf("expected title fg override, got %v", got)
+	}
+	if th.Title.GetBold() {
+		t.Error("expected title bold to be turned off")
+	}
+	if th.Palette.Status.Failed != lipgloss.Color("#ff0000") {
+		t.Errorf("expected failed status override, got %v", th.Palette.Status.Failed)
+	}
+	if _, ok := th.Palette.Status.Running.(compat.AdaptiveColor); !ok {
+		t.Errorf("expected {light, dark} to become an adaptive color, got %T", th.Palette.Status.Running)
+	}
+	if th.Palette.Powerline.Base != lipgloss.Color("#000000") {
+		t.Errorf("expected powerline base override, got %v", th.Palette.Powerline.Base)
+	}
+	if len(th.Palette.Sparkline) != 2 {
+		t.Errorf("expected 2 sparkline colors, got %d", len(th.Palette.Sparkline))
+	}
+
+	// Everything not overridden comes from dracula.
+	dracula := newDraculaTheme()
+	if th.Palette.Status.Queued != dracula.Palette.Status.Queued {
+		t.Errorf("expected queued color inherited from dracula")
+	}
+	if th.TabActive.GetBackground() != dracula.TabActive.GetBackground() {
+		t.Errorf("expected tabActive inherited from dracula")
+	}
+}
+
+func TestLoadThemes_NameFieldAndChainedExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeThemeFile(t, dir, "a.yaml", "name: base-custom\ncolors:\n  accent: \"#abcdef\"\n")
+	writeThemeFile(t, dir, "b.yaml", "name: child\nextends: base-custom\ncolors:\n  muted: \"#222222\"\n")
+	writeThemeFile(t, dir, "notes.txt", "not a theme")
+
+	themes, errs := LoadThemes(dir)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if len(themes) != 2 {
+		t.Fatalf("expected 2 themes, got %d", len(themes))
+	}
+	child := themes["child"]
+	if child == nil {
+		t.Fatal("expected theme named by its name field")
+	}
+	if child.Palette.Accent != lipgloss.Color("#abcdef") {
+		t.Errorf("expected accent inherited from base-custom, got %v", child.Palette.Accent)
+	}
+	if child.Palette.Muted != lipgloss.Color("#222222") {
+		t.Errorf("expected muted override, got %v", child.Palette.Muted)
+	}
+}
+
+func TestLoadThemes_ReportsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeThemeFile(t, dir, "badcolor.yml", "status:\n  failed: reddish\n")
+	writeThemeFile(t, dir, "badkey.yml", "powerline:\n  nope: \"#000000\"\n")
+	writeThemeFile(t, dir, "badstyle.yml", "styles:\n  sidebar:\n    fg: \"1\"\n")
+	writeThemeFile(t, dir, "loop.yml", "extends: loop\n")
+	writeThemeFile(t, dir, "orphan.yml", "extends: missing\n")
+	writeThemeFile(t, dir, "good.yml", "colors:\n  text: \"7\"\n")
+
+	themes, errs := LoadThemes(dir)
+	if len(themes) != 1 || themes["good"] == nil {
+		t.Errorf("expected only the valid theme to load, got %v", themes)
+	}
+	msg := joinErrors(errs)
+	for _, want := range []string{`invalid color "reddish"`, `unknown powerline color "nope"`, `unknown style "sidebar"`, "extends itself", `unknown theme "missing"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected error containing %q, got %q", want, msg)
+		}
+	}
+}
+
+func TestLoadThemes_MissingDir(t *testing.T) {
+	themes, errs := LoadThemes(filepath.Join(t.TempDir(), "nope"))
+	if len(themes) != 0 || len(errs) != 0 {
+		t.Errorf("expected no themes and no errors, got %v, %v", themes, errs)
+	}
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// resolveTheme returns the named theme, preferring a custom theme file over
+// a built-in of the same name. Unknown names fall back to catppuccin-mocha.
+func resolveTheme(name string, custom map[string]*Theme) *Theme {
+	if t, ok := custom[name]; ok {
+		return t
+	}
+	return NewThemeFromConfig(name)
+}
+
+// applyTheme makes t the active theme. Components that read colors at
+// render time pick up the new palette on the next frame; those holding
+// styles from construction get them replaced here.
+func (m *Model) applyTheme(t *Theme) {
+	m.theme = t
+	colors.Set(t.Palette)
+	m.header.SetStyles(t.Title, t.TabActive, t.TabInactive, t.TabCount)
+	m.taskList.SetStyles(t.Title, t.TableHeader, t.TableRow, t.TableRowSelected, t.SectionHeader)
+	m.taskDetail.SetStyles(t.Title, t.Border)
+	m.logView.SetTitleStyle(t.Title)
+	m.mission.SetStyles(t.Title, t.TableRow, t.TableRowSelected)
+	m.diffView.Refresh()
+	m.lastFingerprint = ""
+	m.lastSplitTaskID = ""
+	m.recomputeAndDisplay(m.visibleSessions())
+}
+
+// switchTheme applies the named theme and reports it as a toast.
+func (m *Model) switchTheme(name string) {
+	m.applyTheme(resolveTheme(name, m.customThemes))
+	m.toast.Push("🎨", "Theme", m.theme.ThemeName())
+}
+
+// openThemePicker reloads the themes directory, so edited theme files can
+// be re-applied without restarting, and lists every available theme.
+func (m *Model) openThemePicker() {
+	custom, errs := LoadThemes(m.ctx.Config.ThemesDirPath())
+	m.customThemes = custom
+	if len(errs) > 0 {
+		m.toast.Push("⚠️", "Themes", joinErrors(errs))
+	}
+
+	var items []picker.Item
+	for _, name := range builtinThemeNames {
+		if _, overridden := custom[name]; overridden {
+			continue
+		}
+		items = append(items, picker.Item{Label: name, Detail: "built-in", Value: name})
+	}
+	for _, name := range sortedKeys(custom) {
+		items = append(items, picker.Item{Label: name, Detail: "custom", Value: name})
+	}
+	current := 0
+	for i := range items {
+		if items[i].Value == m.theme.ThemeName() {
+			items[i].Key = "current"
+			current = i
+		}
+	}
+
+	m.themeBeforePicker = m.theme
+	// Moving the cursor previews each theme; closing restores the
+	// previous one.
+	m.openChooser("Themes", items, chooser{
+		closeKeys: []key.Binding{m.keys.SwitchTheme},
+		choose: func(m *Model, item picker.Item) tea.Cmd {
+			m.switchTheme(item.Value)
+			return nil
+		},
+		moved: (*Model).previewPickedTheme,
+		cancelled: func(m *Model) {
+			if m.themeBeforePicker != nil {
+				m.applyTheme(m.themeBeforePicker)
+			}
+		},
+	})
+	m.chooser.MoveCursor(current)
+}
+
+// previewPickedTheme applies the highlighted theme without committing it.
+func (m *Model) previewPickedTheme() {
+	if item, ok := m.chooser.Selected(); ok {
+		m.applyTheme(resolveTheme(item.Value, m.customThemes))
+	}
+}
+
+// joinErrors formats errors as a single "; "-separated line.
+func joinErrors(errs []error) string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	sort.Strings(msgs)
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
+)
+
+func newThemesTestModel(t *testing.T) Model {
+	t.Helper()
+	t.Cleanup(func() { colors.Set(colors.Default()) })
+	dir := t.TempDir()
+	writeThemeFile(t, dir, "neon.yml", "extends: dracula\npowerline:\n  base: \"#010203\"\n")
+	m := NewModel("", false, false, "", "dev")
+	m.ctx.Config.ThemesDir = dir
+	m.applyTheme(NewThemeFromConfig("catppuccin-mocha"))
+	return m
+}
+
+func pressKey(t *testing.T, m Model, msg tea.KeyPressMsg) Model {
+	t.Helper()
+	updated, _ := m.handleKeyPress(msg)
+	return updated.(Model)
+}
+
+func TestThemePicker_ListsBuiltinAndCustomThemes(t *testing.T) {
+	m := newThemesTestModel(t)
+	m = pressKey(t, m, tea.KeyPressMsg{Code: 'T', Text: "T"})
+	if !m.pickerOpen("Themes") {
+		t.Fatal("expected T to open the theme picker")
+	}
+	last, ok := m.chooser.ItemAt(len(builtinThemeNames) + 1)
+	if !ok || last.Value != "neon" || last.Detail != "custom" {
+		t.Errorf("expected custom theme listed after built-ins, got %+v", last)
+	}
+	first, _ := m.chooser.Selected()
+	if first.Value != "catppuccin-mocha" || first.Key != "current" {
+		t.Errorf("expected cursor on the current theme, got %+v", first)
+	}
+}
+
+func TestThemePicker_PreviewAndCancel(t *testing.T) {
+	m := newThemesTestModel(t)
+	m = pressKey(t, m, tea.KeyPressMsg{Code: 'T', Text: "T"})
+	m = pressKey(t, m, tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m.theme.ThemeName() != "dracula" {
+		t.Fatalf("expected moving the cursor to preview dracula, got %q", m.theme.ThemeName())
+	}
+	if colors.Current().Powerline.Base != newDraculaTheme().Palette.Powerline.Base {
+		t.Error("expected preview to install the dracula palette")
+	}
+
+	m = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.pickerOpen("Themes") {
+		t.Error("expected esc to close the picker")
+	}
+	if m.theme.ThemeName() != "catppuccin-mocha" {
+		t.Errorf("expected esc to restore the previous theme, got %q", m.theme.ThemeName())
+	}
+	if colors.Current().Powerline.Base != colors.Default().Powerline.Base {
+		t.Error("expected esc to restore the previous palette")
+	}
+}
+
+func TestThemePicker_SelectCustomTheme(t *testing.T) {
+	m := newThemesTestModel(t)
+	m = pressKey(t, m, tea.KeyPressMsg{Code: 'T', Text: "T"})
+	digit := rune('0' + len(builtinThemeNames) + 1)
+	m = pressKey(t, m, tea.KeyPressMsg{Code: digit, Text: string(digit)})
+
+	if m.pickerOpen("Themes") {
+		t.Error("expected selection to close the picker")
+	}
+	if m.theme.ThemeName() != "neon" {
+		t.Errorf("expected neon theme, got %q", m.theme.ThemeName())
+	}
+	if colors.Current().Powerline.Base != lipgloss.Color("#010203") {
+		t.Errorf("expected neon palette to be active, got %v", colors.Current().Powerline.Base)
+	}
+	if !m.toast.HasToasts() {
+		t.Error("expected a toast confirming the theme switch")
+	}
+}
+
+func TestResolveTheme_CustomOverridesBuiltin(t *testing.T) {
+	custom := &Theme{name: "dracula"}
+	if got := resolveTheme("dracula", map[string]*Theme{"dracula": custom}); got != custom {
+		t.Error("expected a custom theme to shadow the built-in of the same name")
+	}
+	if got := resolveTheme("tokyo-night", nil); got.ThemeName() != "tokyo-night" {
+		t.Errorf("expected built-in tokyo-night, got %q", got.ThemeName())
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"maps"
 	"math/rand"
 	"strings"
 	"time"
@@ -10,9 +11,10 @@ import (
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	"charm.land/lipgloss/v2/compat"
 	"github.com/maxbeizer/gh-agent-viz/internal/config"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/a11y"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/colors"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/conversation"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/footer"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/gitactivity"
@@ -20,6 +22,8 @@ import (
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/help"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/logview"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/mission"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/prompt"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/activeview"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
 	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/taskdetail"
@@ -51,6 +55,22 @@ type Model struct {
 	header      header.Model
 	footer      footer.Model
 	help        help.Model
+	palette     picker.Model
+	chooser     chooser // the open picker of views, themes, snooze times, PR actions and the like
+	prAction         string // PR action the chooser is confirming; empty while picking
+	annotationPrompt prompt.Model
+	promptSessionID  string // session the annotation prompt edits
+	promptField      string // "note" or "tags"
+	reviewPrompt     prompt.Model // line comments, review bodies and Copilot requests
+	reviewField      string       // what reviewPrompt asks for
+	reviewEvent      data.ReviewEvent
+	diffRepo         string // repository of the PR the diff view shows
+	diffPR           int    // number of the PR the diff view shows
+	diffSessionID    string // session whose PR the diff view shows
+	logSessionID     string // session the log and conversation views show
+	fileDir          string // directory the file chooser's paths are relative to
+	customThemes map[string]*Theme // themes loaded from the themes directory
+	themeBeforePicker *Theme       // theme to restore if the theme picker is cancelled
 	taskList    tasklist.Model
 	taskDetail  taskdetail.Model
 	logView     logview.Model
@@ -60,11 +80,17 @@ type Model struct {
 	mission        mission.Model
 	activeView     activeview.Model
 	gitActivity    gitactivity.Model
-	dismissedStore *data.DismissedStore
+	gitWorkDir     string // working directory shown in the git activity view
+	annotations    *data.AnnotationStore // dismissals, snoozes, pins, notes and tags
+	resourceMonitor *data.ResourceMonitor // CPU and memory of live session processes
+	activity       map[string]data.SessionActivity // last read activity of live sessions
+	prStatus       map[string]data.PRStatus        // last fetched status of sessions' PRs
 	statsBar       statsbar.Model
 	viewMode       ViewMode
 	showConversation bool // true when conversation bubble view is active in log mode
 	showPreview  bool
+	currentView  string // name of the last applied saved view
+	statusPinned bool   // true when a saved view chose the status tab; skips smart defaults
 	ready        bool
 	repo         string
 	refreshInt   time.Duration
@@ -74,29 +100,48 @@ type Model struct {
 	toast        toast.Model
 	prevSessions map[string]string // session ID → previous status
 	demo         bool
+	showArchived bool           // archived sessions are listed (read-only)
 	allSessions  []data.Session // accumulated across load phases
 	tokenUsageMap map[string]*data.TokenUsage // cached for cost computation
 	initialLoadDone bool       // true after all initial load phases complete
 	lastFingerprint string     // hash of session data; used to skip no-op refreshes
 	searchActive bool          // true when search input is active
 	searchQuery  string        // current search filter text
+	searchFilter *data.Query   // last successfully parsed searchQuery
+	searchErr    error         // parse error for searchQuery, shown in the search bar
+	searchCompletions []string // autocomplete candidates for the search input
+	searchCompletionIdx int    // index into searchCompletions while cycling with tab (-1 = not cycling)
+	logSearchActive bool       // true while the log or conversation search bar takes input
+	logSearchInput  string     // query typed into the log search bar
 	snapshotPath string        // if set, write snapshot on initial load and quit
+	replay       *data.Snapshot // if set, sessions come from this snapshot instead of live fetchers
 	loadSpinner  spinner.Model // animated spinner shown during initial load
 	loadTagline  string        // randomized tagline for the loading screen
 }
 
 // NewModel creates a new TUI model
 func NewModel(repo string, debug bool, demo bool, snapshotPath string, version string) Model {
+	cfg, err := config.Load("")
+	return newModel(cfg, err, nil, repo, debug, demo, snapshotPath, version)
+}
+
+// newModel builds the model from a loaded config. A non-nil replay drives
+// the TUI from a snapshot instead of the live fetchers.
+func newModel(cfg *config.Config, cfgErr error, replay *data.Snapshot, repo string, debug bool, demo bool, snapshotPath string, version string) Model {
 	ctx := NewProgramContext()
 	ctx.Debug = debug
 	ctx.Version = version
-	cfg, err := config.Load("")
-	if err == nil {
+	if cfgErr == nil {
 		ctx.Config = cfg
 	} else {
-		ctx.Error = fmt.Errorf("failed to load config: %w", err)
+		ctx.Error = fmt.Errorf("failed to load config: %w", cfgErr)
 	}
 
+	applyRenderMode(ctx.Config)
+	ApplySessionRoots(ctx.Config)
+	applyRemoteHosts(ctx.Config)
+	ApplyArchiveDir(ctx.Config)
+
 	if repo == "" && len(ctx.Config.Repos) > 0 {
 		repo = ctx.Config.Repos[0]
 	}
@@ -109,8 +154,10 @@ func NewModel(repo string, debug bool, demo bool, snapshotPath string, version s
 		refreshSeconds = 30
 	}
 
-	theme := NewThemeFromConfig(ctx.Config.Theme)
-	keys := NewKeybindings()
+	customThemes, themeErrs := LoadThemes(ctx.Config.ThemesDirPath())
+	theme := resolveTheme(ctx.Config.Theme, customThemes)
+	colors.Set(theme.Palette)
+	keys, keyProblems := ApplyKeyOverrides(NewKeybindings(), ctx.Config.Keys)
 
 	// Prepare key bindings for footer
 	footerKeys := []key.Binding{
@@ -123,7 +170,10 @@ func NewModel(repo string, debug bool, demo bool, snapshotPath string, version s
 		keys.ExitApp,
 	}
 
-	dismissedStore := data.NewDismissedStore()
+	annotations := data.NewAnnotationStore()
+	if replay != nil {
+		annotations = data.NewMemoryAnnotationStore(replayAnnotations(replay))
+	}
 
 	var animIconFunc func(string, int) string
 	if ctx.Config.AnimationsEnabled() {
@@ -133,28 +183,24 @@ func NewModel(repo string, debug bool, demo bool, snapshotPath string, version s
 	// Loading screen spinner
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("75")})
+	sp.Style = lipgloss.NewStyle().Foreground(colors.Current().Highlight)
 	tagline := loadingTaglines[rand.Intn(len(loadingTaglines))]
 
 	// Determine default view mode
-	defaultView := ViewModeMission // dashboard-first by default
-	switch ctx.Config.DefaultView {
-	case "table":
-		defaultView = ViewModeList
-	case "active":
-		defaultView = ViewModeActive
-	case "dashboard", "mission", "":
-		defaultView = ViewModeMission
-	}
+	defaultView, _ := viewModeFromConfig(ctx.Config.DefaultView) // dashboard-first by default
 
-	return Model{
+	m := Model{
 		ctx:         ctx,
 		theme:       theme,
 		keys:        keys,
 		header:      header.New(theme.Title, theme.TabActive, theme.TabInactive, theme.TabCount, "⚡ Agent Sessions", &ctx.StatusFilter, ctx.Config.AsciiHeaderEnabled(), ctx.Version),
 		footer:      footer.New(theme.Footer, footerKeys),
 		help:        help.New(),
-		taskList:       tasklist.NewWithStore(theme.Title, theme.TableHeader, theme.TableRow, theme.TableRowSelected, theme.SectionHeader, StatusIcon, animIconFunc, dismissedStore),
+		palette:     picker.New("Commands", true),
+		annotationPrompt: prompt.New(),
+		reviewPrompt:     prompt.New(),
+		customThemes: customThemes,
+		taskList:       tasklist.NewWithStore(theme.Title, theme.TableHeader, theme.TableRow, theme.TableRowSelected, theme.SectionHeader, StatusIcon, animIconFunc, annotations),
 		taskDetail:     taskdetail.New(theme.Title, theme.Border, StatusIcon),
 		logView:        logview.New(theme.Title, 80, 20),
 		diffView:       diffview.New(80, 20),
@@ -163,7 +209,8 @@ func NewModel(repo string, debug bool, demo bool, snapshotPath string, version s
 		mission:        mission.New(theme.Title, theme.TableRow, theme.TableRowSelected, StatusIcon, animIconFunc),
 		activeView:     activeview.New(StatusIcon, animIconFunc),
 		gitActivity:    gitactivity.New(80, 20),
-		dismissedStore: dismissedStore,
+		annotations:    annotations,
+		resourceMonitor: data.NewResourceMonitor(),
 		statsBar:       statsbar.New(),
 		viewMode:    defaultView,
 		showPreview: false,
@@ -174,9 +221,26 @@ func NewModel(repo string, debug bool, demo bool, snapshotPath string, version s
 		prevSessions: make(map[string]string, 64),
 		demo:         demo,
 		snapshotPath: snapshotPath,
+		replay:       replay,
 		loadSpinner: sp,
 		loadTagline: tagline,
 	}
+
+	m.help.SetSections(helpSections(keys), firstKey(keys.ShowHelp))
+	if len(keyProblems) > 0 {
+		m.toast.Push("⚠️", "Keys", strings.Join(keyProblems, "; "))
+	}
+	if len(themeErrs) > 0 {
+		m.toast.Push("⚠️", "Themes", joinErrors(themeErrs))
+	}
+
+	// defaultView may also name a saved view
+	if v, ok := ctx.Config.FindView(ctx.Config.DefaultView); ok {
+		if problems := m.applyView(v); len(problems) > 0 {
+			m.toast.Push("⚠️", "View "+v.Name, strings.Join(problems, "; "))
+		}
+	}
+	return m
 }
 
 // Init initializes the Bubble Tea program
@@ -185,11 +249,22 @@ func (m Model) Init() tea.Cmd {
 		m.loadSpinner.Tick,
 		m.fetchLocalSessions,  // Phase 1: fast, shows content immediately
 		m.fetchAgentTasks,     // Phase 2: runs concurrently, returns when API responds
-		checkLatestVersion,    // Non-blocking: check for updates
+	}
+	if m.replay == nil {
+		cmds = append(cmds, checkLatestVersion) // Non-blocking: check for updates
+	}
+	if !m.demo && m.replay == nil && len(data.RemoteHosts()) > 0 {
+		cmds = append(cmds, m.fetchRemoteSessions) // ssh round trips; never block local results
 	}
 	if m.ctx.Config.AnimationsEnabled() {
 		cmds = append(cmds, m.animationTickCmd())
 	}
+	if m.canSampleResources() {
+		cmds = append(cmds, m.resourceTick())
+	}
+	if m.canLoadActivity() {
+		cmds = append(cmds, m.activityTick())
+	}
 	return tea.Batch(cmds...)
 }
 
@@ -201,6 +276,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ctx.Height = msg.Height
 		m.header.SetSize(msg.Width, msg.Height)
 		m.help.SetSize(msg.Width, msg.Height)
+		m.chooser.SetSize(msg.Width, msg.Height)
+		m.annotationPrompt.SetSize(msg.Width, msg.Height)
+		m.reviewPrompt.SetSize(msg.Width, msg.Height)
 		m.statsBar.SetWidth(msg.Width)
 		m.footer.SetWidth(msg.Width)
 		m.updateSplitLayout()
@@ -241,13 +319,59 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Phase 1: show local sessions immediately
 		m.mergeSessions(msg.sessions)
 		// Kick off token usage loading after first render
-		return m, m.fetchTokenUsage
+		return m, tea.Batch(m.fetchTokenUsage, m.loadActivity(), m.loadPRStatus())
 
 	case agentTasksLoadedMsg:
 		// Phase 2: merge agent tasks into existing sessions
 		if msg.sessions != nil {
 			m.mergeSessions(msg.sessions)
 		}
+		return m, m.loadPRStatus()
+
+	case archiveDoneMsg:
+		return m, m.handleArchiveDone(msg)
+
+	case processLocksMsg:
+		m.handleProcessLocks(msg)
+		return m, nil
+
+	case processStoppedMsg:
+		return m, m.handleProcessStopped(msg)
+
+	case staleLocksRemovedMsg:
+		return m, m.handleStaleLocksRemoved(msg)
+
+	case prActionDoneMsg:
+		return m, m.handlePRActionDone(msg)
+
+	case worktreeReadyMsg:
+		return m, m.handleWorktreeReady(msg)
+
+	case archivedSessionsLoadedMsg:
+		if msg.err != nil {
+			m.toast.Push("⚠️", "Archive", msg.err.Error())
+			return m, nil
+		}
+		if !m.showArchived {
+			return m, nil
+		}
+		m.mergeSessions(msg.sessions)
+		m.toast.Push("🗄️", "Archive", fmt.Sprintf("showing %d archived session(s), read-only", len(msg.sessions)))
+		return m, nil
+
+	case remoteSessionsLoadedMsg:
+		if msg.sessions != nil {
+			m.mergeSessions(msg.sessions)
+		}
+		if len(msg.usage) > 0 {
+			usage := make(map[string]*data.TokenUsage, len(m.tokenUsageMap)+len(msg.usage))
+			maps.Copy(usage, m.tokenUsageMap)
+			maps.Copy(usage, msg.usage)
+			m.enrichTokenUsage(usage)
+		}
+		if msg.err != nil {
+			m.toast.Push("⚠️", "Remote hosts", msg.err.Error())
+		}
 		return m, nil
 
 	case tokenUsageLoadedMsg:
@@ -270,49 +394,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.refreshCmd()
 
 	case tasksLoadedMsg:
-		m.ctx.Error = nil
-		m.ctx.Counts = msg.counts
+		m.attachActivity(msg.sessions)
+		m.attachPRStatus(msg.sessions)
 		m.tokenUsageMap = msg.tokenUsage
-		m.header.SetCounts(header.FilterCounts{
-			All:       msg.counts.All,
-			Attention: msg.counts.Attention,
-			Warning:   msg.counts.Warning,
-			Active:    msg.counts.Active,
-			Completed: msg.counts.Completed,
-			Failed:    msg.counts.Failed,
-		})
-		m.taskList.SetLoading(false)
-		m.taskList.SetTasks(msg.tasks)
-		// Always push sessions to all views so they're current when switched to.
-		m.mission.SetSessions(msg.allSessions)
 		m.mission.SetTokenUsage(msg.tokenUsage)
-		if m.viewMode != ViewModeMission {
-			m.taskDetail.SetAllSessions(msg.allSessions)
-		}
+		// Counts, the search and the status tab are worked out here, from the
+		// same sessions the views show.
+		m.recomputeAndDisplay(msg.sessions)
 
-		// Detect status changes and push toasts (skip first load)
+		// Detect status changes and push toasts (skip first load). Sessions
+		// the search hides are tracked too, so their changes aren't missed.
 		if m.prevSessions != nil {
-			for _, s := range msg.tasks {
+			for _, s := range msg.sessions {
 				if prev, ok := m.prevSessions[s.ID]; ok && prev != s.Status {
 					m.toast.Push(StatusIcon(s.Status), s.Title, prev+" → "+s.Status)
 				}
 			}
 		} else {
 			// First load: pick the best default tab based on actual data
-			m.ctx.StatusFilter = smartDefaultFilter(msg.counts)
+			m.ctx.StatusFilter = smartDefaultFilter(m.ctx.Counts)
 			m.taskList.SetLoading(true)
 			clear(m.prevSessions)
-			for _, s := range msg.tasks {
+			for _, s := range msg.sessions {
 				m.prevSessions[s.ID] = s.Status
 			}
 			return m, m.fetchTasks
 		}
 		// Update prevSessions for next comparison
 		clear(m.prevSessions)
-		for _, s := range msg.tasks {
+		for _, s := range msg.sessions {
 			m.prevSessions[s.ID] = s.Status
 		}
-		return m, nil
+		return m, tea.Batch(m.loadActivity(), m.loadPRStatus())
 
 	case taskDetailLoadedMsg:
 		m.ctx.Error = nil
@@ -331,23 +444,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case diffLoadedMsg:
 		m.ctx.Error = nil
+		m.diffRepo, m.diffPR = msg.repo, msg.prNumber
 		m.diffView.SetDiffs(msg.files)
 		return m, nil
 
+	case reviewSubmittedMsg:
+		m.handleReviewSubmitted(msg)
+		return m, nil
+
+	case copilotRequestedMsg:
+		m.handleCopilotRequested(msg)
+		return m, nil
+
 	case gitDiffLoadedMsg:
 		m.ctx.Error = nil
 		m.gitActivity.SetDiffResult(msg.result)
 		return m, nil
 
+	case gitCommitDiffLoadedMsg:
+		m.ctx.Error = nil
+		m.gitActivity.SetCommitDiff(msg.hash, msg.diff)
+		return m, nil
+
 	case gitDiffPollTickMsg:
-		if m.viewMode != ViewModeGitActivity {
-			return m, nil
-		}
-		session := m.taskList.SelectedTask()
-		if session == nil || session.WorkDir == "" {
+		if m.viewMode != ViewModeGitActivity || m.gitWorkDir == "" {
 			return m, nil
 		}
-		return m, tea.Batch(m.fetchGitDiff(session.WorkDir), m.gitDiffPollTick())
+		return m, tea.Batch(m.fetchGitDiff(m.gitWorkDir), m.gitDiffPollTick())
 
 	case refreshTickMsg:
 		cmds := []tea.Cmd{m.fetchTasks, m.refreshCmd()}
@@ -368,11 +491,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.animRunning = cmd != nil
 		return m, cmd
 
+	case resourceTickMsg:
+		return m, m.handleResourceTick()
+
+	case resourcesSampledMsg:
+		m.applyResources(msg.resources)
+		return m, nil
+
+	case activityTickMsg:
+		return m, m.handleActivityTick()
+
+	case activityLoadedMsg:
+		m.applyActivity(msg.activity)
+		return m, nil
+
+	case prStatusLoadedMsg:
+		m.applyPRStatus(msg)
+		return m, nil
+
 	case logPollTickMsg:
 		if m.viewMode != ViewModeLog || !m.logView.IsLive() {
 			return m, nil
 		}
-		session := m.taskList.SelectedTask()
+		session := m.findSession(m.logSessionID)
 		if session == nil {
 			return m, nil
 		}
@@ -401,7 +542,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case clipboardCopiedMsg:
-		m.toast.Push("📋", "Copied", msg.value)
+		m.toast.Push("📋", "Copied "+msg.what, clipboardPreview(msg.value)+" · via "+msg.via)
+		return m, nil
+
+	case copyRequestMsg:
+		return m, m.copyToClipboard(msg.what, msg.text)
+
+	case exportedMsg:
+		m.toast.Push("💾", "Saved "+msg.what, msg.path)
 		return m, nil
 	}
 
@@ -501,7 +649,7 @@ func (m Model) View() tea.View {
 	}
 
 	if m.ctx.Debug {
-		mainView = fmt.Sprintf("DEBUG ON • command logs: %s\n", data.DebugLogPath()) + mainView
+		mainView = fmt.Sprintf("DEBUG ON %s command logs: %s\n", a11y.Glyph("•", "-"), data.DebugLogPath()) + mainView
 	}
 
 	// Show toasts just above the footer
@@ -514,13 +662,27 @@ func (m Model) View() tea.View {
 	searchView := ""
 	if m.searchActive || m.searchQuery != "" {
 		searchStyle := lipgloss.NewStyle().
-			Foreground(compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("75")}).
+			Foreground(colors.Current().Highlight).
 			Bold(true)
 		queryDisplay := m.searchQuery
 		if m.searchActive {
-			queryDisplay += "▍" // cursor
+			queryDisplay += a11y.Glyph("▍", "_") // cursor
+		}
+		searchView = searchStyle.Render(fmt.Sprintf("  %sFilter: %s", a11y.Glyph("🔍 ", ""), queryDisplay))
+		if m.searchErr != nil {
+			searchView += "  " + lipgloss.NewStyle().
+				Foreground(colors.Current().Status.Failed).
+				Render(a11y.Glyph("⚠ ", "! ")+m.searchErr.Error())
 		}
-		searchView = searchStyle.Render(fmt.Sprintf("  🔍 Filter: %s", queryDisplay)) + "\n"
+		searchView += "\n"
+		if m.searchActive && len(m.searchCompletions) > 0 {
+			searchView += lipgloss.NewStyle().Faint(true).
+				Render("     tab: "+searchCompletionHint(m.searchCompletions, m.searchCompletionIdx)) + "\n"
+		}
+	}
+
+	if bar := m.logSearchBar(); bar != "" && m.viewMode == ViewModeLog {
+		searchView = bar
 	}
 
 	// Assemble content without footer
@@ -545,6 +707,14 @@ func (m Model) View() tea.View {
 	// Overlay help panel when visible
 	if m.help.Visible() {
 		result = m.help.View()
+	} else if m.palette.Visible() {
+		result = m.palette.View()
+	} else if m.chooser.Visible() {
+		result = m.chooser.View()
+	} else if m.annotationPrompt.Visible() {
+		result = m.annotationPrompt.View()
+	} else if m.reviewPrompt.Visible() {
+		result = m.reviewPrompt.View()
 	}
 
 	v.SetContent(result)
@@ -567,11 +737,11 @@ var loadingTaglines = []string{
 func (m Model) viewLoading() string {
 	logo := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(compat.AdaptiveColor{Light: lipgloss.Color("24"), Dark: lipgloss.Color("75")}).
+		Foreground(colors.Current().Highlight).
 		Render("⚡ Agent Viz")
 
 	spinnerLine := m.loadSpinner.View() + " " + lipgloss.NewStyle().
-		Foreground(compat.AdaptiveColor{Light: lipgloss.Color("242"), Dark: lipgloss.Color("245")}).
+		Foreground(colors.Current().Muted).
 		Italic(true).
 		Render(m.loadTagline)
 
@@ -607,6 +777,8 @@ func (m Model) viewModeName() string {
 		return "diff"
 	case ViewModeGitActivity:
 		return "git-activity"
+	case ViewModeActive:
+		return "active"
 	default:
 		return "unknown"
 	}
@@ -643,6 +815,7 @@ func (m Model) writeSnapshot() {
 		},
 		Sessions:     sessions,
 		FocusedPanel: m.viewModeName(),
+		State:        m.snapshotState(),
 	}
 	_ = data.WriteSnapshot(m.snapshotPath, snap)
 }
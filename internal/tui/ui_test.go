@@ -6,6 +6,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
 )
 
 func TestResumeSessionErr_ValidRunningSession(t *testing.T) {
@@ -183,15 +184,17 @@ func TestResumeSession_DetailViewResume(t *testing.T) {
 
 func TestResumeSession_LogViewResume(t *testing.T) {
 	m := NewModel("", false, false, "", "dev")
-	m.taskList.SetTasks([]data.Session{
+	m.allSessions = []data.Session{
 		{
 			ID:     "local-1",
 			Status: "queued",
 			Title:  "Local Session",
 			Source: data.SourceLocalCopilot,
 		},
-	})
+	}
+	m.taskList.SetTasks(m.allSessions)
 	m.viewMode = ViewModeLog
+	m.logSessionID = "local-1"
 
 	// Press 's' in log view
 	_, cmd := m.handleLogKeys(tea.KeyPressMsg{Code: 's', Text: "s"})
@@ -509,6 +512,65 @@ func TestHandleListKeys_GOpensGitActivity(t *testing.T) {
 	}
 }
 
+func TestHandleGitActivityKeys_BrowsesCommits(t *testing.T) {
+	m := NewModel("", false, false, "", "dev")
+	m.taskList.SetTasks([]data.Session{
+		{ID: "local-1", Status: "running", Title: "Local Session", Source: data.SourceLocalCopilot, WorkDir: "/tmp/test-repo"},
+	})
+	updated, _ := m.handleListKeys(tea.KeyPressMsg{Code: 'G', Text: "G"})
+	m = updated.(Model)
+	m.gitActivity.SetDiffResult(&data.GitDiffResult{Branch: &data.GitBranchResult{
+		Base:    "main",
+		Commits: []data.GitCommit{{Hash: "bbbb", Short: "bbbb"}, {Hash: "aaaa", Short: "aaaa"}},
+	}})
+
+	updated, _ = m.handleGitActivityKeys(tea.KeyPressMsg{Code: tea.KeyTab})
+	m = updated.(Model)
+	if !m.gitActivity.BrowsingCommits() {
+		t.Fatalf("expected tab to move to the commit list, got section %v", m.gitActivity.Section())
+	}
+	updated, _ = m.handleGitActivityKeys(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	m = updated.(Model)
+	updated, cmd := m.handleGitActivityKeys(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd == nil || m.gitActivity.BrowsingCommits() {
+		t.Fatal("expected enter to open the commit and fetch its diff")
+	}
+	if c, _ := m.gitActivity.SelectedCommit(); c.Hash != "aaaa" {
+		t.Fatalf("expected the second commit to be open, got %+v", c)
+	}
+
+	updated, _ = m.handleGitActivityKeys(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(Model)
+	if m.viewMode != ViewModeGitActivity || !m.gitActivity.BrowsingCommits() {
+		t.Fatal("expected esc to return to the commit list first")
+	}
+	updated, _ = m.handleGitActivityKeys(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if updated.(Model).viewMode == ViewModeGitActivity {
+		t.Fatal("expected a second esc to leave git activity")
+	}
+}
+
+func TestHandleDiffKeys_NavigatesHunksAndFiles(t *testing.T) {
+	m := NewModel("", false, false, "", "dev")
+	m.viewMode = ViewModeDiff
+	m.diffView.SetDiffs(diffview.ParseUnifiedDiff("diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-x\n+y\n" +
+		"diff --git a/b.go b/b.go\n--- a/b.go\n+++ b/b.go\n@@ -1 +1 @@\n-x\n+z\n"))
+
+	for _, k := range []tea.KeyPressMsg{{Code: 'j', Text: "j"}, {Code: 'n', Text: "n"}} {
+		updated, _ := m.handleDiffKeys(k)
+		m = updated.(Model)
+	}
+	if path, line, _ := m.diffView.SelectedLine(); path != "b.go" || line.Kind != diffview.LineHunk {
+		t.Fatalf("expected n to jump to the next file's hunk, got %s %+v", path, line)
+	}
+	updated, _ := m.handleDiffKeys(tea.KeyPressMsg{Code: '[', Text: "["})
+	m = updated.(Model)
+	if path, _, _ := m.diffView.SelectedLine(); path != "a.go" {
+		t.Fatalf("expected [ to show the previous file, got %s", path)
+	}
+}
+
 func TestHandleListKeys_GToastsForNonLocalSession(t *testing.T) {
 	m := NewModel("", false, false, "", "dev")
 	m.taskList.SetTasks([]data.Session{
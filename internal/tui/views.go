@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// viewModeFromConfig maps a config screen name to a ViewMode.
+func viewModeFromConfig(name string) (ViewMode, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "table", "list":
+		return ViewModeList, true
+	case "active":
+		return ViewModeActive, true
+	case "dashboard", "mission", "":
+		return ViewModeMission, true
+	}
+	return ViewModeMission, false
+}
+
+// applyView switches to a saved view, applying each setting it specifies.
+// It returns a description of any settings that were invalid and skipped.
+func (m *Model) applyView(v config.View) []string {
+	var problems []string
+
+	if v.Mode != "" {
+		if mode, ok := viewModeFromConfig(v.Mode); ok {
+			m.viewMode = mode
+		} else {
+			problems = append(problems, fmt.Sprintf("unknown mode %q", v.Mode))
+		}
+	}
+	if v.Status != "" {
+		if isValidFilter(v.Status) {
+			m.ctx.StatusFilter = v.Status
+			m.statusPinned = true
+		} else {
+			problems = append(problems, fmt.Sprintf("unknown status %q", v.Status))
+		}
+	}
+	if v.GroupBy != "" {
+		mode := v.GroupBy
+		if mode == "none" {
+			mode = ""
+		}
+		if !m.taskList.SetGroupBy(mode) {
+			problems = append(problems, fmt.Sprintf("unknown groupBy %q", v.GroupBy))
+		}
+	}
+	if v.Sort != "" && !m.taskList.SetSortBy(v.Sort) {
+		problems = append(problems, fmt.Sprintf("unknown sort %q", v.Sort))
+	}
+	if len(v.Panels) > 0 {
+		if unknown := m.mission.SetVisiblePanels(v.Panels); len(unknown) > 0 {
+			problems = append(problems, fmt.Sprintf("unknown panels %s", strings.Join(unknown, ", ")))
+		}
+	}
+	if v.Preview != nil {
+		m.showPreview = *v.Preview
+		m.updateSplitLayout()
+	}
+
+	if v.Filter != "" {
+		m.setSearchQuery(v.Filter)
+		if m.searchErr != nil {
+			problems = append(problems, fmt.Sprintf("filter: %v", m.searchErr))
+		}
+	}
+	m.currentView = v.Name
+
+	if m.initialLoadDone {
+		m.lastFingerprint = ""
+		m.lastSplitTaskID = ""
+		m.mission.SetSize(m.ctx.Width, m.ctx.Height-6)
+		m.activeView.SetSize(m.ctx.Width, m.ctx.Height-6)
+		m.recomputeAndDisplay(m.visibleSessions())
+	}
+	return problems
+}
+
+// switchToView applies the named saved view and reports the outcome as a toast.
+func (m *Model) switchToView(name string) {
+	v, ok := m.ctx.Config.FindView(name)
+	if !ok {
+		m.toast.Push("⚠️", "View", fmt.Sprintf("no saved view named %q", name))
+		return
+	}
+	if problems := m.applyView(v); len(problems) > 0 {
+		m.toast.Push("⚠️", "View "+v.Name, strings.Join(problems, "; "))
+		return
+	}
+	m.toast.Push("🗂️", "View", v.Name)
+}
+
+// switchToViewIndex applies the n-th (1-based) saved view.
+func (m *Model) switchToViewIndex(n int) bool {
+	if n < 1 || n > len(m.ctx.Config.Views) {
+		return false
+	}
+	m.switchToView(m.ctx.Config.Views[n-1].Name)
+	return true
+}
+
+// openViewPicker shows the saved-view picker, or explains how to define
+// views when none are configured.
+func (m *Model) openViewPicker() {
+	if len(m.ctx.Config.Views) == 0 {
+		m.toast.Push("ℹ️", "Views", "define views: in ~/.gh-agent-viz.yml")
+		return
+	}
+	items := make([]picker.Item, len(m.ctx.Config.Views))
+	for i, v := range m.ctx.Config.Views {
+		items[i] = picker.Item{Label: v.Name, Detail: viewSummary(v), Value: v.Name}
+		if v.Name == m.currentView {
+			items[i].Key = "current"
+		}
+	}
+	m.openChooser("Saved Views", items, chooser{
+		closeKeys: []key.Binding{m.keys.SwitchView},
+		choose: func(m *Model, item picker.Item) tea.Cmd {
+			m.switchToView(item.Value)
+			return nil
+		},
+	})
+}
+
+// viewSummary describes a view's settings in a single short line.
+func viewSummary(v config.View) string {
+	var parts []string
+	if v.Mode != "" {
+		parts = append(parts, v.Mode)
+	}
+	if v.Status != "" {
+		parts = append(parts, v.Status)
+	}
+	if v.Filter != "" {
+		parts = append(parts, v.Filter)
+	}
+	if v.GroupBy != "" {
+		parts = append(parts, "by "+v.GroupBy)
+	}
+	if v.Sort != "" {
+		parts = append(parts, "sort "+v.Sort)
+	}
+	return strings.Join(parts, " · ")
+}
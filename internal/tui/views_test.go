@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/config"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func newViewsTestModel() Model {
+	m := NewModel("", false, false, "", "dev")
+	m.ctx.Config.Views = []config.View{
+		{Name: "failures", Mode: "table", Status: "failed", Filter: "repo:org/api", GroupBy: "none", Sort: "cost"},
+		{Name: "overview", Mode: "dashboard", Status: "all", Panels: []string{"attention", "fleet"}},
+	}
+	m.initialLoadDone = true
+	m.allSessions = []data.Session{
+		{ID: "a", Status: "failed", Repository: "org/api"},
+		{ID: "b", Status: "failed", Repository: "org/web"},
+		{ID: "c", Status: "running", Repository: "org/api"},
+	}
+	return m
+}
+
+func TestApplyView_SetsLayoutAndFilters(t *testing.T) {
+	m := newViewsTestModel()
+	v, _ := m.ctx.Config.FindView("failures")
+	if problems := m.applyView(v); len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	if m.viewMode != ViewModeList {
+		t.Errorf("expected list view, got %v", m.viewMode)
+	}
+	if m.ctx.StatusFilter != "failed" {
+		t.Errorf("expected failed status filter, got %q", m.ctx.StatusFilter)
+	}
+	if m.searchQuery != "repo:org/api" {
+		t.Errorf("expected view filter to become the search query, got %q", m.searchQuery)
+	}
+	if m.taskList.SortBy() != "cost" {
+		t.Errorf("expected cost sort, got %q", m.taskList.SortBy())
+	}
+	selected := m.taskList.SelectedTask()
+	if selected == nil || selected.ID != "a" {
+		t.Errorf("expected only session a in the list, got %+v", selected)
+	}
+	if m.currentView != "failures" {
+		t.Errorf("expected currentView to be recorded, got %q", m.currentView)
+	}
+}
+
+func TestApplyView_KeepsSettingsTheViewOmits(t *testing.T) {
+	m := newViewsTestModel()
+	m.mission.SetVisiblePanels([]string{"attention", "fleet"})
+	m.setSearchQuery("repo:org/web")
+	if problems := m.applyView(config.View{Name: "sorted", Sort: "title"}); len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	if got := strings.Join(m.mission.VisiblePanels(), ","); got != "attention,fleet" {
+		t.Errorf("expected the panels kept, got %s", got)
+	}
+	if m.searchQuery != "repo:org/web" {
+		t.Errorf("expected the search kept, got %q", m.searchQuery)
+	}
+}
+
+func TestApplyView_ReportsInvalidSettings(t *testing.T) {
+	m := newViewsTestModel()
+	problems := m.applyView(config.View{Name: "bad", Mode: "kanban", GroupBy: "owner", Sort: "random", Panels: []string{"nope"}})
+	if len(problems) != 4 {
+		t.Fatalf("expected 4 problems, got %v", problems)
+	}
+}
+
+func TestNumberKeySwitchesView(t *testing.T) {
+	m := newViewsTestModel()
+	m.viewMode = ViewModeList
+	result, _ := m.handleKeyPress(tea.KeyPressMsg{Code: '2', Text: "2"})
+	updated := result.(Model)
+	if updated.currentView != "overview" {
+		t.Fatalf("expected number key 2 to select the second view, got %q", updated.currentView)
+	}
+	if updated.viewMode != ViewModeMission {
+		t.Errorf("expected dashboard mode, got %v", updated.viewMode)
+	}
+}
+
+func TestViewPicker_SelectWithEnter(t *testing.T) {
+	m := newViewsTestModel()
+	m.viewMode = ViewModeMission
+
+	result, _ := m.handleKeyPress(tea.KeyPressMsg{Code: 'v', Text: "v"})
+	m = result.(Model)
+	if !m.pickerOpen("Saved Views") {
+		t.Fatal("expected v to open the view picker")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = result.(Model)
+	if m.pickerOpen("Saved Views") {
+		t.Error("expected picker to close after selection")
+	}
+	if m.currentView != "failures" {
+		t.Errorf("expected first view to be applied, got %q", m.currentView)
+	}
+}
+
+func TestViewPicker_NoViewsShowsToast(t *testing.T) {
+	m := newViewsTestModel()
+	m.ctx.Config.Views = nil
+	m.openViewPicker()
+	if m.pickerOpen("Saved Views") {
+		t.Error("picker should not open without configured views")
+	}
+	if !m.toast.HasToasts() {
+		t.Error("expected a toast explaining how to configure views")
+	}
+}
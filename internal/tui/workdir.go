@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/picker"
+)
+
+// canOpenWorkDir reports whether the editor or a shell can be opened in a
+// session's working directory: a local session on this machine, outside
+// demo data and replays.
+func canOpenWorkDir(m *Model, s *data.Session) bool {
+	return hasWorkDir(s) && !m.demo && m.replay == nil
+}
+
+// openWorkDir hands the terminal to the editor opened on the session's
+// working directory, or a shell started in it.
+func (m *Model) openWorkDir(s *data.Session, editor bool) tea.Cmd {
+	if !canOpenWorkDir(m, s) {
+		if s != nil {
+			m.toast.Push("ℹ️", "Working directory", "only available for local sessions with a working directory")
+		}
+		return nil
+	}
+	return m.openDir(s.WorkDir, editor)
+}
+
+// openDir hands the terminal to the editor or a shell in dir.
+func (m *Model) openDir(dir string, editor bool) tea.Cmd {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		m.toast.Push("⚠️", "Working directory", dir+" no longer exists")
+		return nil
+	}
+	what := "shell"
+	if editor {
+		what = "editor"
+	}
+	return handOff(what, openDirCommand(dir, editor, ""))
+}
+
+// openFileAt hands the terminal to the editor opened on path, relative to
+// dir, at line.
+func (m *Model) openFileAt(dir, path string, line int) tea.Cmd {
+	if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+		m.toast.Push("⚠️", "Open file", path+" does not exist in "+dir)
+		return nil
+	}
+	return handOff("editor", openFileCommand(dir, path, line))
+}
+
+// diffCheckout returns a local checkout to open the diff view's files
+// from: the working directory of a local session, or the worktree its
+// branch was checked out into. It is empty when there is neither.
+func (m Model) diffCheckout() string {
+	s := m.findSession(m.diffSessionID)
+	if s == nil || m.demo || m.replay != nil {
+		return ""
+	}
+	if hasWorkDir(s) {
+		return s.WorkDir
+	}
+	if s.Repository == "" || s.Branch == "" {
+		return ""
+	}
+	path := data.WorktreePath(m.ctx.Config.WorktreeDirPath(), s.Repository, s.Branch)
+	if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+		return ""
+	}
+	return path
+}
+
+// openFilePicker offers the changed files to open in the editor, each at
+// its first change.
+func (m *Model) openFilePicker(dir string, files []diffview.FileDiff) {
+	if len(files) == 0 {
+		m.toast.Push("ℹ️", "Open file", "no changed files in this section")
+		return
+	}
+	items := make([]picker.Item, 0, len(files))
+	for _, f := range files {
+		line := diffview.FirstChangedLine(f)
+		detail := fmt.Sprintf("+%d -%d", f.Additions, f.Deletions)
+		if line > 0 {
+			detail += fmt.Sprintf(" · line %d", line)
+		}
+		items = append(items, picker.Item{Label: f.Path, Detail: detail, Value: strconv.Itoa(line) + ":" + f.Path})
+	}
+	m.fileDir = dir
+	m.openChooser("Open File", items, chooser{choose: (*Model).openPickedFile})
+}
+
+// openPickedFile opens the file chosen from the file chooser.
+func (m *Model) openPickedFile(choice picker.Item) tea.Cmd {
+	lineText, path, _ := strings.Cut(choice.Value, ":")
+	line, _ := strconv.Atoi(lineText)
+	return m.openFileAt(m.fileDir, path, line)
+}
+
+// handleWorkDirKeys handles the editor and shell keys. In the session
+// views they open the selected session's working directory; in the diff
+// and git activity views the editor key opens a changed file at its change
+// instead. It reports whether msg was one of those keys.
+func (m *Model) handleWorkDirKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	editor := key.Matches(msg, m.keys.OpenEditor)
+	if !editor && !key.Matches(msg, m.keys.OpenShell) {
+		return nil, false
+	}
+	switch {
+	case m.viewMode == ViewModeDiff:
+		dir := m.diffCheckout()
+		if dir == "" {
+			m.toast.Push("ℹ️", "Open file", fmt.Sprintf("no local checkout of this branch — press %s to check it out", firstKey(m.keys.CheckoutWorktree)))
+			return nil, true
+		}
+		if !editor {
+			return m.openDir(dir, false), true
+		}
+		if path, line, ok := m.diffView.SelectedFileLine(); ok {
+			return m.openFileAt(dir, path, line), true
+		}
+		return nil, true
+	case m.viewMode == ViewModeGitActivity:
+		if m.gitWorkDir == "" || m.demo || m.replay != nil {
+			return nil, true
+		}
+		if !editor {
+			return m.openDir(m.gitWorkDir, false), true
+		}
+		m.openFilePicker(m.gitWorkDir, m.gitActivity.Files())
+		return nil, true
+	case slices.Contains(sessionModes, m.viewMode):
+		return m.openWorkDir(m.selectedSession(), editor), true
+	}
+	return nil, false
+}
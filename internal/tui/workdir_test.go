@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+	"github.com/maxbeizer/gh-agent-viz/internal/tui/components/diffview"
+)
+
+func TestEditorAtLine(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	tests := []struct {
+		editor string
+		want   string
+	}{
+		{"nvim", "nvim +12 main.go"},
+		{"code -w", "code -w --goto main.go:12"},
+		{"/usr/local/bin/hx", "/usr/local/bin/hx main.go:12"},
+		{"", "vi +12 main.go"},
+	}
+	for _, tt := range tests {
+		t.Setenv("EDITOR", tt.editor)
+		if got := strings.Join(editorAtLine("main.go", 12), " "); got != tt.want {
+			t.Errorf("EDITOR=%q: got %q, want %q", tt.editor, got, tt.want)
+		}
+	}
+	if got := strings.Join(editorAtLine("main.go", 0), " "); got != "vi main.go" {
+		t.Errorf("expected no line argument without a line, got %q", got)
+	}
+	c := openFileCommand("/work", "main.go", 3)
+	if c.Dir != "/work" || strings.Join(c.Args, " ") != "vi +3 main.go" {
+		t.Errorf("expected the editor in the checkout, got %v in %s", c.Args, c.Dir)
+	}
+}
+
+// workDirTestModel adds a local session working in a directory holding
+// main.go, and an agent task without a local checkout.
+func workDirTestModel(t *testing.T) (Model, string) {
+	t.Helper()
+	m := annotationTestModel(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.mergeSessions([]data.Session{
+		{ID: "local", Title: "Hack", Status: "running", Source: data.SourceLocalCopilot, WorkDir: dir, UpdatedAt: time.Now()},
+		{ID: "task", Title: "Fix", Status: "completed", Source: data.SourceAgentTask, Repository: "org/repo", Branch: "copilot/fix", UpdatedAt: time.Now()},
+	})
+	return m, dir
+}
+
+func TestOpenWorkDir(t *testing.T) {
+	m, _ := workDirTestModel(t)
+	if m.openWorkDir(m.findSession("local"), true) == nil {
+		t.Error("expected the editor opened in the working directory")
+	}
+	if m.openWorkDir(m.findSession("task"), false) != nil ||
+		!strings.Contains(ansi.Strip(m.toast.View()), "only available for local sessions") {
+		t.Error("expected an agent task to be refused")
+	}
+	s := m.findSession("local")
+	s.WorkDir = filepath.Join(s.WorkDir, "gone")
+	if m.openWorkDir(s, false) != nil || !strings.Contains(ansi.Strip(m.toast.View()), "no longer exists") {
+		t.Error("expected a removed working directory to be reported")
+	}
+}
+
+func TestWorkDirKeys_OpenChangedFile(t *testing.T) {
+	m, dir := workDirTestModel(t)
+	const diff = "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1,2 @@\n package main\n+// added\n"
+	press := func(k string) tea.Cmd {
+		t.Helper()
+		next, cmd := m.handleKeyPress(tea.KeyPressMsg{Code: []rune(k)[0], Text: k})
+		m = next.(Model)
+		return cmd
+	}
+
+	m.viewMode = ViewModeDiff
+	m.diffView.SetDiffs(diffview.ParseUnifiedDiff(diff))
+	m.diffSessionID = "task"
+	if press("e") != nil || !strings.Contains(ansi.Strip(m.toast.View()), "no local checkout") {
+		t.Error("expected a diff without a local checkout to be refused")
+	}
+	m.diffSessionID = "local"
+	if press("e") == nil {
+		t.Error("expected the diff's file opened in the session's checkout")
+	}
+
+	m.viewMode = ViewModeGitActivity
+	m.gitWorkDir = dir
+	m.gitActivity.SetDiffResult(&data.GitDiffResult{Diff: diff, FileCount: 1, Additions: 1, Untracked: []string{"notes.txt"}})
+	press("e")
+	if !m.pickerOpen("Open File") {
+		t.Fatal("expected the changed files offered")
+	}
+	if item, _ := m.chooser.ItemAt(1); item.Label != "main.go" || !strings.Contains(item.Detail, "line 2") {
+		t.Errorf("expected main.go at its first change, got %+v", item)
+	}
+	if press("2") != nil || !strings.Contains(ansi.Strip(m.toast.View()), "notes.txt does not exist") {
+		t.Error("expected a file missing from the checkout to be reported")
+	}
+	press("e")
+	if press("1") == nil || m.pickerOpen("Open File") {
+		t.Error("expected the chosen file opened in the editor")
+	}
+}
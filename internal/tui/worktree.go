@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"slices"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+// worktreeReadyMsg reports a session branch checked out into a worktree.
+type worktreeReadyMsg struct {
+	sessionID string
+	worktree  data.Worktree
+	editor    bool
+	err       error
+}
+
+// canCheckoutBranch reports whether a session's branch can be checked out
+// locally: it names a repository and a branch other than the default one,
+// and isn't a local session, whose branch is already checked out in its
+// working directory.
+func canCheckoutBranch(m *Model, s *data.Session) bool {
+	return s != nil && !m.demo && m.replay == nil && !hasWorkDir(s) &&
+		s.Repository != "" && s.Branch != "" && !data.IsDefaultBranch(s.Branch)
+}
+
+// checkoutWorktree checks the session's branch out into its worktree in
+// the background, then opens it in the editor or a shell.
+func (m *Model) checkoutWorktree(s *data.Session, editor bool) tea.Cmd {
+	if !canCheckoutBranch(m, s) {
+		if hasWorkDir(s) {
+			m.toast.Push("ℹ️", "Worktree", "already checked out in "+s.WorkDir)
+		} else if s != nil {
+			m.toast.Push("ℹ️", "Worktree", "only available for sessions on a branch other than the default")
+		}
+		return nil
+	}
+	req := data.WorktreeRequest{
+		Repository: s.Repository,
+		Branch:     s.Branch,
+		Dir:        m.ctx.Config.WorktreeDirPath(),
+		Clones:     m.worktreeClones(s.Repository),
+	}
+	id := s.ID
+	m.toast.Push("⏳", "Worktree", "checking out "+s.Branch)
+	return func() tea.Msg {
+		wt, err := data.PrepareWorktree(req)
+		return worktreeReadyMsg{sessionID: id, worktree: wt, editor: editor, err: err}
+	}
+}
+
+// worktreeClones lists local clones of repo to add worktrees to: the one
+// configured for it, then the checkouts local sessions on this machine
+// worked in.
+func (m Model) worktreeClones(repo string) []string {
+	var clones []string
+	if c := m.ctx.Config.WorktreeClonePath(repo); c != "" {
+		clones = append(clones, c)
+	}
+	for _, s := range m.allSessions {
+		if s.Repository == repo && hasWorkDir(&s) && !slices.Contains(clones, s.WorkDir) {
+			clones = append(clones, s.WorkDir)
+		}
+	}
+	return clones
+}
+
+// handleWorktreeReady hands the terminal to the editor or a shell in the
+// worktree, running the post-checkout command first in a new one.
+func (m *Model) handleWorktreeReady(msg worktreeReadyMsg) tea.Cmd {
+	if msg.err != nil {
+		m.toast.Push("⚠️", "Worktree", msg.err.Error())
+		return nil
+	}
+	setup := ""
+	if msg.worktree.Created {
+		setup = m.ctx.Config.Worktrees.PostCheckout
+		m.recordSessionEvent(msg.sessionID, "Checked out into "+msg.worktree.Path)
+	}
+	c := openDirCommand(msg.worktree.Path, msg.editor, setup)
+	return handOff("worktree "+c.Path, c)
+}
+
+// handleWorktreeKeys handles the worktree key in the session views and,
+// for the session whose PR it shows, the diff view. It reports whether msg
+// was that key.
+func (m *Model) handleWorktreeKeys(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if !slices.Contains(worktreeModes, m.viewMode) || !key.Matches(msg, m.keys.CheckoutWorktree) {
+		return nil, false
+	}
+	s := m.selectedSession()
+	if m.viewMode == ViewModeDiff {
+		s = m.findSession(m.diffSessionID)
+	}
+	return m.checkoutWorktree(s, m.ctx.Config.WorktreeOpensEditor()), true
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/maxbeizer/gh-agent-viz/internal/data"
+)
+
+func TestOpenDirCommand(t *testing.T) {
+	t.Setenv("SHELL", "/bin/zsh")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code -w")
+
+	c := openDirCommand("/work/tree", false, "")
+	if strings.Join(c.Args, " ") != "/bin/zsh" || c.Dir != "/work/tree" {
+		t.Errorf("expected a shell in the worktree, got %v in %s", c.Args, c.Dir)
+	}
+	c = openDirCommand("/work/tree", true, "")
+	if strings.Join(c.Args, " ") != "code -w ." {
+		t.Errorf("expected the editor on the worktree, got %v", c.Args)
+	}
+	c = openDirCommand("/work/tree", true, "npm ci")
+	if c.Args[0] != "sh" || !strings.HasPrefix(c.Args[2], "npm ci || ") || strings.Join(c.Args[3:], " ") != "sh code -w ." {
+		t.Errorf("expected the setup command to run before the editor, got %v", c.Args)
+	}
+}
+
+func TestCheckoutWorktree(t *testing.T) {
+	m := annotationTestModel(t)
+	m.ctx.Config.Worktrees.Clones = map[string]string{"org/repo": "/src/repo"}
+	m.mergeSessions([]data.Session{
+		{ID: "task", Title: "Fix", Status: "completed", Source: data.SourceAgentTask, Repository: "org/repo", Branch: "copilot/fix", UpdatedAt: time.Now()},
+		{ID: "local", Title: "Hack", Status: "running", Source: data.SourceLocalCopilot, Repository: "org/repo", Branch: "main", WorkDir: "/home/me/repo", UpdatedAt: time.Now()},
+		{ID: "local-branch", Title: "Try", Status: "running", Source: data.SourceLocalCopilot, Repository: "org/repo", Branch: "try-it", WorkDir: "/home/me/try", UpdatedAt: time.Now()},
+		{ID: "cloud-main", Title: "Docs", Status: "completed", Source: data.SourceAgentTask, Repository: "org/repo", Branch: "main", UpdatedAt: time.Now()},
+	})
+
+	if got := m.worktreeClones("org/repo"); strings.Join(got, " ") != "/src/repo /home/me/repo /home/me/try" {
+		t.Errorf("expected the configured clone, then local checkouts, got %v", got)
+	}
+	if m.checkoutWorktree(m.findSession("local-branch"), false) != nil ||
+		!strings.Contains(ansi.Strip(m.toast.View()), "already checked out in /home/me/try") {
+		t.Error("expected a local session to be pointed at its working directory")
+	}
+	if m.checkoutWorktree(m.findSession("cloud-main"), false) != nil ||
+		!strings.Contains(ansi.Strip(m.toast.View()), "branch other than the default") {
+		t.Error("expected a session on the default branch to be refused")
+	}
+	if m.checkoutWorktree(m.findSession("task"), false) == nil {
+		t.Error("expected the agent's branch to be checked out")
+	}
+
+	if m.handleWorktreeReady(worktreeReadyMsg{err: errors.New("failed to fetch copilot/fix")}) != nil ||
+		!strings.Contains(ansi.Strip(m.toast.View()), "failed to fetch") {
+		t.Error("expected the failure reported without opening anything")
+	}
+	cmd := m.handleWorktreeReady(worktreeReadyMsg{sessionID: "task", worktree: data.Worktree{Path: t.TempDir(), Created: true}})
+	if cmd == nil {
+		t.Fatal("expected the terminal handed to the worktree")
+	}
+	if events := m.annotations.Get("task").Events; len(events) != 1 || !strings.HasPrefix(events[0].Text, "Checked out into ") {
+		t.Errorf("expected the checkout in the session's timeline, got %+v", events)
+	}
+}
+
+func TestWorktreeKeyInDiffView(t *testing.T) {
+	m := annotationTestModel(t)
+	m.mergeSessions([]data.Session{
+		{ID: "task", Title: "Fix", Status: "completed", Source: data.SourceAgentTask, Repository: "org/repo", Branch: "copilot/fix", UpdatedAt: time.Now()},
+	})
+	m.viewMode = ViewModeDiff
+	m.diffSessionID = "task"
+
+	m = pressKeys(t, m, "w")
+	if !strings.Contains(ansi.Strip(m.toast.View()), "checking out copilot/fix") {
+		t.Errorf("expected the diffed session's branch checked out, got %q", ansi.Strip(m.toast.View()))
+	}
+}